@@ -3,8 +3,11 @@
 VERSION="v1.17.1"
 DIR=$(realpath $(dirname "${0}"))
 
-CHARTS_PATH="$DIR/../../k8s/manifests/charts"
+CHARTS_PATH="$DIR/../../src/k8s/pkg/k8sd/manifests/charts"
 
 cd "$CHARTS_PATH" || exit
 
 helm pull --repo https://helm.cilium.io cilium --version $VERSION
+
+# update the checksums of the charts embedded in k8sd
+make -C "$DIR/../../src/k8s" go.checksums
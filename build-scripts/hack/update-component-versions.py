@@ -29,7 +29,8 @@ LOG = logging.getLogger(__name__)
 DIR = Path(__file__).absolute().parent
 SNAPCRAFT = DIR.parent.parent / "snap/snapcraft.yaml"
 COMPONENTS = DIR.parent / "components"
-CHARTS = DIR.parent.parent / "k8s" / "manifests" / "charts"
+K8S_SRC = DIR.parent.parent / "src" / "k8s"
+CHARTS = K8S_SRC / "pkg" / "k8sd" / "manifests" / "charts"
 
 # Version marker for latest Kubernetes version. Expected to be one of:
 #
@@ -187,6 +188,10 @@ def update_component_versions(dry_run: bool):
         if not dry_run:
             pull_helm_chart()
 
+    LOG.info("Updating checksums of embedded charts")
+    if not dry_run:
+        util.parse_output(["make", "-C", K8S_SRC, "go.checksums"])
+
 
 def update_go_version(dry_run: bool):
     k8s_version = (COMPONENTS / "kubernetes/version").read_text().strip()
@@ -3,7 +3,7 @@
 VERSION="v1.2.0"
 DIR=$(realpath $(dirname "${0}"))
 
-CHARTS_PATH="$DIR/../../k8s/manifests/charts"
+CHARTS_PATH="$DIR/../../src/k8s/pkg/k8sd/manifests/charts"
 
 cd "$CHARTS_PATH"
 
@@ -16,6 +16,14 @@ rm -rf gateway-api/templates/*
 rm -rf gateway-api/charts
 cp gateway-api-src/config/crd/standard/* gateway-api/templates/
 cp gateway-api-src/config/crd/experimental/gateway.networking.k8s.io_tlsroutes.yaml gateway-api/templates/
+# experimental CRDs are only installed on the experimental channel
+sed -i '1i {{- if eq .Values.channel "experimental" }}' gateway-api/templates/gateway.networking.k8s.io_tlsroutes.yaml
+echo '{{- end }}' >> gateway-api/templates/gateway.networking.k8s.io_tlsroutes.yaml
+cat > gateway-api/values.yaml <<EOF
+# channel is the release channel of the Gateway API CRDs, one of "standard" or "experimental".
+# The experimental channel adds the TLSRoute CRD.
+channel: experimental
+EOF
 sed -i 's/^\(version: \).*$/\1'"${VERSION:1}"'/' gateway-api/Chart.yaml
 sed -i 's/^\(appVersion: \).*$/\1'"${VERSION:1}"'/' gateway-api/Chart.yaml
 sed -i 's/^\(description: \).*$/\1'"A Helm Chart containing Gateway API CRDs"'/' gateway-api/Chart.yaml
@@ -23,3 +31,6 @@ helm package gateway-api
 
 rm -rf gateway-api-src
 rm -rf gateway-api
+
+# update the checksums of the charts embedded in k8sd
+make -C "$DIR/../../src/k8s" go.checksums
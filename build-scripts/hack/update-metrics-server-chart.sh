@@ -3,8 +3,11 @@
 VERSION="3.12.2"
 DIR=$(realpath $(dirname "${0}"))
 
-CHARTS_PATH="$DIR/../../k8s/manifests/charts"
+CHARTS_PATH="$DIR/../../src/k8s/pkg/k8sd/manifests/charts"
 
 cd "$CHARTS_PATH"
 
 helm pull --repo https://kubernetes-sigs.github.io/metrics-server/ metrics-server --version $VERSION
+
+# update the checksums of the charts embedded in k8sd
+make -C "$DIR/../../src/k8s" go.checksums
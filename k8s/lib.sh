@@ -208,6 +208,24 @@ k8s::init::k8sd() {
   cp "$SNAP/k8s/args/k8sd" "$SNAP_COMMON/args/k8sd"
 }
 
+# Mark the node as post-refresh if the container image was updated since the last start.
+# Nodes running with pebble have no snap hooks, so this replaces the post-refresh snap hook.
+# Example: 'k8s::pebble::detect_image_update'
+k8s::pebble::detect_image_update() {
+  k8s::common::setup_env
+
+  if ! [ -f "$SNAP/bom.json" ]; then
+    return 0
+  fi
+
+  mkdir -p "$SNAP_COMMON/lock"
+  if [ -f "$SNAP_COMMON/lock/bom.json" ] && ! cmp -s "$SNAP/bom.json" "$SNAP_COMMON/lock/bom.json"; then
+    echo "Container image was updated, running post-refresh hook"
+    touch "$SNAP_COMMON/lock/post-refresh"
+  fi
+  cp "$SNAP/bom.json" "$SNAP_COMMON/lock/bom.json"
+}
+
 # Ensure /var/lib/kubelet is a shared mount
 # Example: 'k8s::common::is_strict && k8s::kubelet::ensure_shared_root_dir'
 k8s::kubelet::ensure_shared_root_dir() {
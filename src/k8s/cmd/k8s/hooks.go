@@ -2,6 +2,7 @@ package k8s
 
 import (
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/client/k8sd"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,28 @@ func hookRequireRoot(env cmdutil.ExecutionEnvironment) func(*cobra.Command, []st
 	}
 }
 
+// getK8sdClient returns a k8sd client for a node that is part of a cluster.
+// getK8sdClient prints an error and exits if the client cannot be used.
+func getK8sdClient(cmd *cobra.Command, env cmdutil.ExecutionEnvironment) (k8sd.Client, bool) {
+	client, err := env.Snap.K8sdClient("")
+	if err != nil {
+		cmd.PrintErrf("Error: Failed to create a k8sd client. Make sure that the k8sd service is running.\n\nThe error was: %v\n", err)
+		env.Exit(1)
+		return nil, false
+	}
+
+	if _, initialized, err := client.NodeStatus(cmd.Context()); err != nil {
+		cmd.PrintErrf("Error: Failed to check the current node status.\n\nThe error was: %v\n", err)
+		env.Exit(1)
+		return nil, false
+	} else if !initialized {
+		cmd.PrintErrln("Error: The node is not part of a Kubernetes cluster. You can bootstrap a new cluster with:\n\n  sudo k8s bootstrap")
+		env.Exit(1)
+		return nil, false
+	}
+	return client, true
+}
+
 func hookInitializeFormatter(env cmdutil.ExecutionEnvironment, format *string) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		// initialize formatter
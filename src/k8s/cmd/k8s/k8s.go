@@ -77,6 +77,7 @@ func NewRootCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		newGetJoinTokenCmd(env),
 		newJoinClusterCmd(env),
 		newRemoveNodeCmd(env),
+		newNodePoolCmd(env),
 	)
 
 	// Management
@@ -85,11 +86,16 @@ func NewRootCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		&cobra.Group{ID: "management", Title: "Management Commands:"},
 		newEnableCmd(env),
 		newDisableCmd(env),
+		newFeatureCmd(env),
 		newRefreshCertsCmd(env),
 		newCertsStatusCmd(env),
 		newSetCmd(env),
 		newGetCmd(env),
 		newInspectCmd(env),
+		newTokenCmd(env),
+		newSecretsEncryptionCmd(env),
+		newServiceArgsCmd(env),
+		newCheckCmd(env),
 	)
 
 	// hidden commands
@@ -16,6 +16,7 @@ import (
 	"github.com/canonical/k8s/pkg/client/snapd"
 	"github.com/canonical/k8s/pkg/config"
 	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -49,19 +50,22 @@ func newBootstrapCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &opts.outputFormat), hookCheckLXD()),
 		Args:   cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			snapdClient, err := snapd.NewClient()
-			if err != nil {
-				cmd.PrintErrln("Error: failed to create snapd client: %w", err)
-				env.Exit(1)
-				return
-			}
-			microk8sInfo, err := snapdClient.GetSnapInfo("microk8s")
-			if err != nil {
-				cmd.PrintErrln("Warning: failed to check if microk8s is installed: %w", err)
-			} else if microk8sInfo.StatusCode == 200 && microk8sInfo.HasInstallDate() {
-				cmd.PrintErrln("Error: microk8s snap is installed. Please remove it using the following command and try again:\n\n  sudo snap remove microk8s")
-				env.Exit(1)
-				return
+			// microk8s can only conflict with nodes that run from the snap, containers do not have access to snapd.
+			if env.Snap.RuntimeEnvironment() == snap.RuntimeEnvironmentSnap {
+				snapdClient, err := snapd.NewClient()
+				if err != nil {
+					cmd.PrintErrln("Error: failed to create snapd client: %w", err)
+					env.Exit(1)
+					return
+				}
+				microk8sInfo, err := snapdClient.GetSnapInfo("microk8s")
+				if err != nil {
+					cmd.PrintErrln("Warning: failed to check if microk8s is installed: %w", err)
+				} else if microk8sInfo.StatusCode == 200 && microk8sInfo.HasInstallDate() {
+					cmd.PrintErrln("Error: microk8s snap is installed. Please remove it using the following command and try again:\n\n  sudo snap remove microk8s")
+					env.Exit(1)
+					return
+				}
 			}
 
 			if opts.interactive && opts.configFile != "" {
@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/compliance"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+)
+
+// ComplianceReport is the result of the controls of a hardening profile on the local node.
+type ComplianceReport struct {
+	Profile string              `json:"profile" yaml:"profile"`
+	Results []compliance.Result `json:"results" yaml:"results"`
+}
+
+// count returns the number of results with a status.
+func (r ComplianceReport) count(status compliance.Status) int {
+	var n int
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func (r ComplianceReport) String() string {
+	result := &strings.Builder{}
+	w := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tDESCRIPTION")
+	for _, res := range r.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", res.ID, res.Status, res.Description)
+	}
+	w.Flush()
+
+	if r.count(compliance.StatusFail) > 0 {
+		result.WriteString("\nRemediations:\n")
+		for _, res := range r.Results {
+			if res.Status != compliance.StatusFail {
+				continue
+			}
+			fmt.Fprintf(result, "\n[%s] %s\n", res.ID, res.Description)
+			if res.Details != "" {
+				fmt.Fprintf(result, "  Reason: %s\n", res.Details)
+			}
+			if res.Remediation != "" {
+				fmt.Fprintf(result, "  Remediation: %s\n", res.Remediation)
+			}
+		}
+	}
+
+	fmt.Fprintf(result, "\n%d passed, %d failed, %d skipped", r.count(compliance.StatusPass), r.count(compliance.StatusFail), r.count(compliance.StatusSkip))
+	return result.String()
+}
+
+func newCheckCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var complianceOpts struct {
+		profile      string
+		outputFormat string
+	}
+	complianceCmd := &cobra.Command{
+		Use:   "compliance",
+		Short: "Check the local node against the controls of a hardening benchmark",
+		Long: `Check the service arguments and file permissions of the local node against the controls
+of a hardening benchmark, and print the result of each control with remediation hints.
+Control plane controls are skipped on worker nodes. The command exits with a non-zero
+exit code if any control fails.`,
+		Args:   cmdutil.ExactArgs(env, 0),
+		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &complianceOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			results, err := compliance.Check(env.Snap, complianceOpts.profile)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to check the compliance of the node.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			report := ComplianceReport{Profile: complianceOpts.profile, Results: results}
+			outputFormatter.Print(report)
+			if report.count(compliance.StatusFail) > 0 {
+				env.Exit(1)
+			}
+		},
+	}
+	complianceCmd.Flags().StringVar(&complianceOpts.profile, "profile", types.HardeningProfileCIS, fmt.Sprintf("the hardening profile to check against, one of %s", strings.Join(types.HardeningProfiles, ", ")))
+	complianceCmd.Flags().StringVar(&complianceOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the configuration of the local node",
+	}
+
+	cmd.AddCommand(complianceCmd)
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	"github.com/canonical/k8s/pkg/k8sd/compliance"
+	. "github.com/onsi/gomega"
+)
+
+func TestComplianceReportFormat(t *testing.T) {
+	t.Run("Pass", func(t *testing.T) {
+		g := NewWithT(t)
+		report := k8s.ComplianceReport{
+			Profile: "cis",
+			Results: []compliance.Result{
+				{ID: "1.2.1", Description: "Ensure that the --anonymous-auth argument is set to false", Status: compliance.StatusPass},
+				{ID: "1.2.17", Description: "Ensure that the --profiling argument is set to false", Status: compliance.StatusSkip, Details: "not a control plane node"},
+			},
+		}
+		g.Expect(report.String()).To(Equal(`ID      STATUS  DESCRIPTION
+1.2.1   PASS    Ensure that the --anonymous-auth argument is set to false
+1.2.17  SKIP    Ensure that the --profiling argument is set to false
+
+1 passed, 0 failed, 1 skipped`))
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		g := NewWithT(t)
+		report := k8s.ComplianceReport{
+			Profile: "cis",
+			Results: []compliance.Result{
+				{ID: "1.2.1", Description: "Ensure that the --anonymous-auth argument is set to false", Status: compliance.StatusPass},
+				{
+					ID:          "1.2.17",
+					Description: "Ensure that the --profiling argument is set to false",
+					Status:      compliance.StatusFail,
+					Details:     "--profiling is not set",
+					Remediation: "Set --profiling=false in /args/kube-apiserver and restart the kube-apiserver service.",
+				},
+			},
+		}
+		g.Expect(report.String()).To(Equal(`ID      STATUS  DESCRIPTION
+1.2.1   PASS    Ensure that the --anonymous-auth argument is set to false
+1.2.17  FAIL    Ensure that the --profiling argument is set to false
+
+Remediations:
+
+[1.2.17] Ensure that the --profiling argument is set to false
+  Reason: --profiling is not set
+  Remediation: Set --profiling=false in /args/kube-apiserver and restart the kube-apiserver service.
+
+1 passed, 1 failed, 0 skipped`))
+	})
+}
@@ -2,25 +2,56 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/spf13/cobra"
 )
 
 func newKubeConfigCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	var opts struct {
-		server  string
-		timeout time.Duration
+		server    string
+		oidc      bool
+		username  string
+		groups    []string
+		role      string
+		namespace string
+		ttl       time.Duration
+		timeout   time.Duration
 	}
 	cmd := &cobra.Command{
 		Use:    "config",
 		Hidden: true,
 		Short:  "Generate an admin kubeconfig that can be used to access the Kubernetes cluster",
+		Long:   "Generate an admin kubeconfig that can be used to access the Kubernetes cluster.\nUse --user to generate a kubeconfig for a user with limited permissions instead. The credentials of the\nuser are a token that can be listed and revoked with \"k8s token list\" and \"k8s token revoke\".",
 		Args:   cobra.NoArgs,
 		PreRun: chainPreRunHooks(hookRequireRoot(env)),
 		Run: func(cmd *cobra.Command, args []string) {
+			if opts.username == "" && (len(opts.groups) > 0 || opts.role != "" || opts.namespace != "" || cmd.Flags().Changed("expires-in")) {
+				cmd.PrintErrln("Error: --group, --role, --namespace and --expires-in can only be used together with --user.")
+				env.Exit(1)
+				return
+			}
+			if opts.username != "" && opts.oidc {
+				cmd.PrintErrln("Error: --oidc cannot be used together with --user.")
+				env.Exit(1)
+				return
+			}
+			if opts.ttl < 0 {
+				cmd.PrintErrf("Error: Invalid --expires-in %v, it cannot be negative.\n", opts.ttl)
+				env.Exit(1)
+				return
+			}
+			if opts.role != "" && opts.ttl == 0 {
+				cmd.PrintErrln("Error: --expires-in cannot be 0 when --role is used, as credentials that grant a role must expire.")
+				env.Exit(1)
+				return
+			}
+			if opts.username == "" {
+				opts.ttl = 0
+			}
 			if opts.timeout < minTimeout {
 				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", opts.timeout, minTimeout, minTimeout)
 				opts.timeout = minTimeout
@@ -46,9 +77,24 @@ func newKubeConfigCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 			ctx, cancel := context.WithTimeout(cmd.Context(), opts.timeout)
 			cobra.OnFinalize(cancel)
 
-			response, err := client.KubeConfig(ctx, apiv1.KubeConfigRequest{Server: opts.server})
+			response, err := client.KubeConfig(ctx, types.KubeConfigRequest{
+				Server:    opts.server,
+				OIDC:      opts.oidc,
+				Username:  opts.username,
+				Groups:    opts.groups,
+				Role:      opts.role,
+				Namespace: opts.namespace,
+				TTL:       opts.ttl,
+			})
 			if err != nil {
-				cmd.PrintErrf("Error: Failed to generate an admin kubeconfig for %q.\n\nThe error was: %v\n", opts.server, err)
+				kind := "an admin"
+				switch {
+				case opts.oidc:
+					kind = "an OIDC"
+				case opts.username != "":
+					kind = fmt.Sprintf("a user %q", opts.username)
+				}
+				cmd.PrintErrf("Error: Failed to generate %s kubeconfig for %q.\n\nThe error was: %v\n", kind, opts.server, err)
 				env.Exit(1)
 				return
 			}
@@ -57,6 +103,13 @@ func newKubeConfigCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&opts.server, "server", "", "custom cluster server address")
+	cmd.Flags().BoolVar(&opts.oidc, "oidc", false, "generate a kubeconfig that authenticates with the configured OIDC issuer using the kubectl oidc-login plugin")
+	cmd.Flags().StringVar(&opts.username, "user", "", "generate a kubeconfig for this user instead of the cluster admin")
+	cmd.Flags().StringSliceVar(&opts.groups, "group", nil, "comma-separated list of groups of the user")
+	cmd.Flags().StringVar(&opts.role, "role", "", "name of a ClusterRole to grant to the user")
+	cmd.Flags().StringVar(&opts.namespace, "namespace", "", "grant the role only in this namespace, which is also the default namespace of the kubeconfig")
+	// The CLI uses verbose names for flags instead of abbreviations. Internally and for the API, the common TTL (time-to-live) name is used.
+	cmd.Flags().DurationVar(&opts.ttl, "expires-in", 24*time.Hour, "the time until the user credentials expire, 0 means that they never expire (not allowed with --role)")
 	cmd.Flags().DurationVar(&opts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
 	return cmd
 }
@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+)
+
+type RollbackFeatureResult struct {
+	Name   types.FeatureName            `json:"name" yaml:"name"`
+	Charts []types.FeatureChartRollback `json:"charts" yaml:"charts"`
+}
+
+func (r RollbackFeatureResult) String() string {
+	lines := make([]string, 0, len(r.Charts))
+	for _, chart := range r.Charts {
+		lines = append(lines, fmt.Sprintf("Rolled back %s from revision %d to revision %d.", chart.Name, chart.FromRevision, chart.ToRevision))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newFeatureCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var rollbackOpts struct {
+		outputFormat string
+		timeout      time.Duration
+	}
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback <feature>",
+		Short: "Roll back a feature to its previous revision",
+		Long: "Roll back the Helm releases of a built-in feature to the last revision that was deployed successfully.\n" +
+			"The cluster configuration is not changed, so the feature is upgraded again the next time it is reconciled.",
+		Args:      cmdutil.ExactArgs(env, 1),
+		ValidArgs: append(featureList, string(features.MetricsServer)),
+		PreRun:    chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &rollbackOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if rollbackOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", rollbackOpts.timeout, minTimeout, minTimeout)
+				rollbackOpts.timeout = minTimeout
+			}
+
+			client, err := env.Snap.K8sdClient("")
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to create a k8sd client. Make sure that the k8sd service is running.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			if _, initialized, err := client.NodeStatus(cmd.Context()); err != nil {
+				cmd.PrintErrf("Error: Failed to check the current node status.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			} else if !initialized {
+				cmd.PrintErrln("Error: The node is not part of a Kubernetes cluster. You can bootstrap a new cluster with:\n\n  sudo k8s bootstrap")
+				env.Exit(1)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), rollbackOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			name := types.FeatureName(args[0])
+			response, err := client.RollbackFeature(ctx, types.RollbackFeatureRequest{Name: name})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to roll back feature %q.\n\nThe error was: %v\n", name, err)
+				env.Exit(1)
+				return
+			}
+
+			outputFormatter.Print(RollbackFeatureResult{Name: name, Charts: response.Charts})
+		},
+	}
+	rollbackCmd.Flags().StringVar(&rollbackOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+	rollbackCmd.Flags().DurationVar(&rollbackOpts.timeout, "timeout", 5*time.Minute, "the max time to wait for the command to execute")
+
+	cmd := &cobra.Command{
+		Use:   "feature",
+		Short: "Manage the built-in features",
+	}
+
+	cmd.AddCommand(rollbackCmd)
+
+	return cmd
+}
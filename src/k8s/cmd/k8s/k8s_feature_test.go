@@ -0,0 +1,21 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestRollbackFeatureResultFormat(t *testing.T) {
+	g := NewWithT(t)
+	result := k8s.RollbackFeatureResult{
+		Name: "gateway",
+		Charts: []types.FeatureChartRollback{
+			{Name: "ck-gateway", FromRevision: 4, ToRevision: 3},
+			{Name: "ck-gateway-class", FromRevision: 2, ToRevision: 1},
+		},
+	}
+	g.Expect(result.String()).To(Equal("Rolled back ck-gateway from revision 4 to revision 3.\nRolled back ck-gateway-class from revision 2 to revision 1."))
+}
@@ -6,14 +6,18 @@ import (
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/spf13/cobra"
 )
 
 func newGetJoinTokenCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	var opts struct {
-		worker  bool
-		timeout time.Duration
-		ttl     time.Duration
+		worker       bool
+		pool         string
+		multiUse     bool
+		allowedCIDRs []string
+		timeout      time.Duration
+		ttl          time.Duration
 	}
 	cmd := &cobra.Command{
 		Use:    "get-join-token <node-name>",
@@ -32,6 +36,18 @@ func newGetJoinTokenCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 				name = args[0]
 			}
 
+			if opts.pool != "" && name == "" && !opts.worker {
+				cmd.PrintErrln("Error: A node name is required to join a control-plane node to a node pool.")
+				env.Exit(1)
+				return
+			}
+
+			if !opts.worker && (opts.multiUse || len(opts.allowedCIDRs) > 0) {
+				cmd.PrintErrln("Error: The --multi-use and --allowed-cidrs flags are only supported for worker nodes.")
+				env.Exit(1)
+				return
+			}
+
 			if opts.timeout < minTimeout {
 				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", opts.timeout, minTimeout, minTimeout)
 				opts.timeout = minTimeout
@@ -57,7 +73,12 @@ func newGetJoinTokenCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 				return
 			}
 
-			token, err := client.GetJoinToken(ctx, apiv1.GetJoinTokenRequest{Name: name, Worker: opts.worker, TTL: opts.ttl})
+			token, err := client.GetJoinToken(ctx, types.GetJoinTokenRequest{
+				GetJoinTokenRequest: apiv1.GetJoinTokenRequest{Name: name, Worker: opts.worker, TTL: opts.ttl},
+				Pool:                opts.pool,
+				MultiUse:            opts.multiUse,
+				AllowedCIDRs:        opts.allowedCIDRs,
+			})
 			if err != nil {
 				cmd.PrintErrf("Error: Could not generate a join token for %q.\n\nThe error was: %v\n", name, err)
 				env.Exit(1)
@@ -69,6 +90,9 @@ func newGetJoinTokenCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&opts.worker, "worker", false, "generate a join token for a worker node")
+	cmd.Flags().StringVar(&opts.pool, "pool", "", "the node pool the node will be a member of")
+	cmd.Flags().BoolVar(&opts.multiUse, "multi-use", false, "allow the token to join more than one worker node until it expires")
+	cmd.Flags().StringSliceVar(&opts.allowedCIDRs, "allowed-cidrs", nil, "comma-separated list of CIDRs that the addresses of joining worker nodes must be in")
 	cmd.Flags().DurationVar(&opts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
 	// The CLI uses verbose names for flags instead of abbreviations. Internally and for the API, the common TTL (time-to-live) name is used.
 	cmd.Flags().DurationVar(&opts.ttl, "expires-in", 24*time.Hour, "the time until the token expires")
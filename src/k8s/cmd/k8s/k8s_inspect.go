@@ -1,52 +1,204 @@
 package k8s
 
 import (
-	"syscall"
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/inspect"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/spf13/cobra"
 )
 
+// inspectionReportDir is the top-level directory of the inspection report tarball.
+const inspectionReportDir = "inspection-report"
+
+// secretMarkers are strings that indicate a certificate or a private key in the inspection report.
+var secretMarkers = []string{"BEGIN CERTIFICATE", "PRIVATE KEY"}
+
 func newInspectCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
-	// We're copying the help string from the "inspect.sh" script with
-	// only minor adjustments. At the same time, we'll avoid parsing the
-	// same arguments twice.
-	return &cobra.Command{
-		Use:   "inspect <output-file>",
+	var opts struct {
+		allNamespaces     bool
+		allNodes          bool
+		numSnapLogEntries int
+		timeout           time.Duration
+		coreDumpDir       string
+	}
+	cmd := &cobra.Command{
+		Use:   "inspect [output-file]",
 		Short: "Generate inspection report",
 		Long: `
 This command collects diagnostics and other relevant information from a Kubernetes
 node (either control-plane or worker node) and compiles them into a tarball report.
-The collected data includes service arguments, Kubernetes cluster info, SBOM, system
-diagnostics, network diagnostics, and more. The command needs to be run with
-elevated permissions (sudo).
-
-Arguments:
-  output-file             (Optional) The full path and filename for the generated tarball.
-                          If not provided, a default filename based on the current date
-                          and time will be used.
-  --all-namespaces        (Optional) Acquire detailed debugging information, including logs
-                          from all Kubernetes namespaces.
-  --num-snap-log-entries  (Optional) The maximum number of log entries to collect
-                          from snap services. Default: 100000.
-  --timeout               (Optional) The maximum time in seconds to wait for a command.
-                          Default: 180s.
-  --core-dump-dir         (Optional) Core dump location. Default: /var/crash.
+The collected data includes service logs and arguments, certificate expiry, the
+sanitized cluster configuration, feature statuses, datastore members, Kubernetes
+objects and events, system diagnostics, network diagnostics, and more. Certificates
+and private keys of the cluster configuration are redacted. The command needs to be
+run with elevated permissions (sudo).
+
+If output-file is not provided, a default filename based on the current date and
+time will be used.
+
+On control plane nodes, --all-nodes also collects the node information of all other
+cluster members through k8sd.
 `,
-		DisableFlagParsing: true,
-		PreRun:             chainPreRunHooks(hookRequireRoot(env)),
+		Args:   cmdutil.MaximumNArgs(env, 1),
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
 		Run: func(cmd *cobra.Command, args []string) {
-			inspectScriptPath := env.Snap.K8sInspectScriptPath()
-
-			command := append([]string{inspectScriptPath}, args...)
-			environ := cmdutil.EnvironWithDefaults(
-				env.Environ,
-			)
-			if err := syscall.Exec(inspectScriptPath, command, environ); err != nil {
-				cmd.PrintErrf("Failed to run %s.\n\nError: %v\n", command, err)
+			outputFile := fmt.Sprintf("inspection-report-%s.tar.gz", time.Now().Format("20060102_150405"))
+			if len(args) == 1 {
+				outputFile = args[0]
+			}
+
+			options := types.InspectOptions{
+				NumLogEntries: opts.numSnapLogEntries,
+				AllNamespaces: opts.allNamespaces,
+				Timeout:       opts.timeout,
+				CoreDumpDir:   opts.coreDumpDir,
+			}
+			nodeDir := path.Join("nodes", env.Snap.Hostname())
+
+			var report types.InspectionReport
+			cmd.Println("Collecting node information")
+			report.Merge(nodeDir, inspect.CollectNode(cmd.Context(), env.Snap, options))
+
+			collectK8sdInspection(cmd, env, &report, nodeDir, options, opts.allNodes)
+
+			if len(report.Errors) > 0 {
+				report.AddFile("errors.log", []byte(strings.Join(report.Errors, "\n")+"\n"))
+				cmd.PrintErrf("Warning: %d part(s) of the report could not be collected, see errors.log in the report.\n", len(report.Errors))
+			}
+			if files := filesWithSecrets(report); len(files) > 0 {
+				cmd.PrintErrf("Warning: the following files of the report may contain certificates or private keys, review them before sharing the report:\n  %s\n", strings.Join(files, "\n  "))
+			}
+
+			cmd.Println("Writing report tarball")
+			f, err := os.Create(outputFile)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to create %q.\n\nThe error was: %v\n", outputFile, err)
+				env.Exit(1)
+				return
+			}
+			defer f.Close()
+			if err := WriteInspectionReport(f, report, time.Now()); err != nil {
+				cmd.PrintErrf("Error: Failed to write the inspection report to %q.\n\nThe error was: %v\n", outputFile, err)
 				env.Exit(1)
 				return
 			}
+			cmd.Printf("Report tarball is at %s\n", outputFile)
 		},
 	}
+
+	cmd.Flags().BoolVar(&opts.allNamespaces, "all-namespaces", false, "collect Kubernetes objects and events from all namespaces")
+	cmd.Flags().BoolVar(&opts.allNodes, "all-nodes", false, "collect the node information of all cluster members (control plane nodes only)")
+	cmd.Flags().IntVar(&opts.numSnapLogEntries, "num-snap-log-entries", 100000, "maximum number of log entries to collect from snap services")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 180*time.Second, "maximum time to wait for a command")
+	cmd.Flags().StringVar(&opts.coreDumpDir, "core-dump-dir", "/var/crash", "core dump location, empty to skip collecting core dumps")
+
+	return cmd
+}
+
+// collectK8sdInspection adds the certificates status of the local node to the report. On control plane nodes,
+// it also adds the cluster-wide information and optionally the information of all other cluster members.
+// Failures are recorded in the report, so that a report is still produced if k8sd is unavailable.
+func collectK8sdInspection(cmd *cobra.Command, env cmdutil.ExecutionEnvironment, report *types.InspectionReport, nodeDir string, options types.InspectOptions, allNodes bool) {
+	client, err := env.Snap.K8sdClient("")
+	if err != nil {
+		report.AddError("failed to create k8sd client: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), options.Timeout)
+	defer cancel()
+	status, initialized, err := client.NodeStatus(ctx)
+	switch {
+	case err != nil:
+		report.AddError("failed to get node status from k8sd: %v", err)
+		return
+	case !initialized:
+		report.AddError("the node is not part of a cluster, skipping cluster information")
+		return
+	}
+
+	if certs, err := client.CertificatesStatus(ctx, apiv1.CertificatesStatusRequest{}); err != nil {
+		report.AddError("failed to get certificates status: %v", err)
+	} else {
+		report.AddYAML(path.Join(nodeDir, "certificates.yaml"), certs)
+	}
+
+	if status.NodeStatus.ClusterRole != apiv1.ClusterRoleControlPlane {
+		if allNodes {
+			cmd.PrintErrln("Warning: --all-nodes is only supported on control plane nodes, collecting the local node only.")
+		}
+		return
+	}
+
+	cmd.Println("Collecting cluster information")
+	resp, err := client.Inspect(cmd.Context(), types.InspectRequest{Cluster: true, Members: allNodes, Options: options})
+	if err != nil {
+		report.AddError("failed to collect cluster information from k8sd: %v", err)
+		return
+	}
+	report.Merge("", resp.Report)
+}
+
+// filesWithSecrets returns the sorted paths of the report files that look like they contain certificates or keys.
+func filesWithSecrets(report types.InspectionReport) []string {
+	var files []string
+	for filePath, data := range report.Files {
+		for _, marker := range secretMarkers {
+			if bytes.Contains(data, []byte(marker)) {
+				files = append(files, filePath)
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// WriteInspectionReport writes the report as a gzip-compressed tarball. The files are sorted and placed
+// under the "inspection-report/" directory.
+func WriteInspectionReport(w io.Writer, report types.InspectionReport, modTime time.Time) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	filePaths := make([]string, 0, len(report.Files))
+	for filePath := range report.Files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		data := report.Files[filePath]
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    path.Join(inspectionReportDir, filePath),
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: modTime,
+		}); err != nil {
+			return fmt.Errorf("failed to write header of %s: %w", filePath, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to close tar writer: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+	return nil
 }
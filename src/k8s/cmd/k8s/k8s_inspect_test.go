@@ -0,0 +1,50 @@
+package k8s_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteInspectionReport(t *testing.T) {
+	g := NewWithT(t)
+
+	report := types.InspectionReport{Files: map[string][]byte{
+		"nodes/node1/services.yaml": []byte("kubelet: active\n"),
+		"cluster/config.yaml":       []byte("network: {}\n"),
+	}}
+
+	var b bytes.Buffer
+	g.Expect(k8s.WriteInspectionReport(&b, report, time.Now())).To(Succeed())
+
+	gr, err := gzip.NewReader(&b)
+	g.Expect(err).ToNot(HaveOccurred())
+	tr := tar.NewReader(gr)
+
+	var names []string
+	contents := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		data, err := io.ReadAll(tr)
+		g.Expect(err).ToNot(HaveOccurred())
+		names = append(names, hdr.Name)
+		contents[hdr.Name] = string(data)
+	}
+
+	g.Expect(names).To(Equal([]string{
+		"inspection-report/cluster/config.yaml",
+		"inspection-report/nodes/node1/services.yaml",
+	}))
+	g.Expect(contents).To(HaveKeyWithValue("inspection-report/nodes/node1/services.yaml", "kubelet: active\n"))
+}
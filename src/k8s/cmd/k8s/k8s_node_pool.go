@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type NodePools types.ListNodePoolsResponse
+
+func (p NodePools) String() string {
+	if len(p.Pools) == 0 {
+		return "No node pools found."
+	}
+
+	members := make(map[string][]string)
+	for nodeName, pool := range p.Members {
+		members[pool] = append(members[pool], nodeName)
+	}
+
+	result := &strings.Builder{}
+	w := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMEMBERS\tLABELS\tTAINTS")
+	for _, pool := range p.Pools {
+		sort.Strings(members[pool.Name])
+		labels := make([]string, 0, len(pool.Labels))
+		for key, value := range pool.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(labels)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			pool.Name,
+			strings.Join(members[pool.Name], ","),
+			strings.Join(labels, ","),
+			strings.Join(pool.Taints, ","),
+		)
+	}
+	w.Flush()
+
+	// members, labels and taints may be empty, do not leave trailing whitespace behind
+	lines := strings.Split(strings.TrimRight(result.String(), "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newNodePoolCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var setOpts struct {
+		file    string
+		timeout time.Duration
+	}
+	setCmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or update a node pool",
+		Long: "Create a node pool, or replace the configuration of an existing node pool.\n" +
+			"The configuration is read from a YAML file with the labels, taints, extra-node-kubelet-args, extra-node-containerd-args,\n" +
+			"extra-node-kube-proxy-args and extra-node-config-files of the pool. The changes are rolled out to all members of the pool.\n" +
+			"Nodes join a node pool with \"k8s get-join-token <node-name> --pool <name>\".",
+		Args:   cmdutil.ExactArgs(env, 1),
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if setOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", setOpts.timeout, minTimeout, minTimeout)
+				setOpts.timeout = minTimeout
+			}
+
+			pool := types.NodePool{Name: args[0]}
+			if setOpts.file != "" {
+				var err error
+				if pool, err = getNodePoolFromYaml(env, setOpts.file); err != nil {
+					cmd.PrintErrf("Error: Failed to read the node pool configuration from %q.\n\nThe error was: %v\n", setOpts.file, err)
+					env.Exit(1)
+					return
+				}
+				if pool.Name != "" && pool.Name != args[0] {
+					cmd.PrintErrf("Error: The node pool configuration is for node pool %q, not %q.\n", pool.Name, args[0])
+					env.Exit(1)
+					return
+				}
+				pool.Name = args[0]
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), setOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			if err := client.SetNodePool(ctx, types.SetNodePoolRequest{Pool: pool}); err != nil {
+				cmd.PrintErrf("Error: Failed to set node pool %q.\n\nThe error was: %v\n", args[0], err)
+				env.Exit(1)
+				return
+			}
+		},
+	}
+	setCmd.Flags().StringVar(&setOpts.file, "file", "", "path to the YAML file with the node pool configuration, use - to read from stdin")
+	setCmd.Flags().DurationVar(&setOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	var listOpts struct {
+		outputFormat string
+		timeout      time.Duration
+	}
+	listCmd := &cobra.Command{
+		Use:    "list",
+		Short:  "List the node pools and their members",
+		Args:   cobra.NoArgs,
+		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &listOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if listOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", listOpts.timeout, minTimeout, minTimeout)
+				listOpts.timeout = minTimeout
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), listOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			response, err := client.ListNodePools(ctx, types.ListNodePoolsRequest{})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to list the node pools.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			outputFormatter.Print(NodePools(response))
+		},
+	}
+	listCmd.Flags().StringVar(&listOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+	listCmd.Flags().DurationVar(&listOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	var deleteOpts struct {
+		timeout time.Duration
+	}
+	deleteCmd := &cobra.Command{
+		Use:    "delete <name>",
+		Short:  "Delete a node pool",
+		Long:   "Delete a node pool.\nA node pool can only be deleted after all its members have been removed from the cluster.",
+		Args:   cmdutil.ExactArgs(env, 1),
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if deleteOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", deleteOpts.timeout, minTimeout, minTimeout)
+				deleteOpts.timeout = minTimeout
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), deleteOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			if err := client.DeleteNodePool(ctx, types.DeleteNodePoolRequest{Name: args[0]}); err != nil {
+				cmd.PrintErrf("Error: Failed to delete node pool %q.\n\nThe error was: %v\n", args[0], err)
+				env.Exit(1)
+				return
+			}
+		},
+	}
+	deleteCmd.Flags().DurationVar(&deleteOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	cmd := &cobra.Command{
+		Use:   "node-pool",
+		Short: "Manage node pools and their configuration",
+	}
+
+	cmd.AddCommand(setCmd)
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(deleteCmd)
+
+	return cmd
+}
+
+func getNodePoolFromYaml(env cmdutil.ExecutionEnvironment, filePath string) (types.NodePool, error) {
+	var b []byte
+	var err error
+
+	if filePath == "-" {
+		b, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return types.NodePool{}, fmt.Errorf("failed to read config from stdin: %w", err)
+		}
+	} else {
+		b, err = os.ReadFile(filePath)
+		if err != nil {
+			return types.NodePool{}, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+
+	var pool types.NodePool
+	if err := yaml.UnmarshalStrict(b, &pool); err != nil {
+		return types.NodePool{}, fmt.Errorf("failed to parse YAML config file: %w", err)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,30 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestNodePoolsFormat(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(k8s.NodePools{}.String()).To(Equal("No node pools found."))
+	})
+
+	t.Run("NodePools", func(t *testing.T) {
+		g := NewWithT(t)
+		pools := k8s.NodePools{
+			Pools: []types.NodePool{
+				{Name: "edge"},
+				{Name: "gpu", Labels: map[string]string{"tier": "1", "accelerator": "nvidia"}, Taints: []string{"gpu=true:NoSchedule"}},
+			},
+			Members: map[string]string{"node2": "gpu", "node1": "gpu"},
+		}
+		g.Expect(pools.String()).To(Equal(`NAME  MEMBERS      LABELS                     TAINTS
+edge
+gpu   node1,node2  accelerator=nvidia,tier=1  gpu=true:NoSchedule`))
+	})
+}
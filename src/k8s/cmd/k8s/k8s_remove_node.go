@@ -6,6 +6,7 @@ import (
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/spf13/cobra"
 )
 
@@ -20,12 +21,17 @@ func (r RemoveNodeResult) String() string {
 func newRemoveNodeCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	var opts struct {
 		force        bool
+		skipDrain    bool
+		drainTimeout time.Duration
 		outputFormat string
 		timeout      time.Duration
 	}
 	cmd := &cobra.Command{
-		Use:    "remove-node <node-name>",
-		Short:  "Remove a node from the cluster",
+		Use:   "remove-node <node-name>",
+		Short: "Remove a node from the cluster",
+		Long: "Remove a node from the cluster.\n" +
+			"The node is cordoned and its pods are evicted first, respecting any PodDisruptionBudgets. When removing a control plane node,\n" +
+			"the datastore voter role of the node is moved to another node before the node is torn down.",
 		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &opts.outputFormat)),
 		Args:   cmdutil.ExactArgs(env, 1),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -53,8 +59,17 @@ func newRemoveNodeCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 
 			name := args[0]
 
-			cmd.PrintErrf("Removing %q from the Kubernetes cluster. This may take a few seconds, please wait.\n", name)
-			if err := client.RemoveNode(cmd.Context(), apiv1.RemoveNodeRequest{Name: name, Force: opts.force, Timeout: opts.timeout}); err != nil {
+			if opts.skipDrain {
+				cmd.PrintErrf("Removing %q from the Kubernetes cluster. This may take a few seconds, please wait.\n", name)
+			} else {
+				cmd.PrintErrf("Draining and removing %q from the Kubernetes cluster. This may take a few minutes, please wait.\n", name)
+			}
+			request := types.RemoveNodeRequest{
+				RemoveNodeRequest: apiv1.RemoveNodeRequest{Name: name, Force: opts.force, Timeout: opts.timeout},
+				SkipDrain:         opts.skipDrain,
+				DrainTimeout:      opts.drainTimeout,
+			}
+			if err := client.RemoveNode(cmd.Context(), request); err != nil {
 				cmd.PrintErrf("Error: Failed to remove node %q from the cluster.\n\nThe error was: %v\n", name, err)
 				env.Exit(1)
 				return
@@ -64,7 +79,9 @@ func newRemoveNodeCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&opts.force, "force", false, "forcibly remove the cluster member")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "forcibly remove the cluster member, without evicting its pods first")
+	cmd.Flags().BoolVar(&opts.skipDrain, "skip-drain", false, "remove the node without evicting its pods first")
+	cmd.Flags().DurationVar(&opts.drainTimeout, "drain-timeout", types.DefaultDrainTimeout, "the max time to wait for the pods of the node to be evicted")
 	cmd.Flags().StringVar(&opts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
 	cmd.Flags().DurationVar(&opts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
 
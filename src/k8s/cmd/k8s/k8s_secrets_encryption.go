@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+)
+
+type RotateSecretsEncryptionKeyResult types.RotateSecretsEncryptionKeyResponse
+
+func (r RotateSecretsEncryptionKeyResult) String() string {
+	if r.KeyName == "" {
+		return fmt.Sprintf("Secrets encryption keys were removed. %d secrets were rewritten.", r.RewrittenSecrets)
+	}
+	return fmt.Sprintf("Secrets are now encrypted with key %s. %d secrets were rewritten.", r.KeyName, r.RewrittenSecrets)
+}
+
+func newSecretsEncryptionCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var rotateOpts struct {
+		outputFormat string
+		timeout      time.Duration
+	}
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the key used to encrypt Secrets at rest",
+		Long: "Rotate the key used to encrypt Secrets at rest.\n" +
+			"A new key is added on all control plane nodes, all Secrets are rewritten with the new key and the old keys are retired.\n" +
+			"If secrets encryption is being disabled, all Secrets are rewritten unencrypted and the old keys are removed.",
+		Args:   cobra.NoArgs,
+		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &rotateOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if rotateOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", rotateOpts.timeout, minTimeout, minTimeout)
+				rotateOpts.timeout = minTimeout
+			}
+
+			client, err := env.Snap.K8sdClient("")
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to create a k8sd client. Make sure that the k8sd service is running.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			if _, initialized, err := client.NodeStatus(cmd.Context()); err != nil {
+				cmd.PrintErrf("Error: Failed to check the current node status.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			} else if !initialized {
+				cmd.PrintErrln("Error: The node is not part of a Kubernetes cluster. You can bootstrap a new cluster with:\n\n  sudo k8s bootstrap")
+				env.Exit(1)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), rotateOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			response, err := client.RotateSecretsEncryptionKey(ctx, types.RotateSecretsEncryptionKeyRequest{})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to rotate the secrets encryption key.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			outputFormatter.Print(RotateSecretsEncryptionKeyResult(response))
+		},
+	}
+	rotateCmd.Flags().StringVar(&rotateOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+	rotateCmd.Flags().DurationVar(&rotateOpts.timeout, "timeout", 10*time.Minute, "the max time to wait for the command to execute")
+
+	cmd := &cobra.Command{
+		Use:   "secrets-encryption",
+		Short: "Manage the encryption of Secrets at rest",
+	}
+
+	cmd.AddCommand(rotateCmd)
+
+	return cmd
+}
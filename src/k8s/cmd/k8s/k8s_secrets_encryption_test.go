@@ -0,0 +1,22 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	. "github.com/onsi/gomega"
+)
+
+func TestRotateSecretsEncryptionKeyResultFormat(t *testing.T) {
+	t.Run("Rotated", func(t *testing.T) {
+		g := NewWithT(t)
+		result := k8s.RotateSecretsEncryptionKeyResult{KeyName: "key-20251019-0a1b2c3d", RewrittenSecrets: 12}
+		g.Expect(result.String()).To(Equal("Secrets are now encrypted with key key-20251019-0a1b2c3d. 12 secrets were rewritten."))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		g := NewWithT(t)
+		result := k8s.RotateSecretsEncryptionKeyResult{RewrittenSecrets: 3}
+		g.Expect(result.String()).To(Equal("Secrets encryption keys were removed. 3 secrets were rewritten."))
+	})
+}
@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type ServiceArgs types.ServiceArgs
+
+func (s ServiceArgs) String() string {
+	if len(s.Args) == 0 && len(s.ConfigFiles) == 0 && len(s.Nodes) == 0 {
+		return "No service arguments configured."
+	}
+
+	b, err := yaml.Marshal(types.ServiceArgs(s))
+	if err != nil {
+		return fmt.Sprintf("failed to format service arguments: %v", err)
+	}
+	return strings.TrimSuffix(string(b), "\n")
+}
+
+func newServiceArgsCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var getOpts struct {
+		outputFormat string
+		timeout      time.Duration
+	}
+	getCmd := &cobra.Command{
+		Use:    "get",
+		Short:  "Show the cluster-wide extra arguments of the Kubernetes services",
+		Args:   cobra.NoArgs,
+		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &getOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if getOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", getOpts.timeout, minTimeout, minTimeout)
+				getOpts.timeout = minTimeout
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), getOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			response, err := client.GetServiceArgs(ctx, types.GetServiceArgsRequest{})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to retrieve the service arguments.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			outputFormatter.Print(ServiceArgs(response.ServiceArgs))
+		},
+	}
+	getCmd.Flags().StringVar(&getOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+	getCmd.Flags().DurationVar(&getOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	var setOpts struct {
+		file    string
+		dryRun  bool
+		timeout time.Duration
+	}
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Replace the cluster-wide extra arguments of the Kubernetes services",
+		Long: "Replace the cluster-wide extra arguments and configuration files of the Kubernetes services.\n" +
+			"The configuration is read from a YAML file with the args, config-files and per-node overrides (nodes) to apply.\n" +
+			"The changes are previewed before they are applied. Services are only restarted on nodes where their arguments changed.",
+		Args:   cobra.NoArgs,
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if setOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", setOpts.timeout, minTimeout, minTimeout)
+				setOpts.timeout = minTimeout
+			}
+
+			if setOpts.file == "" {
+				cmd.PrintErrln("Error: The service arguments configuration must be specified with --file.")
+				env.Exit(1)
+				return
+			}
+			serviceArgs, err := getServiceArgsFromYaml(env, setOpts.file)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to read the service arguments from %q.\n\nThe error was: %v\n", setOpts.file, err)
+				env.Exit(1)
+				return
+			}
+			if err := serviceArgs.Validate(); err != nil {
+				cmd.PrintErrf("Error: The service arguments are not valid.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), setOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			current, err := client.GetServiceArgs(ctx, types.GetServiceArgsRequest{})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to retrieve the current service arguments.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			diff := types.DiffServiceArgs(current.ServiceArgs, serviceArgs)
+			if diff == "" {
+				cmd.Println("No changes.")
+				return
+			}
+			cmd.Println(diff)
+
+			if setOpts.dryRun {
+				return
+			}
+
+			if err := client.SetServiceArgs(ctx, types.SetServiceArgsRequest{ServiceArgs: serviceArgs}); err != nil {
+				cmd.PrintErrf("Error: Failed to set the service arguments.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+		},
+	}
+	setCmd.Flags().StringVar(&setOpts.file, "file", "", "path to the YAML file with the service arguments configuration, use - to read from stdin")
+	setCmd.Flags().BoolVar(&setOpts.dryRun, "dry-run", false, "only preview the changes, without applying them")
+	setCmd.Flags().DurationVar(&setOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	cmd := &cobra.Command{
+		Use:   "service-args",
+		Short: "Manage the cluster-wide extra arguments of the Kubernetes services",
+	}
+
+	cmd.AddCommand(getCmd)
+	cmd.AddCommand(setCmd)
+
+	return cmd
+}
+
+func getServiceArgsFromYaml(env cmdutil.ExecutionEnvironment, filePath string) (types.ServiceArgs, error) {
+	var b []byte
+	var err error
+
+	if filePath == "-" {
+		b, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return types.ServiceArgs{}, fmt.Errorf("failed to read config from stdin: %w", err)
+		}
+	} else {
+		b, err = os.ReadFile(filePath)
+		if err != nil {
+			return types.ServiceArgs{}, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+
+	var serviceArgs types.ServiceArgs
+	if err := yaml.UnmarshalStrict(b, &serviceArgs); err != nil {
+		return types.ServiceArgs{}, fmt.Errorf("failed to parse YAML config file: %w", err)
+	}
+
+	return serviceArgs, nil
+}
@@ -0,0 +1,138 @@
+package k8s_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	k8sdmock "github.com/canonical/k8s/pkg/client/k8sd/mock"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	snapmock "github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestServiceArgsFormat(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(k8s.ServiceArgs{}.String()).To(Equal("No service arguments configured."))
+	})
+
+	t.Run("ServiceArgs", func(t *testing.T) {
+		g := NewWithT(t)
+		serviceArgs := k8s.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{
+				Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2"), "--node-ip": nil}},
+			},
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node1": {ConfigFiles: map[string]string{"file": "content"}},
+			},
+		}
+		g.Expect(serviceArgs.String()).To(Equal(`args:
+  kubelet:
+    --node-ip: null
+    --v: "2"
+nodes:
+  node1:
+    config-files:
+      file: content`))
+	})
+}
+
+func TestServiceArgsSetCmd(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "service-args.yaml")
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(file, []byte(`
+args:
+  kubelet:
+    --v: "4"
+nodes:
+  node1:
+    args:
+      kube-proxy:
+        --v: "2"
+`), 0o600)).To(Succeed())
+
+	expectServiceArgs := types.ServiceArgs{
+		ServiceArgsConfig: types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("4")}},
+		},
+		Nodes: map[string]types.ServiceArgsConfig{
+			"node1": {Args: map[string]map[string]*string{"kube-proxy": {"--v": utils.Pointer("2")}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name           string
+		args           []string
+		current        types.ServiceArgs
+		expectSet      bool
+		expectedStdout string
+		expectedStderr string
+		expectedCode   int
+	}{
+		{
+			name:           "DryRun",
+			args:           []string{"--file", file, "--dry-run"},
+			current:        types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2")}}}},
+			expectedStdout: "cluster-wide:\n  ~ kubelet --v=\"2\" -> \"4\"\nnode node1:\n  + kube-proxy --v=\"2\"\n",
+		},
+		{
+			name:           "Set",
+			args:           []string{"--file", file},
+			expectSet:      true,
+			expectedStdout: "+ kubelet --v=\"4\"",
+		},
+		{
+			name:           "NoChanges",
+			args:           []string{"--file", file},
+			current:        expectServiceArgs,
+			expectedStdout: "No changes.",
+		},
+		{
+			name:           "MissingFile",
+			expectedStderr: "must be specified with --file",
+			expectedCode:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			mockClient := &k8sdmock.Mock{
+				NodeStatusInitialized:  true,
+				GetServiceArgsResponse: types.GetServiceArgsResponse{ServiceArgs: tc.current},
+			}
+			var returnCode int
+			env := cmdutil.ExecutionEnvironment{
+				Stdout: stdout,
+				Stderr: stderr,
+				Getuid: func() int { return 0 },
+				Snap: &snapmock.Snap{
+					Mock: snapmock.Mock{
+						K8sdClient: mockClient,
+					},
+				},
+				Exit: func(rc int) { returnCode = rc },
+			}
+			cmd := k8s.NewRootCmd(env)
+
+			cmd.SetArgs(append([]string{"service-args", "set"}, tc.args...))
+			cmd.Execute()
+
+			g.Expect(stdout.String()).To(ContainSubstring(tc.expectedStdout))
+			g.Expect(stderr.String()).To(ContainSubstring(tc.expectedStderr))
+			g.Expect(returnCode).To(Equal(tc.expectedCode))
+
+			if tc.expectSet {
+				g.Expect(mockClient.SetServiceArgsCalledWith).To(Equal(types.SetServiceArgsRequest{ServiceArgs: expectServiceArgs}))
+			} else {
+				g.Expect(mockClient.SetServiceArgsCalledWith).To(Equal(types.SetServiceArgsRequest{}))
+			}
+		})
+	}
+}
@@ -6,21 +6,26 @@ import (
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/spf13/cobra"
 )
 
 func newStatusCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	var opts struct {
-		waitReady    bool
-		outputFormat string
-		timeout      time.Duration
+		waitReady     bool
+		outputFormat  string
+		timeout       time.Duration
+		watch         bool
+		watchInterval time.Duration
 	}
 	cmd := &cobra.Command{
-		Use:    "status",
-		Short:  "Retrieve the current status of the cluster",
-		Long:   "Retrieve the current status of the cluster as well as deployment status of core features.",
-		Args:   cobra.NoArgs,
-		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &opts.outputFormat)),
+		Use:       "status [feature]",
+		Short:     "Retrieve the current status of the cluster",
+		Long:      "Retrieve the current status of the cluster as well as deployment status of core features.\nIf a feature is specified, show its detailed status, including health and conditions.",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: append(featureList, string(features.MetricsServer)),
+		PreRun:    chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &opts.outputFormat)),
 		Run: func(cmd *cobra.Command, args []string) {
 			if opts.timeout < minTimeout {
 				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", opts.timeout, minTimeout, minTimeout)
@@ -47,23 +52,82 @@ func newStatusCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 				return
 			}
 
-			response, err := client.ClusterStatus(ctx, opts.waitReady)
-			if err != nil {
-				cmd.PrintErrf("Error: Failed to retrieve the cluster status.\n\nThe error was: %v\n", err)
-				env.Exit(1)
+			if len(args) == 1 {
+				name := types.FeatureName(args[0])
+				response, err := client.FeatureStatus(ctx, types.GetFeatureStatusRequest{Name: name})
+				if err != nil {
+					cmd.PrintErrf("Error: Failed to retrieve the status of feature %q.\n\nThe error was: %v\n", name, err)
+					env.Exit(1)
+					return
+				}
+
+				outputFormatter.Print(FeatureStatusDetails{Name: name, FeatureStatus: response.Features[name]})
+				return
+			}
+
+			printStatus := func(ctx context.Context) bool {
+				response, err := client.ClusterStatus(ctx, opts.waitReady)
+				if err != nil {
+					cmd.PrintErrf("Error: Failed to retrieve the cluster status.\n\nThe error was: %v\n", err)
+					return false
+				}
+				status := DetailedClusterStatus{ClusterStatus: ClusterStatus(response.ClusterStatus)}
+
+				// silence the config, this should be retrieved with "k8s get".
+				status.Config = apiv1.UserFacingClusterConfig{}
+
+				if nodes, err := client.ClusterNodes(ctx, types.GetClusterNodesRequest{}); err != nil {
+					cmd.PrintErrf("Warning: Failed to retrieve the details of the cluster nodes: %v\n", err)
+				} else {
+					status.Nodes = nodes.Nodes
+				}
+
+				// structured output formats also include the detailed status of each feature.
+				if opts.outputFormat != "" && opts.outputFormat != "plain" {
+					featureStatus, err := client.FeatureStatus(ctx, types.GetFeatureStatusRequest{})
+					if err != nil {
+						cmd.PrintErrf("Error: Failed to retrieve the feature status.\n\nThe error was: %v\n", err)
+						return false
+					}
+					status.Features = featureStatus.Features
+				}
+
+				outputFormatter.Print(status)
+				return true
+			}
+
+			if !opts.watch {
+				if !printStatus(ctx) {
+					env.Exit(1)
+				}
 				return
 			}
-			status := response.ClusterStatus
 
-			// silence the config, this should be retrieved with "k8s get".
-			status.Config = apiv1.UserFacingClusterConfig{}
+			ticker := time.NewTicker(opts.watchInterval)
+			defer ticker.Stop()
+			for {
+				if opts.outputFormat == "" || opts.outputFormat == "plain" {
+					// clear the screen before printing the updated status
+					cmd.Print("\033[H\033[2J")
+				}
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), opts.timeout)
+				printStatus(ctx)
+				cancel()
 
-			outputFormatter.Print(ClusterStatus(status))
+				select {
+				case <-cmd.Context().Done():
+					return
+				case <-ticker.C:
+				}
+			}
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.waitReady, "wait-ready", false, "wait until at least one cluster node is ready")
 	cmd.Flags().StringVar(&opts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
 	cmd.Flags().DurationVar(&opts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+	cmd.Flags().BoolVar(&opts.watch, "watch", false, "continuously refresh the cluster status")
+	cmd.Flags().DurationVar(&opts.watchInterval, "watch-interval", 5*time.Second, "the interval between refreshes in watch mode")
 	return cmd
 }
@@ -2,13 +2,30 @@ package k8s
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 type ClusterStatus apiv1.ClusterStatus
 
+// DetailedClusterStatus is the cluster status including the details of each node and
+// the detailed status of each feature.
+type DetailedClusterStatus struct {
+	ClusterStatus `yaml:",inline"`
+	Nodes         []types.NodeDetails                       `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Features      map[types.FeatureName]types.FeatureStatus `json:"features,omitempty" yaml:"features,omitempty"`
+}
+
+// FeatureStatusDetails is the detailed status of a single feature.
+type FeatureStatusDetails struct {
+	Name                types.FeatureName `json:"name" yaml:"name"`
+	types.FeatureStatus `yaml:",inline"`
+}
+
 // TICS -COV_GO_SUPPRESSED_ERROR
 // we are just formatting the output for the k8s status command, it is ok to ignore failures from result.WriteString()
 
@@ -22,7 +39,6 @@ func (c ClusterStatus) isHA() bool {
 	return voters > 2
 }
 
-// TODO: Print k8s version. However, multiple nodes can run different version, so we would need to query all nodes.
 func (c ClusterStatus) String() string {
 	result := strings.Builder{}
 
@@ -57,7 +73,7 @@ func (c ClusterStatus) String() string {
 	result.WriteString("\n")
 
 	// Datastore
-	// TODO: how to understand if the ds is running or not?
+	// The health of the datastore on each node is part of the node details.
 	if c.Datastore.Type != "" {
 		result.WriteString(fmt.Sprintf("%-25s %s\n", "datastore:", c.Datastore.Type))
 	} else {
@@ -74,4 +90,109 @@ func (c ClusterStatus) String() string {
 	return result.String()
 }
 
+func (c DetailedClusterStatus) String() string {
+	result := strings.Builder{}
+	result.WriteString(c.ClusterStatus.String())
+
+	if len(c.Nodes) == 0 {
+		return result.String()
+	}
+
+	result.WriteString("\n\nnodes:")
+	for _, node := range c.Nodes {
+		result.WriteString("\n" + nodeDetailsString(node))
+	}
+
+	return result.String()
+}
+
+func nodeDetailsString(n types.NodeDetails) string {
+	result := strings.Builder{}
+
+	ready := "not ready"
+	if n.Ready {
+		ready = "ready"
+	}
+	result.WriteString(fmt.Sprintf("  %s (%s, %s, %s)", n.Name, n.Address, n.ClusterRole, ready))
+	if n.Error != "" {
+		result.WriteString(fmt.Sprintf("\n    %-23s %s", "error:", n.Error))
+		return result.String()
+	}
+
+	if n.KubernetesVersion != "" {
+		result.WriteString(fmt.Sprintf("\n    %-23s %s", "kubernetes version:", n.KubernetesVersion))
+	}
+	if n.SnapRevision != "" {
+		result.WriteString(fmt.Sprintf("\n    %-23s %s", "snap revision:", n.SnapRevision))
+	}
+
+	if len(n.Services) > 0 {
+		names := make([]string, 0, len(n.Services))
+		for name := range n.Services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		services := make([]string, 0, len(names))
+		for _, name := range names {
+			services = append(services, fmt.Sprintf("%s (%s)", name, n.Services[name]))
+		}
+		result.WriteString(fmt.Sprintf("\n    %-23s %s", "services:", strings.Join(services, ", ")))
+	}
+
+	if ds := n.Datastore; ds != nil {
+		if ds.Error != "" {
+			result.WriteString(fmt.Sprintf("\n    %-23s %s", "datastore:", ds.Error))
+		} else {
+			leader := ds.Leader
+			if ds.IsLeader {
+				leader = "self"
+			}
+			result.WriteString(fmt.Sprintf("\n    %-23s %s, leader %s, latency %s", "datastore:", ds.Role, leader, ds.Latency.Round(time.Millisecond)))
+		}
+	}
+
+	if !n.CertificatesExpiry.IsZero() {
+		result.WriteString(fmt.Sprintf("\n    %-23s %s", "certificates expiry:", n.CertificatesExpiry.Format(time.RFC3339)))
+	}
+
+	return result.String()
+}
+
+func (f FeatureStatusDetails) String() string {
+	result := strings.Builder{}
+
+	result.WriteString(fmt.Sprintf("%-25s %s\n", "feature:", f.Name))
+	if f.Enabled {
+		result.WriteString(fmt.Sprintf("%-25s %s\n", "enabled:", "yes"))
+	} else {
+		result.WriteString(fmt.Sprintf("%-25s %s\n", "enabled:", "no"))
+	}
+	health := f.Health
+	if health == "" {
+		health = types.FeatureHealthUnknown
+	}
+	result.WriteString(fmt.Sprintf("%-25s %s\n", "health:", health))
+	if f.Version != "" {
+		result.WriteString(fmt.Sprintf("%-25s %s\n", "version:", f.Version))
+	}
+	if f.Message != "" {
+		result.WriteString(fmt.Sprintf("%-25s %s\n", "message:", f.Message))
+	}
+	if !f.UpdatedAt.IsZero() {
+		result.WriteString(fmt.Sprintf("%-25s %s\n", "updated at:", f.UpdatedAt.Format(time.RFC3339)))
+	}
+
+	if len(f.Conditions) == 0 {
+		result.WriteString(fmt.Sprintf("%-25s %s", "conditions:", "none"))
+		return result.String()
+	}
+	result.WriteString("conditions:")
+	for _, c := range f.Conditions {
+		line := fmt.Sprintf("  %-10s %-8s %-15s %-26s %s", c.Type, c.Status, c.Reason, c.LastTransitionTime.Format(time.RFC3339), c.Message)
+		result.WriteString("\n" + strings.TrimRight(line, " "))
+	}
+
+	return result.String()
+}
+
 // TICS +COV_GO_SUPPRESSED_ERROR
@@ -2,9 +2,11 @@ package k8s_test
 
 import (
 	"testing"
+	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	"github.com/canonical/k8s/cmd/k8s"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	. "github.com/onsi/gomega"
 )
 
@@ -92,3 +94,123 @@ gateway                   disabled`,
 		})
 	}
 }
+
+func TestFeatureStatusDetailsFormat(t *testing.T) {
+	t0 := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		status         k8s.FeatureStatusDetails
+		expectedOutput string
+	}{
+		{
+			name: "Healthy feature",
+			status: k8s.FeatureStatusDetails{
+				Name: "dns",
+				FeatureStatus: types.FeatureStatus{
+					Enabled:   true,
+					Message:   "enabled at 10.152.183.10",
+					Version:   "1.12.0",
+					UpdatedAt: t0,
+					Health:    types.FeatureHealthHealthy,
+					Conditions: []types.FeatureCondition{
+						{Type: types.FeatureConditionApplied, Status: types.ConditionTrue, Reason: "Reconciled", Message: "enabled at 10.152.183.10", LastTransitionTime: t0},
+						{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue, Reason: "CheckPassed", LastTransitionTime: t0},
+					},
+				},
+			},
+			expectedOutput: `feature:                  dns
+enabled:                  yes
+health:                   healthy
+version:                  1.12.0
+message:                  enabled at 10.152.183.10
+updated at:               2025-01-02T03:04:05Z
+conditions:
+  Applied    True     Reconciled      2025-01-02T03:04:05Z       enabled at 10.152.183.10
+  Healthy    True     CheckPassed     2025-01-02T03:04:05Z`,
+		},
+		{
+			name: "Unknown feature",
+			status: k8s.FeatureStatusDetails{
+				Name: "gateway",
+			},
+			expectedOutput: `feature:                  gateway
+enabled:                  no
+health:                   unknown
+conditions:               none`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tc.status.String()).To(Equal(tc.expectedOutput))
+		})
+	}
+}
+
+func TestDetailedClusterStatusFormat(t *testing.T) {
+	g := NewWithT(t)
+
+	status := k8s.DetailedClusterStatus{
+		ClusterStatus: k8s.ClusterStatus{
+			Ready: true,
+			Members: []apiv1.NodeStatus{
+				{Name: "node1", DatastoreRole: apiv1.DatastoreRoleVoter, Address: "192.168.0.1", ClusterRole: apiv1.ClusterRoleControlPlane},
+			},
+			Datastore: apiv1.Datastore{Type: "k8s-dqlite"},
+		},
+		Nodes: []types.NodeDetails{
+			{
+				Name:              "node1",
+				Address:           "192.168.0.1:6400",
+				ClusterRole:       apiv1.ClusterRoleControlPlane,
+				Ready:             true,
+				SnapRevision:      "1234",
+				KubernetesVersion: "v1.32.0",
+				Services: map[string]types.ServiceState{
+					"kubelet":    types.ServiceStateActive,
+					"containerd": types.ServiceStateActive,
+					"k8s-dqlite": types.ServiceStateInactive,
+				},
+				Datastore:          &types.NodeDatastoreStatus{Role: apiv1.DatastoreRoleVoter, Leader: "192.168.0.1:9000", IsLeader: true, Latency: 1500 * time.Microsecond},
+				CertificatesExpiry: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+			{
+				Name:        "node2",
+				Address:     "192.168.0.2:6400",
+				ClusterRole: apiv1.ClusterRoleControlPlane,
+				Error:       "failed to get node details: connection refused",
+			},
+			{
+				Name:              "worker1",
+				Address:           "192.168.0.3",
+				ClusterRole:       apiv1.ClusterRoleWorker,
+				KubernetesVersion: "v1.31.2",
+			},
+		},
+	}
+
+	g.Expect(status.String()).To(Equal(`cluster status:           ready
+control plane nodes:      192.168.0.1 (voter)
+high availability:        no
+datastore:                k8s-dqlite
+network:                  disabled
+dns:                      disabled
+ingress:                  disabled
+load-balancer:            disabled
+local-storage:            disabled
+gateway                   disabled
+
+nodes:
+  node1 (192.168.0.1:6400, control-plane, ready)
+    kubernetes version:     v1.32.0
+    snap revision:          1234
+    services:               containerd (active), k8s-dqlite (inactive), kubelet (active)
+    datastore:              voter, leader self, latency 2ms
+    certificates expiry:    2026-01-02T03:04:05Z
+  node2 (192.168.0.2:6400, control-plane, not ready)
+    error:                  failed to get node details: connection refused
+  worker1 (192.168.0.3, worker, not ready)
+    kubernetes version:     v1.31.2`))
+}
@@ -0,0 +1,285 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+)
+
+type KubernetesAuthTokens []types.KubernetesAuthToken
+
+func (t KubernetesAuthTokens) String() string {
+	if len(t) == 0 {
+		return "No tokens found."
+	}
+
+	formatTime := func(t time.Time, zero string) string {
+		if t.IsZero() {
+			return zero
+		}
+		return t.Format("Jan 02, 2006 15:04 MST")
+	}
+
+	result := &strings.Builder{}
+	w := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSERNAME\tGROUPS\tCREATED\tEXPIRES\tLAST USED\tDESCRIPTION")
+	for _, token := range t {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			token.ID,
+			token.Username,
+			strings.Join(token.Groups, ","),
+			formatTime(token.CreatedAt, "unknown"),
+			formatTime(token.Expiry, "never"),
+			formatTime(token.LastUsedAt, "never"),
+			token.Description,
+		)
+	}
+	w.Flush()
+
+	// the description may be empty, do not leave trailing whitespace behind
+	lines := strings.Split(strings.TrimRight(result.String(), "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+type JoinTokens []types.JoinToken
+
+func (t JoinTokens) String() string {
+	if len(t) == 0 {
+		return "No join tokens found."
+	}
+
+	valueOr := func(value string, empty string) string {
+		if value == "" {
+			return empty
+		}
+		return value
+	}
+
+	result := &strings.Builder{}
+	w := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNODE\tROLE\tEXPIRES\tUSE\tALLOWED CIDRS\tPOOL")
+	for _, token := range t {
+		id, role, use := "-", "control-plane", "single"
+		if token.Worker {
+			id, role = strconv.FormatInt(token.ID, 10), "worker"
+		}
+		if token.MultiUse {
+			use = "multi"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			id,
+			valueOr(token.Name, "(any)"),
+			role,
+			token.Expiry.Format("Jan 02, 2006 15:04 MST"),
+			use,
+			valueOr(strings.Join(token.AllowedCIDRs, ","), "(any)"),
+			token.Pool,
+		)
+	}
+	w.Flush()
+
+	// the pool may be empty, do not leave trailing whitespace behind
+	lines := strings.Split(strings.TrimRight(result.String(), "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newTokenCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var createOpts struct {
+		groups      []string
+		description string
+		ttl         time.Duration
+		timeout     time.Duration
+	}
+	createCmd := &cobra.Command{
+		Use:   "create <username>",
+		Short: "Create a token to authenticate with the Kubernetes API server",
+		Long: "Create a token to authenticate with the Kubernetes API server.\n" +
+			"Tokens that never expire are reused, so the same token is printed again for the same user, groups and description until it is revoked.\n" +
+			"Tokens with an expiry are only printed once and cannot be retrieved again.",
+		Args:   cmdutil.ExactArgs(env, 1),
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if createOpts.ttl < 0 {
+				cmd.PrintErrf("Error: Invalid --expires-in %v, it cannot be negative.\n", createOpts.ttl)
+				env.Exit(1)
+				return
+			}
+			if createOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", createOpts.timeout, minTimeout, minTimeout)
+				createOpts.timeout = minTimeout
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), createOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			response, err := client.CreateKubernetesAuthToken(ctx, types.GenerateKubernetesAuthTokenRequest{
+				Username:    args[0],
+				Groups:      createOpts.groups,
+				Description: createOpts.description,
+				TTL:         createOpts.ttl,
+			})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to create a token for %q.\n\nThe error was: %v\n", args[0], err)
+				env.Exit(1)
+				return
+			}
+
+			cmd.Println(response.Token)
+		},
+	}
+	createCmd.Flags().StringSliceVar(&createOpts.groups, "groups", nil, "comma-separated list of groups of the user")
+	createCmd.Flags().StringVar(&createOpts.description, "description", "", "a description of the token")
+	// The CLI uses verbose names for flags instead of abbreviations. Internally and for the API, the common TTL (time-to-live) name is used.
+	createCmd.Flags().DurationVar(&createOpts.ttl, "expires-in", 0, "the time until the token expires, 0 means that the token never expires")
+	createCmd.Flags().DurationVar(&createOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	var listOpts struct {
+		join         bool
+		outputFormat string
+		timeout      time.Duration
+	}
+	listCmd := &cobra.Command{
+		Use:    "list",
+		Short:  "List the tokens to authenticate with the Kubernetes API server",
+		Long:   "List the tokens to authenticate with the Kubernetes API server.\nWith --join, list the outstanding tokens for nodes to join the cluster instead.",
+		Args:   cobra.NoArgs,
+		PreRun: chainPreRunHooks(hookRequireRoot(env), hookInitializeFormatter(env, &listOpts.outputFormat)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if listOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", listOpts.timeout, minTimeout, minTimeout)
+				listOpts.timeout = minTimeout
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), listOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			if listOpts.join {
+				response, err := client.ListJoinTokens(ctx, types.ListJoinTokensRequest{})
+				if err != nil {
+					cmd.PrintErrf("Error: Failed to list the join tokens.\n\nThe error was: %v\n", err)
+					env.Exit(1)
+					return
+				}
+
+				outputFormatter.Print(JoinTokens(response.Tokens))
+				return
+			}
+
+			response, err := client.ListKubernetesAuthTokens(ctx, types.ListKubernetesAuthTokensRequest{})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to list the tokens.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			outputFormatter.Print(KubernetesAuthTokens(response.Tokens))
+		},
+	}
+	listCmd.Flags().BoolVar(&listOpts.join, "join", false, "list the tokens for nodes to join the cluster")
+	listCmd.Flags().StringVar(&listOpts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+	listCmd.Flags().DurationVar(&listOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	var revokeOpts struct {
+		join    bool
+		worker  bool
+		timeout time.Duration
+	}
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <id|token|node-name>",
+		Short: "Revoke a token to authenticate with the Kubernetes API server",
+		Long: "Revoke a token to authenticate with the Kubernetes API server.\nThe token can be specified by its ID, as shown by \"k8s token list\", or by the token itself.\n" +
+			"With --join, revoke a token for a node to join the cluster instead. Worker node tokens are specified by their ID, as shown by \"k8s token list --join\", " +
+			"or by the node name with --worker. Control plane tokens are specified by the node name.",
+		Args:   cmdutil.ExactArgs(env, 1),
+		PreRun: chainPreRunHooks(hookRequireRoot(env)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if revokeOpts.worker && !revokeOpts.join {
+				cmd.PrintErrln("Error: --worker can only be used with --join.")
+				env.Exit(1)
+				return
+			}
+			if revokeOpts.timeout < minTimeout {
+				cmd.PrintErrf("Timeout %v is less than minimum of %v. Using the minimum %v instead.\n", revokeOpts.timeout, minTimeout, minTimeout)
+				revokeOpts.timeout = minTimeout
+			}
+
+			if revokeOpts.join {
+				request := types.RevokeJoinTokenRequest{Name: args[0], Worker: revokeOpts.worker}
+				if id, err := strconv.ParseInt(args[0], 10, 64); err == nil && !revokeOpts.worker {
+					request = types.RevokeJoinTokenRequest{ID: id}
+				}
+
+				client, ok := getK8sdClient(cmd, env)
+				if !ok {
+					return
+				}
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), revokeOpts.timeout)
+				cobra.OnFinalize(cancel)
+
+				if err := client.RevokeJoinToken(ctx, request); err != nil {
+					cmd.PrintErrf("Error: Failed to revoke the join token.\n\nThe error was: %v\n", err)
+					env.Exit(1)
+				}
+				return
+			}
+
+			request := types.RevokeKubernetesAuthTokenRequest{Token: args[0]}
+			if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+				request = types.RevokeKubernetesAuthTokenRequest{ID: id}
+			}
+
+			client, ok := getK8sdClient(cmd, env)
+			if !ok {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), revokeOpts.timeout)
+			cobra.OnFinalize(cancel)
+
+			if err := client.RevokeKubernetesAuthToken(ctx, request); err != nil {
+				cmd.PrintErrf("Error: Failed to revoke the token.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+		},
+	}
+	revokeCmd.Flags().BoolVar(&revokeOpts.join, "join", false, "revoke a token for a node to join the cluster")
+	revokeCmd.Flags().BoolVar(&revokeOpts.worker, "worker", false, "with --join, revoke all worker node tokens of the named node")
+	revokeCmd.Flags().DurationVar(&revokeOpts.timeout, "timeout", 90*time.Second, "the max time to wait for the command to execute")
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage tokens to authenticate with the Kubernetes API server and to join nodes",
+		Long:  "Manage tokens to authenticate with the Kubernetes API server and to join nodes.\nNew tokens for nodes to join the cluster are created with \"k8s get-join-token\".",
+	}
+
+	cmd.AddCommand(createCmd)
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(revokeCmd)
+
+	return cmd
+}
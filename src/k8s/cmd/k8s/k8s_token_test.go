@@ -0,0 +1,55 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/cmd/k8s"
+	. "github.com/onsi/gomega"
+)
+
+func TestKubernetesAuthTokensFormat(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(k8s.KubernetesAuthTokens(nil).String()).To(Equal("No tokens found."))
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		g := NewWithT(t)
+		tokens := k8s.KubernetesAuthTokens{
+			{ID: 1, Username: "admin", Groups: []string{"system:masters"}},
+			{
+				ID:          2,
+				Username:    "ci",
+				Description: "ci pipeline",
+				CreatedAt:   time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC),
+				Expiry:      time.Date(2025, 2, 2, 3, 4, 0, 0, time.UTC),
+				LastUsedAt:  time.Date(2025, 1, 3, 3, 4, 0, 0, time.UTC),
+			},
+		}
+		g.Expect(tokens.String()).To(Equal(`ID  USERNAME  GROUPS          CREATED                 EXPIRES                 LAST USED               DESCRIPTION
+1   admin     system:masters  unknown                 never                   never
+2   ci                        Jan 02, 2025 03:04 UTC  Feb 02, 2025 03:04 UTC  Jan 03, 2025 03:04 UTC  ci pipeline`))
+	})
+}
+
+func TestJoinTokensFormat(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(k8s.JoinTokens(nil).String()).To(Equal("No join tokens found."))
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		g := NewWithT(t)
+		expiry := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+		tokens := k8s.JoinTokens{
+			{Name: "cp-2", Expiry: expiry, Pool: "gpu"},
+			{ID: 3, Name: "worker-1", Worker: true, Expiry: expiry},
+			{ID: 4, Worker: true, Expiry: expiry, MultiUse: true, AllowedCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}, Pool: "edge"},
+		}
+		g.Expect(tokens.String()).To(Equal(`ID  NODE      ROLE           EXPIRES                 USE     ALLOWED CIDRS            POOL
+-   cp-2      control-plane  Jan 02, 2025 03:04 UTC  single  (any)                    gpu
+3   worker-1  worker         Jan 02, 2025 03:04 UTC  single  (any)
+4   (any)     worker         Jan 02, 2025 03:04 UTC  multi   10.0.0.0/24,10.0.1.0/24  edge`))
+	})
+}
@@ -19,6 +19,9 @@ var rootCmdOpts struct {
 	disableNodeLabelController          bool
 	disableControlPlaneConfigController bool
 	disableFeatureController            bool
+	disableFeatureHealthController      bool
+	disableFeatureDriftController       bool
+	disableIngressClassController       bool
 	disableUpdateNodeConfigController   bool
 	disableCSRSigningController         bool
 	drainConnectionsTimeout             time.Duration
@@ -57,6 +60,9 @@ func NewRootCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 				DisableControlPlaneConfigController: rootCmdOpts.disableControlPlaneConfigController,
 				DisableUpdateNodeConfigController:   rootCmdOpts.disableUpdateNodeConfigController,
 				DisableFeatureController:            rootCmdOpts.disableFeatureController,
+				DisableFeatureHealthController:      rootCmdOpts.disableFeatureHealthController,
+				DisableFeatureDriftController:       rootCmdOpts.disableFeatureDriftController,
+				DisableIngressClassController:       rootCmdOpts.disableIngressClassController,
 				DisableCSRSigningController:         rootCmdOpts.disableCSRSigningController,
 				DrainConnectionsTimeout:             rootCmdOpts.drainConnectionsTimeout,
 			})
@@ -88,6 +94,9 @@ func NewRootCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableControlPlaneConfigController, "disable-control-plane-config-controller", false, "Disable the Control Plane Config Controller")
 	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableUpdateNodeConfigController, "disable-update-node-config-controller", false, "Disable the Update Node Config Controller")
 	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableFeatureController, "disable-feature-controller", false, "Disable the Feature Controller")
+	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableFeatureHealthController, "disable-feature-health-controller", false, "Disable the Feature Health Controller")
+	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableFeatureDriftController, "disable-feature-drift-controller", false, "Disable the Feature Drift Controller")
+	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableIngressClassController, "disable-ingress-class-controller", false, "Disable the Ingress Class Controller")
 	cmd.PersistentFlags().BoolVar(&rootCmdOpts.disableCSRSigningController, "disable-csrsigning-controller", false, "Disable the CSR signing controller")
 
 	cmd.Flags().Uint("port", 0, "Default port for the HTTP API")
@@ -95,6 +104,7 @@ func NewRootCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	cmd.Flags().DurationVar(&rootCmdOpts.drainConnectionsTimeout, "drain-connection-timeout", 10*time.Second, "amount of time to allow for all connections to drain when shutting down")
 
 	cmd.AddCommand(newSqlCmd(env))
+	cmd.AddCommand(newDBCmd(env))
 
 	addCommands(
 		cmd,
@@ -40,6 +40,14 @@ Note that before applying any changes, a database backup is created at:
 
 const recoveryConfirmation = "Do you want to proceed? (yes/no): "
 
+const declarativeMessage = `Surviving cluster members specified.
+
+The command will assign the voter role to the surviving cluster members and the
+spare role to all other cluster members.
+
+Initiating the dqlite database recovery.
+`
+
 const nonInteractiveMessage = `Non-interactive mode requested.
 
 The command will assume that the dqlite configuration files have already been
@@ -87,6 +95,8 @@ var clusterRecoverOpts struct {
 	NonInteractive    bool
 	SkipK8sd          bool
 	SkipK8sDqlite     bool
+	Members           []string
+	MembersFile       string
 }
 
 func logDebugf(format string, args ...interface{}) {
@@ -103,37 +113,61 @@ func newClusterRecoverCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cluster-recover",
 		Short: "Recover the cluster from this member if quorum is lost",
+		Long: "Recover the cluster from this member if quorum is lost.\n" +
+			"The surviving cluster members can be specified with --members or --members-file, in which case the\n" +
+			"member roles are updated without any prompts. Use \"k8sd cluster-recover apply\" to install the\n" +
+			"generated recovery tarballs on the remaining cluster members.",
+		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			log.Configure(log.Options{
 				LogLevel:     rootCmdOpts.logLevel,
 				AddDirHeader: true,
 			})
 
-			if err := recoveryCmdPrechecks(cmd); err != nil {
+			declarative := len(clusterRecoverOpts.Members) > 0 || clusterRecoverOpts.MembersFile != ""
+			if declarative {
+				clusterRecoverOpts.NonInteractive = true
+			}
+
+			if err := recoveryCmdPrechecks(cmd, declarative); err != nil {
 				cmd.PrintErrf("Recovery precheck failed: %v\n", err)
 				env.Exit(1)
 			}
 
+			var plan *recoveryPlan
+			if declarative {
+				var err error
+				if plan, err = newRecoveryPlan(); err != nil {
+					cmd.PrintErrf("Invalid surviving cluster members, error: %v\n", err)
+					env.Exit(1)
+				}
+			}
+
 			if clusterRecoverOpts.SkipK8sd {
 				cmd.Printf("Skipping k8sd recovery.\n")
 			} else {
-				k8sdTarballPath, err := recoverK8sd()
+				k8sdTarballPath, err := recoverK8sd(plan)
 				if err != nil {
 					cmd.PrintErrf("Failed to recover k8sd, error: %v\n", err)
 					env.Exit(1)
 				}
+				checksumPath, err := writeRecoveryChecksum(k8sdTarballPath)
+				if err != nil {
+					cmd.PrintErrf("Failed to write k8sd recovery tarball checksum, error: %v\n", err)
+					env.Exit(1)
+				}
 				cmd.Printf("K8sd cluster changes applied.\n")
-				cmd.Printf("New database state saved to %s\n", k8sdTarballPath)
-				cmd.Printf("*Before* starting any cluster member, copy %s to %s "+
-					"on all remaining cluster members.\n",
-					k8sdTarballPath, k8sdTarballPath)
+				cmd.Printf("New database state saved to %s (checksum in %s)\n", k8sdTarballPath, checksumPath)
+				cmd.Printf("*Before* starting any cluster member, copy %s and %s "+
+					"to all remaining cluster members and run:\n\n  %s\n\n",
+					k8sdTarballPath, checksumPath, recoveryApplyCommand(k8sdTarballPath))
 				cmd.Printf("K8sd will load this file during startup.\n\n")
 			}
 
 			if clusterRecoverOpts.SkipK8sDqlite {
 				cmd.Printf("Skipping k8s-dqlite recovery.\n")
 			} else {
-				k8sDqlitePreRecoveryTarball, k8sDqlitePostRecoveryTarball, err := recoverK8sDqlite()
+				k8sDqlitePreRecoveryTarball, k8sDqlitePostRecoveryTarball, err := recoverK8sDqlite(plan)
 				if err != nil {
 					cmd.PrintErrf(
 						"Failed to recover k8s-dqlite, error: %v, "+
@@ -141,18 +175,23 @@ func newClusterRecoverCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 						err, k8sDqlitePreRecoveryTarball)
 					env.Exit(1)
 				}
+				checksumPath, err := writeRecoveryChecksum(k8sDqlitePostRecoveryTarball)
+				if err != nil {
+					cmd.PrintErrf("Failed to write k8s-dqlite recovery tarball checksum, error: %v\n", err)
+					env.Exit(1)
+				}
 				cmd.Printf("K8s-dqlite cluster changes applied.\n")
-				cmd.Printf("New database state saved to %s\n",
-					k8sDqlitePostRecoveryTarball)
-				cmd.Printf("*Before* starting any cluster member, copy %s "+
-					"on all remaining cluster members and extract the archive to %s.\n",
-					k8sDqlitePostRecoveryTarball, clusterRecoverOpts.K8sDqliteStateDir)
+				cmd.Printf("New database state saved to %s (checksum in %s)\n",
+					k8sDqlitePostRecoveryTarball, checksumPath)
+				cmd.Printf("*Before* starting any cluster member, copy %s and %s "+
+					"to all remaining cluster members and run:\n\n  %s\n\n",
+					k8sDqlitePostRecoveryTarball, checksumPath, recoveryApplyCommand(k8sDqlitePostRecoveryTarball))
 				cmd.Printf("Pre-recovery database backup: %s\n\n", k8sDqlitePreRecoveryTarball)
 			}
 		},
 	}
 
-	cmd.Flags().StringVar(&clusterRecoverOpts.K8sDqliteStateDir, "k8s-dqlite-state-dir",
+	cmd.PersistentFlags().StringVar(&clusterRecoverOpts.K8sDqliteStateDir, "k8s-dqlite-state-dir",
 		"", "k8s-dqlite datastore location")
 	cmd.Flags().BoolVar(&clusterRecoverOpts.NonInteractive, "non-interactive",
 		false, "disable interactive prompts, assume that the configs have been updated")
@@ -160,6 +199,12 @@ func newClusterRecoverCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		false, "skip k8sd recovery")
 	cmd.Flags().BoolVar(&clusterRecoverOpts.SkipK8sDqlite, "skip-k8s-dqlite",
 		false, "skip k8s-dqlite recovery")
+	cmd.Flags().StringSliceVar(&clusterRecoverOpts.Members, "members",
+		nil, "names or addresses of the surviving cluster members, implies --non-interactive")
+	cmd.Flags().StringVar(&clusterRecoverOpts.MembersFile, "members-file",
+		"", "YAML file with the names or addresses of the surviving cluster members, implies --non-interactive")
+
+	cmd.AddCommand(newClusterRecoverApplyCmd(env))
 
 	return cmd
 }
@@ -177,7 +222,7 @@ func removeEmptyLines(content []byte) []byte {
 	return out
 }
 
-func recoveryCmdPrechecks(cmd *cobra.Command) error {
+func recoveryCmdPrechecks(cmd *cobra.Command, declarative bool) error {
 	log := log.FromContext(cmd.Context())
 
 	log.V(1).Info("Running prechecks.")
@@ -196,7 +241,10 @@ func recoveryCmdPrechecks(cmd *cobra.Command) error {
 	cmd.Print(preRecoveryMessage)
 	cmd.Print("\n")
 
-	if clusterRecoverOpts.NonInteractive {
+	if declarative {
+		cmd.Print(declarativeMessage)
+		cmd.Print("\n")
+	} else if clusterRecoverOpts.NonInteractive {
 		cmd.Print(nonInteractiveMessage)
 		cmd.Print("\n")
 	} else {
@@ -371,7 +419,8 @@ func createK8sDqliteRecoveryTarball(pathSuffix string) (string, error) {
 }
 
 // On success, returns the recovery tarball path.
-func recoverK8sd() (string, error) {
+// If a recovery plan is specified, the member roles of the plan are used instead of prompting the user.
+func recoverK8sd(plan *recoveryPlan) (string, error) {
 	m, err := microcluster.App(
 		microcluster.Args{
 			StateDir: rootCmdOpts.stateDir,
@@ -381,6 +430,14 @@ func recoverK8sd() (string, error) {
 		return "", fmt.Errorf("could not initialize microcluster app, error: %w", err)
 	}
 
+	if plan != nil {
+		tarballPath, err := m.RecoverFromQuorumLoss(plan.k8sdMembers)
+		if err != nil {
+			return "", fmt.Errorf("k8sd recovery failed, error: %w", err)
+		}
+		return tarballPath, nil
+	}
+
 	// The following method parses cluster.yaml and filters out the entries
 	// that are not included in the trust store. Note that in case of k8s-dqlite,
 	// there is no trust store.
@@ -488,7 +545,8 @@ func recoverK8sd() (string, error) {
 	return tarballPath, nil
 }
 
-func recoverK8sDqlite() (string, string, error) {
+// If a recovery plan is specified, the member roles of the plan are used instead of prompting the user.
+func recoverK8sDqlite(plan *recoveryPlan) (string, string, error) {
 	k8sDqliteStateDir := clusterRecoverOpts.K8sDqliteStateDir
 
 	var err error
@@ -496,7 +554,12 @@ func recoverK8sDqlite() (string, string, error) {
 	clusterYamlPath := path.Join(k8sDqliteStateDir, "cluster.yaml")
 	clusterYamlCommentHeader := fmt.Sprintf("# k8s-dqlite cluster configuration\n# (%s)\n", clusterYamlPath)
 
-	if clusterRecoverOpts.NonInteractive {
+	if plan != nil {
+		clusterYamlContent, err = yaml.Marshal(plan.k8sDqliteMembers)
+		if err != nil {
+			return "", "", fmt.Errorf("could not serialize k8s-dqlite cluster members, error: %w", err)
+		}
+	} else if clusterRecoverOpts.NonInteractive {
 		clusterYamlContent, err = os.ReadFile(clusterYamlPath)
 		if err != nil {
 			return "", "", fmt.Errorf(
@@ -552,6 +615,13 @@ func recoverK8sDqlite() (string, string, error) {
 		return "", "", fmt.Errorf("failed to create pre-recovery backup tarball, error: %w", err)
 	}
 
+	if plan != nil {
+		// The new member roles are written after the backup, so that the backup has the original cluster.yaml
+		if err := utils.WriteFile(clusterYamlPath, clusterYamlContent, os.FileMode(0o644)); err != nil {
+			return preRecoveryTarball, "", fmt.Errorf("could not write file: %s, error: %w", clusterYamlPath, err)
+		}
+	}
+
 	if err = dqlite.ReconfigureMembershipExt(k8sDqliteStateDir, newMembers); err != nil {
 		return preRecoveryTarball, "", fmt.Errorf("k8s-dqlite recovery failed, error: %w", err)
 	}
@@ -0,0 +1,214 @@
+package k8sd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// k8sdRecoveryTarballName is the name of the recovery tarball created by microcluster.
+// K8sd loads the tarball from its state directory during startup.
+const k8sdRecoveryTarballName = "recovery_db.tar.gz"
+
+// k8sDqliteRecoveryTarballPrefix is the name prefix of the recovery tarballs created for k8s-dqlite.
+const k8sDqliteRecoveryTarballPrefix = "recovery-k8s-dqlite-"
+
+// k8sDqliteNodeFiles are the node specific files of k8s-dqlite, which are not part of the recovery tarball.
+var k8sDqliteNodeFiles = []string{"cluster.crt", "cluster.key", "info.yaml"}
+
+func newClusterRecoverApplyCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var opts struct {
+		checksum string
+	}
+	cmd := &cobra.Command{
+		Use:   "apply <tarball>",
+		Short: "Install a recovery tarball on a remaining cluster member",
+		Long: "Install a recovery tarball created by \"k8sd cluster-recover\" on a remaining cluster member.\n" +
+			"The checksum of the tarball is verified before any changes are made. All k8s services must be stopped.",
+		Args: cmdutil.ExactArgs(env, 1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Configure(log.Options{
+				LogLevel:     rootCmdOpts.logLevel,
+				AddDirHeader: true,
+			})
+
+			tarballPath := args[0]
+			if err := verifyRecoveryChecksum(tarballPath, opts.checksum); err != nil {
+				cmd.PrintErrf("Recovery tarball verification failed: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			switch name := filepath.Base(tarballPath); {
+			case name == k8sdRecoveryTarballName:
+				if rootCmdOpts.stateDir == "" {
+					cmd.PrintErrf("Failed to apply k8sd recovery tarball: k8sd state dir not specified\n")
+					env.Exit(1)
+					return
+				}
+				destPath, err := applyK8sdRecoveryTarball(tarballPath)
+				if err != nil {
+					cmd.PrintErrf("Failed to apply k8sd recovery tarball, error: %v\n", err)
+					env.Exit(1)
+					return
+				}
+				cmd.Printf("K8sd recovery tarball installed to %s.\n", destPath)
+				cmd.Printf("K8sd will load this file during startup.\n")
+
+			case strings.HasPrefix(name, k8sDqliteRecoveryTarballPrefix):
+				if clusterRecoverOpts.K8sDqliteStateDir == "" {
+					cmd.PrintErrf("Failed to apply k8s-dqlite recovery tarball: k8s-dqlite state dir not specified\n")
+					env.Exit(1)
+					return
+				}
+				if err := ensureK8sDqliteMembersStopped(cmd.Context()); err != nil {
+					cmd.PrintErrf("Failed to apply k8s-dqlite recovery tarball, error: %v\n", err)
+					env.Exit(1)
+					return
+				}
+				backupDir, err := applyK8sDqliteRecoveryTarball(tarballPath)
+				if err != nil {
+					cmd.PrintErrf("Failed to apply k8s-dqlite recovery tarball, error: %v, backup: %s\n", err, backupDir)
+					env.Exit(1)
+					return
+				}
+				cmd.Printf("K8s-dqlite recovery tarball extracted to %s.\n", clusterRecoverOpts.K8sDqliteStateDir)
+				cmd.Printf("Previous k8s-dqlite state directory moved to %s.\n", backupDir)
+
+			default:
+				cmd.PrintErrf("Error: %q is not a k8sd (%s) or k8s-dqlite (%s*) recovery tarball.\n", tarballPath, k8sdRecoveryTarballName, k8sDqliteRecoveryTarballPrefix)
+				env.Exit(1)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.checksum, "checksum", "", "expected SHA256 checksum of the tarball, read from <tarball>.sha256 if not specified")
+
+	return cmd
+}
+
+// recoveryApplyCommand returns the command to install a recovery tarball on the remaining cluster members.
+func recoveryApplyCommand(tarballPath string) string {
+	return fmt.Sprintf("sudo %s cluster-recover apply --state-dir=%s --k8s-dqlite-state-dir=%s %s",
+		os.Args[0], rootCmdOpts.stateDir, clusterRecoverOpts.K8sDqliteStateDir, tarballPath)
+}
+
+// writeRecoveryChecksum writes the SHA256 checksum of a recovery tarball next to it, in the format of sha256sum.
+// On success, returns the path of the checksum file.
+func writeRecoveryChecksum(tarballPath string) (string, error) {
+	checksum, err := utils.FileSHA256(tarballPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute checksum of %s, error: %w", tarballPath, err)
+	}
+
+	checksumPath := tarballPath + ".sha256"
+	content := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(tarballPath))
+	if err := utils.WriteFile(checksumPath, []byte(content), os.FileMode(0o644)); err != nil {
+		return "", fmt.Errorf("could not write file: %s, error: %w", checksumPath, err)
+	}
+	return checksumPath, nil
+}
+
+// verifyRecoveryChecksum verifies the SHA256 checksum of a recovery tarball.
+// If no checksum is specified, it is read from the checksum file next to the tarball.
+func verifyRecoveryChecksum(tarballPath string, expected string) error {
+	if expected == "" {
+		checksumPath := tarballPath + ".sha256"
+		b, err := os.ReadFile(checksumPath)
+		if err != nil {
+			return fmt.Errorf("could not read checksum file, use --checksum to specify the checksum, error: %w", err)
+		}
+		fields := strings.Fields(string(b))
+		if len(fields) == 0 {
+			return fmt.Errorf("checksum file %s is empty", checksumPath)
+		}
+		expected = fields[0]
+	}
+
+	checksum, err := utils.FileSHA256(tarballPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute checksum of %s, error: %w", tarballPath, err)
+	}
+	if !strings.EqualFold(checksum, expected) {
+		return fmt.Errorf("checksum mismatch for %s, expected %s but got %s", tarballPath, expected, checksum)
+	}
+	return nil
+}
+
+// applyK8sdRecoveryTarball copies the k8sd recovery tarball to the k8sd state directory.
+// On success, returns the path of the installed tarball.
+func applyK8sdRecoveryTarball(tarballPath string) (string, error) {
+	destPath := path.Join(rootCmdOpts.stateDir, k8sdRecoveryTarballName)
+
+	src, err := filepath.Abs(tarballPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid tarball path %s, error: %w", tarballPath, err)
+	}
+	dst, err := filepath.Abs(destPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination path %s, error: %w", destPath, err)
+	}
+	if src == dst {
+		return destPath, nil
+	}
+
+	if err := utils.CopyFile(tarballPath, destPath); err != nil {
+		return "", fmt.Errorf("could not copy %s to %s, error: %w", tarballPath, destPath, err)
+	}
+	if err := os.Chmod(destPath, 0o600); err != nil {
+		return "", fmt.Errorf("could not set permissions of %s, error: %w", destPath, err)
+	}
+	return destPath, nil
+}
+
+// applyK8sDqliteRecoveryTarball replaces the k8s-dqlite state directory with the contents of the recovery tarball.
+// The previous state directory is kept as a backup, and the node specific files are copied over from it.
+// Returns the path of the backup directory.
+func applyK8sDqliteRecoveryTarball(tarballPath string) (string, error) {
+	stateDir := clusterRecoverOpts.K8sDqliteStateDir
+	timestamp := time.Now().Format("2006-01-02T150405Z0700")
+	backupDir := fmt.Sprintf("%s.bkp-%s", filepath.Clean(stateDir), timestamp)
+
+	stat, err := os.Stat(stateDir)
+	if err != nil {
+		return "", fmt.Errorf("could not access k8s-dqlite state dir, error: %w", err)
+	}
+
+	if err := os.Rename(stateDir, backupDir); err != nil {
+		return "", fmt.Errorf("could not back up k8s-dqlite state dir to %s, error: %w", backupDir, err)
+	}
+	if err := os.Mkdir(stateDir, stat.Mode().Perm()); err != nil {
+		return backupDir, fmt.Errorf("could not create k8s-dqlite state dir, error: %w", err)
+	}
+
+	if err := utils.ExtractTarball(tarballPath, stateDir); err != nil {
+		return backupDir, fmt.Errorf("could not extract %s, error: %w", tarballPath, err)
+	}
+
+	for _, name := range k8sDqliteNodeFiles {
+		src := path.Join(backupDir, name)
+		dst := path.Join(stateDir, name)
+
+		info, err := os.Stat(src)
+		if err != nil {
+			return backupDir, fmt.Errorf("could not access %s, error: %w", src, err)
+		}
+		if err := utils.CopyFile(src, dst); err != nil {
+			return backupDir, fmt.Errorf("could not copy %s to %s, error: %w", src, dst, err)
+		}
+		if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+			return backupDir, fmt.Errorf("could not set permissions of %s, error: %w", dst, err)
+		}
+	}
+
+	return backupDir, nil
+}
@@ -0,0 +1,208 @@
+package k8sd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+
+	"github.com/canonical/go-dqlite/v2"
+	"github.com/canonical/go-dqlite/v2/client"
+	"github.com/canonical/microcluster/v2/cluster"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"gopkg.in/yaml.v2"
+)
+
+// recoveryPlan is the new membership of the dqlite clusters, based on the surviving cluster members.
+type recoveryPlan struct {
+	k8sdMembers      []cluster.DqliteMember
+	k8sDqliteMembers []dqlite.NodeInfo
+}
+
+// recoveryMembersFile is the format of the file passed with --members-file.
+type recoveryMembersFile struct {
+	// Members are the names or addresses of the surviving cluster members.
+	Members []string `yaml:"members"`
+}
+
+// readSurvivingMembers returns the surviving cluster members from the --members or --members-file flags.
+func readSurvivingMembers() ([]string, error) {
+	if len(clusterRecoverOpts.Members) > 0 && clusterRecoverOpts.MembersFile != "" {
+		return nil, fmt.Errorf("only one of --members and --members-file can be specified")
+	}
+
+	members := clusterRecoverOpts.Members
+	if clusterRecoverOpts.MembersFile != "" {
+		b, err := os.ReadFile(clusterRecoverOpts.MembersFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read members file, error: %w", err)
+		}
+		var f recoveryMembersFile
+		if err := yaml.UnmarshalStrict(b, &f); err != nil {
+			return nil, fmt.Errorf("couldn't parse members file, error: %w", err)
+		}
+		members = f.Members
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no surviving cluster members specified")
+	}
+	return members, nil
+}
+
+// readDqliteNodeInfo parses the info.yaml of a dqlite node.
+func readDqliteNodeInfo(infoYamlPath string) (dqlite.NodeInfo, error) {
+	b, err := os.ReadFile(infoYamlPath)
+	if err != nil {
+		return dqlite.NodeInfo{}, fmt.Errorf("could not read %s, error: %w", infoYamlPath, err)
+	}
+	var info dqlite.NodeInfo
+	if err := yaml.Unmarshal(b, &info); err != nil {
+		return dqlite.NodeInfo{}, fmt.Errorf("couldn't parse %s, error: %w", infoYamlPath, err)
+	}
+	return info, nil
+}
+
+// newRecoveryPlan validates the surviving cluster members against the dqlite member info
+// of k8sd and k8s-dqlite, and computes the new member roles.
+func newRecoveryPlan() (*recoveryPlan, error) {
+	survivors, err := readSurvivingMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := microcluster.App(
+		microcluster.Args{
+			StateDir: rootCmdOpts.stateDir,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize microcluster app, error: %w", err)
+	}
+
+	// Surviving members are specified by their k8sd name or address, so the k8sd members
+	// are always needed, even if the k8sd recovery is skipped.
+	k8sdMembers, err := m.GetDqliteClusterMembers()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve K8sd cluster members, error: %w", err)
+	}
+	k8sdLocal, err := readDqliteNodeInfo(path.Join(m.FileSystem.DatabaseDir, "info.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &recoveryPlan{}
+	var survivingHosts []string
+	if plan.k8sdMembers, survivingHosts, err = k8sdRecoveryMembers(k8sdMembers, survivors, k8sdLocal.ID); err != nil {
+		return nil, err
+	}
+
+	if !clusterRecoverOpts.SkipK8sDqlite {
+		clusterYamlPath := path.Join(clusterRecoverOpts.K8sDqliteStateDir, "cluster.yaml")
+		b, err := os.ReadFile(clusterYamlPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read k8s-dqlite cluster.yaml, error: %w", err)
+		}
+		var k8sDqliteMembers []dqlite.NodeInfo
+		if err := yaml.Unmarshal(b, &k8sDqliteMembers); err != nil {
+			return nil, fmt.Errorf("couldn't parse k8s-dqlite cluster.yaml, error: %w", err)
+		}
+		k8sDqliteLocal, err := readDqliteNodeInfo(path.Join(clusterRecoverOpts.K8sDqliteStateDir, "info.yaml"))
+		if err != nil {
+			return nil, err
+		}
+
+		if plan.k8sDqliteMembers, err = k8sDqliteRecoveryMembers(k8sDqliteMembers, survivingHosts, k8sDqliteLocal.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// k8sdRecoveryMembers assigns the voter role to the surviving k8sd cluster members and the spare role to all other members.
+// Surviving members are matched by name, address or host. The local member must be one of the surviving members.
+// k8sdRecoveryMembers also returns the hosts of the surviving members.
+func k8sdRecoveryMembers(members []cluster.DqliteMember, survivors []string, localID uint64) ([]cluster.DqliteMember, []string, error) {
+	surviving := make(map[uint64]bool, len(survivors))
+	for _, survivor := range survivors {
+		var matches []cluster.DqliteMember
+		for _, member := range members {
+			host, _, _ := net.SplitHostPort(member.Address)
+			if survivor == member.Name || survivor == member.Address || survivor == host {
+				matches = append(matches, member)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return nil, nil, fmt.Errorf("%q is not a k8sd cluster member", survivor)
+		case 1:
+			surviving[matches[0].DqliteID] = true
+		default:
+			return nil, nil, fmt.Errorf("%q matches multiple k8sd cluster members", survivor)
+		}
+	}
+
+	if !surviving[localID] {
+		return nil, nil, fmt.Errorf("the local k8sd cluster member must be one of the surviving members")
+	}
+
+	newMembers := make([]cluster.DqliteMember, 0, len(members))
+	var survivingHosts []string
+	for _, member := range members {
+		if surviving[member.DqliteID] {
+			member.Role = client.Voter.String()
+			host, _, err := net.SplitHostPort(member.Address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid address %q of k8sd cluster member %q, error: %w", member.Address, member.Name, err)
+			}
+			survivingHosts = append(survivingHosts, host)
+		} else {
+			member.Role = client.Spare.String()
+		}
+		newMembers = append(newMembers, member)
+	}
+
+	return newMembers, survivingHosts, nil
+}
+
+// k8sDqliteRecoveryMembers assigns the voter role to the k8s-dqlite members on the surviving hosts and the spare role
+// to all other members. Every surviving host must have exactly one k8s-dqlite member, and the local member must be one
+// of the surviving members.
+func k8sDqliteRecoveryMembers(members []dqlite.NodeInfo, survivingHosts []string, localID uint64) ([]dqlite.NodeInfo, error) {
+	surviving := make(map[uint64]bool, len(survivingHosts))
+	for _, survivingHost := range survivingHosts {
+		var matches []dqlite.NodeInfo
+		for _, member := range members {
+			if host, _, err := net.SplitHostPort(member.Address); err == nil && host == survivingHost {
+				matches = append(matches, member)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("surviving member %s is not a k8s-dqlite cluster member, use --skip-k8s-dqlite if k8s-dqlite is not used", survivingHost)
+		case 1:
+			surviving[matches[0].ID] = true
+		default:
+			return nil, fmt.Errorf("surviving member %s matches multiple k8s-dqlite cluster members", survivingHost)
+		}
+	}
+
+	if !surviving[localID] {
+		return nil, fmt.Errorf("the local k8s-dqlite cluster member must be one of the surviving members")
+	}
+
+	newMembers := make([]dqlite.NodeInfo, 0, len(members))
+	for _, member := range members {
+		if surviving[member.ID] {
+			member.Role = client.Voter
+		} else {
+			member.Role = client.Spare
+		}
+		newMembers = append(newMembers, member)
+	}
+
+	return newMembers, nil
+}
@@ -0,0 +1,156 @@
+package k8sd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/go-dqlite/v2"
+	"github.com/canonical/go-dqlite/v2/client"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/microcluster/v2/cluster"
+	. "github.com/onsi/gomega"
+)
+
+func TestK8sdRecoveryMembers(t *testing.T) {
+	members := []cluster.DqliteMember{
+		{DqliteID: 1, Name: "node1", Address: "10.0.0.1:6400", Role: "voter"},
+		{DqliteID: 2, Name: "node2", Address: "10.0.0.2:6400", Role: "voter"},
+		{DqliteID: 3, Name: "node3", Address: "10.0.0.3:6400", Role: "voter"},
+	}
+
+	t.Run("ByNameAndAddress", func(t *testing.T) {
+		g := NewWithT(t)
+		newMembers, hosts, err := k8sdRecoveryMembers(members, []string{"node1", "10.0.0.2"}, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(hosts).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		g.Expect(newMembers).To(Equal([]cluster.DqliteMember{
+			{DqliteID: 1, Name: "node1", Address: "10.0.0.1:6400", Role: "voter"},
+			{DqliteID: 2, Name: "node2", Address: "10.0.0.2:6400", Role: "voter"},
+			{DqliteID: 3, Name: "node3", Address: "10.0.0.3:6400", Role: "spare"},
+		}))
+		// the input members are not modified
+		g.Expect(members[2].Role).To(Equal("voter"))
+	})
+
+	t.Run("UnknownMember", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := k8sdRecoveryMembers(members, []string{"node1", "node4"}, 1)
+		g.Expect(err).To(MatchError(ContainSubstring(`"node4" is not a k8sd cluster member`)))
+	})
+
+	t.Run("LocalMemberLost", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := k8sdRecoveryMembers(members, []string{"node2"}, 1)
+		g.Expect(err).To(MatchError(ContainSubstring("local k8sd cluster member")))
+	})
+
+	t.Run("AmbiguousMember", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := k8sdRecoveryMembers([]cluster.DqliteMember{
+			{DqliteID: 1, Name: "node1", Address: "10.0.0.1:6400"},
+			{DqliteID: 2, Name: "node2", Address: "10.0.0.1:6401"},
+		}, []string{"10.0.0.1"}, 1)
+		g.Expect(err).To(MatchError(ContainSubstring("matches multiple")))
+	})
+}
+
+func TestK8sDqliteRecoveryMembers(t *testing.T) {
+	members := []dqlite.NodeInfo{
+		{ID: 11, Address: "10.0.0.1:9000", Role: client.Voter},
+		{ID: 12, Address: "10.0.0.2:9000", Role: client.Voter},
+		{ID: 13, Address: "10.0.0.3:9000", Role: client.StandBy},
+	}
+
+	t.Run("Roles", func(t *testing.T) {
+		g := NewWithT(t)
+		newMembers, err := k8sDqliteRecoveryMembers(members, []string{"10.0.0.1", "10.0.0.3"}, 11)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(newMembers).To(Equal([]dqlite.NodeInfo{
+			{ID: 11, Address: "10.0.0.1:9000", Role: client.Voter},
+			{ID: 12, Address: "10.0.0.2:9000", Role: client.Spare},
+			{ID: 13, Address: "10.0.0.3:9000", Role: client.Voter},
+		}))
+	})
+
+	t.Run("MissingMember", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := k8sDqliteRecoveryMembers(members, []string{"10.0.0.1", "10.0.0.4"}, 11)
+		g.Expect(err).To(MatchError(ContainSubstring("10.0.0.4 is not a k8s-dqlite cluster member")))
+	})
+
+	t.Run("LocalMemberLost", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := k8sDqliteRecoveryMembers(members, []string{"10.0.0.2"}, 11)
+		g.Expect(err).To(MatchError(ContainSubstring("local k8s-dqlite cluster member")))
+	})
+}
+
+func TestRecoveryChecksum(t *testing.T) {
+	g := NewWithT(t)
+
+	tarballPath := filepath.Join(t.TempDir(), "recovery_db.tar.gz")
+	g.Expect(os.WriteFile(tarballPath, []byte("tarball"), 0o600)).To(Succeed())
+
+	checksumPath, err := writeRecoveryChecksum(tarballPath)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checksumPath).To(Equal(tarballPath + ".sha256"))
+
+	b, err := os.ReadFile(checksumPath)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(HaveSuffix("  recovery_db.tar.gz\n"))
+
+	g.Expect(verifyRecoveryChecksum(tarballPath, "")).To(Succeed())
+	g.Expect(verifyRecoveryChecksum(tarballPath, "invalid")).To(MatchError(ContainSubstring("checksum mismatch")))
+
+	g.Expect(os.WriteFile(tarballPath, []byte("modified"), 0o600)).To(Succeed())
+	g.Expect(verifyRecoveryChecksum(tarballPath, "")).To(MatchError(ContainSubstring("checksum mismatch")))
+
+	g.Expect(os.Remove(checksumPath)).To(Succeed())
+	g.Expect(verifyRecoveryChecksum(tarballPath, "")).ToNot(Succeed())
+}
+
+func TestApplyK8sDqliteRecoveryTarball(t *testing.T) {
+	g := NewWithT(t)
+
+	// recovered state of the k8s-dqlite cluster
+	recoveredDir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(recoveredDir, "cluster.yaml"), []byte("recovered"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(recoveredDir, "0000000000000001-0000000000000002"), []byte("segment"), 0o600)).To(Succeed())
+	tarballPath := filepath.Join(t.TempDir(), "recovery-k8s-dqlite-post-recovery.tar.gz")
+	g.Expect(utils.CreateTarball(tarballPath, recoveredDir, ".", nil)).To(Succeed())
+
+	// local state of the remaining cluster member
+	stateDir := filepath.Join(t.TempDir(), "k8s-dqlite")
+	g.Expect(os.Mkdir(stateDir, 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(stateDir, "cluster.yaml"), []byte("old"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(stateDir, "cluster.crt"), []byte("crt"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(stateDir, "cluster.key"), []byte("key"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(stateDir, "info.yaml"), []byte("info"), 0o644)).To(Succeed())
+
+	clusterRecoverOpts.K8sDqliteStateDir = stateDir
+	t.Cleanup(func() { clusterRecoverOpts.K8sDqliteStateDir = "" })
+
+	backupDir, err := applyK8sDqliteRecoveryTarball(tarballPath)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for name, expected := range map[string]string{
+		"cluster.yaml":                      "recovered",
+		"0000000000000001-0000000000000002": "segment",
+		"cluster.crt":                       "crt",
+		"cluster.key":                       "key",
+		"info.yaml":                         "info",
+	} {
+		b, err := os.ReadFile(filepath.Join(stateDir, name))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(b)).To(Equal(expected), name)
+	}
+
+	info, err := os.Stat(filepath.Join(stateDir, "cluster.key"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+	b, err := os.ReadFile(filepath.Join(backupDir, "cluster.yaml"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(Equal("old"))
+}
@@ -0,0 +1,311 @@
+package k8sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/canonical/k8s/cmd/util"
+	"github.com/canonical/k8s/pkg/k8sd/app"
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// QueryResult is the result of a read-only query against the k8sd database.
+type QueryResult struct {
+	Columns []string `json:"columns" yaml:"columns"`
+	Rows    [][]any  `json:"rows" yaml:"rows"`
+}
+
+func (r QueryResult) String() string {
+	if len(r.Rows) == 0 {
+		return "No rows found."
+	}
+
+	table := make([][]string, 0, len(r.Rows)+1)
+	table = append(table, r.Columns)
+	for _, row := range r.Rows {
+		values := make([]string, 0, len(row))
+		for _, value := range row {
+			if value == nil {
+				values = append(values, "NULL")
+			} else {
+				values = append(values, fmt.Sprint(value))
+			}
+		}
+		table = append(table, values)
+	}
+	return formatTable(table)
+}
+
+// TableInfo describes a table of the k8sd database.
+type TableInfo struct {
+	Name    string   `json:"name" yaml:"name"`
+	Columns []string `json:"columns" yaml:"columns"`
+	Rows    int64    `json:"rows" yaml:"rows"`
+}
+
+// Tables is the result of "k8sd db tables".
+type Tables []TableInfo
+
+func (t Tables) String() string {
+	table := [][]string{{"NAME", "ROWS", "COLUMNS"}}
+	for _, info := range t {
+		table = append(table, []string{info.Name, fmt.Sprint(info.Rows), strings.Join(info.Columns, ",")})
+	}
+	return formatTable(table)
+}
+
+// ClusterConfigDump is the result of "k8sd db dump-config".
+// The configuration is stored as a generic document, so that the YAML keys match the JSON keys in the database.
+type ClusterConfigDump map[string]any
+
+func (c ClusterConfigDump) String() string {
+	b, err := yaml.Marshal(map[string]any(c))
+	if err != nil {
+		return fmt.Sprintf("failed to format cluster configuration: %v", err)
+	}
+	return strings.TrimRight(string(b), "\n")
+}
+
+// FeatureStatuses is the result of "k8sd db feature-status".
+type FeatureStatuses map[types.FeatureName]types.FeatureStatus
+
+func (f FeatureStatuses) String() string {
+	if len(f) == 0 {
+		return "No feature status found."
+	}
+
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	table := [][]string{{"NAME", "ENABLED", "HEALTH", "VERSION", "UPDATED", "MESSAGE"}}
+	for _, name := range names {
+		status := f[types.FeatureName(name)]
+		table = append(table, []string{
+			name,
+			fmt.Sprint(status.Enabled),
+			string(status.Health),
+			status.Version,
+			status.UpdatedAt.Format(time.RFC3339),
+			status.Message,
+		})
+	}
+	return formatTable(table)
+}
+
+// formatTable formats rows of values as a table with aligned columns.
+func formatTable(table [][]string) string {
+	result := &strings.Builder{}
+	w := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	for _, row := range table {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	// values may be empty, do not leave trailing whitespace behind
+	lines := strings.Split(strings.TrimRight(result.String(), "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newDBCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
+	var opts struct {
+		outputFormat string
+	}
+
+	var formatter cmdutil.Formatter
+	initializeFormatter := func(cmd *cobra.Command, args []string) {
+		var err error
+		if formatter, err = cmdutil.NewFormatter(opts.outputFormat, cmd.OutOrStdout()); err != nil {
+			cmd.PrintErrf("Error: Unknown --output-format %q. It must be one of %q (default), %q or %q.\n", opts.outputFormat, "plain", "json", "yaml")
+			env.Exit(1)
+			return
+		}
+	}
+
+	// query runs one of the read-only queries of the database package against the k8sd database, using the SQL API
+	// of the k8sd daemon. Queries of the user are sent to k8sd instead, which validates them and redacts secrets.
+	// query prints an error and exits if the query fails.
+	query := func(cmd *cobra.Command, sqlQuery string) (QueryResult, bool) {
+		app, err := app.New(app.Config{
+			StateDir: rootCmdOpts.stateDir,
+			Snap:     env.Snap,
+		})
+		if err != nil {
+			cmd.PrintErrf("Error: Failed to initialize k8sd app.\n\nThe error was: %v\n", err)
+			env.Exit(1)
+			return QueryResult{}, false
+		}
+
+		_, batch, err := app.MicroCluster().SQL(cmd.Context(), sqlQuery)
+		if err != nil {
+			cmd.PrintErrf("Error: Failed to execute the SQL query.\n\nThe error was: %v\n", err)
+			env.Exit(1)
+			return QueryResult{}, false
+		}
+		if len(batch.Results) != 1 {
+			cmd.PrintErrf("Error: Expected 1 result for the SQL query, got %d.\n", len(batch.Results))
+			env.Exit(1)
+			return QueryResult{}, false
+		}
+		return QueryResult{Columns: batch.Results[0].Columns, Rows: batch.Results[0].Rows}, true
+	}
+
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Inspect the k8sd database",
+		Long: "Inspect the k8sd database of a running k8sd daemon. Only read-only queries against the k8sd tables are allowed.\n" +
+			"The data is fetched from the k8sd daemon on this node.",
+	}
+	cmd.PersistentFlags().StringVar(&opts.outputFormat, "output-format", "plain", "set the output format to one of plain, json or yaml")
+
+	queryCmd := &cobra.Command{
+		Use:   "query <query>",
+		Short: "Run a read-only SQL query against the k8sd database",
+		Long: "Run a single SELECT statement against the k8sd tables. Use - to read the query from stdin.\n" +
+			"Queries that modify the database, use comments or read tables that are not part of the k8sd schema are rejected.\n" +
+			"Tokens and private keys are redacted from the result.",
+		Args:   cmdutil.ExactArgs(env, 1),
+		PreRun: initializeFormatter,
+		Run: func(cmd *cobra.Command, args []string) {
+			q := args[0]
+			if q == "-" {
+				b, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					cmd.PrintErrf("Error: Failed to read the query from stdin.\n\nThe error was: %v\n", err)
+					env.Exit(1)
+					return
+				}
+				q = string(b)
+			}
+
+			client, err := env.Snap.K8sdClient("")
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to create a k8sd client. Make sure that the k8sd service is running.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+			resp, err := client.QueryDatabase(cmd.Context(), types.QueryDatabaseRequest{Query: q})
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to execute the SQL query.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+			formatter.Print(QueryResult{Columns: resp.Columns, Rows: resp.Rows})
+		},
+	}
+
+	tablesCmd := &cobra.Command{
+		Use:    "tables",
+		Short:  "List the tables of the k8sd database",
+		Args:   cobra.NoArgs,
+		PreRun: initializeFormatter,
+		Run: func(cmd *cobra.Command, args []string) {
+			schema, err := database.LoadSchema()
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to load the k8sd database schema.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+
+			tables := make(Tables, 0, len(schema))
+			for _, name := range schema.Tables() {
+				result, ok := query(cmd, fmt.Sprintf("SELECT COUNT(*) FROM %s", name))
+				if !ok {
+					return
+				}
+				// numbers are decoded from JSON
+				var count float64
+				if len(result.Rows) == 1 && len(result.Rows[0]) == 1 {
+					count, _ = result.Rows[0][0].(float64)
+				}
+				tables = append(tables, TableInfo{Name: name, Columns: schema[name], Rows: int64(count)})
+			}
+			formatter.Print(tables)
+		},
+	}
+
+	var dumpConfigOpts struct {
+		showSecrets bool
+	}
+	dumpConfigCmd := &cobra.Command{
+		Use:    "dump-config",
+		Short:  "Print the cluster configuration stored in the k8sd database",
+		Long:   "Print the cluster configuration stored in the k8sd database. Private keys are redacted, unless --show-secrets is set.",
+		Args:   cobra.NoArgs,
+		PreRun: initializeFormatter,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, ok := query(cmd, database.ClusterConfigQuery)
+			if !ok {
+				return
+			}
+			config, err := database.ClusterConfigFromRows(result.Rows)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to decode the cluster configuration.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+			if !dumpConfigOpts.showSecrets {
+				config = database.RedactClusterConfig(config)
+			}
+
+			dump, err := clusterConfigDump(config)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to encode the cluster configuration.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+			formatter.Print(dump)
+		},
+	}
+	dumpConfigCmd.Flags().BoolVar(&dumpConfigOpts.showSecrets, "show-secrets", false, "do not redact the private keys of the cluster")
+
+	featureStatusCmd := &cobra.Command{
+		Use:    "feature-status",
+		Short:  "Print the status of the features stored in the k8sd database",
+		Args:   cobra.NoArgs,
+		PreRun: initializeFormatter,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, ok := query(cmd, database.FeatureStatusQuery)
+			if !ok {
+				return
+			}
+			statuses, err := database.FeatureStatusesFromRows(cmd.Context(), result.Rows)
+			if err != nil {
+				cmd.PrintErrf("Error: Failed to decode the feature statuses.\n\nThe error was: %v\n", err)
+				env.Exit(1)
+				return
+			}
+			formatter.Print(FeatureStatuses(statuses))
+		},
+	}
+
+	cmd.AddCommand(queryCmd, tablesCmd, dumpConfigCmd, featureStatusCmd)
+
+	return cmd
+}
+
+// clusterConfigDump converts the cluster configuration to a ClusterConfigDump.
+func clusterConfigDump(config types.ClusterConfig) (ClusterConfigDump, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cluster configuration: %w", err)
+	}
+	var dump ClusterConfigDump
+	if err := json.Unmarshal(b, &dump); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster configuration: %w", err)
+	}
+	return dump, nil
+}
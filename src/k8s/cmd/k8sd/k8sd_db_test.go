@@ -0,0 +1,39 @@
+package k8sd
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterConfigDump(t *testing.T) {
+	g := NewWithT(t)
+
+	config := types.ClusterConfig{
+		Certificates: types.Certificates{
+			CAKey:          utils.Pointer("CA KEY"),
+			K8sdPrivateKey: utils.Pointer("PRIVATE KEY"),
+		},
+	}
+
+	dump, err := clusterConfigDump(database.RedactClusterConfig(config))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dump.String()).To(ContainSubstring("ca-key: <redacted>"))
+	g.Expect(dump.String()).ToNot(ContainSubstring("PRIVATE KEY"))
+}
+
+func TestQueryResultString(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(QueryResult{Columns: []string{"name"}}.String()).To(Equal("No rows found."))
+	g.Expect(QueryResult{
+		Columns: []string{"name", "enabled", "health"},
+		Rows: [][]any{
+			{"network", float64(1), "healthy"},
+			{"dns", float64(0), nil},
+		},
+	}.String()).To(Equal("name     enabled  health\nnetwork  1        healthy\ndns      0        NULL"))
+}
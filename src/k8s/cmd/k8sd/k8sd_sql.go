@@ -11,7 +11,9 @@ func newSqlCmd(env cmdutil.ExecutionEnvironment) *cobra.Command {
 		Use:    "sql <query>",
 		Short:  "Execute an SQL query against the daemon",
 		Hidden: true,
-		Args:   cmdutil.ExactArgs(env, 1),
+		// arbitrary queries may modify the database, and the raw rows are hard to read
+		Deprecated: "use \"k8sd db query\" for read-only queries, or \"k8sd db tables\", \"k8sd db dump-config\" and \"k8sd db feature-status\" instead",
+		Args:       cmdutil.ExactArgs(env, 1),
 		Run: func(cmd *cobra.Command, args []string) {
 			app, err := app.New(app.Config{
 				StateDir: rootCmdOpts.stateDir,
@@ -32,7 +32,7 @@ type ExecutionEnvironment struct {
 func DefaultExecutionEnvironment() ExecutionEnvironment {
 	var s snap.Snap
 	switch os.Getenv("K8SD_RUNTIME_ENVIRONMENT") {
-	case "", "snap":
+	case "", snap.RuntimeEnvironmentSnap:
 		// If this node is already bootstrapped / joined, we should already know where the
 		// containerd base directory is. If not, leave the defaults.
 		containerdBaseDir := ""
@@ -45,7 +45,7 @@ func DefaultExecutionEnvironment() ExecutionEnvironment {
 			SnapInstanceName:  os.Getenv("SNAP_INSTANCE_NAME"),
 			ContainerdBaseDir: containerdBaseDir,
 		})
-	case "pebble":
+	case snap.RuntimeEnvironmentPebble:
 		s = snap.NewPebble(snap.PebbleOpts{
 			SnapDir:       os.Getenv("SNAP"),
 			SnapCommonDir: os.Getenv("SNAP_COMMON"),
@@ -0,0 +1,87 @@
+package dqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// DemoteNodeByAddress moves the voter role of a node to another node of the cluster, so that the node
+// can be removed without affecting the quorum. If the node is the leader, the leadership is transferred first.
+// DemoteNodeByAddress promotes a stand-by or spare node to voter (if any), and then assigns the spare role to the node.
+// DemoteNodeByAddress does nothing if the node is not a voter.
+func (c *Client) DemoteNodeByAddress(ctx context.Context, address string) error {
+	client, err := c.clientGetter(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create dqlite client: %w", err)
+	}
+	defer client.Close()
+
+	members, err := client.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve cluster nodes: %w", err)
+	}
+
+	var (
+		memberExists       bool
+		memberToDemote     NodeInfo
+		otherVoters        []NodeInfo
+		standByNode, spare *NodeInfo
+	)
+	for _, member := range members {
+		switch {
+		case member.Address == address:
+			memberToDemote = member
+			memberExists = true
+		case member.Role == Voter:
+			otherVoters = append(otherVoters, member)
+		case member.Role == StandBy && standByNode == nil:
+			standByNode = &member
+		case member.Role == Spare && spare == nil:
+			spare = &member
+		}
+	}
+
+	if !memberExists {
+		return fmt.Errorf("cluster does not have a node with address %v", address)
+	}
+	if memberToDemote.Role != Voter {
+		return nil
+	}
+
+	// Prefer stand-by nodes, as they already replicate the database.
+	replacement := standByNode
+	if replacement == nil {
+		replacement = spare
+	}
+	if replacement == nil && len(otherVoters) == 0 {
+		return fmt.Errorf("cannot move voter role as there is no other node in the cluster")
+	}
+
+	if replacement != nil {
+		if err := client.Assign(ctx, replacement.ID, Voter); err != nil {
+			return fmt.Errorf("failed to assign voter role to %d: %w", replacement.ID, err)
+		}
+		otherVoters = append(otherVoters, *replacement)
+	}
+
+	leader, err := client.Leader(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve dqlite leader: %w", err)
+	}
+	if leader != nil && leader.ID == memberToDemote.ID {
+		if err := client.Transfer(ctx, otherVoters[0].ID); err != nil {
+			return fmt.Errorf("failed to transfer leadership to %d: %w", otherVoters[0].ID, err)
+		}
+		// Recreate client to point to the new leader.
+		client, err = c.clientGetter(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create dqlite client: %w", err)
+		}
+		defer client.Close()
+	}
+
+	if err := client.Assign(ctx, memberToDemote.ID, Spare); err != nil {
+		return fmt.Errorf("failed to assign spare role to %d: %w", memberToDemote.ID, err)
+	}
+	return nil
+}
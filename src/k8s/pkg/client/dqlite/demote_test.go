@@ -0,0 +1,81 @@
+package dqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/client/dqlite"
+	. "github.com/onsi/gomega"
+)
+
+func TestDemoteNodeByAddress(t *testing.T) {
+	t.Run("Voter", func(t *testing.T) {
+		withDqliteCluster(t, 2, func(ctx context.Context, dirs []string) {
+			g := NewWithT(t)
+			client, err := dqlite.NewClient(ctx, dqlite.ClientOpts{
+				ClusterYAML: filepath.Join(dirs[0], "cluster.yaml"),
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+
+			members, err := client.ListMembers(ctx)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(members).To(HaveLen(2))
+
+			memberToDemote := members[0]
+			if members[0].Role != dqlite.Voter {
+				memberToDemote = members[1]
+			}
+
+			g.Expect(client.DemoteNodeByAddress(ctx, memberToDemote.Address)).To(Succeed())
+
+			members, err = client.ListMembers(ctx)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(members).To(HaveLen(2))
+			for _, member := range members {
+				if member.Address == memberToDemote.Address {
+					g.Expect(member.Role).To(Equal(dqlite.Spare))
+				} else {
+					g.Expect(member.Role).To(Equal(dqlite.Voter))
+				}
+			}
+		})
+	})
+
+	t.Run("NotVoter", func(t *testing.T) {
+		withDqliteCluster(t, 2, func(ctx context.Context, dirs []string) {
+			g := NewWithT(t)
+			client, err := dqlite.NewClient(ctx, dqlite.ClientOpts{
+				ClusterYAML: filepath.Join(dirs[0], "cluster.yaml"),
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+
+			members, err := client.ListMembers(ctx)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(members).To(HaveLen(2))
+
+			spare := members[0]
+			if members[0].Role == dqlite.Voter {
+				spare = members[1]
+			}
+
+			g.Expect(client.DemoteNodeByAddress(ctx, spare.Address)).To(Succeed())
+		})
+	})
+
+	t.Run("OnlyNode", func(t *testing.T) {
+		withDqliteCluster(t, 1, func(ctx context.Context, dirs []string) {
+			g := NewWithT(t)
+			client, err := dqlite.NewClient(ctx, dqlite.ClientOpts{
+				ClusterYAML: filepath.Join(dirs[0], "cluster.yaml"),
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+
+			members, err := client.ListMembers(ctx)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(members).To(HaveLen(1))
+
+			g.Expect(client.DemoteNodeByAddress(ctx, members[0].Address)).ToNot(Succeed())
+		})
+	})
+}
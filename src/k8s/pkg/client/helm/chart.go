@@ -8,7 +8,7 @@ type InstallableChart struct {
 	// Namespace is the namespace to install the chart.
 	Namespace string
 
-	// ManifestPath is the path to the chart's manifest, typically relative to "$SNAP/k8s/manifests".
-	// TODO(neoaggelos): this should be a *chart.Chart, and we should use the "embed" package to load it when building k8sd.
+	// ManifestPath is the path to the chart archive or directory in the manifests filesystem of the client,
+	// typically "charts/<chart>" for the charts embedded in k8sd (see pkg/k8sd/manifests).
 	ManifestPath string
 }
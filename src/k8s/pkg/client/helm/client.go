@@ -6,29 +6,33 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"io/fs"
 
 	"github.com/canonical/k8s/pkg/log"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+// maxHistory is the maximum number of revisions kept for each release, so that repeated upgrades
+// (e.g. repairs of drifted features) do not grow the release history indefinitely.
+const maxHistory = 10
+
 // client implements Client using Helm.
 type client struct {
 	restClientGetter func(string) genericclioptions.RESTClientGetter
-	manifestsBaseDir string
+	manifests        fs.FS
 }
 
 // ensure *client implements Client.
 var _ Client = &client{}
 
 // NewClient creates a new client.
-func NewClient(manifestsBaseDir string, restClientGetter func(string) genericclioptions.RESTClientGetter) *client {
+// manifests is the filesystem that contains the charts, at the ManifestPath of each InstallableChart.
+func NewClient(manifests fs.FS, restClientGetter func(string) genericclioptions.RESTClientGetter) *client {
 	return &client{
 		restClientGetter: restClientGetter,
-		manifestsBaseDir: manifestsBaseDir,
+		manifests:        manifests,
 	}
 }
 
@@ -53,6 +57,7 @@ func (h *client) Apply(ctx context.Context, c InstallableChart, desired State, v
 
 	isInstalled := true
 	var oldConfig map[string]any
+	var oldRevision int
 
 	// get the latest Helm release with the specified name
 	get := action.NewGet(cfg)
@@ -65,6 +70,7 @@ func (h *client) Apply(ctx context.Context, c InstallableChart, desired State, v
 	} else {
 		// keep the existing release configuration, to check if any changes were made.
 		oldConfig = release.Config
+		oldRevision = release.Version
 	}
 
 	switch {
@@ -81,7 +87,7 @@ func (h *client) Apply(ctx context.Context, c InstallableChart, desired State, v
 		install.Namespace = c.Namespace
 		install.CreateNamespace = true
 
-		chart, err := loader.Load(filepath.Join(h.manifestsBaseDir, c.ManifestPath))
+		chart, err := loadChart(h.manifests, c.ManifestPath)
 		if err != nil {
 			return false, fmt.Errorf("failed to load manifest for %s: %w", c.Name, err)
 		}
@@ -95,15 +101,34 @@ func (h *client) Apply(ctx context.Context, c InstallableChart, desired State, v
 		upgrade := action.NewUpgrade(cfg)
 		upgrade.Namespace = c.Namespace
 		upgrade.ResetThenReuseValues = true
+		upgrade.MaxHistory = maxHistory
 
-		chart, err := loader.Load(filepath.Join(h.manifestsBaseDir, c.ManifestPath))
+		chart, err := loadChart(h.manifests, c.ManifestPath)
 		if err != nil {
 			return false, fmt.Errorf("failed to load manifest for %s: %w", c.Name, err)
 		}
 
 		release, err := upgrade.RunWithContext(ctx, c.Name, chart, values)
 		if err != nil {
-			return false, fmt.Errorf("failed to upgrade %s: %w", c.Name, err)
+			// an upgrade that fails to deploy leaves a failed revision behind, roll back to the last revision that was deployed successfully.
+			// the upgrade may also fail before creating a revision (e.g. another operation is in progress), or because it was cancelled.
+			// the release is left untouched in these cases.
+			if ctx.Err() != nil {
+				return false, fmt.Errorf("failed to upgrade %s: %w", c.Name, err)
+			}
+			releases, historyErr := action.NewHistory(cfg).Run(c.Name)
+			if historyErr != nil {
+				return false, fmt.Errorf("failed to upgrade %s: %w (failed to get release history: %v)", c.Name, err, historyErr)
+			}
+			if _, ok := failedUpgradeRevision(revisionsFromReleases(releases), oldRevision); !ok {
+				return false, fmt.Errorf("failed to upgrade %s: %w", c.Name, err)
+			}
+
+			restored, rollbackErr := h.rollback(ctx, cfg, c)
+			if rollbackErr != nil {
+				return false, fmt.Errorf("failed to upgrade %s: %w (rollback also failed: %v)", c.Name, err, rollbackErr)
+			}
+			return false, fmt.Errorf("failed to upgrade %s (rolled back to revision %d): %w", c.Name, restored.Revision, err)
 		}
 
 		// oldConfig and release.Config are the previous and current values. they are compared by checking their respective JSON, as that is good enough for our needs of comparing unstructured map[string]any data.
@@ -122,6 +147,55 @@ func (h *client) Apply(ctx context.Context, c InstallableChart, desired State, v
 	}
 }
 
+// History implements the Client interface.
+func (h *client) History(ctx context.Context, c InstallableChart) ([]Revision, error) {
+	cfg, err := h.newActionConfiguration(ctx, c.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action configuration: %w", err)
+	}
+
+	releases, err := action.NewHistory(cfg).Run(c.Name)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get history of release %s: %w", c.Name, err)
+	}
+	return revisionsFromReleases(releases), nil
+}
+
+// Rollback implements the Client interface.
+func (h *client) Rollback(ctx context.Context, c InstallableChart) (Revision, error) {
+	cfg, err := h.newActionConfiguration(ctx, c.Namespace)
+	if err != nil {
+		return Revision{}, fmt.Errorf("failed to create action configuration: %w", err)
+	}
+
+	return h.rollback(ctx, cfg, c)
+}
+
+// rollback rolls a release back to the newest revision before the current one that was deployed successfully.
+func (h *client) rollback(ctx context.Context, cfg *action.Configuration, c InstallableChart) (Revision, error) {
+	releases, err := action.NewHistory(cfg).Run(c.Name)
+	if err != nil {
+		return Revision{}, fmt.Errorf("failed to get history of release %s: %w", c.Name, err)
+	}
+
+	previous, ok := previousRevision(revisionsFromReleases(releases))
+	if !ok {
+		return Revision{}, fmt.Errorf("release %s has no previous revision to roll back to", c.Name)
+	}
+
+	log.FromContext(ctx).WithName("helm").Info("Rolling back release", "release", c.Name, "revision", previous.Revision)
+	rollback := action.NewRollback(cfg)
+	rollback.Version = previous.Revision
+	rollback.MaxHistory = maxHistory
+	if err := rollback.Run(c.Name); err != nil {
+		return Revision{}, fmt.Errorf("failed to roll back %s to revision %d: %w", c.Name, previous.Revision, err)
+	}
+	return previous, nil
+}
+
 func jsonEqual(v1 any, v2 any) bool {
 	b1, err1 := json.Marshal(v1)
 	b2, err2 := json.Marshal(v2)
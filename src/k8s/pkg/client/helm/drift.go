@@ -0,0 +1,189 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	// DriftReasonModified means that the live object differs from the manifest of the release.
+	DriftReasonModified = "modified"
+	// DriftReasonMissing means that the object of the release was deleted from the cluster.
+	DriftReasonMissing = "missing"
+)
+
+// DriftedObject is an object of a release that differs from its manifest on the cluster.
+type DriftedObject struct {
+	// Kind is the kind of the object, e.g. "Deployment".
+	Kind string `json:"kind" yaml:"kind"`
+	// Namespace is the namespace of the object. It is empty for cluster-scoped objects.
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name" yaml:"name"`
+	// Reason is one of DriftReasonModified or DriftReasonMissing.
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+func (o DriftedObject) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s (%s)", o.Kind, o.Name, o.Reason)
+	}
+	return fmt.Sprintf("%s %s/%s (%s)", o.Kind, o.Namespace, o.Name, o.Reason)
+}
+
+// Drift implements the Client interface.
+func (h *client) Drift(ctx context.Context, c InstallableChart) ([]DriftedObject, error) {
+	cfg, err := h.newActionConfiguration(ctx, c.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action configuration: %w", err)
+	}
+
+	release, err := action.NewGet(cfg).Run(c.Name)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get status of release %s: %w", c.Name, err)
+	}
+
+	resources, err := cfg.KubeClient.Build(bytes.NewBufferString(release.Manifest), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of release %s: %w", c.Name, err)
+	}
+
+	var drifted []DriftedObject
+	for _, info := range resources {
+		object := DriftedObject{Kind: info.Mapping.GroupVersionKind.Kind, Namespace: info.Namespace, Name: info.Name}
+
+		live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				object.Reason = DriftReasonMissing
+				drifted = append(drifted, object)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %s: %w", object.Kind, object.Name, err)
+		}
+
+		desiredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert manifest of %s %s: %w", object.Kind, object.Name, err)
+		}
+		liveObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert live %s %s: %w", object.Kind, object.Name, err)
+		}
+
+		if !manifestMatches(desiredObject, liveObject) {
+			object.Reason = DriftReasonModified
+			drifted = append(drifted, object)
+		}
+	}
+
+	return drifted, nil
+}
+
+// manifestMatches returns true if all fields of the desired object are set to the same value on the live object.
+// Fields that are only set on the live object (e.g. defaults and fields managed by controllers) are ignored.
+// The status of objects is ignored, and only labels and annotations are compared from the object metadata.
+func manifestMatches(desired map[string]any, live map[string]any) bool {
+	for key, desiredValue := range desired {
+		switch key {
+		case "status", "stringData":
+			// status is owned by controllers, stringData is merged into data by the kube-apiserver
+			continue
+		case "metadata":
+			desiredMetadata, _ := desiredValue.(map[string]any)
+			liveMetadata, _ := live["metadata"].(map[string]any)
+			for _, field := range []string{"labels", "annotations"} {
+				if !isSubset(desiredMetadata[field], liveMetadata[field]) {
+					return false
+				}
+			}
+		default:
+			if !isSubset(desiredValue, live[key]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSubset returns true if desired is contained in live.
+// Maps may have additional keys on the live object, while lists must have the same length.
+// Fields that are unset or set to their zero value on the desired object match unset fields on the live object,
+// as the kube-apiserver does not persist empty fields.
+func isSubset(desired any, live any) bool {
+	if live == nil {
+		return isZero(desired)
+	}
+
+	switch desired := desired.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		live, ok := live.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range desired {
+			if !isSubset(value, live[key]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		live, ok := live.([]any)
+		if !ok || len(desired) != len(live) {
+			return false
+		}
+		for idx := range desired {
+			if !isSubset(desired[idx], live[idx]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		if liveString, ok := live.(string); ok && desired != liveString {
+			// the kube-apiserver normalizes quantities, e.g. "0.5" is stored as "500m"
+			desiredQuantity, err1 := apiresource.ParseQuantity(desired)
+			liveQuantity, err2 := apiresource.ParseQuantity(liveString)
+			return err1 == nil && err2 == nil && desiredQuantity.Cmp(liveQuantity) == 0
+		}
+		return jsonEqual(desired, live)
+	default:
+		// numbers may be decoded as int64 or float64, compare their JSON representation instead
+		return jsonEqual(desired, live)
+	}
+}
+
+// isZero returns true if v is unset or the zero value of its type.
+func isZero(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	default:
+		return false
+	}
+}
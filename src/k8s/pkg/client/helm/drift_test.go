@@ -0,0 +1,128 @@
+package helm
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestManifestMatches(t *testing.T) {
+	desired := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":   "coredns",
+			"labels": map[string]any{"app": "coredns"},
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"hostNetwork": false,
+					"containers": []any{
+						map[string]any{
+							"name":      "coredns",
+							"image":     "coredns:1.11",
+							"resources": map[string]any{"limits": map[string]any{"cpu": "0.5"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	live := func(mutate func(spec map[string]any)) map[string]any {
+		spec := map[string]any{
+			"replicas": float64(2),
+			"strategy": map[string]any{"type": "RollingUpdate"},
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{
+							"name":            "coredns",
+							"image":           "coredns:1.11",
+							"imagePullPolicy": "IfNotPresent",
+							"resources":       map[string]any{"limits": map[string]any{"cpu": "500m"}},
+						},
+					},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(spec)
+		}
+		return map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":            "coredns",
+				"resourceVersion": "1234",
+				"labels":          map[string]any{"app": "coredns", "app.kubernetes.io/managed-by": "Helm"},
+			},
+			"spec":   spec,
+			"status": map[string]any{"replicas": int64(2)},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		live     map[string]any
+		expected bool
+	}{
+		{name: "Defaulted", live: live(nil), expected: true},
+		{name: "Scaled", live: live(func(spec map[string]any) { spec["replicas"] = int64(3) }), expected: false},
+		{
+			name: "ImageChanged",
+			live: live(func(spec map[string]any) {
+				spec["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)["image"] = "coredns:latest"
+			}),
+			expected: false,
+		},
+		{
+			name: "ContainerAdded",
+			live: live(func(spec map[string]any) {
+				podSpec := spec["template"].(map[string]any)["spec"].(map[string]any)
+				podSpec["containers"] = append(podSpec["containers"].([]any), map[string]any{"name": "sidecar"})
+			}),
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(manifestMatches(desired, tc.live)).To(Equal(tc.expected))
+		})
+	}
+
+	t.Run("LabelRemoved", func(t *testing.T) {
+		g := NewWithT(t)
+		obj := live(nil)
+		obj["metadata"].(map[string]any)["labels"] = map[string]any{"app.kubernetes.io/managed-by": "Helm"}
+		g.Expect(manifestMatches(desired, obj)).To(BeFalse())
+	})
+}
+
+func TestIsSubset(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		desired  any
+		live     any
+		expected bool
+	}{
+		{name: "EqualStrings", desired: "a", live: "a", expected: true},
+		{name: "DifferentStrings", desired: "a", live: "b", expected: false},
+		{name: "Numbers", desired: int64(80), live: float64(80), expected: true},
+		{name: "Quantities", desired: "1024Mi", live: "1Gi", expected: true},
+		{name: "EmptyMapUnset", desired: map[string]any{}, live: nil, expected: true},
+		{name: "FalseUnset", desired: false, live: nil, expected: true},
+		{name: "ValueUnset", desired: "a", live: nil, expected: false},
+		{name: "ExtraLiveKeys", desired: map[string]any{"a": "1"}, live: map[string]any{"a": "1", "b": "2"}, expected: true},
+		{name: "MissingLiveKey", desired: map[string]any{"a": "1", "b": "2"}, live: map[string]any{"a": "1"}, expected: false},
+		{name: "ListLength", desired: []any{"a"}, live: []any{"a", "b"}, expected: false},
+		{name: "TypeMismatch", desired: map[string]any{"a": "1"}, live: "a", expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isSubset(tc.desired, tc.live)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -8,6 +8,19 @@ type Client interface {
 	// When state is StatePresent, Apply will install or upgrade the chart using the specified values as configuration. Apply returns true if the chart was not installed, or any values were changed.
 	// When state is StateUpgradeOnly, Apply will upgrade the chart using the specified values as configuration. Apply returns true if the chart was not installed, or any values were changed. An error is returned if the chart is not already installed.
 	// When state is StateDeleted, Apply will ensure that the chart is removed. If the chart is not installed, this is a no-op. Apply returns true if the chart was previously installed.
+	// If an upgrade fails, Apply rolls the chart back to the last successfully deployed revision.
 	// Apply returns an error in case of failure.
 	Apply(ctx context.Context, f InstallableChart, desired State, values map[string]any) (bool, error)
+
+	// History returns the revisions of the release of an InstallableChart, ordered from oldest to newest.
+	// History returns no revisions if the chart is not installed.
+	History(ctx context.Context, f InstallableChart) ([]Revision, error)
+
+	// Rollback rolls an InstallableChart back to the last successfully deployed revision before the current one.
+	// Rollback returns the revision that was restored, or an error if there is no such revision.
+	Rollback(ctx context.Context, f InstallableChart) (Revision, error)
+
+	// Drift compares the manifests of the current release of an InstallableChart with the live objects on the cluster.
+	// Drift returns the objects that were modified or deleted. Drift returns no objects if the chart is not installed.
+	Drift(ctx context.Context, f InstallableChart) ([]DriftedObject, error)
 }
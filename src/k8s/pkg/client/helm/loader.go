@@ -0,0 +1,43 @@
+package helm
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// loadChart loads a chart from fsys. name is the path to either a chart archive or a chart directory.
+func loadChart(fsys fs.FS, name string) (*chart.Chart, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chart: %w", err)
+	}
+
+	if !info.IsDir() {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open chart archive: %w", err)
+		}
+		defer f.Close()
+		return loader.LoadArchive(f)
+	}
+
+	var files []*loader.BufferedFile
+	if err := fs.WalkDir(fsys, name, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		files = append(files, &loader.BufferedFile{Name: strings.TrimPrefix(path, name+"/"), Data: data})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read chart directory: %w", err)
+	}
+	return loader.LoadFiles(files)
+}
@@ -0,0 +1,75 @@
+package helm
+
+import (
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/canonical/k8s/pkg/k8sd/manifests"
+	. "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+func TestLoadChart(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		entries, err := fs.ReadDir(manifests.FS(""), "charts")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(entries).To(Not(BeEmpty()))
+
+		for _, entry := range entries {
+			t.Run(entry.Name(), func(t *testing.T) {
+				g := NewWithT(t)
+
+				chart, err := loadChart(manifests.FS(""), path.Join("charts", entry.Name()))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(chart.Validate()).To(Succeed())
+			})
+		}
+	})
+
+	t.Run("Render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		chart, err := loadChart(manifests.FS(""), "charts/ck-loadbalancer")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		values, err := chartutil.ToRenderValues(chart, map[string]any{
+			"driver": "metallb",
+			"ipPool": map[string]any{"cidrs": []map[string]any{{"cidr": "10.42.254.176/28"}}},
+		}, chartutil.ReleaseOptions{Name: "ck-loadbalancer", Namespace: "metallb-system"}, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		rendered, err := engine.Render(chart, values)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(rendered).To(ContainElement(ContainSubstring("10.42.254.176/28")))
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fsys := fstest.MapFS{
+			"charts/test/Chart.yaml":               &fstest.MapFile{Data: []byte("apiVersion: v2\nname: test\nversion: 0.1.0\n")},
+			"charts/test/values.yaml":              &fstest.MapFile{Data: []byte("key: value\n")},
+			"charts/test/templates/configmap.yaml": &fstest.MapFile{Data: []byte("kind: ConfigMap\n")},
+			"charts/test/templates/_helpers.tpl":   &fstest.MapFile{Data: []byte("{{/* helpers */}}\n")},
+			"charts/other/Chart.yaml":              &fstest.MapFile{Data: []byte("apiVersion: v2\nname: other\nversion: 0.2.0\n")},
+		}
+
+		chart, err := loadChart(fsys, "charts/test")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(chart.Name()).To(Equal("test"))
+		g.Expect(chart.Values).To(HaveKeyWithValue("key", "value"))
+		g.Expect(chart.Templates).To(HaveLen(2))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := loadChart(fstest.MapFS{}, "charts/missing.tgz")
+		g.Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+}
@@ -18,6 +18,18 @@ type Mock struct {
 	ApplyCalledWith []MockApplyArguments
 	ApplyChanged    bool
 	ApplyErr        error
+
+	HistoryCalledWith []helm.InstallableChart
+	HistoryRevisions  map[string][]helm.Revision
+	HistoryErr        error
+
+	RollbackCalledWith []helm.InstallableChart
+	RollbackRevision   helm.Revision
+	RollbackErr        error
+
+	DriftCalledWith []helm.InstallableChart
+	DriftObjects    map[string][]helm.DriftedObject
+	DriftErr        error
 }
 
 // Apply implements helm.Client.
@@ -26,4 +38,24 @@ func (m *Mock) Apply(ctx context.Context, c helm.InstallableChart, desired helm.
 	return m.ApplyChanged, m.ApplyErr
 }
 
+// History implements helm.Client.
+// HistoryRevisions is keyed by the name of the chart.
+func (m *Mock) History(_ context.Context, c helm.InstallableChart) ([]helm.Revision, error) {
+	m.HistoryCalledWith = append(m.HistoryCalledWith, c)
+	return m.HistoryRevisions[c.Name], m.HistoryErr
+}
+
+// Rollback implements helm.Client.
+func (m *Mock) Rollback(_ context.Context, c helm.InstallableChart) (helm.Revision, error) {
+	m.RollbackCalledWith = append(m.RollbackCalledWith, c)
+	return m.RollbackRevision, m.RollbackErr
+}
+
+// Drift implements helm.Client.
+// DriftObjects is keyed by the name of the chart.
+func (m *Mock) Drift(_ context.Context, c helm.InstallableChart) ([]helm.DriftedObject, error) {
+	m.DriftCalledWith = append(m.DriftCalledWith, c)
+	return m.DriftObjects[c.Name], m.DriftErr
+}
+
 var _ helm.Client = &Mock{}
@@ -0,0 +1,70 @@
+package helm
+
+import (
+	"sort"
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Revision describes a revision of the release of a chart.
+type Revision struct {
+	// Revision is the number of the revision.
+	Revision int `json:"revision" yaml:"revision"`
+	// Status is the Helm status of the revision, e.g. "deployed", "superseded" or "failed".
+	Status string `json:"status" yaml:"status"`
+	// ChartVersion is the version of the chart that was deployed.
+	ChartVersion string `json:"chart-version,omitempty" yaml:"chart-version,omitempty"`
+	// Updated is when the revision was deployed.
+	Updated time.Time `json:"updated" yaml:"updated"`
+	// Description is the Helm description of the revision, e.g. "Upgrade complete".
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+func revisionFromRelease(r *release.Release) Revision {
+	rev := Revision{Revision: r.Version}
+	if r.Info != nil {
+		rev.Status = r.Info.Status.String()
+		rev.Updated = r.Info.LastDeployed.Time
+		rev.Description = r.Info.Description
+	}
+	if r.Chart != nil && r.Chart.Metadata != nil {
+		rev.ChartVersion = r.Chart.Metadata.Version
+	}
+	return rev
+}
+
+// revisionsFromReleases returns the revisions of a release history, ordered from oldest to newest.
+func revisionsFromReleases(releases []*release.Release) []Revision {
+	revisions := make([]Revision, 0, len(releases))
+	for _, r := range releases {
+		revisions = append(revisions, revisionFromRelease(r))
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions
+}
+
+// previousRevision returns the newest revision before the current (newest) one that was deployed successfully.
+// Failed or pending revisions are skipped, so that a release is never rolled back to a broken state.
+func previousRevision(revisions []Revision) (Revision, bool) {
+	for idx := len(revisions) - 2; idx >= 0; idx-- {
+		switch release.Status(revisions[idx].Status) {
+		case release.StatusDeployed, release.StatusSuperseded:
+			return revisions[idx], true
+		}
+	}
+	return Revision{}, false
+}
+
+// failedUpgradeRevision returns the newest revision, if it is a failed revision that was created after the specified one.
+// It is used to check that a failed upgrade created a revision that needs to be rolled back.
+func failedUpgradeRevision(revisions []Revision, after int) (Revision, bool) {
+	if len(revisions) == 0 {
+		return Revision{}, false
+	}
+	newest := revisions[len(revisions)-1]
+	if newest.Revision <= after || release.Status(newest.Status) != release.StatusFailed {
+		return Revision{}, false
+	}
+	return newest, true
+}
@@ -0,0 +1,91 @@
+package helm
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPreviousRevision(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		revisions []Revision
+		expected  int
+		expectOK  bool
+	}{
+		{name: "NoRevisions"},
+		{name: "OnlyCurrent", revisions: []Revision{{Revision: 1, Status: "deployed"}}},
+		{
+			name:      "Superseded",
+			revisions: []Revision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "deployed"}},
+			expected:  1,
+			expectOK:  true,
+		},
+		{
+			name:      "FailedUpgrade",
+			revisions: []Revision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "deployed"}, {Revision: 3, Status: "failed"}},
+			expected:  2,
+			expectOK:  true,
+		},
+		{
+			name:      "SkipFailed",
+			revisions: []Revision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "failed"}, {Revision: 3, Status: "deployed"}},
+			expected:  1,
+			expectOK:  true,
+		},
+		{
+			name:      "NoDeployedRevision",
+			revisions: []Revision{{Revision: 1, Status: "failed"}, {Revision: 2, Status: "failed"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			revision, ok := previousRevision(tc.revisions)
+			g.Expect(ok).To(Equal(tc.expectOK))
+			g.Expect(revision.Revision).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestFailedUpgradeRevision(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		revisions []Revision
+		after     int
+		expected  int
+		expectOK  bool
+	}{
+		{name: "NoRevisions", after: 1},
+		{
+			name:      "FailedUpgrade",
+			revisions: []Revision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "deployed"}, {Revision: 3, Status: "failed"}},
+			after:     2,
+			expected:  3,
+			expectOK:  true,
+		},
+		{
+			name:      "NoNewRevision",
+			revisions: []Revision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "deployed"}},
+			after:     2,
+		},
+		{
+			name:      "PreviouslyFailed",
+			revisions: []Revision{{Revision: 1, Status: "deployed"}, {Revision: 2, Status: "failed"}},
+			after:     2,
+		},
+		{
+			name:      "PendingUpgrade",
+			revisions: []Revision{{Revision: 1, Status: "deployed"}, {Revision: 2, Status: "pending-upgrade"}},
+			after:     1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			revision, ok := failedUpgradeRevision(tc.revisions, tc.after)
+			g.Expect(ok).To(Equal(tc.expectOK))
+			g.Expect(revision.Revision).To(Equal(tc.expected))
+		})
+	}
+}
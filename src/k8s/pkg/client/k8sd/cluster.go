@@ -6,6 +6,7 @@ import (
 	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 func (c *k8sd) BootstrapCluster(ctx context.Context, request apiv1.BootstrapClusterRequest) (apiv1.BootstrapClusterResponse, error) {
@@ -35,16 +36,48 @@ func (c *k8sd) JoinCluster(ctx context.Context, request apiv1.JoinClusterRequest
 	return err
 }
 
-func (c *k8sd) RemoveNode(ctx context.Context, request apiv1.RemoveNodeRequest) error {
+func (c *k8sd) RemoveNode(ctx context.Context, request types.RemoveNodeRequest) error {
 	// NOTE(neoaggelos): microcluster adds an arbitrary 30 second timeout in case no context deadline is set.
 	// Configure a client deadline for timeout + 30 seconds (the timeout will come from the server)
-	ctx, cancel := context.WithTimeout(ctx, request.Timeout+30*time.Second)
+	timeout := request.Timeout + 30*time.Second
+	if !request.SkipDrain && !request.Force {
+		// the node is drained before the removal timeout applies
+		if request.DrainTimeout > 0 {
+			timeout += request.DrainTimeout
+		} else {
+			timeout += types.DefaultDrainTimeout
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	_, err := query(ctx, c, "POST", apiv1.RemoveNodeRPC, request, &apiv1.RemoveNodeResponse{})
+	_, err := query(ctx, c, "POST", types.RemoveNodeRPC, request, &apiv1.RemoveNodeResponse{})
 	return err
 }
 
-func (c *k8sd) GetJoinToken(ctx context.Context, request apiv1.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error) {
-	return query(ctx, c, "POST", apiv1.GetJoinTokenRPC, request, &apiv1.GetJoinTokenResponse{})
+func (c *k8sd) GetJoinToken(ctx context.Context, request types.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error) {
+	return query(ctx, c, "POST", types.GetJoinTokenRPC, request, &apiv1.GetJoinTokenResponse{})
+}
+
+func (c *k8sd) ListJoinTokens(ctx context.Context, request types.ListJoinTokensRequest) (types.ListJoinTokensResponse, error) {
+	return query(ctx, c, "GET", types.ListJoinTokensRPC, request, &types.ListJoinTokensResponse{})
+}
+
+func (c *k8sd) RevokeJoinToken(ctx context.Context, request types.RevokeJoinTokenRequest) error {
+	_, err := query(ctx, c, "DELETE", types.RevokeJoinTokenRPC, request, &types.RevokeJoinTokenResponse{})
+	return err
+}
+
+func (c *k8sd) ListNodePools(ctx context.Context, request types.ListNodePoolsRequest) (types.ListNodePoolsResponse, error) {
+	return query(ctx, c, "GET", types.ListNodePoolsRPC, request, &types.ListNodePoolsResponse{})
+}
+
+func (c *k8sd) SetNodePool(ctx context.Context, request types.SetNodePoolRequest) error {
+	_, err := query(ctx, c, "PUT", types.SetNodePoolRPC, request, &types.SetNodePoolResponse{})
+	return err
+}
+
+func (c *k8sd) DeleteNodePool(ctx context.Context, request types.DeleteNodePoolRequest) error {
+	_, err := query(ctx, c, "DELETE", types.DeleteNodePoolRPC, request, &types.DeleteNodePoolResponse{})
+	return err
 }
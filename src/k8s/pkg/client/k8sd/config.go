@@ -4,6 +4,7 @@ import (
 	"context"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 func (c *k8sd) SetClusterConfig(ctx context.Context, request apiv1.SetClusterConfigRequest) error {
@@ -14,3 +15,12 @@ func (c *k8sd) SetClusterConfig(ctx context.Context, request apiv1.SetClusterCon
 func (c *k8sd) GetClusterConfig(ctx context.Context) (apiv1.GetClusterConfigResponse, error) {
 	return query(ctx, c, "GET", apiv1.GetClusterConfigRPC, nil, &apiv1.GetClusterConfigResponse{})
 }
+
+func (c *k8sd) GetServiceArgs(ctx context.Context, request types.GetServiceArgsRequest) (types.GetServiceArgsResponse, error) {
+	return query(ctx, c, "GET", types.GetServiceArgsRPC, request, &types.GetServiceArgsResponse{})
+}
+
+func (c *k8sd) SetServiceArgs(ctx context.Context, request types.SetServiceArgsRequest) error {
+	_, err := query(ctx, c, "PUT", types.SetServiceArgsRPC, request, &types.SetServiceArgsResponse{})
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 // ClusterClient implements methods for managing the cluster members.
@@ -11,11 +12,21 @@ type ClusterClient interface {
 	// BootstrapCluster initializes a new cluster using the provided configuration.
 	BootstrapCluster(context.Context, apiv1.BootstrapClusterRequest) (apiv1.BootstrapClusterResponse, error)
 	// GetJoinToken generates a token for nodes to join the cluster.
-	GetJoinToken(context.Context, apiv1.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error)
+	GetJoinToken(context.Context, types.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error)
+	// ListJoinTokens lists the outstanding control plane and worker node join tokens.
+	ListJoinTokens(context.Context, types.ListJoinTokensRequest) (types.ListJoinTokensResponse, error)
+	// RevokeJoinToken revokes a join token, so that it can no longer be used to join a node.
+	RevokeJoinToken(context.Context, types.RevokeJoinTokenRequest) error
 	// JoinCluster joins an existing cluster.
 	JoinCluster(context.Context, apiv1.JoinClusterRequest) error
 	// RemoveNode removes a node from the cluster.
-	RemoveNode(context.Context, apiv1.RemoveNodeRequest) error
+	RemoveNode(context.Context, types.RemoveNodeRequest) error
+	// ListNodePools lists the node pools and their members.
+	ListNodePools(context.Context, types.ListNodePoolsRequest) (types.ListNodePoolsResponse, error)
+	// SetNodePool creates a node pool, or replaces the configuration of an existing node pool.
+	SetNodePool(context.Context, types.SetNodePoolRequest) error
+	// DeleteNodePool deletes a node pool that has no members.
+	DeleteNodePool(context.Context, types.DeleteNodePoolRequest) error
 }
 
 // StatusClient implements methods for retrieving the current status of the cluster.
@@ -25,6 +36,14 @@ type StatusClient interface {
 	NodeStatus(ctx context.Context) (apiv1.NodeStatusResponse, bool, error)
 	// ClusterStatus retrieves the current status of the Kubernetes cluster.
 	ClusterStatus(ctx context.Context, waitReady bool) (apiv1.ClusterStatusResponse, error)
+	// FeatureStatus retrieves the detailed status (health and conditions) of the built-in features.
+	FeatureStatus(context.Context, types.GetFeatureStatusRequest) (types.GetFeatureStatusResponse, error)
+	// ClusterNodes retrieves versions, service states and datastore health of all nodes in the cluster.
+	ClusterNodes(context.Context, types.GetClusterNodesRequest) (types.GetClusterNodesResponse, error)
+	// Inspect collects an inspection report of the cluster, for troubleshooting.
+	Inspect(context.Context, types.InspectRequest) (types.InspectResponse, error)
+	// QueryDatabase runs a read-only query against the k8sd database. Secrets are redacted from the result.
+	QueryDatabase(context.Context, types.QueryDatabaseRequest) (types.QueryDatabaseResponse, error)
 }
 
 // ConfigClient implements methods to retrieve and manage the cluster configuration.
@@ -33,6 +52,10 @@ type ConfigClient interface {
 	GetClusterConfig(context.Context) (apiv1.GetClusterConfigResponse, error)
 	// SetClusterConfig updates the k8sd cluster configuration.
 	SetClusterConfig(context.Context, apiv1.SetClusterConfigRequest) error
+	// GetServiceArgs retrieves the cluster-wide extra arguments of the Kubernetes services.
+	GetServiceArgs(context.Context, types.GetServiceArgsRequest) (types.GetServiceArgsResponse, error)
+	// SetServiceArgs replaces the cluster-wide extra arguments of the Kubernetes services.
+	SetServiceArgs(context.Context, types.SetServiceArgsRequest) error
 }
 
 // ClusterMaintenanceClient implements methods to manage the cluster.
@@ -45,12 +68,22 @@ type ClusterMaintenanceClient interface {
 	RefreshCertificatesUpdate(context.Context, apiv1.RefreshCertificatesUpdateRequest) (apiv1.RefreshCertificatesUpdateResponse, error)
 	// CertificatesStatus shows the status of the node's certificates.
 	CertificatesStatus(context.Context, apiv1.CertificatesStatusRequest) (apiv1.CertificatesStatusResponse, error)
+	// RotateSecretsEncryptionKey rotates the key used to encrypt Secrets at rest and re-encrypts all Secrets.
+	RotateSecretsEncryptionKey(context.Context, types.RotateSecretsEncryptionKeyRequest) (types.RotateSecretsEncryptionKeyResponse, error)
+	// RollbackFeature rolls the Helm releases of a built-in feature back to their previous revision.
+	RollbackFeature(context.Context, types.RollbackFeatureRequest) (types.RollbackFeatureResponse, error)
 }
 
 // UserClient implements methods to enable accessing the cluster.
 type UserClient interface {
 	// KubeConfig retrieves a kubeconfig file that can be used to access the cluster.
-	KubeConfig(context.Context, apiv1.KubeConfigRequest) (apiv1.KubeConfigResponse, error)
+	KubeConfig(context.Context, types.KubeConfigRequest) (apiv1.KubeConfigResponse, error)
+	// CreateKubernetesAuthToken creates a new token to authenticate with the Kubernetes API server.
+	CreateKubernetesAuthToken(context.Context, types.GenerateKubernetesAuthTokenRequest) (types.GenerateKubernetesAuthTokenResponse, error)
+	// ListKubernetesAuthTokens lists the tokens to authenticate with the Kubernetes API server.
+	ListKubernetesAuthTokens(context.Context, types.ListKubernetesAuthTokensRequest) (types.ListKubernetesAuthTokensResponse, error)
+	// RevokeKubernetesAuthToken revokes a token to authenticate with the Kubernetes API server.
+	RevokeKubernetesAuthToken(context.Context, types.RevokeKubernetesAuthTokenRequest) error
 }
 
 // ClusterAPIClient implements methods related to ClusterAPI endpoints.
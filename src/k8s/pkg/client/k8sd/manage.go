@@ -4,6 +4,7 @@ import (
 	"context"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 func (c *k8sd) RefreshCertificatesPlan(ctx context.Context, request apiv1.RefreshCertificatesPlanRequest) (apiv1.RefreshCertificatesPlanResponse, error) {
@@ -21,3 +22,11 @@ func (c *k8sd) RefreshCertificatesUpdate(ctx context.Context, request apiv1.Refr
 func (c *k8sd) CertificatesStatus(ctx context.Context, request apiv1.CertificatesStatusRequest) (apiv1.CertificatesStatusResponse, error) {
 	return query(ctx, c, "GET", apiv1.CertificatesStatusRPC, request, &apiv1.CertificatesStatusResponse{})
 }
+
+func (c *k8sd) RotateSecretsEncryptionKey(ctx context.Context, request types.RotateSecretsEncryptionKeyRequest) (types.RotateSecretsEncryptionKeyResponse, error) {
+	return query(ctx, c, "POST", types.RotateSecretsEncryptionKeyRPC, request, &types.RotateSecretsEncryptionKeyResponse{})
+}
+
+func (c *k8sd) RollbackFeature(ctx context.Context, request types.RollbackFeatureRequest) (types.RollbackFeatureResponse, error) {
+	return query(ctx, c, "POST", types.RollbackFeatureRPC, request, &types.RollbackFeatureResponse{})
+}
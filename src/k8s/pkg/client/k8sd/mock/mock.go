@@ -5,6 +5,7 @@ import (
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	"github.com/canonical/k8s/pkg/client/k8sd"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 // Mock is a mock implementation of k8sd.Client.
@@ -13,26 +14,55 @@ type Mock struct {
 	BootstrapClusterCalledWith apiv1.BootstrapClusterRequest
 	BootstrapClusterResponse   apiv1.BootstrapClusterResponse
 	BootstrapClusterErr        error
-	GetJoinTokenCalledWith     apiv1.GetJoinTokenRequest
+	GetJoinTokenCalledWith     types.GetJoinTokenRequest
 	GetJoinTokenResponse       apiv1.GetJoinTokenResponse
 	GetJoinTokenErr            error
+	ListJoinTokensCalledWith   types.ListJoinTokensRequest
+	ListJoinTokensResponse     types.ListJoinTokensResponse
+	ListJoinTokensErr          error
+	RevokeJoinTokenCalledWith  types.RevokeJoinTokenRequest
+	RevokeJoinTokenErr         error
 	JoinClusterCalledWith      apiv1.JoinClusterRequest
 	JoinClusterErr             error
-	RemoveNodeCalledWith       apiv1.RemoveNodeRequest
+	RemoveNodeCalledWith       types.RemoveNodeRequest
 	RemoveNodeErr              error
+	ListNodePoolsCalledWith    types.ListNodePoolsRequest
+	ListNodePoolsResponse      types.ListNodePoolsResponse
+	ListNodePoolsErr           error
+	SetNodePoolCalledWith      types.SetNodePoolRequest
+	SetNodePoolErr             error
+	DeleteNodePoolCalledWith   types.DeleteNodePoolRequest
+	DeleteNodePoolErr          error
 
 	// k8sd.StatusClient
-	NodeStatusResponse    apiv1.NodeStatusResponse
-	NodeStatusInitialized bool
-	NodeStatusErr         error
-	ClusterStatusResponse apiv1.ClusterStatusResponse
-	ClusterStatusErr      error
+	NodeStatusResponse      apiv1.NodeStatusResponse
+	NodeStatusInitialized   bool
+	NodeStatusErr           error
+	ClusterStatusResponse   apiv1.ClusterStatusResponse
+	ClusterStatusErr        error
+	FeatureStatusCalledWith types.GetFeatureStatusRequest
+	FeatureStatusResponse   types.GetFeatureStatusResponse
+	FeatureStatusErr        error
+	ClusterNodesCalledWith  types.GetClusterNodesRequest
+	ClusterNodesResponse    types.GetClusterNodesResponse
+	ClusterNodesErr         error
+	InspectCalledWith       types.InspectRequest
+	InspectResponse         types.InspectResponse
+	InspectErr              error
+	QueryDatabaseCalledWith types.QueryDatabaseRequest
+	QueryDatabaseResponse   types.QueryDatabaseResponse
+	QueryDatabaseErr        error
 
 	// k8sd.ConfigClient
 	GetClusterConfigResponse   apiv1.GetClusterConfigResponse
 	GetClusterConfigErr        error
 	SetClusterConfigCalledWith apiv1.SetClusterConfigRequest
 	SetClusterConfigErr        error
+	GetServiceArgsCalledWith   types.GetServiceArgsRequest
+	GetServiceArgsResponse     types.GetServiceArgsResponse
+	GetServiceArgsErr          error
+	SetServiceArgsCalledWith   types.SetServiceArgsRequest
+	SetServiceArgsErr          error
 
 	// k8sd.ClusterMaintenanceClient
 	RefreshCertificatesPlanCalledWith   apiv1.RefreshCertificatesPlanRequest
@@ -49,11 +79,28 @@ type Mock struct {
 	CertificatesStatusResponse   apiv1.CertificatesStatusResponse
 	CertificatesStatusErr        error
 
+	RotateSecretsEncryptionKeyCalledWith types.RotateSecretsEncryptionKeyRequest
+	RotateSecretsEncryptionKeyResponse   types.RotateSecretsEncryptionKeyResponse
+	RotateSecretsEncryptionKeyErr        error
+
+	RollbackFeatureCalledWith types.RollbackFeatureRequest
+	RollbackFeatureResponse   types.RollbackFeatureResponse
+	RollbackFeatureErr        error
+
 	// k8sd.UserClient
-	KubeConfigCalledWith apiv1.KubeConfigRequest
+	KubeConfigCalledWith types.KubeConfigRequest
 	KubeConfigResponse   apiv1.KubeConfigResponse
 	KubeConfigErr        error
 
+	CreateKubernetesAuthTokenCalledWith types.GenerateKubernetesAuthTokenRequest
+	CreateKubernetesAuthTokenResponse   types.GenerateKubernetesAuthTokenResponse
+	CreateKubernetesAuthTokenErr        error
+	ListKubernetesAuthTokensCalledWith  types.ListKubernetesAuthTokensRequest
+	ListKubernetesAuthTokensResponse    types.ListKubernetesAuthTokensResponse
+	ListKubernetesAuthTokensErr         error
+	RevokeKubernetesAuthTokenCalledWith types.RevokeKubernetesAuthTokenRequest
+	RevokeKubernetesAuthTokenErr        error
+
 	// k8sd.ClusterAPIClient
 	SetClusterAPIAuthTokenCalledWith apiv1.ClusterAPISetAuthTokenRequest
 	SetClusterAPIAuthTokenErr        error
@@ -64,21 +111,46 @@ func (m *Mock) BootstrapCluster(_ context.Context, request apiv1.BootstrapCluste
 	return m.BootstrapClusterResponse, m.BootstrapClusterErr
 }
 
-func (m *Mock) GetJoinToken(_ context.Context, request apiv1.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error) {
+func (m *Mock) GetJoinToken(_ context.Context, request types.GetJoinTokenRequest) (apiv1.GetJoinTokenResponse, error) {
 	m.GetJoinTokenCalledWith = request
 	return m.GetJoinTokenResponse, m.GetJoinTokenErr
 }
 
+func (m *Mock) ListJoinTokens(_ context.Context, request types.ListJoinTokensRequest) (types.ListJoinTokensResponse, error) {
+	m.ListJoinTokensCalledWith = request
+	return m.ListJoinTokensResponse, m.ListJoinTokensErr
+}
+
+func (m *Mock) RevokeJoinToken(_ context.Context, request types.RevokeJoinTokenRequest) error {
+	m.RevokeJoinTokenCalledWith = request
+	return m.RevokeJoinTokenErr
+}
+
 func (m *Mock) JoinCluster(_ context.Context, request apiv1.JoinClusterRequest) error {
 	m.JoinClusterCalledWith = request
 	return m.JoinClusterErr
 }
 
-func (m *Mock) RemoveNode(_ context.Context, request apiv1.RemoveNodeRequest) error {
+func (m *Mock) RemoveNode(_ context.Context, request types.RemoveNodeRequest) error {
 	m.RemoveNodeCalledWith = request
 	return m.RemoveNodeErr
 }
 
+func (m *Mock) ListNodePools(_ context.Context, request types.ListNodePoolsRequest) (types.ListNodePoolsResponse, error) {
+	m.ListNodePoolsCalledWith = request
+	return m.ListNodePoolsResponse, m.ListNodePoolsErr
+}
+
+func (m *Mock) SetNodePool(_ context.Context, request types.SetNodePoolRequest) error {
+	m.SetNodePoolCalledWith = request
+	return m.SetNodePoolErr
+}
+
+func (m *Mock) DeleteNodePool(_ context.Context, request types.DeleteNodePoolRequest) error {
+	m.DeleteNodePoolCalledWith = request
+	return m.DeleteNodePoolErr
+}
+
 func (m *Mock) NodeStatus(_ context.Context) (apiv1.NodeStatusResponse, bool, error) {
 	return m.NodeStatusResponse, m.NodeStatusInitialized, m.NodeStatusErr
 }
@@ -87,6 +159,26 @@ func (m *Mock) ClusterStatus(_ context.Context, waitReady bool) (apiv1.ClusterSt
 	return m.ClusterStatusResponse, m.ClusterStatusErr
 }
 
+func (m *Mock) FeatureStatus(_ context.Context, request types.GetFeatureStatusRequest) (types.GetFeatureStatusResponse, error) {
+	m.FeatureStatusCalledWith = request
+	return m.FeatureStatusResponse, m.FeatureStatusErr
+}
+
+func (m *Mock) ClusterNodes(_ context.Context, request types.GetClusterNodesRequest) (types.GetClusterNodesResponse, error) {
+	m.ClusterNodesCalledWith = request
+	return m.ClusterNodesResponse, m.ClusterNodesErr
+}
+
+func (m *Mock) Inspect(_ context.Context, request types.InspectRequest) (types.InspectResponse, error) {
+	m.InspectCalledWith = request
+	return m.InspectResponse, m.InspectErr
+}
+
+func (m *Mock) QueryDatabase(_ context.Context, request types.QueryDatabaseRequest) (types.QueryDatabaseResponse, error) {
+	m.QueryDatabaseCalledWith = request
+	return m.QueryDatabaseResponse, m.QueryDatabaseErr
+}
+
 func (m *Mock) RefreshCertificatesPlan(_ context.Context, request apiv1.RefreshCertificatesPlanRequest) (apiv1.RefreshCertificatesPlanResponse, error) {
 	return m.RefreshCertificatesPlanResponse, m.RefreshCertificatesPlanErr
 }
@@ -105,6 +197,16 @@ func (m *Mock) CertificatesStatus(_ context.Context, request apiv1.CertificatesS
 	return m.CertificatesStatusResponse, m.CertificatesStatusErr
 }
 
+func (m *Mock) RotateSecretsEncryptionKey(_ context.Context, request types.RotateSecretsEncryptionKeyRequest) (types.RotateSecretsEncryptionKeyResponse, error) {
+	m.RotateSecretsEncryptionKeyCalledWith = request
+	return m.RotateSecretsEncryptionKeyResponse, m.RotateSecretsEncryptionKeyErr
+}
+
+func (m *Mock) RollbackFeature(_ context.Context, request types.RollbackFeatureRequest) (types.RollbackFeatureResponse, error) {
+	m.RollbackFeatureCalledWith = request
+	return m.RollbackFeatureResponse, m.RollbackFeatureErr
+}
+
 func (m *Mock) GetClusterConfig(_ context.Context) (apiv1.GetClusterConfigResponse, error) {
 	return m.GetClusterConfigResponse, m.GetClusterConfigErr
 }
@@ -114,11 +216,36 @@ func (m *Mock) SetClusterConfig(_ context.Context, request apiv1.SetClusterConfi
 	return m.SetClusterConfigErr
 }
 
-func (m *Mock) KubeConfig(_ context.Context, request apiv1.KubeConfigRequest) (apiv1.KubeConfigResponse, error) {
+func (m *Mock) GetServiceArgs(_ context.Context, request types.GetServiceArgsRequest) (types.GetServiceArgsResponse, error) {
+	m.GetServiceArgsCalledWith = request
+	return m.GetServiceArgsResponse, m.GetServiceArgsErr
+}
+
+func (m *Mock) SetServiceArgs(_ context.Context, request types.SetServiceArgsRequest) error {
+	m.SetServiceArgsCalledWith = request
+	return m.SetServiceArgsErr
+}
+
+func (m *Mock) KubeConfig(_ context.Context, request types.KubeConfigRequest) (apiv1.KubeConfigResponse, error) {
 	m.KubeConfigCalledWith = request
 	return m.KubeConfigResponse, m.KubeConfigErr
 }
 
+func (m *Mock) CreateKubernetesAuthToken(_ context.Context, request types.GenerateKubernetesAuthTokenRequest) (types.GenerateKubernetesAuthTokenResponse, error) {
+	m.CreateKubernetesAuthTokenCalledWith = request
+	return m.CreateKubernetesAuthTokenResponse, m.CreateKubernetesAuthTokenErr
+}
+
+func (m *Mock) ListKubernetesAuthTokens(_ context.Context, request types.ListKubernetesAuthTokensRequest) (types.ListKubernetesAuthTokensResponse, error) {
+	m.ListKubernetesAuthTokensCalledWith = request
+	return m.ListKubernetesAuthTokensResponse, m.ListKubernetesAuthTokensErr
+}
+
+func (m *Mock) RevokeKubernetesAuthToken(_ context.Context, request types.RevokeKubernetesAuthTokenRequest) error {
+	m.RevokeKubernetesAuthTokenCalledWith = request
+	return m.RevokeKubernetesAuthTokenErr
+}
+
 func (m *Mock) SetClusterAPIAuthToken(_ context.Context, request apiv1.ClusterAPISetAuthTokenRequest) error {
 	m.SetClusterAPIAuthTokenCalledWith = request
 	return m.SetClusterAPIAuthTokenErr
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/utils/control"
 	"github.com/canonical/lxd/shared/api"
 )
@@ -41,3 +42,19 @@ func (c *k8sd) ClusterStatus(ctx context.Context, waitReady bool) (apiv1.Cluster
 	}
 	return response, nil
 }
+
+func (c *k8sd) FeatureStatus(ctx context.Context, request types.GetFeatureStatusRequest) (types.GetFeatureStatusResponse, error) {
+	return query(ctx, c, "GET", types.GetFeatureStatusRPC, request, &types.GetFeatureStatusResponse{})
+}
+
+func (c *k8sd) ClusterNodes(ctx context.Context, request types.GetClusterNodesRequest) (types.GetClusterNodesResponse, error) {
+	return query(ctx, c, "GET", types.GetClusterNodesRPC, request, &types.GetClusterNodesResponse{})
+}
+
+func (c *k8sd) Inspect(ctx context.Context, request types.InspectRequest) (types.InspectResponse, error) {
+	return query(ctx, c, "POST", types.InspectRPC, request, &types.InspectResponse{})
+}
+
+func (c *k8sd) QueryDatabase(ctx context.Context, request types.QueryDatabaseRequest) (types.QueryDatabaseResponse, error) {
+	return query(ctx, c, "POST", types.QueryDatabaseRPC, request, &types.QueryDatabaseResponse{})
+}
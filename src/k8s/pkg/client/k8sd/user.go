@@ -4,8 +4,22 @@ import (
 	"context"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
-func (c *k8sd) KubeConfig(ctx context.Context, request apiv1.KubeConfigRequest) (apiv1.KubeConfigResponse, error) {
-	return query(ctx, c, "GET", apiv1.KubeConfigRPC, request, &apiv1.KubeConfigResponse{})
+func (c *k8sd) KubeConfig(ctx context.Context, request types.KubeConfigRequest) (apiv1.KubeConfigResponse, error) {
+	return query(ctx, c, "GET", types.KubeConfigRPC, request, &apiv1.KubeConfigResponse{})
+}
+
+func (c *k8sd) CreateKubernetesAuthToken(ctx context.Context, request types.GenerateKubernetesAuthTokenRequest) (types.GenerateKubernetesAuthTokenResponse, error) {
+	return query(ctx, c, "POST", types.GenerateKubernetesAuthTokenRPC, request, &types.GenerateKubernetesAuthTokenResponse{})
+}
+
+func (c *k8sd) ListKubernetesAuthTokens(ctx context.Context, request types.ListKubernetesAuthTokensRequest) (types.ListKubernetesAuthTokensResponse, error) {
+	return query(ctx, c, "GET", types.ListKubernetesAuthTokensRPC, request, &types.ListKubernetesAuthTokensResponse{})
+}
+
+func (c *k8sd) RevokeKubernetesAuthToken(ctx context.Context, request types.RevokeKubernetesAuthTokenRequest) error {
+	_, err := query(ctx, c, "DELETE", types.RevokeKubernetesAuthTokenRPC, request, &apiv1.RevokeKubernetesAuthTokenResponse{})
+	return err
 }
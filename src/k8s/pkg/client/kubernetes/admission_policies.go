@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplyAdmissionPolicies creates or updates the ValidatingAdmissionPolicies and ValidatingAdmissionPolicyBindings.
+// Policies are applied before bindings, so that bindings never refer to missing policies.
+func (c *Client) ApplyAdmissionPolicies(ctx context.Context, policies types.AdmissionPolicies) error {
+	for _, policy := range policies.Policies {
+		existing, err := c.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, policy.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			policy.ResourceVersion = existing.ResourceVersion
+			_, err = c.AdmissionregistrationV1().ValidatingAdmissionPolicies().Update(ctx, &policy, metav1.UpdateOptions{})
+		case apierrors.IsNotFound(err):
+			_, err = c.AdmissionregistrationV1().ValidatingAdmissionPolicies().Create(ctx, &policy, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply validatingadmissionpolicy %s: %w", policy.Name, err)
+		}
+	}
+
+	for _, binding := range policies.Bindings {
+		existing, err := c.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(ctx, binding.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			binding.ResourceVersion = existing.ResourceVersion
+			_, err = c.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Update(ctx, &binding, metav1.UpdateOptions{})
+		case apierrors.IsNotFound(err):
+			_, err = c.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Create(ctx, &binding, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply validatingadmissionpolicybinding %s: %w", binding.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyAdmissionPolicies(t *testing.T) {
+	g := NewWithT(t)
+	client := &Client{Interface: fake.NewSimpleClientset(
+		&admissionregistrationv1.ValidatingAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+			Spec:       admissionregistrationv1.ValidatingAdmissionPolicySpec{Validations: []admissionregistrationv1.Validation{{Expression: "false"}}},
+		},
+	)}
+	ctx := context.Background()
+
+	policies := types.AdmissionPolicies{
+		Policies: []admissionregistrationv1.ValidatingAdmissionPolicy{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+				Spec:       admissionregistrationv1.ValidatingAdmissionPolicySpec{Validations: []admissionregistrationv1.Validation{{Expression: "true"}}},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "new"}},
+		},
+		Bindings: []admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "new-binding"},
+				Spec:       admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{PolicyName: "new"},
+			},
+		},
+	}
+	g.Expect(client.ApplyAdmissionPolicies(ctx, policies)).To(Succeed())
+	// applying the same policies again updates them
+	g.Expect(client.ApplyAdmissionPolicies(ctx, policies)).To(Succeed())
+
+	existing, err := client.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, "existing", metav1.GetOptions{})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(existing.Spec.Validations).To(ConsistOf(admissionregistrationv1.Validation{Expression: "true"}))
+
+	_, err = client.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, "new", metav1.GetOptions{})
+	g.Expect(err).To(Not(HaveOccurred()))
+
+	binding, err := client.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(ctx, "new-binding", metav1.GetOptions{})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(binding.Spec.PolicyName).To(Equal("new"))
+}
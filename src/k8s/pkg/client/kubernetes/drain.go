@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/canonical/k8s/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/util/retry"
+)
+
+// drainPollInterval is the interval between eviction attempts while draining a node.
+var drainPollInterval = 5 * time.Second
+
+// CordonNode marks the node as unschedulable.
+// CordonNode will retry if there is a conflict on the resource.
+func (c *Client) CordonNode(ctx context.Context, nodeName string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		node, err := c.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get node: %w", err)
+		}
+		if node.Spec.Unschedulable {
+			return nil
+		}
+		node.Spec.Unschedulable = true
+		if _, err := c.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update node: %w", err)
+		}
+		return nil
+	})
+}
+
+// DrainNode cordons the node and evicts all pods running on it through the eviction API,
+// so that PodDisruptionBudgets are respected.
+// DrainNode skips DaemonSet and static pods, as they cannot be moved to other nodes.
+// DrainNode retries evictions that are blocked by a PodDisruptionBudget and waits for the evicted
+// pods to terminate until the context is done.
+func (c *Client) DrainNode(ctx context.Context, nodeName string) error {
+	log := log.FromContext(ctx).WithValues("node", nodeName)
+
+	if err := c.CordonNode(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to cordon node: %w", err)
+	}
+
+	for {
+		pods, err := c.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list pods: %w", err)
+		}
+
+		var remaining []string
+		for _, pod := range pods.Items {
+			if !podNeedsEviction(pod) {
+				continue
+			}
+			remaining = append(remaining, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			if pod.DeletionTimestamp != nil {
+				// already evicted, wait for the pod to terminate
+				continue
+			}
+
+			err := c.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			})
+			switch {
+			case err == nil, apierrors.IsNotFound(err):
+			case apierrors.IsTooManyRequests(err):
+				log.Info("Pod eviction is blocked by a PodDisruptionBudget, will retry", "pod", pod.Name, "namespace", pod.Namespace)
+			default:
+				return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+
+		if len(remaining) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pods %v to be evicted: %w", remaining, ctx.Err())
+		case <-time.After(drainPollInterval):
+		}
+	}
+}
+
+// podNeedsEviction returns true if the pod must be evicted to drain its node.
+func podNeedsEviction(pod corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller && owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestDrainNode(t *testing.T) {
+	drainPollInterval = 10 * time.Millisecond
+
+	newPod := func(name string, mutate func(*corev1.Pod)) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if mutate != nil {
+			mutate(pod)
+		}
+		return pod
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+
+	// withEvictions deletes evicted pods, unless they are blocked.
+	withEvictions := func(clientset *fake.Clientset, blocked map[string]bool) *[]string {
+		var evicted []string
+		clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+			if blocked[eviction.Name] {
+				return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+			evicted = append(evicted, eviction.Name)
+			return true, nil, clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+		})
+		return &evicted
+	}
+
+	t.Run("Drain", func(t *testing.T) {
+		g := NewWithT(t)
+		clientset := fake.NewSimpleClientset(
+			node,
+			newPod("app", nil),
+			newPod("daemon", func(p *corev1.Pod) {
+				p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds", Controller: ptr.To(true)}}
+			}),
+			newPod("static", func(p *corev1.Pod) {
+				p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+			}),
+			newPod("completed", func(p *corev1.Pod) { p.Status.Phase = corev1.PodSucceeded }),
+		)
+		evicted := withEvictions(clientset, nil)
+		client := &Client{Interface: clientset}
+
+		g.Expect(client.DrainNode(context.Background(), "node1")).To(Succeed())
+		g.Expect(*evicted).To(ConsistOf("app"))
+
+		n, err := clientset.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(n.Spec.Unschedulable).To(BeTrue())
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		g := NewWithT(t)
+		clientset := fake.NewSimpleClientset(node, newPod("app", nil), newPod("protected", nil))
+		evicted := withEvictions(clientset, map[string]bool{"protected": true})
+		client := &Client{Interface: clientset}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := client.DrainNode(ctx, "node1")
+		g.Expect(err).To(MatchError(ContainSubstring("default/protected")))
+		g.Expect(*evicted).To(ConsistOf("app"))
+	})
+
+	t.Run("NodeNotFound", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &Client{Interface: fake.NewSimpleClientset()}
+
+		g.Expect(client.DrainNode(context.Background(), "node1")).ToNot(Succeed())
+	})
+}
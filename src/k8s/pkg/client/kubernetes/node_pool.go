@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// NodePoolLabel is set on nodes that are members of a node pool, with the name of the pool as value.
+	NodePoolLabel = "k8sd.io/node-pool"
+	// nodePoolLabelsAnnotation records the keys of the labels that were set from the node pool.
+	nodePoolLabelsAnnotation = "k8sd.io/node-pool-labels"
+	// nodePoolTaintsAnnotation records the "key:Effect" of the taints that were set from the node pool.
+	nodePoolTaintsAnnotation = "k8sd.io/node-pool-taints"
+)
+
+// ApplyNodePool sets the labels and taints of a node pool on a node.
+// ApplyNodePool removes any labels and taints that were previously set from a node pool, but are no longer part of it.
+// An empty pool name removes the node from its node pool.
+// ApplyNodePool returns false if the node does not exist.
+func (c *Client) ApplyNodePool(ctx context.Context, nodeName string, pool string, labels map[string]string, taints []v1.Taint) (bool, error) {
+	found := true
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				found = false
+				return nil
+			}
+			return fmt.Errorf("failed to get node: %w", err)
+		}
+
+		updated := node.DeepCopy()
+		setNodePool(updated, pool, labels, taints)
+		if apiequality.Semantic.DeepEqual(node, updated) {
+			return nil
+		}
+
+		_, err = c.CoreV1().Nodes().Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return false, fmt.Errorf("failed to update node %s: %w", nodeName, err)
+	}
+	return found, nil
+}
+
+// ListNodePoolNodes returns the names of all nodes that are members of a node pool.
+func (c *Client) ListNodePoolNodes(ctx context.Context) ([]string, error) {
+	nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: NodePoolLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	names := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		names = append(names, node.Name)
+	}
+	return names, nil
+}
+
+// setNodePool updates the labels, taints and annotations of the node in-place.
+func setNodePool(node *v1.Node, pool string, labels map[string]string, taints []v1.Taint) {
+	// remove labels and taints that were previously set from the node pool
+	for _, key := range splitAnnotation(node.Annotations[nodePoolLabelsAnnotation]) {
+		if _, ok := labels[key]; !ok {
+			delete(node.Labels, key)
+		}
+	}
+	previousTaints := make(map[string]struct{})
+	for _, taint := range splitAnnotation(node.Annotations[nodePoolTaintsAnnotation]) {
+		previousTaints[taint] = struct{}{}
+	}
+	newTaints := make(map[string]v1.Taint, len(taints))
+	for _, taint := range taints {
+		newTaints[taintID(taint)] = taint
+	}
+	var nodeTaints []v1.Taint
+	for _, taint := range node.Spec.Taints {
+		id := taintID(taint)
+		if _, ok := newTaints[id]; ok {
+			continue
+		}
+		if _, ok := previousTaints[id]; ok {
+			continue
+		}
+		nodeTaints = append(nodeTaints, taint)
+	}
+
+	// set labels and taints of the node pool
+	labelKeys := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if node.Labels == nil {
+			node.Labels = make(map[string]string)
+		}
+		node.Labels[key] = value
+		labelKeys = append(labelKeys, key)
+	}
+	taintIDs := make([]string, 0, len(taints))
+	for _, taint := range taints {
+		nodeTaints = append(nodeTaints, taint)
+		taintIDs = append(taintIDs, taintID(taint))
+	}
+	node.Spec.Taints = nodeTaints
+
+	if pool != "" {
+		if node.Labels == nil {
+			node.Labels = make(map[string]string)
+		}
+		node.Labels[NodePoolLabel] = pool
+	} else {
+		delete(node.Labels, NodePoolLabel)
+	}
+	setAnnotation(node, nodePoolLabelsAnnotation, labelKeys)
+	setAnnotation(node, nodePoolTaintsAnnotation, taintIDs)
+}
+
+func taintID(taint v1.Taint) string {
+	return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+}
+
+func splitAnnotation(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func setAnnotation(node *v1.Node, key string, values []string) {
+	if len(values) == 0 {
+		delete(node.Annotations, key)
+		return
+	}
+	sort.Strings(values)
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[key] = strings.Join(values, ",")
+}
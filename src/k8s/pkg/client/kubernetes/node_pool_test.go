@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	clientset := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"existing": "label"}},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{Key: "existing", Effect: v1.TaintEffectNoSchedule}},
+		},
+	})
+	client := &Client{Interface: clientset}
+
+	t.Run("NodeNotFound", func(t *testing.T) {
+		g := NewWithT(t)
+		found, err := client.ApplyNodePool(ctx, "node2", "gpu", nil, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(found).To(BeFalse())
+	})
+
+	t.Run("SetPool", func(t *testing.T) {
+		g := NewWithT(t)
+		found, err := client.ApplyNodePool(ctx, "node1", "gpu",
+			map[string]string{"accelerator": "nvidia", "tier": "1"},
+			[]v1.Taint{{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}},
+		)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(found).To(BeTrue())
+
+		node, err := clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(node.Labels).To(Equal(map[string]string{
+			"existing":          "label",
+			"accelerator":       "nvidia",
+			"tier":              "1",
+			"k8sd.io/node-pool": "gpu",
+		}))
+		g.Expect(node.Spec.Taints).To(ConsistOf(
+			v1.Taint{Key: "existing", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule},
+		))
+		g.Expect(node.Annotations).To(HaveKeyWithValue("k8sd.io/node-pool-labels", "accelerator,tier"))
+		g.Expect(node.Annotations).To(HaveKeyWithValue("k8sd.io/node-pool-taints", "gpu:NoSchedule"))
+
+		names, err := client.ListNodePoolNodes(ctx)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(names).To(ConsistOf("node1"))
+	})
+
+	t.Run("UpdatePool", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := client.ApplyNodePool(ctx, "node1", "gpu",
+			map[string]string{"accelerator": "amd"},
+			[]v1.Taint{{Key: "gpu", Value: "false", Effect: v1.TaintEffectNoSchedule}},
+		)
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		node, err := clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(node.Labels).To(Equal(map[string]string{
+			"existing":          "label",
+			"accelerator":       "amd",
+			"k8sd.io/node-pool": "gpu",
+		}))
+		g.Expect(node.Spec.Taints).To(ConsistOf(
+			v1.Taint{Key: "existing", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "gpu", Value: "false", Effect: v1.TaintEffectNoSchedule},
+		))
+		g.Expect(node.Annotations).To(HaveKeyWithValue("k8sd.io/node-pool-labels", "accelerator"))
+	})
+
+	t.Run("RemovePool", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := client.ApplyNodePool(ctx, "node1", "", nil, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		node, err := clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(node.Labels).To(Equal(map[string]string{"existing": "label"}))
+		g.Expect(node.Spec.Taints).To(ConsistOf(v1.Taint{Key: "existing", Effect: v1.TaintEffectNoSchedule}))
+		g.Expect(node.Annotations).To(BeEmpty())
+
+		names, err := client.ListNodePoolNodes(ctx)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(names).To(BeEmpty())
+	})
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplyUserRoleBinding grants a ClusterRole to a user.
+// ApplyUserRoleBinding creates a RoleBinding if namespace is set, or a ClusterRoleBinding otherwise.
+// ApplyUserRoleBinding replaces any existing binding with the same name.
+func (c *Client) ApplyUserRoleBinding(ctx context.Context, name string, namespace string, clusterRole string, username string, labels map[string]string) error {
+	subjects := []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: username}}
+	roleRef := rbacv1.RoleRef{Kind: "ClusterRole", APIGroup: rbacv1.GroupName, Name: clusterRole}
+	meta := metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+
+	// the roleRef of a binding is immutable, so the binding is re-created instead of updated
+	if namespace != "" {
+		if err := c.RbacV1().RoleBindings(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete existing rolebinding %s/%s: %w", namespace, name, err)
+		}
+		if _, err := c.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{ObjectMeta: meta, Subjects: subjects, RoleRef: roleRef}, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create rolebinding %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+
+	if err := c.RbacV1().ClusterRoleBindings().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete existing clusterrolebinding %s: %w", name, err)
+	}
+	if _, err := c.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{ObjectMeta: meta, Subjects: subjects, RoleRef: roleRef}, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create clusterrolebinding %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteRoleBindings deletes all RoleBindings (in any namespace) and ClusterRoleBindings that match the label selector.
+func (c *Client) DeleteRoleBindings(ctx context.Context, labelSelector string) error {
+	listOptions := metav1.ListOptions{LabelSelector: labelSelector}
+
+	roleBindings, err := c.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list rolebindings: %w", err)
+	}
+	for _, binding := range roleBindings.Items {
+		if err := c.RbacV1().RoleBindings(binding.Namespace).Delete(ctx, binding.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rolebinding %s/%s: %w", binding.Namespace, binding.Name, err)
+		}
+	}
+
+	clusterRoleBindings, err := c.RbacV1().ClusterRoleBindings().List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list clusterrolebindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if err := c.RbacV1().ClusterRoleBindings().Delete(ctx, binding.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete clusterrolebinding %s: %w", binding.Name, err)
+		}
+	}
+	return nil
+}
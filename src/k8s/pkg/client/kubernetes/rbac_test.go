@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyUserRoleBinding(t *testing.T) {
+	t.Run("ClusterRoleBinding", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &Client{Interface: fake.NewSimpleClientset()}
+		ctx := context.Background()
+
+		g.Expect(client.ApplyUserRoleBinding(ctx, "binding", "", "view", "alice", map[string]string{"key": "value"})).To(Succeed())
+		// re-applying with a different role replaces the binding
+		g.Expect(client.ApplyUserRoleBinding(ctx, "binding", "", "edit", "alice", map[string]string{"key": "value"})).To(Succeed())
+
+		binding, err := client.RbacV1().ClusterRoleBindings().Get(ctx, "binding", metav1.GetOptions{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(binding.RoleRef.Name).To(Equal("edit"))
+		g.Expect(binding.Labels).To(HaveKeyWithValue("key", "value"))
+		g.Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "User", APIGroup: "rbac.authorization.k8s.io", Name: "alice"}))
+	})
+
+	t.Run("RoleBinding", func(t *testing.T) {
+		g := NewWithT(t)
+		client := &Client{Interface: fake.NewSimpleClientset()}
+		ctx := context.Background()
+
+		g.Expect(client.ApplyUserRoleBinding(ctx, "binding", "dev", "view", "alice", nil)).To(Succeed())
+
+		binding, err := client.RbacV1().RoleBindings("dev").Get(ctx, "binding", metav1.GetOptions{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{Kind: "ClusterRole", APIGroup: "rbac.authorization.k8s.io", Name: "view"}))
+
+		_, err = client.RbacV1().ClusterRoleBindings().Get(ctx, "binding", metav1.GetOptions{})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestDeleteRoleBindings(t *testing.T) {
+	g := NewWithT(t)
+	client := &Client{Interface: fake.NewSimpleClientset(
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "match", Namespace: "dev", Labels: map[string]string{"id": "1"}}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "dev", Labels: map[string]string{"id": "2"}}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "match", Labels: map[string]string{"id": "1"}}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}},
+	)}
+	ctx := context.Background()
+
+	g.Expect(client.DeleteRoleBindings(ctx, "id=1")).To(Succeed())
+
+	roleBindings, err := client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(roleBindings.Items).To(HaveLen(1))
+	g.Expect(roleBindings.Items[0].Name).To(Equal("other"))
+
+	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(clusterRoleBindings.Items).To(HaveLen(1))
+	g.Expect(clusterRoleBindings.Items[0].Name).To(Equal("unlabeled"))
+}
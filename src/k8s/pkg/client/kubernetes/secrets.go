@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RewriteSecrets updates all Secrets in the cluster without changing them, so that the kube-apiserver
+// stores them again using the current encryption provider configuration.
+// Secrets that are modified or deleted concurrently are skipped, as they have been rewritten already.
+// RewriteSecrets returns the number of Secrets that were rewritten.
+func (c *Client) RewriteSecrets(ctx context.Context) (int, error) {
+	var count int
+	opts := metav1.ListOptions{Limit: 100}
+	for {
+		secrets, err := c.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, opts)
+		if err != nil {
+			return count, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		for _, secret := range secrets.Items {
+			if _, err := c.CoreV1().Secrets(secret.Namespace).Update(ctx, &secret, metav1.UpdateOptions{}); err != nil {
+				if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+					continue
+				}
+				return count, fmt.Errorf("failed to rewrite secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+			count++
+		}
+		if secrets.Continue == "" {
+			return count, nil
+		}
+		opts.Continue = secrets.Continue
+	}
+}
@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRewriteSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "kube-system"}},
+	)
+	var updated []string
+	clientset.PrependReactor("update", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		secret := action.(k8stesting.UpdateAction).GetObject().(*corev1.Secret)
+		updated = append(updated, secret.Namespace+"/"+secret.Name)
+		return false, nil, nil
+	})
+	client := &Client{Interface: clientset}
+
+	count, err := client.RewriteSecrets(context.Background())
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(count).To(Equal(2))
+	g.Expect(updated).To(ConsistOf("default/a", "kube-system/b"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,3 +17,28 @@ func (c *Client) GetServiceClusterIP(ctx context.Context, name, namespace string
 
 	return svc.Spec.ClusterIP, nil
 }
+
+// ListPendingLoadBalancerServices returns the namespaced names of all services of type LoadBalancer
+// that do not have an ingress address allocated yet.
+// If include is not nil, only services for which it returns true are considered.
+func (c *Client) ListPendingLoadBalancerServices(ctx context.Context, include func(svc corev1.Service) bool) ([]string, error) {
+	services, err := c.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var pending []string
+	for _, svc := range services.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if include != nil && !include(svc) {
+			continue
+		}
+		if len(svc.Status.LoadBalancer.Ingress) == 0 {
+			pending = append(pending, fmt.Sprintf("%s/%s", svc.Namespace, svc.Name))
+		}
+	}
+
+	return pending, nil
+}
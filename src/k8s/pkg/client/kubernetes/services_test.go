@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetServiceClusterIP(t *testing.T) {
@@ -67,3 +68,46 @@ func TestGetServiceClusterIP(t *testing.T) {
 		})
 	}
 }
+
+func TestListPendingLoadBalancerServices(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-ip", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "allocated", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.10"}}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "kube-system"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-class", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: ptr.To("example.com/lb")},
+		},
+	)
+	client := &Client{Interface: clientset}
+
+	t.Run("All", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pending, err := client.ListPendingLoadBalancerServices(context.Background(), nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pending).To(ConsistOf("kube-system/pending", "default/other-class"))
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pending, err := client.ListPendingLoadBalancerServices(context.Background(), func(svc corev1.Service) bool {
+			return svc.Spec.LoadBalancerClass == nil
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pending).To(ConsistOf("kube-system/pending"))
+	})
+}
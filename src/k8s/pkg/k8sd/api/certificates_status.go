@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -53,33 +54,40 @@ func (e *Endpoints) getCertificatesStatus(s state.State, r *http.Request) respon
 	if err != nil {
 		return response.InternalError(fmt.Errorf("failed to check if node is a worker: %w", err))
 	}
+
+	var status apiv1.CertificatesStatusResponse
 	if isWorker {
-		return getCertsStatusWorker(s, r, snap)
+		status, err = getCertsStatusWorker(snap)
+	} else {
+		status, err = getCertsStatusControlPlane(r.Context(), s, snap)
+	}
+	if err != nil {
+		return response.InternalError(err)
 	}
-	return getCertsStatusControlPlane(s, r, snap)
+	return response.SyncResponse(true, status)
 }
 
 // getCertsStatusControlPlane collects certificate status information for
 // control plane nodes. It reads control plane certificates, kubeconfig
 // certificates, and certificate authority statuses.
-func getCertsStatusControlPlane(s state.State, r *http.Request, snap snap.Snap) response.Response {
-	clusterConfig, err := databaseutil.GetClusterConfig(r.Context(), s)
+func getCertsStatusControlPlane(ctx context.Context, s state.State, snap snap.Snap) (apiv1.CertificatesStatusResponse, error) {
+	clusterConfig, err := databaseutil.GetClusterConfig(ctx, s)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to retrieve cluster configuration: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to retrieve cluster configuration: %w", err)
 	}
 	authorities, err := readCertificateAuthorities(&clusterConfig)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to read certificates authorities: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read certificates authorities: %w", err)
 	}
 
 	nodeCerts, err := loadCertificateStatusesFromDir(snap.KubernetesPKIDir(), controlPlaneCertificateNames)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to read node certificates: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read node certificates: %w", err)
 	}
 
 	kubeConfigCerts, err := readKubeconfigCertificates(snap.KubernetesConfigDir(), controlPlaneKubeconfigs)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to read kubeconfig certificates: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read kubeconfig certificates: %w", err)
 	}
 
 	var certificates []apiv1.CertificateStatus
@@ -89,29 +97,29 @@ func getCertsStatusControlPlane(s state.State, r *http.Request, snap snap.Snap)
 	if clusterConfig.Datastore.GetType() == "external" {
 		dataStoreCerts, err := loadCertificateStatusesFromDir(snap.EtcdPKIDir(), dataStoreCertificateNames)
 		if err != nil {
-			return response.InternalError(fmt.Errorf("failed to read datastore certificates: %w", err))
+			return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read datastore certificates: %w", err)
 		}
 		certificates = append(certificates, dataStoreCerts...)
 	}
 
 	updateExternallyManaged(authorities, certificates)
-	return response.SyncResponse(true, apiv1.CertificatesStatusResponse{
+	return apiv1.CertificatesStatusResponse{
 		Certificates:           certificates,
 		CertificateAuthorities: authorities,
-	})
+	}, nil
 }
 
 // getCertsStatusWorker collects certificate status information for worker
 // nodes. It reads worker certificates and kubeconfig certificates.
-func getCertsStatusWorker(s state.State, r *http.Request, snap snap.Snap) response.Response {
+func getCertsStatusWorker(snap snap.Snap) (apiv1.CertificatesStatusResponse, error) {
 	nodeCerts, err := loadCertificateStatusesFromDir(snap.KubernetesPKIDir(), workerCertificateNames)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to read node certificates: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read node certificates: %w", err)
 	}
 
 	kubeConfigCerts, err := readKubeconfigCertificates(snap.KubernetesConfigDir(), workerKubeconfigs)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to read kubeconfig certificates: %w", err))
+		return apiv1.CertificatesStatusResponse{}, fmt.Errorf("failed to read kubeconfig certificates: %w", err)
 	}
 
 	var certificates []apiv1.CertificateStatus
@@ -124,10 +132,10 @@ func getCertsStatusWorker(s state.State, r *http.Request, snap snap.Snap) respon
 		cert.ExternallyManaged = true
 	}
 
-	return response.SyncResponse(true, apiv1.CertificatesStatusResponse{
+	return apiv1.CertificatesStatusResponse{
 		Certificates:           certificates,
 		CertificateAuthorities: []apiv1.CertificateAuthorityStatus{},
-	})
+	}, nil
 }
 
 // readKubeconfigCertificates reads the client certificates from kubeconfig
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"net/http"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	"github.com/canonical/k8s/pkg/k8sd/database"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/features/cilium"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
@@ -30,20 +32,67 @@ func (e *Endpoints) putClusterConfig(s state.State, r *http.Request) response.Re
 		return response.BadRequest(fmt.Errorf("failed to parse datastore config: %w", err))
 	}
 
+	provider, changeProvider := requestedConfig.Annotations.Get(types.AnnotationSecretsEncryptionProvider)
+	if changeProvider {
+		if !secretsEncryptionRotateMu.TryLock() {
+			return response.BadRequest(fmt.Errorf("a secrets encryption key rotation is already in progress"))
+		}
+	}
+
+	var newKey *types.SecretsEncryptionKey
 	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
-		if _, err := database.SetClusterConfig(ctx, tx, requestedConfig); err != nil {
+		newKey = nil
+
+		existing, err := database.GetClusterConfig(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get cluster configuration: %w", err)
+		}
+		merged, err := types.MergeClusterConfig(existing, requestedConfig)
+		if err != nil {
+			return fmt.Errorf("failed to merge cluster configuration: %w", err)
+		}
+
+		// generate a new key to encrypt Secrets at rest if the secrets encryption provider has changed.
+		// the new key is only used for decryption and the provider is not changed yet, see rolloutSecretsEncryptionKey.
+		config := requestedConfig
+		if keys, changed, err := merged.SecretsEncryptionKeysForProvider(); err != nil {
+			return fmt.Errorf("failed to generate secrets encryption key: %w", err)
+		} else if changed {
+			newKey = &keys[0]
+
+			config.Annotations = maps.Clone(requestedConfig.Annotations)
+			if v, ok := existing.Annotations.Get(types.AnnotationSecretsEncryptionProvider); ok {
+				config.Annotations[types.AnnotationSecretsEncryptionProvider] = v
+			} else {
+				delete(config.Annotations, types.AnnotationSecretsEncryptionProvider)
+			}
+			config.Certificates.SecretsEncryptionKeys = utils.Pointer(append(existing.Certificates.GetSecretsEncryptionKeys(), *newKey))
+		}
+
+		if _, err := database.SetClusterConfig(ctx, tx, config); err != nil {
 			return fmt.Errorf("failed to update cluster configuration: %w", err)
 		}
 		return nil
 	}); err != nil {
+		if changeProvider {
+			secretsEncryptionRotateMu.Unlock()
+		}
 		return response.InternalError(fmt.Errorf("database transaction to update cluster configuration failed: %w", err))
 	}
 
+	if newKey != nil {
+		// rolling out the key restarts the kube-apiserver on each control plane node, which takes longer than the request.
+		go rolloutSecretsEncryptionKey(context.WithoutCancel(r.Context()), s, e.provider.Snap(), provider, *newKey)
+	} else if changeProvider {
+		secretsEncryptionRotateMu.Unlock()
+	}
+
 	e.provider.NotifyUpdateNodeConfigController()
 	e.provider.NotifyFeatureController(
 		!requestedConfig.Network.Empty(),
-		!requestedConfig.Gateway.Empty(),
-		!requestedConfig.Ingress.Empty(),
+		!requestedConfig.Gateway.Empty() || requestedConfig.Annotations.HasPrefix(types.AnnotationGatewayPrefix),
+		// ingress annotations are only read when the ingress is reconciled
+		!requestedConfig.Ingress.Empty() || requestedConfig.Annotations.HasPrefix(cilium.AnnotationIngressPrefix),
 		!requestedConfig.LoadBalancer.Empty(),
 		!requestedConfig.LocalStorage.Empty(),
 		!requestedConfig.MetricsServer.Empty(),
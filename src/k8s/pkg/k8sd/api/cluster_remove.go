@@ -4,30 +4,56 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	apiv1_annotations "github.com/canonical/k8s-snap-api/api/v1/annotations"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/k8s/pkg/utils/control"
 	nodeutil "github.com/canonical/k8s/pkg/utils/node"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/cluster"
 	"github.com/canonical/microcluster/v2/state"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.Response {
-	snap := e.provider.Snap()
+	req := types.RemoveNodeRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	return e.removeNode(s, r, req)
+}
 
+// postClusterAPIRemove removes a node on behalf of Cluster API.
+// Cluster API drains the node of a machine before deleting it, so the node is not drained again.
+func (e *Endpoints) postClusterAPIRemove(s state.State, r *http.Request) response.Response {
 	req := apiv1.RemoveNodeRequest{}
 	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
 		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
 	}
 
+	return e.removeNode(s, r, types.RemoveNodeRequest{RemoveNodeRequest: req, SkipDrain: true})
+}
+
+func (e *Endpoints) removeNode(s state.State, r *http.Request, req types.RemoveNodeRequest) response.Response {
+	snap := e.provider.Snap()
+
+	// a forced removal is used when the node is unreachable, so its pods cannot be evicted gracefully
+	if !req.SkipDrain && !req.Force {
+		if err := drainNode(r.Context(), snap, req.Name, req.DrainTimeout); err != nil {
+			return response.InternalError(fmt.Errorf("failed to drain node %q, the node can be removed without draining with --skip-drain: %w", req.Name, err))
+		}
+	}
+
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 	if req.Timeout > 0 {
@@ -43,6 +69,7 @@ func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.R
 	}
 	if isControlPlane {
 		log.Info("Waiting for node to not be pending")
+		var memberAddress string
 		control.WaitUntilReady(ctx, func() (bool, error) {
 			var notPending bool
 			if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
@@ -53,6 +80,7 @@ func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.R
 				}
 				log.WithValues("role", member.Role).Info("Current node role")
 				notPending = member.Role != cluster.Pending
+				memberAddress = member.Address
 				return nil
 			}); err != nil {
 				log.Error(err, "Transaction to check cluster member role failed")
@@ -60,6 +88,10 @@ func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.R
 			return notPending, nil
 		})
 
+		if memberAddress != "" {
+			moveK8sDqliteVoterRole(ctx, s, snap, memberAddress)
+		}
+
 		log.Info("Starting node deletion")
 
 		// Remove control plane via microcluster API.
@@ -79,9 +111,12 @@ func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.R
 		if err := c.DeleteClusterMember(deleteCtx, req.Name, req.Force); err != nil {
 			return response.InternalError(fmt.Errorf("failed to delete cluster member %s: %w", req.Name, err))
 		}
+		removeNodePoolMember(ctx, s, req.Name)
 		return response.SyncResponse(true, &apiv1.RemoveNodeResponse{})
 	}
 
+	removeNodePoolMember(ctx, s, req.Name)
+
 	cfg, err := databaseutil.GetClusterConfig(ctx, s)
 	if err != nil {
 		return response.InternalError(fmt.Errorf("failed to get cluster config: %w", err))
@@ -109,3 +144,75 @@ func (e *Endpoints) postClusterRemove(s state.State, r *http.Request) response.R
 
 	return response.SyncResponse(true, &apiv1.RemoveNodeResponse{})
 }
+
+// removeNodePoolMember removes a node that is removed from the cluster from its node pool.
+// Failures are logged, as they do not affect the node removal.
+func removeNodePoolMember(ctx context.Context, s state.State, nodeName string) {
+	if err := databaseutil.RemoveNodePoolMember(ctx, s, nodeName); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to remove node from its node pool", "name", nodeName)
+	}
+}
+
+// drainNode cordons the node and evicts its pods before the node is removed.
+// drainNode does nothing if the node was never registered in Kubernetes.
+func drainNode(ctx context.Context, snap snap.Snap, nodeName string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = types.DefaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log := log.FromContext(ctx).WithValues("name", nodeName)
+
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create k8s client: %w", err)
+	}
+	if _, err := client.GetNode(ctx, nodeName); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Node is not registered in Kubernetes, skipping drain")
+			return nil
+		}
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	log.Info("Draining node")
+	if err := client.DrainNode(ctx, nodeName); err != nil {
+		return err
+	}
+	log.Info("Node drained")
+	return nil
+}
+
+// moveK8sDqliteVoterRole moves the k8s-dqlite voter role of a control plane node that is being removed to
+// another node, so that the quorum of the datastore is not affected while the node is torn down.
+// Failures are logged, as the node is removed from the k8s-dqlite cluster in the pre-remove hook regardless.
+func moveK8sDqliteVoterRole(ctx context.Context, s state.State, snap snap.Snap, memberAddress string) {
+	log := log.FromContext(ctx).WithValues("address", memberAddress)
+
+	cfg, err := databaseutil.GetClusterConfig(ctx, s)
+	if err != nil {
+		log.Error(err, "Failed to get cluster config")
+		return
+	}
+	if cfg.Datastore.GetType() != "k8s-dqlite" {
+		return
+	}
+
+	host, _, err := net.SplitHostPort(memberAddress)
+	if err != nil {
+		log.Error(err, "Failed to parse cluster member address")
+		return
+	}
+
+	client, err := snap.K8sDqliteClient(ctx)
+	if err != nil {
+		log.Error(err, "Failed to create k8s-dqlite client")
+		return
+	}
+
+	log.Info("Moving k8s-dqlite voter role away from node")
+	if err := client.DemoteNodeByAddress(ctx, net.JoinHostPort(host, fmt.Sprintf("%d", cfg.Datastore.GetK8sDqlitePort()))); err != nil {
+		log.Error(err, "Failed to move k8s-dqlite voter role away from node")
+	}
+}
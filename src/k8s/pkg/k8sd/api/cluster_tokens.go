@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
@@ -18,7 +20,7 @@ import (
 )
 
 func (e *Endpoints) postClusterJoinTokens(s state.State, r *http.Request) response.Response {
-	req := apiv1.GetJoinTokenRequest{}
+	req := types.GetJoinTokenRequest{}
 	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
 		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
 	}
@@ -28,6 +30,42 @@ func (e *Endpoints) postClusterJoinTokens(s state.State, r *http.Request) respon
 		return response.BadRequest(fmt.Errorf("invalid hostname %q: %w", req.Name, err))
 	}
 
+	if !req.Worker && (req.MultiUse || len(req.AllowedCIDRs) > 0) {
+		return response.BadRequest(fmt.Errorf("multi-use tokens and allowed CIDRs are only supported for worker node tokens"))
+	}
+
+	scope := types.JoinToken{Name: hostname, Worker: req.Worker, MultiUse: req.MultiUse, AllowedCIDRs: req.AllowedCIDRs, Pool: req.Pool}
+	if err := scope.ValidateAllowedCIDRs(); err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Pool != "" {
+		if hostname == "" && !req.Worker {
+			return response.BadRequest(fmt.Errorf("a node name is required to join a control plane node to node pool %q", req.Pool))
+		}
+
+		var exists bool
+		if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+			if exists, err = nodePoolExists(ctx, tx, req.Pool); err != nil || !exists {
+				return err
+			}
+			if req.Worker {
+				// worker node tokens record the node pool, the node becomes a member when the token is used
+				return nil
+			}
+			return database.SetNodePoolMember(ctx, tx, hostname, req.Pool)
+		}); err != nil {
+			return response.InternalError(fmt.Errorf("database transaction to set node pool of %q failed: %w", hostname, err))
+		}
+		if !exists {
+			return response.BadRequest(fmt.Errorf("node pool %q does not exist", req.Pool))
+		}
+		if !req.Worker {
+			// the node pool configuration is already distributed, so that it can be applied as soon as the node joins
+			e.provider.NotifyUpdateNodeConfigController()
+		}
+	}
+
 	var token string
 
 	ttl := req.TTL
@@ -37,7 +75,8 @@ func (e *Endpoints) postClusterJoinTokens(s state.State, r *http.Request) respon
 	}
 
 	if req.Worker {
-		token, err = getOrCreateWorkerToken(r.Context(), s, hostname, ttl)
+		scope.Expiry = time.Now().Add(ttl)
+		token, err = createWorkerToken(r.Context(), s, scope)
 	} else {
 		token, err = getOrCreateJoinToken(r.Context(), e.provider.MicroCluster(), hostname, ttl)
 	}
@@ -71,11 +110,11 @@ func getOrCreateJoinToken(ctx context.Context, m *microcluster.MicroCluster, tok
 	return token, nil
 }
 
-func getOrCreateWorkerToken(ctx context.Context, s state.State, nodeName string, ttl time.Duration) (string, error) {
+func createWorkerToken(ctx context.Context, s state.State, scope types.JoinToken) (string, error) {
 	var token string
 	if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		token, err = database.GetOrCreateWorkerNodeToken(ctx, tx, nodeName, time.Now().Add(ttl))
+		token, err = database.CreateWorkerNodeToken(ctx, tx, scope)
 		if err != nil {
 			return fmt.Errorf("failed to create worker node token: %w", err)
 		}
@@ -108,3 +147,76 @@ func getOrCreateWorkerToken(ctx context.Context, s state.State, nodeName string,
 
 	return token, nil
 }
+
+func (e *Endpoints) getClusterJoinTokens(s state.State, r *http.Request) response.Response {
+	var tokens []types.JoinToken
+
+	records, err := e.provider.MicroCluster().ListJoinTokens(r.Context())
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to list control plane join tokens: %w", err))
+	}
+
+	var poolMembers map[string]string
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		workerTokens, err := database.ListWorkerNodeTokens(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to list worker node tokens: %w", err)
+		}
+		tokens = append(tokens, workerTokens...)
+
+		if poolMembers, err = database.GetNodePoolMembers(ctx, tx); err != nil {
+			return fmt.Errorf("failed to get node pool members: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction to list join tokens failed: %w", err))
+	}
+
+	now := time.Now()
+	controlPlaneTokens := make([]types.JoinToken, 0, len(records))
+	for _, record := range records {
+		if !now.Before(record.ExpiresAt) {
+			continue
+		}
+		controlPlaneTokens = append(controlPlaneTokens, types.JoinToken{
+			Name:   record.Name,
+			Expiry: record.ExpiresAt,
+			Pool:   poolMembers[record.Name],
+		})
+	}
+	slices.SortFunc(controlPlaneTokens, func(a, b types.JoinToken) int { return strings.Compare(a.Name, b.Name) })
+
+	return response.SyncResponse(true, &types.ListJoinTokensResponse{Tokens: append(controlPlaneTokens, tokens...)})
+}
+
+func (e *Endpoints) deleteClusterJoinTokens(s state.State, r *http.Request) response.Response {
+	req := types.RevokeJoinTokenRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	switch {
+	case req.ID != 0 && req.Name != "":
+		return response.BadRequest(fmt.Errorf("only one of id or name can be specified"))
+	case req.ID != 0:
+		if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+			return database.DeleteWorkerNodeTokenByID(ctx, tx, req.ID)
+		}); err != nil {
+			return response.InternalError(fmt.Errorf("failed to revoke worker node token: %w", err))
+		}
+	case req.Name != "" && req.Worker:
+		if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+			return database.DeleteWorkerNodeTokensByName(ctx, tx, req.Name)
+		}); err != nil {
+			return response.InternalError(fmt.Errorf("failed to revoke worker node tokens: %w", err))
+		}
+	case req.Name != "":
+		if err := e.provider.MicroCluster().RevokeJoinToken(r.Context(), req.Name); err != nil {
+			return response.InternalError(fmt.Errorf("failed to revoke control plane join token: %w", err))
+		}
+	default:
+		return response.BadRequest(fmt.Errorf("either id or name must be specified"))
+	}
+
+	return response.SyncResponse(true, &types.RevokeJoinTokenResponse{})
+}
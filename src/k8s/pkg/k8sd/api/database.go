@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+// postQueryDatabase runs a read-only query against the k8sd tables. The query is validated by k8sd,
+// so that clients can not modify the database, and secrets are redacted from the result.
+func (e *Endpoints) postQueryDatabase(s state.State, r *http.Request) response.Response {
+	req := types.QueryDatabaseRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	schema, err := database.LoadSchema()
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to load database schema: %w", err))
+	}
+	if err := schema.ValidateReadOnlyQuery(req.Query); err != nil {
+		return response.BadRequest(fmt.Errorf("query is not allowed: %w", err))
+	}
+
+	var resp types.QueryDatabaseResponse
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		resp.Columns, resp.Rows, err = schema.ReadOnlyQuery(ctx, tx, req.Query)
+		return err
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction failed: %w", err))
+	}
+
+	return response.SyncResponse(true, &resp)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/microcluster/v2/rest"
 )
 
@@ -52,6 +53,18 @@ func (e *Endpoints) Endpoints() []rest.Endpoint {
 			Post:              rest.EndpointAction{Handler: e.postClusterBootstrap},
 			AllowedBeforeInit: true,
 		},
+		// Detailed status (conditions and health) of the built-in features
+		{
+			Name: "FeatureStatus",
+			Path: types.GetFeatureStatusRPC,
+			Get:  rest.EndpointAction{Handler: e.getFeatureStatus, AccessHandler: e.restrictWorkers},
+		},
+		// Roll back the Helm releases of a built-in feature to their previous revision
+		{
+			Name: "FeatureRollback",
+			Path: types.RollbackFeatureRPC,
+			Post: rest.EndpointAction{Handler: e.postRollbackFeature, AccessHandler: e.restrictWorkers},
+		},
 		// Node
 		// Returns the status (e.g. current role) of the local node (control-plane, worker or unknown).
 		{
@@ -59,12 +72,38 @@ func (e *Endpoints) Endpoints() []rest.Endpoint {
 			Path: apiv1.NodeStatusRPC,
 			Get:  rest.EndpointAction{Handler: e.getNodeStatus},
 		},
+		// Returns versions, service states and datastore health of the local node.
+		{
+			Name: "NodeDetails",
+			Path: types.GetNodeDetailsRPC,
+			Get:  rest.EndpointAction{Handler: e.getNodeDetails},
+		},
+		// Returns the details of all nodes in the cluster.
+		{
+			Name: "ClusterNodes",
+			Path: types.GetClusterNodesRPC,
+			Get:  rest.EndpointAction{Handler: e.getClusterNodes, AccessHandler: e.restrictWorkers},
+		},
+		// Collects inspection reports of the local node, the cluster and the other cluster members.
+		{
+			Name: "Inspect",
+			Path: types.InspectRPC,
+			Post: rest.EndpointAction{Handler: e.postInspect, AccessHandler: e.restrictWorkersToClusterMembers},
+		},
+		// Runs read-only queries against the k8sd database.
+		{
+			Name: "QueryDatabase",
+			Path: types.QueryDatabaseRPC,
+			Post: rest.EndpointAction{Handler: e.postQueryDatabase},
+		},
 		// Clustering
 		// Unified token endpoint for both, control-plane and worker-node.
 		{
-			Name: "GetJoinToken",
-			Path: apiv1.GetJoinTokenRPC,
-			Post: rest.EndpointAction{Handler: e.postClusterJoinTokens, AccessHandler: e.restrictWorkers},
+			Name:   "GetJoinToken",
+			Path:   apiv1.GetJoinTokenRPC, // == types.ListJoinTokensRPC == types.RevokeJoinTokenRPC
+			Get:    rest.EndpointAction{Handler: e.getClusterJoinTokens, AccessHandler: e.restrictWorkers},
+			Post:   rest.EndpointAction{Handler: e.postClusterJoinTokens, AccessHandler: e.restrictWorkers},
+			Delete: rest.EndpointAction{Handler: e.deleteClusterJoinTokens, AccessHandler: e.restrictWorkers},
 		},
 		{
 			Name: "JoinCluster",
@@ -127,10 +166,37 @@ func (e *Endpoints) Endpoints() []rest.Endpoint {
 		// Kubernetes auth tokens and token review webhook for kube-apiserver
 		{
 			Name:   "KubernetesAuthTokens",
-			Path:   apiv1.GenerateKubernetesAuthTokenRPC, // == apiv1.RevokeKubernetesAuthTokenRPC
+			Path:   apiv1.GenerateKubernetesAuthTokenRPC, // == apiv1.RevokeKubernetesAuthTokenRPC == types.ListKubernetesAuthTokensRPC
+			Get:    rest.EndpointAction{Handler: e.getKubernetesAuthTokens, AccessHandler: e.restrictWorkers},
 			Post:   rest.EndpointAction{Handler: e.postKubernetesAuthTokens},
 			Delete: rest.EndpointAction{Handler: e.deleteKubernetesAuthTokens},
 		},
+		// Node pools
+		{
+			Name:   "NodePools",
+			Path:   types.ListNodePoolsRPC, // == types.SetNodePoolRPC == types.DeleteNodePoolRPC
+			Get:    rest.EndpointAction{Handler: e.getNodePools, AccessHandler: e.restrictWorkers},
+			Put:    rest.EndpointAction{Handler: e.putNodePool, AccessHandler: e.restrictWorkers},
+			Delete: rest.EndpointAction{Handler: e.deleteNodePool, AccessHandler: e.restrictWorkers},
+		},
+		// Service arguments
+		{
+			Name: "ServiceArgs",
+			Path: types.GetServiceArgsRPC, // == types.SetServiceArgsRPC
+			Get:  rest.EndpointAction{Handler: e.getServiceArgs, AccessHandler: e.restrictWorkers},
+			Put:  rest.EndpointAction{Handler: e.putServiceArgs, AccessHandler: e.restrictWorkers},
+		},
+		// Secrets encryption
+		{
+			Name: "SecretsEncryption/Rotate",
+			Path: types.RotateSecretsEncryptionKeyRPC,
+			Post: rest.EndpointAction{Handler: e.postRotateSecretsEncryptionKey, AccessHandler: e.restrictWorkers},
+		},
+		{
+			Name: "SecretsEncryption/Apply",
+			Path: types.ApplySecretsEncryptionConfigRPC,
+			Post: rest.EndpointAction{Handler: e.postApplySecretsEncryptionConfig, AccessHandler: e.restrictWorkers},
+		},
 		{
 			Name: "KubernetesAuthWebhook",
 			Path: apiv1.ReviewKubernetesAuthTokenRPC,
@@ -150,7 +216,7 @@ func (e *Endpoints) Endpoints() []rest.Endpoint {
 		{
 			Name: "ClusterAPI/RemoveNode",
 			Path: apiv1.ClusterAPIRemoveNodeRPC,
-			Post: rest.EndpointAction{Handler: e.postClusterRemove, AccessHandler: ValidateCAPIAuthTokenAccessHandler("capi-auth-token"), AllowUntrusted: true},
+			Post: rest.EndpointAction{Handler: e.postClusterAPIRemove, AccessHandler: ValidateCAPIAuthTokenAccessHandler("capi-auth-token"), AllowUntrusted: true},
 		},
 		{
 			Name: "ClusterAPI/CertificatesExpiry",
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+func (e *Endpoints) postRollbackFeature(s state.State, r *http.Request) response.Response {
+	req := types.RollbackFeatureRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	charts, ok := features.Charts[req.Name]
+	if !ok {
+		return response.BadRequest(fmt.Errorf("unknown feature %q", req.Name))
+	}
+	if len(charts) == 0 {
+		return response.BadRequest(fmt.Errorf("feature %q does not deploy any charts of its own and can not be rolled back", req.Name))
+	}
+
+	helmClient := e.provider.Snap().HelmClient()
+	resp := &types.RollbackFeatureResponse{}
+	for _, chart := range charts {
+		history, err := helmClient.History(r.Context(), chart)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get history of %s: %w", chart.Name, err))
+		}
+		if len(history) == 0 {
+			// the chart is not installed, e.g. optional charts of a feature
+			continue
+		}
+
+		restored, err := helmClient.Rollback(r.Context(), chart)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to roll back %s: %w", chart.Name, err))
+		}
+		resp.Charts = append(resp.Charts, types.FeatureChartRollback{
+			Name:         chart.Name,
+			FromRevision: history[len(history)-1].Revision,
+			ToRevision:   restored.Revision,
+		})
+		log.FromContext(r.Context()).Info("Rolled back feature", "feature", req.Name, "chart", chart.Name, "revision", restored.Revision)
+	}
+
+	if len(resp.Charts) == 0 {
+		return response.BadRequest(fmt.Errorf("feature %q is not deployed", req.Name))
+	}
+
+	return response.SyncResponse(true, resp)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+func (e *Endpoints) getFeatureStatus(s state.State, r *http.Request) response.Response {
+	req := types.GetFeatureStatusRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	var statuses map[types.FeatureName]types.FeatureStatus
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		statuses, err = database.GetFeatureStatuses(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get feature statuses: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction failed: %w", err))
+	}
+
+	if req.Name != "" {
+		status, ok := statuses[req.Name]
+		if !ok {
+			return response.NotFound(fmt.Errorf("no status found for feature %q", req.Name))
+		}
+		statuses = map[types.FeatureName]types.FeatureStatus{req.Name: status}
+	}
+
+	return response.SyncResponse(true, &types.GetFeatureStatusResponse{
+		Features: statuses,
+	})
+}
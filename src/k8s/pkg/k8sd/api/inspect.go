@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/api/impl"
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/inspect"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+// inspectMemberTimeout is the maximum amount of time to wait for the inspection report of a single cluster member.
+var inspectMemberTimeout = 5 * time.Minute
+
+func (e *Endpoints) postInspect(s state.State, r *http.Request) response.Response {
+	req := types.InspectRequest{}
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	ctx := r.Context()
+	snap := e.provider.Snap()
+
+	var report types.InspectionReport
+	if req.Node {
+		report.Merge("", inspect.CollectNode(ctx, snap, req.Options))
+		if status, err := getCertsStatusControlPlane(ctx, s, snap); err != nil {
+			report.AddError("failed to get certificates status: %v", err)
+		} else {
+			report.AddYAML("certificates.yaml", status)
+		}
+	}
+
+	if req.Cluster {
+		report.Merge("cluster", e.inspectCluster(ctx, s, req.Options))
+	}
+
+	if req.Members {
+		members, err := impl.GetClusterMembers(ctx, s)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get cluster members: %w", err))
+		}
+		namesByAddress := make(map[string]string, len(members))
+		for _, member := range members {
+			namesByAddress[member.Address] = member.Name
+		}
+
+		cluster, err := s.Cluster(false)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get cluster clients: %w", err))
+		}
+
+		memberReq := types.InspectRequest{Node: true, Options: req.Options}
+
+		var mu sync.Mutex
+		_ = cluster.Query(ctx, true, func(ctx context.Context, c *client.Client) error {
+			ctx, cancel := context.WithTimeout(ctx, inspectMemberTimeout)
+			defer cancel()
+
+			address := c.URL().URL.Host
+			name, ok := namesByAddress[address]
+			if !ok {
+				name = address
+			}
+
+			var resp types.InspectResponse
+			err := c.Query(ctx, "POST", apiv1.K8sdAPIVersion, api.NewURL().Path(strings.Split(types.InspectRPC, "/")...), memberReq, &resp)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.AddError("failed to inspect cluster member %s: %v", name, err)
+				return nil
+			}
+			report.Merge(path.Join("nodes", name), resp.Report)
+			return nil
+		})
+	}
+
+	return response.SyncResponse(true, &types.InspectResponse{Report: report})
+}
+
+// inspectCluster collects the cluster-wide information of the inspection report.
+func (e *Endpoints) inspectCluster(ctx context.Context, s state.State, opts types.InspectOptions) types.InspectionReport {
+	var report types.InspectionReport
+
+	config, err := databaseutil.GetClusterConfig(ctx, s)
+	if err != nil {
+		report.AddError("failed to get cluster config: %v", err)
+	} else {
+		report.AddYAML("config.yaml", inspect.SanitizeClusterConfig(config))
+	}
+
+	if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		statuses, err := database.GetFeatureStatuses(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get feature statuses: %w", err)
+		}
+		report.AddYAML("feature-status.yaml", statuses)
+		return nil
+	}); err != nil {
+		report.AddError("failed to get feature statuses: %v", err)
+	}
+
+	if nodePools, err := databaseutil.GetNodePools(ctx, s); err != nil {
+		report.AddError("failed to get node pools: %v", err)
+	} else {
+		report.AddYAML("node-pools.yaml", nodePools)
+	}
+
+	if members, err := impl.GetClusterMembers(ctx, s); err != nil {
+		report.AddError("failed to get k8sd cluster members: %v", err)
+	} else {
+		report.AddYAML("k8sd-members.yaml", members)
+	}
+
+	if config.Datastore.GetType() == "k8s-dqlite" {
+		if client, err := e.provider.Snap().K8sDqliteClient(ctx); err != nil {
+			report.AddError("failed to create k8s-dqlite client: %v", err)
+		} else if members, err := client.ListMembers(ctx); err != nil {
+			report.AddError("failed to list k8s-dqlite members: %v", err)
+		} else {
+			report.AddYAML("k8s-dqlite-members.yaml", members)
+		}
+	}
+
+	if client, err := e.provider.Snap().KubernetesClient(""); err != nil {
+		report.AddError("failed to create Kubernetes client: %v", err)
+	} else {
+		report.Merge("", inspect.CollectKubernetes(ctx, client, opts))
+	}
+
+	return report
+}
@@ -1,19 +1,23 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
 	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/state"
 )
 
 func (e *Endpoints) getKubeconfig(s state.State, r *http.Request) response.Response {
-	req := apiv1.KubeConfigRequest{}
+	req := types.KubeConfigRequest{}
 	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
 		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
 	}
@@ -28,12 +32,115 @@ func (e *Endpoints) getKubeconfig(s state.State, r *http.Request) response.Respo
 		server = fmt.Sprintf("%s:%d", s.Address().Hostname(), config.APIServer.GetSecurePort())
 	}
 
-	kubeconfig, err := setup.KubeconfigString(server, config.Certificates.GetCACert(), config.Certificates.GetAdminClientCert(), config.Certificates.GetAdminClientKey())
-	if err != nil {
-		return response.InternalError(fmt.Errorf("failed to get kubeconfig: %w", err))
+	var kubeconfig string
+	switch {
+	case req.OIDC && req.Username != "":
+		return response.BadRequest(fmt.Errorf("an OIDC kubeconfig cannot be generated for a specific user"))
+	case req.Username == "" && (len(req.Groups) > 0 || req.Role != "" || req.Namespace != "" || req.TTL != 0):
+		return response.BadRequest(fmt.Errorf("groups, role, namespace and ttl can only be set together with a username"))
+	case req.TTL < 0:
+		return response.BadRequest(fmt.Errorf("ttl cannot be negative"))
+	case req.Role != "" && req.TTL == 0:
+		return response.BadRequest(fmt.Errorf("a ttl is required when a role is granted"))
+	case req.Username != "":
+		token, err := e.createUserCredentials(r.Context(), s, req)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to create credentials for user %q: %w", req.Username, err))
+		}
+		kubeconfig, err = setup.TokenKubeconfigString(server, config.Certificates.GetCACert(), token, req.Namespace)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get kubeconfig: %w", err))
+		}
+	case req.OIDC:
+		if !config.APIServer.OIDCEnabled() {
+			return response.BadRequest(fmt.Errorf("OIDC authentication is not configured on the cluster"))
+		}
+		kubeconfig, err = setup.OIDCKubeconfigString(server, config.Certificates.GetCACert(), config.APIServer.GetOIDCIssuerURL(), config.APIServer.GetOIDCClientID())
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get kubeconfig: %w", err))
+		}
+	default:
+		kubeconfig, err = setup.KubeconfigString(server, config.Certificates.GetCACert(), config.Certificates.GetAdminClientCert(), config.Certificates.GetAdminClientKey())
+		if err != nil {
+			return response.InternalError(fmt.Errorf("failed to get kubeconfig: %w", err))
+		}
 	}
 
 	return response.SyncResponse(true, &apiv1.KubeConfigResponse{
 		KubeConfig: kubeconfig,
 	})
 }
+
+// kubernetesAuthTokenIDLabel is the label set on RBAC bindings that are created for a k8sd auth token.
+// The bindings are deleted when the token is revoked.
+const kubernetesAuthTokenIDLabel = "k8sd.io/kubernetes-auth-token-id"
+
+// createUserCredentials creates a k8sd auth token for the user of the request and binds the requested role to the user.
+// createUserCredentials returns the token.
+// Tokens without a role may be shared between requests, whereas a new token is always created for a role,
+// so that its binding is owned by this request only.
+func (e *Endpoints) createUserCredentials(ctx context.Context, s state.State, req types.KubeConfigRequest) (string, error) {
+	e.deleteExpiredAuthTokens(ctx, s)
+
+	if req.Role == "" {
+		_, token, err := databaseutil.GetOrCreateAuthToken(ctx, s, req.Username, req.Groups, "kubeconfig", req.TTL)
+		if err != nil {
+			return "", fmt.Errorf("failed to create auth token: %w", err)
+		}
+		return token, nil
+	}
+
+	description := fmt.Sprintf("kubeconfig with role %s", req.Role)
+	if req.Namespace != "" {
+		description = fmt.Sprintf("%s in namespace %s", description, req.Namespace)
+	}
+	id, token, err := databaseutil.CreateAuthToken(ctx, s, req.Username, req.Groups, description, req.TTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	client, err := e.provider.Snap().KubernetesClient("")
+	if err != nil {
+		err = fmt.Errorf("failed to create kubernetes client: %w", err)
+	} else {
+		name := fmt.Sprintf("k8sd:kubeconfig:%d", id)
+		labels := map[string]string{kubernetesAuthTokenIDLabel: strconv.FormatInt(id, 10)}
+		err = client.ApplyUserRoleBinding(ctx, name, req.Namespace, req.Role, req.Username, labels)
+	}
+	if err != nil {
+		// do not leave behind a token without the requested permissions, the token was created above and is not shared
+		if revokeErr := databaseutil.RevokeAuthTokenByID(ctx, s, id); revokeErr != nil {
+			log.FromContext(ctx).Error(revokeErr, "Failed to revoke auth token", "id", id)
+		}
+		return "", fmt.Errorf("failed to bind role %q: %w", req.Role, err)
+	}
+
+	return token, nil
+}
+
+// deleteUserRoleBindings deletes the RBAC bindings that were created for the k8sd auth token with the specified ID.
+func (e *Endpoints) deleteUserRoleBindings(ctx context.Context, id int64) error {
+	client, err := e.provider.Snap().KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	if err := client.DeleteRoleBindings(ctx, fmt.Sprintf("%s=%d", kubernetesAuthTokenIDLabel, id)); err != nil {
+		return fmt.Errorf("failed to delete role bindings: %w", err)
+	}
+	return nil
+}
+
+// deleteExpiredAuthTokens removes the expired k8sd auth tokens along with their RBAC bindings.
+// Failures are logged, as the cleanup is retried the next time a token is created.
+func (e *Endpoints) deleteExpiredAuthTokens(ctx context.Context, s state.State) {
+	ids, err := databaseutil.DeleteExpiredAuthTokens(ctx, s)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to delete expired auth tokens")
+		return
+	}
+	for _, id := range ids {
+		if err := e.deleteUserRoleBindings(ctx, id); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to delete role bindings of expired auth token", "id", id)
+		}
+	}
+}
@@ -11,36 +11,73 @@ import (
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	"github.com/canonical/k8s/pkg/k8sd/database"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/state"
 )
 
+func (e *Endpoints) getKubernetesAuthTokens(s state.State, r *http.Request) response.Response {
+	tokens, err := databaseutil.ListAuthTokens(r.Context(), s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to list auth tokens: %w", err))
+	}
+
+	return response.SyncResponse(true, types.ListKubernetesAuthTokensResponse{Tokens: tokens})
+}
+
 func (e *Endpoints) postKubernetesAuthTokens(s state.State, r *http.Request) response.Response {
-	request := apiv1.GenerateKubernetesAuthTokenRequest{}
+	request := types.GenerateKubernetesAuthTokenRequest{}
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
 	}
+	if request.TTL < 0 {
+		return response.BadRequest(fmt.Errorf("ttl cannot be negative"))
+	}
 
-	token, err := databaseutil.GetOrCreateAuthToken(r.Context(), s, request.Username, request.Groups)
+	e.deleteExpiredAuthTokens(r.Context(), s)
+
+	id, token, err := databaseutil.GetOrCreateAuthToken(r.Context(), s, request.Username, request.Groups, request.Description, request.TTL)
 	if err != nil {
 		return response.InternalError(err)
 	}
 
-	return response.SyncResponse(true, apiv1.GenerateKubernetesAuthTokenResponse{Token: token})
+	return response.SyncResponse(true, types.GenerateKubernetesAuthTokenResponse{ID: id, Token: token})
 }
 
 func (e *Endpoints) deleteKubernetesAuthTokens(s state.State, r *http.Request) response.Response {
-	request := apiv1.RevokeKubernetesAuthTokenRequest{}
+	request := types.RevokeKubernetesAuthTokenRequest{}
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
 	}
 
-	err := databaseutil.RevokeAuthToken(r.Context(), s, request.Token)
-	if err != nil {
+	id := request.ID
+	var err error
+	switch {
+	case request.Token != "" && request.ID != 0:
+		return response.BadRequest(fmt.Errorf("only one of token or id can be specified"))
+	case request.Token != "":
+		id, err = databaseutil.RevokeAuthToken(r.Context(), s, request.Token)
+	case request.ID != 0:
+		err = databaseutil.RevokeAuthTokenByID(r.Context(), s, request.ID)
+	default:
+		return response.BadRequest(fmt.Errorf("either token or id must be specified"))
+	}
+	switch {
+	case errors.Is(err, database.ErrTokenNotFound) && id == 0:
+		// revoking an unknown token is a no-op, so that revocation can be retried safely
+		return response.SyncResponse(true, nil)
+	case errors.Is(err, database.ErrTokenNotFound):
+		// the token is already gone, but a previous attempt may have failed to remove its role bindings
+	case err != nil:
 		return response.InternalError(fmt.Errorf("failed to revoke auth token: %w", err))
 	}
 
+	// remove any permissions that were granted when the token was created, e.g. with "k8s config --user"
+	if err := e.deleteUserRoleBindings(r.Context(), id); err != nil {
+		return response.InternalError(fmt.Errorf("auth token was revoked, but its role bindings could not be removed: %w", err))
+	}
+
 	return response.SyncResponse(true, nil)
 }
 
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/client/dqlite"
+	"github.com/canonical/k8s/pkg/k8sd/api/impl"
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/state"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// controlPlaneServices are the services reported for control plane nodes.
+	controlPlaneServices = []string{"containerd", "kubelet", "kube-proxy", "kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+	// nodeDetailsTimeout is the maximum amount of time to wait for the details of a single cluster member.
+	nodeDetailsTimeout = 10 * time.Second
+)
+
+func (e *Endpoints) getNodeDetails(s state.State, r *http.Request) response.Response {
+	details, err := getLocalNodeDetails(r.Context(), s, e.provider.Snap())
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, &types.GetNodeDetailsResponse{Node: details})
+}
+
+func (e *Endpoints) getClusterNodes(s state.State, r *http.Request) response.Response {
+	ctx := r.Context()
+
+	local, err := getLocalNodeDetails(ctx, s, e.provider.Snap())
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to get details of local node: %w", err))
+	}
+	nodes := []types.NodeDetails{local}
+
+	members, err := impl.GetClusterMembers(ctx, s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to get cluster members: %w", err))
+	}
+	membersByAddress := make(map[string]apiv1.NodeStatus, len(members))
+	for _, member := range members {
+		membersByAddress[member.Address] = member
+	}
+
+	cluster, err := s.Cluster(false)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to get cluster clients: %w", err))
+	}
+
+	var mu sync.Mutex
+	_ = cluster.Query(ctx, true, func(ctx context.Context, c *client.Client) error {
+		ctx, cancel := context.WithTimeout(ctx, nodeDetailsTimeout)
+		defer cancel()
+
+		address := c.URL().URL.Host
+		var details types.NodeDetails
+		var resp types.GetNodeDetailsResponse
+		if err := c.Query(ctx, "GET", apiv1.K8sdAPIVersion, api.NewURL().Path(strings.Split(types.GetNodeDetailsRPC, "/")...), nil, &resp); err != nil {
+			member := membersByAddress[address]
+			details = types.NodeDetails{
+				Name:        member.Name,
+				Address:     member.Address,
+				ClusterRole: apiv1.ClusterRoleControlPlane,
+				Error:       fmt.Sprintf("failed to get node details: %v", err),
+			}
+		} else {
+			details = resp.Node
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		nodes = append(nodes, details)
+		return nil
+	})
+
+	// Workers are not cluster members, report what is known about them from Kubernetes.
+	if client, err := e.provider.Snap().KubernetesClient(""); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to create Kubernetes client")
+	} else if k8sNodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Kubernetes nodes")
+	} else {
+		known := make(map[string]int, len(nodes))
+		for idx, node := range nodes {
+			known[node.Name] = idx
+		}
+		for _, node := range k8sNodes.Items {
+			if idx, ok := known[node.Name]; ok {
+				nodes[idx].Ready = nodeReady(node)
+				continue
+			}
+			nodes = append(nodes, workerNodeDetails(node))
+		}
+	}
+
+	return response.SyncResponse(true, &types.GetClusterNodesResponse{Nodes: nodes})
+}
+
+// getLocalNodeDetails collects the details of the local node.
+// Failures to retrieve individual details are not fatal and are reported as unknown values.
+func getLocalNodeDetails(ctx context.Context, s state.State, snap snap.Snap) (types.NodeDetails, error) {
+	log := log.FromContext(ctx)
+
+	status, err := impl.GetLocalNodeStatus(ctx, s, snap)
+	if err != nil {
+		return types.NodeDetails{}, fmt.Errorf("failed to get local node status: %w", err)
+	}
+
+	details := types.NodeDetails{
+		Name:        status.Name,
+		Address:     status.Address,
+		ClusterRole: status.ClusterRole,
+		Services:    make(map[string]types.ServiceState),
+	}
+
+	if details.SnapRevision, err = snap.Revision(ctx); err != nil {
+		log.Error(err, "Failed to get snap revision")
+	}
+	if details.KubernetesVersion, err = snap.NodeKubernetesVersion(ctx); err != nil {
+		log.Error(err, "Failed to get Kubernetes version")
+	}
+
+	services := []string{"containerd", "kubelet", "kube-proxy", "k8s-apiserver-proxy"}
+	certificateNames := workerCertificateNames
+	if status.ClusterRole == apiv1.ClusterRoleControlPlane {
+		services = controlPlaneServices
+		certificateNames = controlPlaneCertificateNames
+	}
+
+	if status.ClusterRole == apiv1.ClusterRoleControlPlane {
+		config, err := databaseutil.GetClusterConfig(ctx, s)
+		if err != nil {
+			return types.NodeDetails{}, fmt.Errorf("failed to get cluster config: %w", err)
+		}
+		if config.Datastore.GetType() == "k8s-dqlite" {
+			services = append(services, "k8s-dqlite")
+			nodeAddress := net.JoinHostPort(s.Address().Hostname(), fmt.Sprintf("%d", config.Datastore.GetK8sDqlitePort()))
+			details.Datastore = getK8sDqliteStatus(ctx, snap, nodeAddress)
+		}
+	}
+
+	for _, service := range services {
+		switch active, err := snap.ServiceActive(ctx, service); {
+		case err != nil:
+			log.V(1).Info("Failed to get service state", "service", service, "error", err)
+			details.Services[service] = types.ServiceStateUnknown
+		case active:
+			details.Services[service] = types.ServiceStateActive
+		default:
+			details.Services[service] = types.ServiceStateInactive
+		}
+	}
+
+	if certificates, err := loadCertificateStatusesFromDir(snap.KubernetesPKIDir(), certificateNames); err != nil {
+		log.V(1).Info("Failed to read node certificates", "error", err)
+	} else {
+		for _, certificate := range certificates {
+			expires, err := time.Parse(time.RFC3339, certificate.Expires)
+			if err != nil {
+				continue
+			}
+			if details.CertificatesExpiry.IsZero() || expires.Before(details.CertificatesExpiry) {
+				details.CertificatesExpiry = expires
+			}
+		}
+	}
+
+	return details, nil
+}
+
+// getK8sDqliteStatus reports the role of the local k8s-dqlite member, the current leader and the
+// latency of reaching the leader.
+func getK8sDqliteStatus(ctx context.Context, snap snap.Snap, nodeAddress string) *types.NodeDatastoreStatus {
+	status := &types.NodeDatastoreStatus{}
+
+	client, err := snap.K8sDqliteClient(ctx)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to create k8s-dqlite client: %v", err)
+		return status
+	}
+
+	start := time.Now()
+	leader, err := client.Leader(ctx)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to get k8s-dqlite leader: %v", err)
+		return status
+	}
+	status.Latency = time.Since(start)
+	if leader != nil {
+		status.Leader = leader.Address
+		status.IsLeader = leader.Address == nodeAddress
+	}
+
+	members, err := client.ListMembers(ctx)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to list k8s-dqlite members: %v", err)
+		return status
+	}
+	for _, member := range members {
+		if member.Address != nodeAddress {
+			continue
+		}
+		switch member.Role {
+		case dqlite.Voter:
+			status.Role = apiv1.DatastoreRoleVoter
+		case dqlite.StandBy:
+			status.Role = apiv1.DatastoreRoleStandBy
+		case dqlite.Spare:
+			status.Role = apiv1.DatastoreRoleSpare
+		}
+	}
+
+	return status
+}
+
+// workerNodeDetails reports the details of a worker node as known by Kubernetes.
+func workerNodeDetails(node corev1.Node) types.NodeDetails {
+	details := types.NodeDetails{
+		Name:              node.Name,
+		ClusterRole:       apiv1.ClusterRoleWorker,
+		Ready:             nodeReady(node),
+		KubernetesVersion: node.Status.NodeInfo.KubeletVersion,
+	}
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			details.Address = address.Address
+			break
+		}
+	}
+	return details
+}
+
+// nodeReady returns true if the Kubernetes node has the Ready condition.
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
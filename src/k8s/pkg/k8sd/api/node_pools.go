@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+func (e *Endpoints) getNodePools(s state.State, r *http.Request) response.Response {
+	nodePools, err := databaseutil.GetNodePools(r.Context(), s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to retrieve node pools: %w", err))
+	}
+
+	return response.SyncResponse(true, &types.ListNodePoolsResponse{Pools: nodePools.Pools, Members: nodePools.Members})
+}
+
+func (e *Endpoints) putNodePool(s state.State, r *http.Request) response.Response {
+	var req types.SetNodePoolRequest
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+	if err := req.Pool.Validate(); err != nil {
+		return response.BadRequest(fmt.Errorf("invalid node pool: %w", err))
+	}
+
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		return database.SetNodePool(ctx, tx, req.Pool)
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction to set node pool failed: %w", err))
+	}
+
+	// roll out the node pool configuration to the member nodes
+	e.provider.NotifyUpdateNodeConfigController()
+
+	return response.SyncResponse(true, &types.SetNodePoolResponse{})
+}
+
+func (e *Endpoints) deleteNodePool(s state.State, r *http.Request) response.Response {
+	var req types.DeleteNodePoolRequest
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+
+	var members []string
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		if exists, err := nodePoolExists(ctx, tx, req.Name); err != nil {
+			return err
+		} else if !exists {
+			return nil
+		}
+
+		allMembers, err := database.GetNodePoolMembers(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get node pool members: %w", err)
+		}
+		for nodeName, pool := range allMembers {
+			if pool == req.Name {
+				members = append(members, nodeName)
+			}
+		}
+		if len(members) > 0 {
+			return nil
+		}
+
+		return database.DeleteNodePool(ctx, tx, req.Name)
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction to delete node pool failed: %w", err))
+	}
+
+	if len(members) > 0 {
+		sort.Strings(members)
+		return response.BadRequest(fmt.Errorf("node pool %q still has members, remove the nodes from the cluster first: %s", req.Name, strings.Join(members, ", ")))
+	}
+
+	return response.SyncResponse(true, &types.DeleteNodePoolResponse{})
+}
+
+// nodePoolExists checks whether a node pool with the specified name exists.
+func nodePoolExists(ctx context.Context, tx *sql.Tx, name string) (bool, error) {
+	pools, err := database.GetNodePools(ctx, tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get node pools: %w", err)
+	}
+	return slices.ContainsFunc(pools, func(pool types.NodePool) bool { return pool.Name == name }), nil
+}
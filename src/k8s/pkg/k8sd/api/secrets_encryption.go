@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+var (
+	// secretsEncryptionApplyTimeout is the maximum amount of time to wait for a control plane node
+	// to apply the secrets encryption configuration.
+	secretsEncryptionApplyTimeout = 3 * time.Minute
+
+	// secretsEncryptionRolloutTimeout is the maximum amount of time to roll out a new secrets encryption key
+	// after the secrets encryption provider was changed.
+	secretsEncryptionRolloutTimeout = 30 * time.Minute
+
+	// secretsEncryptionRotateMu prevents concurrent key rotations and provider changes through the same node.
+	secretsEncryptionRotateMu sync.Mutex
+)
+
+func (e *Endpoints) postApplySecretsEncryptionConfig(s state.State, r *http.Request) response.Response {
+	config, err := databaseutil.GetClusterConfig(r.Context(), s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to retrieve cluster configuration: %w", err))
+	}
+
+	if err := applySecretsEncryptionConfig(r.Context(), e.provider.Snap(), config); err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, &types.ApplySecretsEncryptionConfigResponse{})
+}
+
+func (e *Endpoints) postRotateSecretsEncryptionKey(s state.State, r *http.Request) response.Response {
+	ctx := r.Context()
+	snap := e.provider.Snap()
+
+	if !secretsEncryptionRotateMu.TryLock() {
+		return response.BadRequest(fmt.Errorf("a secrets encryption key rotation is already in progress"))
+	}
+	defer secretsEncryptionRotateMu.Unlock()
+
+	config, err := databaseutil.GetClusterConfig(ctx, s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to retrieve cluster configuration: %w", err))
+	}
+
+	provider := config.APIServer.GetSecretsEncryptionProvider()
+	switch {
+	case !config.SecretsEncryptionEnabled():
+		return response.BadRequest(fmt.Errorf("secrets encryption is not enabled, set %s to enable it", types.AnnotationSecretsEncryptionProvider))
+	case provider == "kms":
+		return response.BadRequest(fmt.Errorf("keys of the kms provider are managed by the KMS plugin"))
+	}
+
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to create kubernetes client: %w", err))
+	}
+
+	// updateKeys stores the secrets encryption keys and applies them on all control plane nodes.
+	updateKeys := func(keys []types.SecretsEncryptionKey) error {
+		if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := database.SetClusterConfig(ctx, tx, types.ClusterConfig{Certificates: types.Certificates{SecretsEncryptionKeys: &keys}}); err != nil {
+				return fmt.Errorf("failed to update cluster configuration: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("database transaction to update secrets encryption keys failed: %w", err)
+		}
+		return applySecretsEncryptionConfigOnControlPlanes(ctx, s, snap)
+	}
+
+	oldKeys := config.Certificates.GetSecretsEncryptionKeys()
+	newKeys := []types.SecretsEncryptionKey{}
+	if provider != "" {
+		key, err := types.NewSecretsEncryptionKey(provider)
+		if err != nil {
+			return response.InternalError(err)
+		}
+		newKeys = append(newKeys, key)
+
+		// All control plane nodes must be able to decrypt with the new key before any of them uses it for encryption.
+		if err := updateKeys(append(slices.Clone(oldKeys), key)); err != nil {
+			return response.InternalError(fmt.Errorf("failed to add new secrets encryption key: %w", err))
+		}
+		if err := updateKeys(append([]types.SecretsEncryptionKey{key}, oldKeys...)); err != nil {
+			return response.InternalError(fmt.Errorf("failed to use new secrets encryption key: %w", err))
+		}
+	}
+
+	count, err := client.RewriteSecrets(ctx)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to rewrite secrets after %d secrets, the old keys have not been retired: %w", count, err))
+	}
+
+	if err := updateKeys(newKeys); err != nil {
+		return response.InternalError(fmt.Errorf("failed to retire old secrets encryption keys: %w", err))
+	}
+
+	resp := &types.RotateSecretsEncryptionKeyResponse{RewrittenSecrets: count}
+	if len(newKeys) > 0 {
+		resp.KeyName = newKeys[0].Name
+	}
+	return response.SyncResponse(true, resp)
+}
+
+// rolloutSecretsEncryptionKey changes the secrets encryption provider and starts using the new key of the provider
+// for encryption. The new key must already be stored as the last key, so that it is only used for decryption, and the
+// provider must not be changed yet. All control plane nodes must be able to decrypt with the new key before any of them
+// uses it for encryption, the same as when rotating keys.
+// rolloutSecretsEncryptionKey releases secretsEncryptionRotateMu when done.
+func rolloutSecretsEncryptionKey(ctx context.Context, s state.State, snap snap.Snap, provider string, key types.SecretsEncryptionKey) {
+	defer secretsEncryptionRotateMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, secretsEncryptionRolloutTimeout)
+	defer cancel()
+	log := log.FromContext(ctx).WithValues("provider", provider, "key", key.Name)
+
+	if err := applySecretsEncryptionConfigOnControlPlanes(ctx, s, snap); err != nil {
+		log.Error(err, "Failed to add new secrets encryption key, the secrets encryption provider was not changed")
+		return
+	}
+
+	if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		config, err := database.GetClusterConfig(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get cluster configuration: %w", err)
+		}
+		oldKeys := config.Certificates.GetSecretsEncryptionKeys()
+		if !slices.Contains(oldKeys, key) {
+			return fmt.Errorf("secrets encryption key %s was removed", key.Name)
+		}
+
+		keys := []types.SecretsEncryptionKey{key}
+		for _, oldKey := range oldKeys {
+			if oldKey != key {
+				keys = append(keys, oldKey)
+			}
+		}
+		if _, err := database.SetClusterConfig(ctx, tx, types.ClusterConfig{
+			Annotations:  types.Annotations{types.AnnotationSecretsEncryptionProvider: provider},
+			Certificates: types.Certificates{SecretsEncryptionKeys: &keys},
+		}); err != nil {
+			return fmt.Errorf("failed to update cluster configuration: %w", err)
+		}
+		return nil
+	}); err != nil {
+		log.Error(err, "Failed to change the secrets encryption provider")
+		return
+	}
+
+	if err := applySecretsEncryptionConfigOnControlPlanes(ctx, s, snap); err != nil {
+		log.Error(err, "Failed to use new secrets encryption key")
+		return
+	}
+	log.Info("Changed secrets encryption provider")
+}
+
+// applySecretsEncryptionConfig applies the secrets encryption configuration on the local node.
+// If the configuration changed, kube-apiserver is restarted and applySecretsEncryptionConfig waits until it is available again.
+func applySecretsEncryptionConfig(ctx context.Context, snap snap.Snap, config types.ClusterConfig) error {
+	changed, err := setup.SecretsEncryption(snap, config)
+	if err != nil {
+		return fmt.Errorf("failed to configure secrets encryption: %w", err)
+	} else if !changed {
+		return nil
+	}
+
+	if err := snap.RestartServices(ctx, []string{"kube-apiserver"}); err != nil {
+		return fmt.Errorf("failed to restart kube-apiserver: %w", err)
+	}
+
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	if err := client.WaitKubernetesEndpointAvailable(ctx); err != nil {
+		return fmt.Errorf("kube-apiserver did not become available: %w", err)
+	}
+	return nil
+}
+
+// applySecretsEncryptionConfigOnControlPlanes applies the secrets encryption configuration on all control plane nodes.
+// The nodes apply the configuration one after the other, and restart kube-apiserver through the restart coordinator,
+// so that the Kubernetes API remains available during the rollout.
+func applySecretsEncryptionConfigOnControlPlanes(ctx context.Context, s state.State, snap snap.Snap) error {
+	config, err := databaseutil.GetClusterConfig(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve cluster configuration: %w", err)
+	}
+
+	localCtx, cancel := context.WithTimeout(ctx, secretsEncryptionApplyTimeout)
+	defer cancel()
+	if err := applySecretsEncryptionConfig(localCtx, snap, config); err != nil {
+		return fmt.Errorf("failed to apply on %s: %w", s.Name(), err)
+	}
+
+	cluster, err := s.Cluster(false)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster clients: %w", err)
+	}
+
+	var errs []string
+	_ = cluster.Query(ctx, false, func(ctx context.Context, c *client.Client) error {
+		ctx, cancel := context.WithTimeout(ctx, secretsEncryptionApplyTimeout)
+		defer cancel()
+
+		var resp types.ApplySecretsEncryptionConfigResponse
+		if err := c.Query(ctx, "POST", apiv1.K8sdAPIVersion, api.NewURL().Path(strings.Split(types.ApplySecretsEncryptionConfigRPC, "/")...), nil, &resp); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to apply secrets encryption configuration", "address", c.URL().URL.Host)
+			errs = append(errs, fmt.Sprintf("failed to apply on %s: %v", c.URL().URL.Host, err))
+		}
+		return nil
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+func (e *Endpoints) getServiceArgs(s state.State, r *http.Request) response.Response {
+	config, err := databaseutil.GetClusterConfig(r.Context(), s)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("failed to retrieve cluster configuration: %w", err))
+	}
+
+	var serviceArgs types.ServiceArgs
+	if config.ServiceArgs != nil {
+		serviceArgs = *config.ServiceArgs
+	}
+	return response.SyncResponse(true, &types.GetServiceArgsResponse{ServiceArgs: serviceArgs})
+}
+
+func (e *Endpoints) putServiceArgs(s state.State, r *http.Request) response.Response {
+	var req types.SetServiceArgsRequest
+	if err := utils.NewStrictJSONDecoder(r.Body).Decode(&req); err != nil {
+		return response.BadRequest(fmt.Errorf("failed to parse request: %w", err))
+	}
+	if err := req.ServiceArgs.Validate(); err != nil {
+		return response.BadRequest(fmt.Errorf("invalid service arguments: %w", err))
+	}
+
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := database.SetClusterConfig(ctx, tx, types.ClusterConfig{ServiceArgs: &req.ServiceArgs}); err != nil {
+			return fmt.Errorf("failed to update cluster configuration: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("database transaction to set service arguments failed: %w", err))
+	}
+
+	// roll out the service arguments to the worker nodes. control plane nodes pick up the changes from the cluster configuration.
+	e.provider.NotifyUpdateNodeConfigController()
+
+	return response.SyncResponse(true, &types.SetServiceArgsResponse{})
+}
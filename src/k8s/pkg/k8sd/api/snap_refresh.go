@@ -1,11 +1,13 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/state"
@@ -24,6 +26,9 @@ func (e *Endpoints) postSnapRefresh(s state.State, r *http.Request) response.Res
 
 	id, err := e.provider.Snap().Refresh(e.Context(), refreshOpts)
 	if err != nil {
+		if errors.Is(err, snap.ErrNotSupported) {
+			return response.NotImplemented(fmt.Errorf("failed to refresh snap: %w", err))
+		}
 		return response.InternalError(fmt.Errorf("failed to refresh snap: %w", err))
 	}
 
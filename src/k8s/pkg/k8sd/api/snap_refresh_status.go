@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/state"
@@ -18,6 +20,9 @@ func (e *Endpoints) postSnapRefreshStatus(s state.State, r *http.Request) respon
 
 	status, err := e.provider.Snap().RefreshStatus(e.Context(), req.ChangeID)
 	if err != nil {
+		if errors.Is(err, snap.ErrNotSupported) {
+			return response.NotImplemented(fmt.Errorf("failed to get snap refresh status: %w", err))
+		}
 		return response.InternalError(fmt.Errorf("failed to get snap refresh status: %w", err))
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/canonical/k8s/pkg/k8sd/database"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
 	"github.com/canonical/k8s/pkg/k8sd/pki"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/utils"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/microcluster/v2/state"
@@ -33,6 +34,27 @@ func (e *Endpoints) postWorkerInfo(s state.State, r *http.Request) response.Resp
 		return response.BadRequest(fmt.Errorf("failed to parse node IP address %s", req.Address))
 	}
 
+	// The token itself is already validated in the access handler.
+	workerToken := r.Header.Get("Worker-Token")
+	var scope types.JoinToken
+	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		scope, _, err = database.GetWorkerNodeToken(ctx, tx, workerToken)
+		return err
+	}); err != nil {
+		return response.InternalError(fmt.Errorf("get worker node token transaction failed: %w", err))
+	}
+	remoteAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddress = r.RemoteAddr
+	}
+	// both the address of the node and the address the request comes from must be allowed by the token
+	for _, address := range []string{req.Address, remoteAddress} {
+		if !scope.AllowsAddress(address) {
+			return response.Forbidden(fmt.Errorf("the join token does not allow nodes with address %s to join", address))
+		}
+	}
+
 	cfg, err := databaseutil.GetClusterConfig(r.Context(), s)
 	if err != nil {
 		return response.InternalError(fmt.Errorf("failed to get cluster config: %w", err))
@@ -74,11 +96,22 @@ func (e *Endpoints) postWorkerInfo(s state.State, r *http.Request) response.Resp
 		return response.InternalError(fmt.Errorf("failed to retrieve list of known kube-apiserver endpoints: %w", err))
 	}
 
-	workerToken := r.Header.Get("Worker-Token")
 	if err := s.Database().Transaction(r.Context(), func(ctx context.Context, tx *sql.Tx) error {
+		if scope.Pool != "" {
+			if err := database.SetNodePoolMember(ctx, tx, workerName, scope.Pool); err != nil {
+				return fmt.Errorf("failed to set node pool: %w", err)
+			}
+		}
+		if scope.MultiUse {
+			return nil
+		}
 		return database.DeleteWorkerNodeToken(ctx, tx, workerToken)
 	}); err != nil {
-		return response.InternalError(fmt.Errorf("delete worker node token transaction failed: %w", err))
+		return response.InternalError(fmt.Errorf("use worker node token transaction failed: %w", err))
+	}
+	if scope.Pool != "" {
+		// distribute the node pool configuration, so that it can be applied as soon as the node joins
+		e.provider.NotifyUpdateNodeConfigController()
 	}
 
 	return response.SyncResponse(true, &apiv1.GetWorkerJoinInfoResponse{
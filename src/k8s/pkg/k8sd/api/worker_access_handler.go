@@ -28,6 +28,17 @@ func (e *Endpoints) restrictWorkers(s state.State, r *http.Request) (bool, respo
 	return true, nil
 }
 
+// restrictWorkersToClusterMembers allows requests on control plane nodes. On worker nodes, it only allows requests
+// from other cluster members, e.g. the inspection of all cluster members that is started on a control plane node.
+// Requests are authenticated before the access handler runs, so any request that does not come over the local
+// unix socket was sent by a cluster member.
+func (e *Endpoints) restrictWorkersToClusterMembers(s state.State, r *http.Request) (bool, response.Response) {
+	if r.RemoteAddr != "@" {
+		return true, nil
+	}
+	return e.restrictWorkers(s, r)
+}
+
 // ValidateWorkerInfoAccessHandler access handler checks if the worker is allowed to access this endpoint with the provided token.
 func ValidateWorkerInfoAccessHandler(nodeHeaderName string, tokenHeaderName string) func(s state.State, r *http.Request) (bool, response.Response) {
 	return func(s state.State, r *http.Request) (bool, response.Response) {
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+)
+
+func TestRestrictWorkersToClusterMembers(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		worker     bool
+		remoteAddr string
+		expectErr  bool
+	}{
+		{name: "ControlPlaneLocal", remoteAddr: "@"},
+		{name: "ControlPlaneMember", remoteAddr: "10.0.0.2:6400"},
+		{name: "WorkerLocal", worker: true, remoteAddr: "@", expectErr: true},
+		{name: "WorkerMember", worker: true, remoteAddr: "10.0.0.2:6400"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			if tc.worker {
+				g.Expect(os.WriteFile(filepath.Join(dir, "worker"), nil, 0o600)).To(Succeed())
+			}
+
+			e := &Endpoints{
+				context: context.Background(),
+				provider: &mock.Provider{
+					SnapFn: func() snap.Snap {
+						return &mock.Snap{
+							Mock: mock.Mock{
+								LockFilesDir: dir,
+							},
+						}
+					},
+				},
+			}
+
+			valid, resp := e.restrictWorkersToClusterMembers(nil, &http.Request{RemoteAddr: tc.remoteAddr})
+			if tc.expectErr {
+				g.Expect(valid).To(BeFalse())
+				g.Expect(resp).NotTo(BeNil())
+			} else {
+				g.Expect(valid).To(BeTrue())
+				g.Expect(resp).To(BeNil())
+			}
+		})
+	}
+}
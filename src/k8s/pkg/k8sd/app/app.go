@@ -14,6 +14,8 @@ import (
 	"github.com/canonical/k8s/pkg/k8sd/controllers/csrsigning"
 	"github.com/canonical/k8s/pkg/k8sd/controllers/upgrade"
 	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/restart"
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/utils/control"
@@ -44,6 +46,12 @@ type Config struct {
 	DisableUpdateNodeConfigController bool
 	// DisableFeatureController is a bool flag to disable feature controller
 	DisableFeatureController bool
+	// DisableFeatureHealthController is a bool flag to disable feature health controller
+	DisableFeatureHealthController bool
+	// DisableFeatureDriftController is a bool flag to disable feature drift controller
+	DisableFeatureDriftController bool
+	// DisableIngressClassController is a bool flag to disable ingress class controller
+	DisableIngressClassController bool
 	// DisableCSRSigningController is a bool flag to disable csrsigning controller.
 	DisableCSRSigningController bool
 	// DisableUpgradeController is a bool flag to disable upgrade controller.
@@ -65,6 +73,9 @@ type App struct {
 	// readyWg is used to denote that the microcluster node is now running
 	readyWg sync.WaitGroup
 
+	// restartCoordinator serializes restarts of the quorum-sensitive services across the control plane nodes
+	restartCoordinator *restart.Coordinator
+
 	nodeConfigController         *controllers.NodeConfigurationController
 	nodeLabelController          *controllers.NodeLabelController
 	controlPlaneConfigController *controllers.ControlPlaneConfigurationController
@@ -84,6 +95,12 @@ type App struct {
 	triggerFeatureControllerMetricsServerCh chan struct{}
 	triggerFeatureControllerDNSCh           chan struct{}
 	featureController                       *controllers.FeatureController
+
+	featureHealthController *controllers.FeatureHealthController
+
+	featureDriftController *controllers.FeatureDriftController
+
+	ingressClassController *controllers.IngressClassController
 }
 
 // New initializes a new microcluster instance from configuration.
@@ -111,10 +128,24 @@ func New(cfg Config) (*App, error) {
 	}
 	app.readyWg.Add(1)
 
+	getNodeName := func(ctx context.Context) (string, error) {
+		serverStatus, err := cluster.Status(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to retrieve microcluster status: %w", err)
+		}
+		return serverStatus.Name, nil
+	}
+
+	app.restartCoordinator = restart.New(restart.Options{
+		Snap:        cfg.Snap,
+		GetNodeName: getNodeName,
+	})
+
 	if !cfg.DisableNodeConfigController {
 		app.nodeConfigController = controllers.NewNodeConfigurationController(
-			cfg.Snap,
+			app.restartCoordinator,
 			app.readyWg.Wait,
+			getNodeName,
 		)
 	} else {
 		log.L().Info("node-config-controller disabled via config")
@@ -122,15 +153,9 @@ func New(cfg Config) (*App, error) {
 
 	if !cfg.DisableNodeLabelController {
 		app.nodeLabelController = controllers.NewNodeLabelController(
-			cfg.Snap,
+			app.restartCoordinator,
 			app.readyWg.Wait,
-			func(ctx context.Context) (string, error) {
-				serverStatus, err := cluster.Status(ctx)
-				if err != nil {
-					return "", fmt.Errorf("failed to retrieve microcluster status: %w", err)
-				}
-				return serverStatus.Name, nil
-			},
+			getNodeName,
 		)
 	} else {
 		log.L().Info("node-label-controller disabled via config")
@@ -138,9 +163,10 @@ func New(cfg Config) (*App, error) {
 
 	if !cfg.DisableControlPlaneConfigController {
 		app.controlPlaneConfigController = controllers.NewControlPlaneConfigurationController(
-			cfg.Snap,
+			app.restartCoordinator,
 			app.readyWg.Wait,
 			time.NewTicker(10*time.Second).C,
+			getNodeName,
 		)
 	} else {
 		log.L().Info("control-plane-config-controller disabled via config")
@@ -182,6 +208,38 @@ func New(cfg Config) (*App, error) {
 		log.L().Info("feature-controller disabled via config")
 	}
 
+	if !cfg.DisableFeatureHealthController {
+		app.featureHealthController = controllers.NewFeatureHealthController(
+			cfg.Snap,
+			app.readyWg.Wait,
+			time.NewTicker(30*time.Second).C,
+			features.HealthChecks(features.StatusChecks),
+		)
+	} else {
+		log.L().Info("feature-health-controller disabled via config")
+	}
+
+	if !cfg.DisableFeatureDriftController {
+		app.featureDriftController = controllers.NewFeatureDriftController(
+			cfg.Snap,
+			app.readyWg.Wait,
+			time.NewTicker(5*time.Minute).C,
+			features.Charts,
+		)
+	} else {
+		log.L().Info("feature-drift-controller disabled via config")
+	}
+
+	if !cfg.DisableIngressClassController {
+		app.ingressClassController = controllers.NewIngressClassController(
+			cfg.Snap,
+			app.readyWg.Wait,
+			time.NewTicker(10*time.Second).C,
+		)
+	} else {
+		log.L().Info("ingress-class-controller disabled via config")
+	}
+
 	if !cfg.DisableCSRSigningController {
 		app.csrsigningController = csrsigning.New(csrsigning.Options{
 			Snap:           cfg.Snap,
@@ -296,6 +354,12 @@ func (a *App) markNodeReady(ctx context.Context, s state.State) error {
 		return fmt.Errorf("failed to wait for database to be open: %w", err)
 	}
 
+	log.Info("Starting restart coordinator")
+	if err := a.restartCoordinator.Start(ctx, restart.NewDatabaseLease(s)); err != nil {
+		// restarts are not coordinated, but the node can still operate
+		log.Error(err, "Failed to start restart coordinator")
+	}
+
 	log.Info("Waiting for kubernetes endpoint")
 	if err := control.WaitUntilReady(ctx, func() (bool, error) {
 		client, err := a.snap.KubernetesNodeClient("")
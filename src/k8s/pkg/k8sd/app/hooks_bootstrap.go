@@ -260,7 +260,7 @@ func (a *App) onBootstrapWorkerNode(ctx context.Context, s state.State, encodedT
 	if err := setup.Containerd(snap, joinConfig.ExtraNodeContainerdConfig, joinConfig.ExtraNodeContainerdArgs); err != nil {
 		return fmt.Errorf("failed to configure containerd: %w", err)
 	}
-	if err := setup.KubeletWorker(snap, s.Name(), nodeIPs, response.ClusterDNS, response.ClusterDomain, response.CloudProvider, joinConfig.ExtraNodeKubeletArgs); err != nil {
+	if err := setup.KubeletWorker(snap, s.Name(), nodeIPs, response.ClusterDNS, response.ClusterDomain, response.CloudProvider, setup.HardeningServiceArgs(cfg.HardeningProfile(), "kubelet", joinConfig.ExtraNodeKubeletArgs)); err != nil {
 		return fmt.Errorf("failed to configure kubelet: %w", err)
 	}
 	if err := setup.KubeProxy(ctx, snap, s.Name(), response.PodCIDR, localhostAddress, joinConfig.ExtraNodeKubeProxyArgs); err != nil {
@@ -272,6 +272,9 @@ func (a *App) onBootstrapWorkerNode(ctx context.Context, s state.State, encodedT
 	if err := setup.ExtraNodeConfigFiles(snap, joinConfig.ExtraNodeConfigFiles); err != nil {
 		return fmt.Errorf("failed to write extra node config files: %w", err)
 	}
+	if err := setup.Hardening(snap, cfg.HardeningProfile(), false); err != nil {
+		return fmt.Errorf("failed to apply hardening profile: %w", err)
+	}
 
 	if err := snaputil.MarkAsWorkerNode(snap, true); err != nil {
 		return fmt.Errorf("failed to mark node as worker: %w", err)
@@ -437,6 +440,14 @@ func (a *App) onBootstrapControlPlane(ctx context.Context, s state.State, bootst
 	cfg.Certificates.K8sdPublicKey = utils.Pointer(certificates.K8sdPublicKey)
 	cfg.Certificates.K8sdPrivateKey = utils.Pointer(certificates.K8sdPrivateKey)
 
+	// Generate the key to encrypt Secrets at rest (if enabled)
+	// The key is used for encryption right away, as there are no other control plane nodes that need to decrypt with it first.
+	if keys, changed, err := cfg.SecretsEncryptionKeysForProvider(); err != nil {
+		return fmt.Errorf("failed to generate secrets encryption key: %w", err)
+	} else if changed {
+		cfg.Certificates.SecretsEncryptionKeys = &keys
+	}
+
 	serviceConfigs := types.K8sServiceConfigs{
 		ExtraNodeKubeSchedulerArgs:         bootstrapConfig.ExtraNodeKubeSchedulerArgs,
 		ExtraNodeKubeControllerManagerArgs: bootstrapConfig.ExtraNodeKubeControllerManagerArgs,
@@ -470,25 +481,31 @@ func (a *App) onBootstrapControlPlane(ctx context.Context, s state.State, bootst
 	if err := setup.Containerd(snap, bootstrapConfig.ExtraNodeContainerdConfig, bootstrapConfig.ExtraNodeContainerdArgs); err != nil {
 		return fmt.Errorf("failed to configure containerd: %w", err)
 	}
-	if err := setup.KubeletControlPlane(snap, s.Name(), nodeIPs, cfg.Kubelet.GetClusterDNS(), cfg.Kubelet.GetClusterDomain(), cfg.Kubelet.GetCloudProvider(), cfg.Kubelet.GetControlPlaneTaints(), bootstrapConfig.ExtraNodeKubeletArgs); err != nil {
+	if err := setup.KubeletControlPlane(snap, s.Name(), nodeIPs, cfg.Kubelet.GetClusterDNS(), cfg.Kubelet.GetClusterDomain(), cfg.Kubelet.GetCloudProvider(), cfg.Kubelet.GetControlPlaneTaints(), setup.HardeningServiceArgs(cfg.HardeningProfile(), "kubelet", bootstrapConfig.ExtraNodeKubeletArgs)); err != nil {
 		return fmt.Errorf("failed to configure kubelet: %w", err)
 	}
 	if err := setup.KubeProxy(ctx, snap, s.Name(), cfg.Network.GetPodCIDR(), localhostAddress, bootstrapConfig.ExtraNodeKubeProxyArgs); err != nil {
 		return fmt.Errorf("failed to configure kube-proxy: %w", err)
 	}
-	if err := setup.KubeControllerManager(snap, bootstrapConfig.ExtraNodeKubeControllerManagerArgs); err != nil {
+	if err := setup.KubeControllerManager(snap, setup.HardeningServiceArgs(cfg.HardeningProfile(), "kube-controller-manager", bootstrapConfig.ExtraNodeKubeControllerManagerArgs)); err != nil {
 		return fmt.Errorf("failed to configure kube-controller-manager: %w", err)
 	}
-	if err := setup.KubeScheduler(snap, bootstrapConfig.ExtraNodeKubeSchedulerArgs); err != nil {
+	if err := setup.KubeScheduler(snap, setup.HardeningServiceArgs(cfg.HardeningProfile(), "kube-scheduler", bootstrapConfig.ExtraNodeKubeSchedulerArgs)); err != nil {
 		return fmt.Errorf("failed to configure kube-scheduler: %w", err)
 	}
-	if err := setup.KubeAPIServer(snap, cfg.APIServer.GetSecurePort(), nodeIP, cfg.Network.GetServiceCIDR(), s.Address().Path("1.0", "kubernetes", "auth", "webhook").String(), true, cfg.Datastore, cfg.APIServer.GetAuthorizationMode(), bootstrapConfig.ExtraNodeKubeAPIServerArgs); err != nil {
+	if _, err := setup.SecretsEncryption(snap, cfg); err != nil {
+		return fmt.Errorf("failed to configure secrets encryption: %w", err)
+	}
+	if err := setup.KubeAPIServer(snap, nodeIP, cfg.Network.GetServiceCIDR(), s.Address().Path("1.0", "kubernetes", "auth", "webhook").String(), true, cfg.Datastore, cfg.APIServer, bootstrapConfig.ExtraNodeKubeAPIServerArgs); err != nil {
 		return fmt.Errorf("failed to configure kube-apiserver: %w", err)
 	}
 
 	if err := setup.ExtraNodeConfigFiles(snap, bootstrapConfig.ExtraNodeConfigFiles); err != nil {
 		return fmt.Errorf("failed to write extra node config files: %w", err)
 	}
+	if err := setup.Hardening(snap, cfg.HardeningProfile(), true); err != nil {
+		return fmt.Errorf("failed to apply hardening profile: %w", err)
+	}
 
 	// Write cluster configuration to dqlite
 	if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
 	snaputil "github.com/canonical/k8s/pkg/snap/util"
 	"github.com/canonical/k8s/pkg/utils"
@@ -25,6 +27,12 @@ func (a *App) onNodeReady(ctx context.Context, s state.State) error {
 		log.Error(err, "failed to apply custom CRDs: %w")
 	}
 
+	// Apply the admission policies of the cluster configuration
+	log.Info("Applying admission policies")
+	if err := a.applyAdmissionPolicies(ctx, s); err != nil {
+		log.Error(err, "Failed to apply admission policies")
+	}
+
 	// Check if a refresh was performed and if so, run the custom post-refresh hook
 	log.Info("Checking if snap is post-refresh")
 	isPostRefresh, err := utils.FileExists(a.snap.PostRefreshLockPath())
@@ -71,3 +79,36 @@ func (a *App) applyCustomCRDs(ctx context.Context) error {
 
 	return nil
 }
+
+// applyAdmissionPolicies applies the validating admission policies of the cluster configuration on control plane nodes.
+// Policies that are removed from the cluster configuration are not deleted from the cluster.
+func (a *App) applyAdmissionPolicies(ctx context.Context, s state.State) error {
+	isWorker, err := snaputil.IsWorker(a.snap)
+	if err != nil {
+		return fmt.Errorf("failed to check if node is a worker: %w", err)
+	}
+	if isWorker {
+		return nil
+	}
+
+	cfg, err := databaseutil.GetClusterConfig(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster configuration: %w", err)
+	}
+	if cfg.APIServer.GetAdmissionPolicies() == "" {
+		return nil
+	}
+	policies, err := types.ParseAdmissionPolicies(cfg.APIServer.GetAdmissionPolicies())
+	if err != nil {
+		return fmt.Errorf("failed to parse admission policies: %w", err)
+	}
+
+	k8sClient, err := a.snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	if err := k8sClient.ApplyAdmissionPolicies(ctx, policies); err != nil {
+		return fmt.Errorf("failed to apply admission policies: %w", err)
+	}
+	return nil
+}
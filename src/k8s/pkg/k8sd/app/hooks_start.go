@@ -9,6 +9,7 @@ import (
 
 	"github.com/canonical/k8s/pkg/k8sd/database"
 	databaseutil "github.com/canonical/k8s/pkg/k8sd/database/util"
+	"github.com/canonical/k8s/pkg/k8sd/features"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/utils"
@@ -16,6 +17,14 @@ import (
 	"github.com/canonical/microcluster/v2/state"
 )
 
+const (
+	// featureRepairLeaseName is the name of the lease that is held by the control plane node that repairs drifted features.
+	featureRepairLeaseName = "feature-repair"
+	// featureRepairLeaseDuration outlasts the interval of the feature drift controller, so that the lease is
+	// renewed by the same node as long as it is running.
+	featureRepairLeaseDuration = 15 * time.Minute
+)
+
 func (a *App) onStart(ctx context.Context, s state.State) error {
 	// start a goroutine to mark the node as running
 	go func() {
@@ -54,9 +63,15 @@ func (a *App) onStart(ctx context.Context, s state.State) error {
 
 	// start update node config controller
 	if a.updateNodeConfigController != nil {
-		go a.updateNodeConfigController.Run(ctx, func(ctx context.Context) (types.ClusterConfig, error) {
-			return databaseutil.GetClusterConfig(ctx, s)
-		})
+		go a.updateNodeConfigController.Run(
+			ctx,
+			func(ctx context.Context) (types.ClusterConfig, error) {
+				return databaseutil.GetClusterConfig(ctx, s)
+			},
+			func(ctx context.Context) (types.NodePools, error) {
+				return databaseutil.GetNodePools(ctx, s)
+			},
+		)
 	}
 
 	// start feature controller
@@ -92,6 +107,14 @@ func (a *App) onStart(ctx context.Context, s state.State) error {
 					// set .UpdatedAt field in a lot of places for every event/error.
 					// this is not 100% accurate but should be good enough
 					featureStatus.UpdatedAt = time.Now()
+
+					// keep the conditions reported by other controllers (e.g. health)
+					statuses, err := database.GetFeatureStatuses(ctx, tx)
+					if err != nil {
+						return fmt.Errorf("failed to get feature statuses: %w", err)
+					}
+					featureStatus.MergeConditions(statuses[name])
+
 					if err := database.SetFeatureStatus(ctx, tx, name, featureStatus); err != nil {
 						return fmt.Errorf("failed to set feature status in db for %q: %w", name, err)
 					}
@@ -104,6 +127,110 @@ func (a *App) onStart(ctx context.Context, s state.State) error {
 		)
 	}
 
+	// start feature health controller
+	if a.featureHealthController != nil {
+		go a.featureHealthController.Run(
+			ctx,
+			func(ctx context.Context) (map[types.FeatureName]types.FeatureStatus, error) {
+				var statuses map[types.FeatureName]types.FeatureStatus
+				if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					var err error
+					statuses, err = database.GetFeatureStatuses(ctx, tx)
+					return err
+				}); err != nil {
+					return nil, fmt.Errorf("database transaction to get feature statuses failed: %w", err)
+				}
+				return statuses, nil
+			},
+			func(ctx context.Context, name types.FeatureName, condition types.FeatureCondition) error {
+				if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					statuses, err := database.GetFeatureStatuses(ctx, tx)
+					if err != nil {
+						return fmt.Errorf("failed to get feature statuses: %w", err)
+					}
+					status, ok := statuses[name]
+					if !ok || !status.SetCondition(condition) {
+						return nil
+					}
+					if err := database.SetFeatureStatus(ctx, tx, name, status); err != nil {
+						return fmt.Errorf("failed to set feature status in db for %q: %w", name, err)
+					}
+					return nil
+				}); err != nil {
+					return fmt.Errorf("database transaction to set feature condition failed: %w", err)
+				}
+				return nil
+			},
+		)
+	}
+
+	// start feature drift controller
+	if a.featureDriftController != nil {
+		go a.featureDriftController.Run(
+			ctx,
+			func(ctx context.Context) (types.ClusterConfig, error) {
+				return databaseutil.GetClusterConfig(ctx, s)
+			},
+			func(ctx context.Context) (map[types.FeatureName]types.FeatureStatus, error) {
+				var statuses map[types.FeatureName]types.FeatureStatus
+				if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					var err error
+					statuses, err = database.GetFeatureStatuses(ctx, tx)
+					return err
+				}); err != nil {
+					return nil, fmt.Errorf("database transaction to get feature statuses failed: %w", err)
+				}
+				return statuses, nil
+			},
+			func(ctx context.Context, name types.FeatureName, condition types.FeatureCondition) error {
+				if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					statuses, err := database.GetFeatureStatuses(ctx, tx)
+					if err != nil {
+						return fmt.Errorf("failed to get feature statuses: %w", err)
+					}
+					status, ok := statuses[name]
+					if !ok || !status.SetCondition(condition) {
+						return nil
+					}
+					if err := database.SetFeatureStatus(ctx, tx, name, status); err != nil {
+						return fmt.Errorf("failed to set feature status in db for %q: %w", name, err)
+					}
+					return nil
+				}); err != nil {
+					return fmt.Errorf("database transaction to set feature condition failed: %w", err)
+				}
+				return nil
+			},
+			func(ctx context.Context) (bool, error) {
+				var acquired bool
+				if err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					var err error
+					acquired, err = database.AcquireRestartLease(ctx, tx, featureRepairLeaseName, s.Name(), time.Now().Add(featureRepairLeaseDuration))
+					return err
+				}); err != nil {
+					return false, fmt.Errorf("database transaction to acquire feature repair lease failed: %w", err)
+				}
+				return acquired, nil
+			},
+			func(name types.FeatureName) {
+				a.NotifyFeatureController(
+					name == features.Network,
+					name == features.Gateway,
+					name == features.Ingress,
+					name == features.LoadBalancer,
+					name == features.LocalStorage,
+					name == features.MetricsServer,
+					name == features.DNS,
+				)
+			},
+		)
+	}
+
+	// start ingress class controller
+	if a.ingressClassController != nil {
+		go a.ingressClassController.Run(ctx)
+	}
+
 	// start csrsigning controller
 	if a.csrsigningController != nil {
 		go func() {
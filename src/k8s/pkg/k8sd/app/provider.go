@@ -11,8 +11,10 @@ func (a *App) MicroCluster() *microcluster.MicroCluster {
 	return a.cluster
 }
 
+// Snap returns the snap of the node. Restarts of services through the returned snap are coordinated
+// with the other control plane nodes, see restart.Coordinator.
 func (a *App) Snap() snap.Snap {
-	return a.snap
+	return a.restartCoordinator
 }
 
 func (a *App) NotifyUpdateNodeConfigController() {
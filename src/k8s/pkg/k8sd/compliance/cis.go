@@ -0,0 +1,229 @@
+package compliance
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// cisAPIServerTLSCipherSuites are the strong cipher suites of the kube-apiserver, as listed by CIS control 1.2.31.
+	cisAPIServerTLSCipherSuites = []string{
+		"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA", "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+		"TLS_RSA_WITH_AES_128_CBC_SHA", "TLS_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_RSA_WITH_AES_256_GCM_SHA384",
+	}
+
+	// cisKubeletTLSCipherSuites are the strong cipher suites of the kubelet, as listed by CIS control 4.2.13.
+	cisKubeletTLSCipherSuites = []string{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_RSA_WITH_AES_256_GCM_SHA384", "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	}
+
+	// cisEncryptionProviders are the encryption providers that CIS control 1.2.30 accepts for Secrets.
+	cisEncryptionProviders = []string{"aescbc", "kms", "secretbox"}
+)
+
+// Check evaluates the controls of a hardening profile on the local node.
+func Check(snap snap.Snap, profile string) ([]Result, error) {
+	switch profile {
+	case types.HardeningProfileCIS:
+		return CheckCIS(snap)
+	default:
+		return nil, fmt.Errorf("unknown profile %q, must be one of %v", profile, types.HardeningProfiles)
+	}
+}
+
+// CheckCIS evaluates the controls of the CIS Kubernetes benchmark that can be checked on the local node.
+// Control plane controls are skipped on worker nodes.
+func CheckCIS(snap snap.Snap) ([]Result, error) {
+	worker, err := isWorker(snap)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{snap: snap, args: make(map[string]map[string]string)}
+
+	var skipControlPlane string
+	if worker {
+		skipControlPlane = "not a control plane node"
+	}
+	return append(
+		evaluate(n, cisControlPlaneControls(snap), skipControlPlane),
+		evaluate(n, cisWorkerControls(snap), "")...,
+	), nil
+}
+
+// cisControlPlaneControls are the controls of the CIS Kubernetes benchmark for control plane nodes.
+func cisControlPlaneControls(snap snap.Snap) []control {
+	argsFile := func(service string) files { return pathFiles(argumentsFile(snap, service)) }
+	pkiFiles := func(pattern string) files { return pathFiles(filepath.Join(snap.KubernetesPKIDir(), pattern)) }
+	configFile := func(name string) files { return pathFiles(filepath.Join(snap.KubernetesConfigDir(), name)) }
+
+	return []control{
+		// Control plane node configuration files
+		filePermissions("1.1.1", "Ensure that the API server configuration file permissions are set to 600", argsFile("kube-apiserver"), 0o600),
+		fileOwnership("1.1.2", "Ensure that the API server configuration file ownership is set to root:root", argsFile("kube-apiserver")),
+		filePermissions("1.1.3", "Ensure that the controller manager configuration file permissions are set to 600", argsFile("kube-controller-manager"), 0o600),
+		fileOwnership("1.1.4", "Ensure that the controller manager configuration file ownership is set to root:root", argsFile("kube-controller-manager")),
+		filePermissions("1.1.5", "Ensure that the scheduler configuration file permissions are set to 600", argsFile("kube-scheduler"), 0o600),
+		fileOwnership("1.1.6", "Ensure that the scheduler configuration file ownership is set to root:root", argsFile("kube-scheduler")),
+		filePermissions("1.1.7", "Ensure that the dqlite configuration file permissions are set to 644 or more restrictive", argsFile("k8s-dqlite"), 0o644),
+		fileOwnership("1.1.8", "Ensure that the dqlite configuration file ownership is set to root:root", argsFile("k8s-dqlite")),
+		filePermissions("1.1.9", "Ensure that the Container Network Interface file permissions are set to 600", pathFiles(filepath.Join(snap.CNIConfDir(), "*")), 0o600),
+		fileOwnership("1.1.10", "Ensure that the Container Network Interface file ownership is set to root:root", pathFiles(filepath.Join(snap.CNIConfDir(), "*"))),
+		filePermissions("1.1.11", "Ensure that the dqlite data directory permissions are set to 700 or more restrictive", pathFiles(snap.K8sDqliteStateDir()), 0o700),
+		fileOwnership("1.1.12", "Ensure that the dqlite data directory ownership is set to root:root", pathFiles(snap.K8sDqliteStateDir())),
+		filePermissions("1.1.13", "Ensure that the admin.conf file permissions are set to 600", configFile("admin.conf"), 0o600),
+		fileOwnership("1.1.14", "Ensure that the admin.conf file ownership is set to root:root", configFile("admin.conf")),
+		filePermissions("1.1.15", "Ensure that the scheduler.conf file permissions are set to 600", configFile("scheduler.conf"), 0o600),
+		fileOwnership("1.1.16", "Ensure that the scheduler.conf file ownership is set to root:root", configFile("scheduler.conf")),
+		filePermissions("1.1.17", "Ensure that the controller-manager.conf file permissions are set to 600", configFile("controller.conf"), 0o600),
+		fileOwnership("1.1.18", "Ensure that the controller-manager.conf file ownership is set to root:root", configFile("controller.conf")),
+		fileOwnership("1.1.19", "Ensure that the Kubernetes PKI directory and file ownership is set to root:root", pkiFiles("*")),
+		filePermissions("1.1.20", "Ensure that the Kubernetes PKI certificate file permissions are set to 600", pkiFiles("*.crt"), 0o600),
+		filePermissions("1.1.21", "Ensure that the Kubernetes PKI key file permissions are set to 600", pkiFiles("*.key"), 0o600),
+
+		// API server
+		argEquals("1.2.1", "Ensure that the --anonymous-auth argument is set to false", "kube-apiserver", "--anonymous-auth", "false"),
+		argNotSet("1.2.2", "Ensure that the --token-auth-file parameter is not set", "kube-apiserver", "--token-auth-file"),
+		argNotContains("1.2.3", "Ensure that the DenyServiceExternalIPs admission plugin is not set", "kube-apiserver", "--enable-admission-plugins", "DenyServiceExternalIPs"),
+		argSet("1.2.4", "Ensure that the --kubelet-client-certificate argument is set as appropriate", "kube-apiserver", "--kubelet-client-certificate"),
+		argSet("1.2.4", "Ensure that the --kubelet-client-key argument is set as appropriate", "kube-apiserver", "--kubelet-client-key"),
+		argSet("1.2.5", "Ensure that the --kubelet-certificate-authority argument is set as appropriate", "kube-apiserver", "--kubelet-certificate-authority"),
+		argNotContains("1.2.6", "Ensure that the --authorization-mode argument is not set to AlwaysAllow", "kube-apiserver", "--authorization-mode", "AlwaysAllow"),
+		argContains("1.2.7", "Ensure that the --authorization-mode argument includes Node", "kube-apiserver", "--authorization-mode", "Node"),
+		argContains("1.2.8", "Ensure that the --authorization-mode argument includes RBAC", "kube-apiserver", "--authorization-mode", "RBAC"),
+		argContains("1.2.9", "Ensure that the admission control plugin EventRateLimit is set", "kube-apiserver", "--enable-admission-plugins", "EventRateLimit"),
+		argSet("1.2.9", "Ensure that the admission control configuration file of the EventRateLimit plugin is set", "kube-apiserver", "--admission-control-config-file"),
+		argNotContains("1.2.10", "Ensure that the admission control plugin AlwaysAdmit is not set", "kube-apiserver", "--enable-admission-plugins", "AlwaysAdmit"),
+		argContains("1.2.11", "Ensure that the admission control plugin AlwaysPullImages is set", "kube-apiserver", "--enable-admission-plugins", "AlwaysPullImages"),
+		argNotContains("1.2.13", "Ensure that the admission control plugin ServiceAccount is set", "kube-apiserver", "--disable-admission-plugins", "ServiceAccount"),
+		argNotContains("1.2.14", "Ensure that the admission control plugin NamespaceLifecycle is set", "kube-apiserver", "--disable-admission-plugins", "NamespaceLifecycle"),
+		argContains("1.2.15", "Ensure that the admission control plugin NodeRestriction is set", "kube-apiserver", "--enable-admission-plugins", "NodeRestriction"),
+		argNotEquals("1.2.16", "Ensure that the --secure-port argument is not set to 0", "kube-apiserver", "--secure-port", "0"),
+		argEquals("1.2.17", "Ensure that the --profiling argument is set to false", "kube-apiserver", "--profiling", "false"),
+		argSet("1.2.18", "Ensure that the --audit-log-path argument is set", "kube-apiserver", "--audit-log-path"),
+		argAtLeast("1.2.19", "Ensure that the --audit-log-maxage argument is set to 30 or as appropriate", "kube-apiserver", "--audit-log-maxage", 30),
+		argAtLeast("1.2.20", "Ensure that the --audit-log-maxbackup argument is set to 10 or as appropriate", "kube-apiserver", "--audit-log-maxbackup", 10),
+		argAtLeast("1.2.21", "Ensure that the --audit-log-maxsize argument is set to 100 or as appropriate", "kube-apiserver", "--audit-log-maxsize", 100),
+		argSet("1.2.22", "Ensure that the --request-timeout argument is set as appropriate", "kube-apiserver", "--request-timeout"),
+		argNotEquals("1.2.23", "Ensure that the --service-account-lookup argument is set to true", "kube-apiserver", "--service-account-lookup", "false"),
+		argSet("1.2.24", "Ensure that the --service-account-key-file argument is set as appropriate", "kube-apiserver", "--service-account-key-file"),
+		argSet("1.2.26", "Ensure that the --tls-cert-file argument is set as appropriate", "kube-apiserver", "--tls-cert-file"),
+		argSet("1.2.26", "Ensure that the --tls-private-key-file argument is set as appropriate", "kube-apiserver", "--tls-private-key-file"),
+		argSet("1.2.27", "Ensure that the --client-ca-file argument is set as appropriate", "kube-apiserver", "--client-ca-file"),
+		argSet("1.2.29", "Ensure that the --encryption-provider-config argument is set as appropriate", "kube-apiserver", "--encryption-provider-config"),
+		{
+			id:          "1.2.30",
+			description: "Ensure that encryption providers are appropriately configured",
+			remediation: fmt.Sprintf("Configure one of %v as the first encryption provider of Secrets, e.g. with: k8s set annotations.%s=aescbc", cisEncryptionProviders, types.AnnotationSecretsEncryptionProvider),
+			check:       checkEncryptionProviders,
+		},
+		argSubsetOf("1.2.31", "Ensure that the API Server only makes use of Strong Cryptographic Ciphers", "kube-apiserver", "--tls-cipher-suites", cisAPIServerTLSCipherSuites),
+
+		// Controller manager
+		argSet("1.3.1", "Ensure that the --terminated-pod-gc-threshold argument is set as appropriate", "kube-controller-manager", "--terminated-pod-gc-threshold"),
+		argEquals("1.3.2", "Ensure that the --profiling argument is set to false", "kube-controller-manager", "--profiling", "false"),
+		argEquals("1.3.3", "Ensure that the --use-service-account-credentials argument is set to true", "kube-controller-manager", "--use-service-account-credentials", "true"),
+		argSet("1.3.4", "Ensure that the --service-account-private-key-file argument is set as appropriate", "kube-controller-manager", "--service-account-private-key-file"),
+		argSet("1.3.5", "Ensure that the --root-ca-file argument is set as appropriate", "kube-controller-manager", "--root-ca-file"),
+		argNotContains("1.3.6", "Ensure that the RotateKubeletServerCertificate argument is set to true", "kube-controller-manager", "--feature-gates", "RotateKubeletServerCertificate=false"),
+		argEquals("1.3.7", "Ensure that the --bind-address argument is set to 127.0.0.1", "kube-controller-manager", "--bind-address", "127.0.0.1"),
+
+		// Scheduler
+		argEquals("1.4.1", "Ensure that the --profiling argument is set to false", "kube-scheduler", "--profiling", "false"),
+		argEquals("1.4.2", "Ensure that the --bind-address argument is set to 127.0.0.1", "kube-scheduler", "--bind-address", "127.0.0.1"),
+
+		// Logging
+		argSet("3.2.1", "Ensure that a minimal audit policy is created", "kube-apiserver", "--audit-policy-file"),
+	}
+}
+
+// cisWorkerControls are the controls of the CIS Kubernetes benchmark for all nodes.
+func cisWorkerControls(snap snap.Snap) []control {
+	configFile := func(name string) files { return pathFiles(filepath.Join(snap.KubernetesConfigDir(), name)) }
+
+	return []control{
+		// Worker node configuration files
+		filePermissions("4.1.1", "Ensure that the kubelet service file permissions are set to 600", pathFiles(setup.KubeletServiceFile), 0o600),
+		fileOwnership("4.1.2", "Ensure that the kubelet service file ownership is set to root:root", pathFiles(setup.KubeletServiceFile)),
+		filePermissions("4.1.3", "If proxy kubeconfig file exists ensure permissions are set to 600", configFile("proxy.conf"), 0o600),
+		fileOwnership("4.1.4", "If proxy kubeconfig file exists ensure ownership is set to root:root", configFile("proxy.conf")),
+		filePermissions("4.1.5", "Ensure that the --kubeconfig kubelet.conf file permissions are set to 600", configFile("kubelet.conf"), 0o600),
+		fileOwnership("4.1.6", "Ensure that the --kubeconfig kubelet.conf file ownership is set to root:root", configFile("kubelet.conf")),
+		filePermissions("4.1.7", "Ensure that the certificate authorities file permissions are set to 600", argFile("kubelet", "--client-ca-file"), 0o600),
+		fileOwnership("4.1.8", "Ensure that the client certificate authorities file ownership is set to root:root", argFile("kubelet", "--client-ca-file")),
+		filePermissions("4.1.9", "If the kubelet config.yaml configuration file is being used validate permissions set to 600", argFile("kubelet", "--config"), 0o600),
+		fileOwnership("4.1.10", "If the kubelet config.yaml configuration file is being used validate file ownership is set to root:root", argFile("kubelet", "--config")),
+
+		// Kubelet
+		argEquals("4.2.1", "Ensure that the --anonymous-auth argument is set to false", "kubelet", "--anonymous-auth", "false"),
+		argNotContains("4.2.2", "Ensure that the --authorization-mode argument is not set to AlwaysAllow", "kubelet", "--authorization-mode", "AlwaysAllow"),
+		argSet("4.2.3", "Ensure that the --client-ca-file argument is set as appropriate", "kubelet", "--client-ca-file"),
+		argEquals("4.2.4", "Verify that the --read-only-port argument is set to 0", "kubelet", "--read-only-port", "0"),
+		argNotEquals("4.2.5", "Ensure that the --streaming-connection-idle-timeout argument is not set to 0", "kubelet", "--streaming-connection-idle-timeout", "0", "0s"),
+		argEquals("4.2.6", "Ensure that the --protect-kernel-defaults argument is set to true", "kubelet", "--protect-kernel-defaults", "true"),
+		argNotEquals("4.2.7", "Ensure that the --make-iptables-util-chains argument is set to true", "kubelet", "--make-iptables-util-chains", "false"),
+		argNotEquals("4.2.9", "Ensure that the --event-qps argument is set to a level which ensures appropriate event capture", "kubelet", "--event-qps", "0"),
+		argSet("4.2.10", "Ensure that the --tls-cert-file argument is set as appropriate", "kubelet", "--tls-cert-file"),
+		argSet("4.2.10", "Ensure that the --tls-private-key-file argument is set as appropriate", "kubelet", "--tls-private-key-file"),
+		argNotEquals("4.2.11", "Ensure that the --rotate-certificates argument is not set to false", "kubelet", "--rotate-certificates", "false"),
+		argNotContains("4.2.12", "Verify that the RotateKubeletServerCertificate argument is set to true", "kubelet", "--feature-gates", "RotateKubeletServerCertificate=false"),
+		argSubsetOf("4.2.13", "Ensure that the Kubelet only makes use of Strong Cryptographic Ciphers", "kubelet", "--tls-cipher-suites", cisKubeletTLSCipherSuites),
+	}
+}
+
+// checkEncryptionProviders checks that Secrets are encrypted with one of the providers accepted by CIS control 1.2.30.
+func checkEncryptionProviders(n *node) (Status, string) {
+	path, _, err := n.arg("kube-apiserver", "--encryption-provider-config")
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	if path == "" {
+		return StatusFail, "--encryption-provider-config is not set"
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("failed to read encryption provider configuration: %v", err)
+	}
+	var config struct {
+		Resources []struct {
+			Resources []string         `json:"resources"`
+			Providers []map[string]any `json:"providers"`
+		} `json:"resources"`
+	}
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return StatusFail, fmt.Sprintf("failed to parse encryption provider configuration: %v", err)
+	}
+
+	for _, resource := range config.Resources {
+		if !slices.Contains(resource.Resources, "secrets") || len(resource.Providers) == 0 {
+			continue
+		}
+		// Secrets are encrypted with the first provider
+		for provider := range resource.Providers[0] {
+			if slices.Contains(cisEncryptionProviders, provider) {
+				return StatusPass, ""
+			}
+			return StatusFail, fmt.Sprintf("Secrets are encrypted with the %s provider", provider)
+		}
+	}
+	return StatusFail, "Secrets are not encrypted"
+}
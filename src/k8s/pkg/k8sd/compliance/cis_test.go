@@ -0,0 +1,217 @@
+package compliance_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/compliance"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+)
+
+// cisArgs are service arguments that comply with the CIS benchmark. "$DIR" is replaced with the test directory.
+var cisArgs = map[string]map[string]string{
+	"kube-apiserver": {
+		"--anonymous-auth":                "false",
+		"--authorization-mode":            "Node,RBAC",
+		"--enable-admission-plugins":      "NodeRestriction,EventRateLimit,AlwaysPullImages",
+		"--admission-control-config-file": "$DIR/args/conf.d/admission-control-config.yaml",
+		"--kubelet-client-certificate":    "$DIR/pki/apiserver-kubelet-client.crt",
+		"--kubelet-client-key":            "$DIR/pki/apiserver-kubelet-client.key",
+		"--kubelet-certificate-authority": "$DIR/pki/ca.crt",
+		"--secure-port":                   "6443",
+		"--profiling":                     "false",
+		"--audit-log-path":                "/var/log/kubernetes/audit.log",
+		"--audit-log-maxage":              "30",
+		"--audit-log-maxbackup":           "10",
+		"--audit-log-maxsize":             "100",
+		"--audit-policy-file":             "$DIR/args/conf.d/audit-policy.yaml",
+		"--request-timeout":               "60s",
+		"--service-account-key-file":      "$DIR/pki/serviceaccount.key",
+		"--tls-cert-file":                 "$DIR/pki/apiserver.crt",
+		"--tls-private-key-file":          "$DIR/pki/apiserver.key",
+		"--client-ca-file":                "$DIR/pki/client-ca.crt",
+		"--encryption-provider-config":    "$DIR/args/conf.d/encryption-config.yaml",
+		"--tls-cipher-suites":             "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	},
+	"kube-controller-manager": {
+		"--terminated-pod-gc-threshold":      "12500",
+		"--profiling":                        "false",
+		"--use-service-account-credentials":  "true",
+		"--service-account-private-key-file": "$DIR/pki/serviceaccount.key",
+		"--root-ca-file":                     "$DIR/pki/ca.crt",
+		"--bind-address":                     "127.0.0.1",
+	},
+	"kube-scheduler": {
+		"--profiling":    "false",
+		"--bind-address": "127.0.0.1",
+	},
+	"k8s-dqlite": {
+		"--storage-dir": "$DIR/k8s-dqlite",
+	},
+	"kubelet": {
+		"--anonymous-auth":                    "false",
+		"--authorization-mode":                "Webhook",
+		"--client-ca-file":                    "$DIR/pki/client-ca.crt",
+		"--read-only-port":                    "0",
+		"--streaming-connection-idle-timeout": "5m",
+		"--protect-kernel-defaults":           "true",
+		"--tls-cert-file":                     "$DIR/pki/kubelet.crt",
+		"--tls-private-key-file":              "$DIR/pki/kubelet.key",
+		"--tls-cipher-suites":                 "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	},
+}
+
+const encryptionConfig = `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - aescbc:
+      keys:
+      - name: key-1
+        secret: c2VjcmV0
+  - identity: {}
+`
+
+// setupNode writes the service arguments and files of a node in a temporary directory.
+func setupNode(t *testing.T, g Gomega, args map[string]map[string]string, worker bool) *mock.Snap {
+	dir := t.TempDir()
+	s := &mock.Snap{Mock: mock.Mock{
+		ServiceArgumentsDir:   filepath.Join(dir, "args"),
+		ServiceExtraConfigDir: filepath.Join(dir, "args", "conf.d"),
+		KubernetesPKIDir:      filepath.Join(dir, "pki"),
+		KubernetesConfigDir:   filepath.Join(dir, "kubernetes"),
+		CNIConfDir:            filepath.Join(dir, "cni"),
+		K8sDqliteStateDir:     filepath.Join(dir, "k8s-dqlite"),
+		LockFilesDir:          filepath.Join(dir, "lock"),
+	}}
+	for _, d := range []string{s.Mock.ServiceExtraConfigDir, s.Mock.KubernetesPKIDir, s.Mock.KubernetesConfigDir, s.Mock.K8sDqliteStateDir, s.Mock.LockFilesDir} {
+		g.Expect(os.MkdirAll(d, 0o700)).To(Succeed())
+	}
+	if worker {
+		g.Expect(os.WriteFile(filepath.Join(s.Mock.LockFilesDir, "worker"), nil, 0o600)).To(Succeed())
+	}
+
+	for service, serviceArgs := range args {
+		var lines []string
+		for key, value := range serviceArgs {
+			lines = append(lines, fmt.Sprintf("%s=%s", key, strings.ReplaceAll(value, "$DIR", dir)))
+		}
+		g.Expect(os.WriteFile(filepath.Join(s.Mock.ServiceArgumentsDir, service), []byte(strings.Join(lines, "\n")+"\n"), 0o600)).To(Succeed())
+	}
+	for _, name := range []string{"ca.crt", "client-ca.crt", "apiserver.crt", "apiserver.key"} {
+		g.Expect(os.WriteFile(filepath.Join(s.Mock.KubernetesPKIDir, name), nil, 0o600)).To(Succeed())
+	}
+	for _, name := range []string{"admin.conf", "kubelet.conf", "proxy.conf"} {
+		g.Expect(os.WriteFile(filepath.Join(s.Mock.KubernetesConfigDir, name), nil, 0o600)).To(Succeed())
+	}
+	g.Expect(os.WriteFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "encryption-config.yaml"), []byte(encryptionConfig), 0o600)).To(Succeed())
+	return s
+}
+
+// resultsByID returns the results of the controls with an ID.
+func resultsByID(results []compliance.Result, id string) []compliance.Result {
+	var matching []compliance.Result
+	for _, result := range results {
+		if result.ID == id {
+			matching = append(matching, result)
+		}
+	}
+	return matching
+}
+
+func TestCheckCIS(t *testing.T) {
+	t.Run("Compliant", func(t *testing.T) {
+		g := NewWithT(t)
+		s := setupNode(t, g, cisArgs, false)
+
+		results, err := compliance.CheckCIS(s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(results).ToNot(BeEmpty())
+		for _, result := range results {
+			g.Expect(result.Status).ToNot(Equal(compliance.StatusFail), "control %s failed: %s", result.ID, result.Details)
+		}
+		g.Expect(resultsByID(results, "1.2.1")).To(ConsistOf(HaveField("Status", compliance.StatusPass)))
+		g.Expect(resultsByID(results, "1.2.30")).To(ConsistOf(HaveField("Status", compliance.StatusPass)))
+	})
+
+	t.Run("NonCompliant", func(t *testing.T) {
+		g := NewWithT(t)
+
+		args := make(map[string]map[string]string, len(cisArgs))
+		for service, serviceArgs := range cisArgs {
+			args[service] = make(map[string]string, len(serviceArgs))
+			for key, value := range serviceArgs {
+				args[service][key] = value
+			}
+		}
+		args["kube-apiserver"]["--anonymous-auth"] = "true"
+		args["kube-apiserver"]["--authorization-mode"] = "AlwaysAllow"
+		args["kube-apiserver"]["--audit-log-maxage"] = "7"
+		args["kube-apiserver"]["--tls-cipher-suites"] = "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_RSA_WITH_RC4_128_SHA"
+		delete(args["kube-scheduler"], "--profiling")
+		args["kubelet"]["--read-only-port"] = "10255"
+
+		s := setupNode(t, g, args, false)
+		g.Expect(os.Chmod(filepath.Join(s.Mock.KubernetesPKIDir, "apiserver.key"), 0o644)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "encryption-config.yaml"), []byte(strings.ReplaceAll(encryptionConfig, "aescbc", "aesgcm")), 0o600)).To(Succeed())
+
+		results, err := compliance.CheckCIS(s)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var failed []string
+		for _, result := range results {
+			if result.Status == compliance.StatusFail {
+				failed = append(failed, result.ID)
+				g.Expect(result.Details).ToNot(BeEmpty())
+				g.Expect(result.Remediation).ToNot(BeEmpty())
+			}
+		}
+		g.Expect(failed).To(ConsistOf("1.1.21", "1.2.1", "1.2.6", "1.2.7", "1.2.8", "1.2.19", "1.2.30", "1.2.31", "1.4.1", "4.2.4"))
+
+		g.Expect(resultsByID(results, "1.2.1")).To(ConsistOf(And(
+			HaveField("Details", `--anonymous-auth is set to "true"`),
+			HaveField("Remediation", fmt.Sprintf("Set --anonymous-auth=false in %s and restart the kube-apiserver service.", filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))),
+		)))
+		g.Expect(resultsByID(results, "1.1.21")).To(ConsistOf(HaveField("Remediation", fmt.Sprintf("Run: chmod 600 %s", filepath.Join(s.Mock.KubernetesPKIDir, "*.key")))))
+	})
+
+	t.Run("Worker", func(t *testing.T) {
+		g := NewWithT(t)
+		s := setupNode(t, g, map[string]map[string]string{"kubelet": cisArgs["kubelet"]}, true)
+
+		results, err := compliance.CheckCIS(s)
+		g.Expect(err).ToNot(HaveOccurred())
+		for _, result := range results {
+			if strings.HasPrefix(result.ID, "4.") {
+				g.Expect(result.Status).ToNot(Equal(compliance.StatusFail), "control %s failed: %s", result.ID, result.Details)
+			} else {
+				g.Expect(result.Status).To(Equal(compliance.StatusSkip))
+				g.Expect(result.Details).To(Equal("not a control plane node"))
+			}
+		}
+	})
+
+	t.Run("MissingArguments", func(t *testing.T) {
+		g := NewWithT(t)
+		s := setupNode(t, g, nil, true)
+
+		results, err := compliance.CheckCIS(s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(resultsByID(results, "4.2.1")).To(ConsistOf(And(
+			HaveField("Status", compliance.StatusFail),
+			HaveField("Details", "arguments file of kubelet not found"),
+		)))
+	})
+}
+
+func TestCheck(t *testing.T) {
+	g := NewWithT(t)
+	_, err := compliance.Check(&mock.Snap{}, "unknown")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,329 @@
+// Package compliance checks the configuration of the local node against the controls of hardening benchmarks.
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+// Status is the outcome of a control on the local node.
+type Status string
+
+const (
+	// StatusPass means that the node complies with the control.
+	StatusPass Status = "PASS"
+	// StatusFail means that the node does not comply with the control.
+	StatusFail Status = "FAIL"
+	// StatusSkip means that the control does not apply to the node, e.g. control plane controls on worker nodes.
+	StatusSkip Status = "SKIP"
+)
+
+// Result is the result of a control on the local node.
+type Result struct {
+	// ID is the identifier of the control in the benchmark, e.g. "1.2.1".
+	ID string `json:"id" yaml:"id"`
+	// Description is the description of the control.
+	Description string `json:"description" yaml:"description"`
+	// Status is the outcome of the control.
+	Status Status `json:"status" yaml:"status"`
+	// Details explain why the control failed or was skipped.
+	Details string `json:"details,omitempty" yaml:"details,omitempty"`
+	// Remediation is a hint on how to comply with a failed control.
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// control is a control of a benchmark that can be evaluated on the local node.
+type control struct {
+	id          string
+	description string
+	remediation string
+	// service is the service that the control applies to. The remediation of failed controls on service arguments
+	// points to the arguments file of the service.
+	service string
+	check   func(n *node) (Status, string)
+}
+
+// node is the local node that controls are evaluated against.
+type node struct {
+	snap snap.Snap
+	// args are the arguments of each service, by service name. Missing arguments files are kept as nil.
+	args map[string]map[string]string
+}
+
+// arg returns the value of a service argument, and whether the argument is set.
+func (n *node) arg(service string, arg string) (string, bool, error) {
+	args, ok := n.args[service]
+	if !ok {
+		b, err := os.ReadFile(argumentsFile(n.snap, service))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", false, fmt.Errorf("failed to read arguments of %s: %w", service, err)
+		}
+		if err == nil {
+			args = make(map[string]string)
+			for _, line := range strings.Split(string(b), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					key, value := utils.ParseArgumentLine(line)
+					args[key] = value
+				}
+			}
+		}
+		n.args[service] = args
+	}
+	if args == nil {
+		return "", false, fmt.Errorf("arguments file of %s not found", service)
+	}
+	value, ok := args[arg]
+	return value, ok, nil
+}
+
+// evaluate runs a list of controls. Controls are skipped with the given reason, if not empty.
+func evaluate(n *node, controls []control, skipReason string) []Result {
+	results := make([]Result, 0, len(controls))
+	for _, c := range controls {
+		result := Result{ID: c.id, Description: c.description}
+		if skipReason != "" {
+			result.Status, result.Details = StatusSkip, skipReason
+		} else {
+			result.Status, result.Details = c.check(n)
+		}
+		if result.Status == StatusFail {
+			result.Remediation = c.remediation
+			if c.service != "" {
+				result.Remediation = fmt.Sprintf("%s in %s and restart the %s service.", c.remediation, argumentsFile(n.snap, c.service), c.service)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// isWorker returns true if the local node is a worker node.
+func isWorker(snap snap.Snap) (bool, error) {
+	worker, err := snaputil.IsWorker(snap)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if the node is a worker node: %w", err)
+	}
+	return worker, nil
+}
+
+func argumentsFile(snap snap.Snap, service string) string {
+	return filepath.Join(snap.ServiceArgumentsDir(), service)
+}
+
+// argCheck returns a control that checks the value of a service argument.
+// check is called with the value of the argument and whether it is set, and returns an error if the value is not compliant.
+func argCheck(id, description, service, arg, remediation string, check func(value string, set bool) error) control {
+	return control{
+		id:          id,
+		description: description,
+		remediation: remediation,
+		service:     service,
+		check: func(n *node) (Status, string) {
+			value, set, err := n.arg(service, arg)
+			if err != nil {
+				return StatusFail, err.Error()
+			}
+			if err := check(value, set); err != nil {
+				return StatusFail, err.Error()
+			}
+			return StatusPass, ""
+		},
+	}
+}
+
+// argEquals returns a control that checks that a service argument is set to a value.
+func argEquals(id, description, service, arg, want string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Set %s=%s", arg, want), func(value string, set bool) error {
+		if !set {
+			return fmt.Errorf("%s is not set", arg)
+		}
+		if value != want {
+			return fmt.Errorf("%s is set to %q", arg, value)
+		}
+		return nil
+	})
+}
+
+// argSet returns a control that checks that a service argument is set to a non-empty value.
+func argSet(id, description, service, arg string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Set %s", arg), func(value string, set bool) error {
+		if value == "" {
+			return fmt.Errorf("%s is not set", arg)
+		}
+		return nil
+	})
+}
+
+// argNotSet returns a control that checks that a service argument is not set.
+func argNotSet(id, description, service, arg string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Remove %s", arg), func(value string, set bool) error {
+		if set {
+			return fmt.Errorf("%s is set", arg)
+		}
+		return nil
+	})
+}
+
+// argNotEquals returns a control that checks that a service argument is not set to any of the given values.
+func argNotEquals(id, description, service, arg string, values ...string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Remove %s or set it to a value other than %s", arg, strings.Join(values, ", ")), func(value string, set bool) error {
+		for _, v := range values {
+			if set && value == v {
+				return fmt.Errorf("%s is set to %q", arg, value)
+			}
+		}
+		return nil
+	})
+}
+
+// argContains returns a control that checks that a comma-separated service argument contains an item.
+func argContains(id, description, service, arg, item string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Add %s to %s", item, arg), func(value string, set bool) error {
+		if !listContains(value, item) {
+			return fmt.Errorf("%s does not contain %s", arg, item)
+		}
+		return nil
+	})
+}
+
+// argNotContains returns a control that checks that a comma-separated service argument does not contain an item.
+func argNotContains(id, description, service, arg, item string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Remove %s from %s", item, arg), func(value string, set bool) error {
+		if listContains(value, item) {
+			return fmt.Errorf("%s contains %s", arg, item)
+		}
+		return nil
+	})
+}
+
+// argAtLeast returns a control that checks that a numeric service argument is set to at least a value.
+func argAtLeast(id, description, service, arg string, minimum int) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Set %s=%d or higher", arg, minimum), func(value string, set bool) error {
+		if !set {
+			return fmt.Errorf("%s is not set", arg)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < minimum {
+			return fmt.Errorf("%s is set to %q", arg, value)
+		}
+		return nil
+	})
+}
+
+// argSubsetOf returns a control that checks that a comma-separated service argument is set and only contains allowed items.
+func argSubsetOf(id, description, service, arg string, allowed []string) control {
+	return argCheck(id, description, service, arg, fmt.Sprintf("Set %s=%s", arg, strings.Join(allowed, ",")), func(value string, set bool) error {
+		if value == "" {
+			return fmt.Errorf("%s is not set", arg)
+		}
+		for _, item := range strings.Split(value, ",") {
+			if !listContains(strings.Join(allowed, ","), item) {
+				return fmt.Errorf("%s contains %s", arg, item)
+			}
+		}
+		return nil
+	})
+}
+
+func listContains(list string, item string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if strings.TrimSpace(v) == item {
+			return true
+		}
+	}
+	return false
+}
+
+// files are the files that a control applies to.
+type files struct {
+	// name refers to the files in remediation hints.
+	name string
+	list func(n *node) ([]string, error)
+}
+
+// pathFiles returns the files that match a path pattern.
+func pathFiles(pattern string) files {
+	return files{name: pattern, list: func(*node) ([]string, error) { return filepath.Glob(pattern) }}
+}
+
+// argFile returns the file that a service argument points to, if set.
+func argFile(service string, arg string) files {
+	return files{
+		name: fmt.Sprintf("<%s %s>", service, arg),
+		list: func(n *node) ([]string, error) {
+			value, _, err := n.arg(service, arg)
+			if err != nil || value == "" {
+				return nil, err
+			}
+			return []string{value}, nil
+		},
+	}
+}
+
+// fileCheck returns a control that checks the existing files of a control. The control is skipped if no files exist.
+func fileCheck(id, description, remediation string, files files, check func(path string, info fs.FileInfo) error) control {
+	return control{
+		id:          id,
+		description: description,
+		remediation: remediation,
+		check: func(n *node) (Status, string) {
+			paths, err := files.list(n)
+			if err != nil {
+				return StatusFail, err.Error()
+			}
+
+			var checked bool
+			for _, path := range paths {
+				info, err := os.Stat(path)
+				if err != nil {
+					if errors.Is(err, fs.ErrNotExist) {
+						continue
+					}
+					return StatusFail, err.Error()
+				}
+				checked = true
+				if err := check(path, info); err != nil {
+					return StatusFail, err.Error()
+				}
+			}
+			if !checked {
+				return StatusSkip, "file not found"
+			}
+			return StatusPass, ""
+		},
+	}
+}
+
+// filePermissions returns a control that checks that files have the given permissions or more restrictive ones.
+func filePermissions(id, description string, files files, maxMode fs.FileMode) control {
+	return fileCheck(id, description, fmt.Sprintf("Run: chmod %o %s", maxMode, files.name), files, func(path string, info fs.FileInfo) error {
+		if mode := info.Mode().Perm(); mode&^maxMode != 0 {
+			return fmt.Errorf("%s has permissions %o", path, mode)
+		}
+		return nil
+	})
+}
+
+// fileOwnership returns a control that checks that files are owned by root:root.
+func fileOwnership(id, description string, files files) control {
+	return fileCheck(id, description, fmt.Sprintf("Run: chown root:root %s", files.name), files, func(path string, info fs.FileInfo) error {
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return fmt.Errorf("failed to get the owner of %s", path)
+		}
+		if stat.Uid != 0 || stat.Gid != 0 {
+			return fmt.Errorf("%s is owned by %d:%d", path, stat.Uid, stat.Gid)
+		}
+		return nil
+	})
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/canonical/k8s/pkg/k8sd/pki"
@@ -20,17 +21,20 @@ type ControlPlaneConfigurationController struct {
 	snap      snap.Snap
 	waitReady func()
 	triggerCh <-chan time.Time
+	// getNodeName is used to find the service arguments overrides of the local node.
+	getNodeName func(ctx context.Context) (string, error)
 	// reconciledCh is used to notify that the controller has finished its reconciliation loop.
 	reconciledCh chan struct{}
 }
 
 // NewControlPlaneConfigurationController creates a new controller.
 // triggerCh is typically a `time.NewTicker(<duration>).C`.
-func NewControlPlaneConfigurationController(snap snap.Snap, waitReady func(), triggerCh <-chan time.Time) *ControlPlaneConfigurationController {
+func NewControlPlaneConfigurationController(snap snap.Snap, waitReady func(), triggerCh <-chan time.Time, getNodeName func(ctx context.Context) (string, error)) *ControlPlaneConfigurationController {
 	return &ControlPlaneConfigurationController{
 		snap:         snap,
 		waitReady:    waitReady,
 		triggerCh:    triggerCh,
+		getNodeName:  getNodeName,
 		reconciledCh: make(chan struct{}, 1),
 	}
 }
@@ -78,6 +82,10 @@ func (c *ControlPlaneConfigurationController) Run(ctx context.Context, getCluste
 }
 
 func (c *ControlPlaneConfigurationController) reconcile(ctx context.Context, config types.ClusterConfig) error {
+	// the arguments of all sources are collected and applied once, so that they do not overwrite each other
+	args := setup.ServiceArguments{}
+	var restartServices []string
+
 	// kube-apiserver: external datastore
 	if config.Datastore.GetType() == "external" {
 		// certificates
@@ -89,32 +97,81 @@ func (c *ControlPlaneConfigurationController) reconcile(ctx context.Context, con
 		if err != nil {
 			return fmt.Errorf("failed to reconcile external datastore certificates: %w", err)
 		}
+		if certificatesChanged {
+			restartServices = append(restartServices, "kube-apiserver")
+		}
 
 		// kube-apiserver arguments
 		updateArgs, deleteArgs := config.Datastore.ToKubeAPIServerArguments(c.snap)
-		argsChanged, err := snaputil.UpdateServiceArguments(c.snap, "kube-apiserver", updateArgs, deleteArgs)
-		if err != nil {
-			return fmt.Errorf("failed to update kube-apiserver datastore arguments: %w", err)
-		}
+		args.Set("kube-apiserver", updateArgs, deleteArgs)
+	}
 
-		if certificatesChanged || argsChanged {
-			if err := c.snap.RestartServices(ctx, []string{"kube-apiserver"}); err != nil {
-				return fmt.Errorf("failed to restart kube-apiserver to apply configuration: %w", err)
-			}
-		}
+	// kube-apiserver: OIDC authentication
+	if certificatesChanged, err := setup.EnsureOIDCPKI(c.snap, config.APIServer.GetOIDCCACert()); err != nil {
+		return fmt.Errorf("failed to reconcile OIDC CA certificate: %w", err)
+	} else if certificatesChanged {
+		restartServices = append(restartServices, "kube-apiserver")
+	}
+	updateArgs, deleteArgs := config.APIServer.ToKubeAPIServerOIDCArguments(c.snap)
+	args.Set("kube-apiserver", updateArgs, deleteArgs)
+
+	// kube-apiserver: audit logging
+	if filesChanged, err := setup.EnsureKubeAPIServerAuditConfig(c.snap, config.APIServer.GetAuditPolicy(), config.APIServer.GetAuditWebhookConfig()); err != nil {
+		return fmt.Errorf("failed to reconcile audit configuration: %w", err)
+	} else if filesChanged {
+		restartServices = append(restartServices, "kube-apiserver")
+	}
+	updateArgs, deleteArgs = config.APIServer.ToKubeAPIServerAuditArguments(c.snap)
+	args.Set("kube-apiserver", updateArgs, deleteArgs)
+
+	// kube-apiserver: admission
+	if filesChanged, err := setup.EnsureKubeAPIServerAdmissionConfig(c.snap, config.APIServer); err != nil {
+		return fmt.Errorf("failed to reconcile admission configuration: %w", err)
+	} else if filesChanged {
+		restartServices = append(restartServices, "kube-apiserver")
+	}
+	updateArgs, deleteArgs = config.APIServer.ToKubeAPIServerAdmissionArguments(c.snap)
+	args.Set("kube-apiserver", updateArgs, deleteArgs)
+
+	// kube-apiserver: secrets encryption
+	if fileChanged, err := setup.SecretsEncryptionConfig(c.snap, config); err != nil {
+		return fmt.Errorf("failed to reconcile secrets encryption: %w", err)
+	} else if fileChanged {
+		restartServices = append(restartServices, "kube-apiserver")
 	}
+	updateArgs, deleteArgs = config.ToKubeAPIServerSecretsEncryptionArguments(c.snap)
+	args.Set("kube-apiserver", updateArgs, deleteArgs)
 
 	// kube-controller-manager: cloud-provider
 	if v := config.Kubelet.CloudProvider; v != nil {
-		mustRestart, err := snaputil.UpdateServiceArguments(c.snap, "kube-controller-manager", map[string]string{"--cloud-provider": *v}, nil)
+		args.Set("kube-controller-manager", map[string]string{"--cloud-provider": *v}, nil)
+	}
+
+	// cluster-wide service arguments. these are set last, so that they take precedence over the arguments above
+	if config.ServiceArgs != nil {
+		nodeName, err := c.getNodeName(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get node name: %w", err)
+		}
+
+		services, err := setup.ServiceArgs(c.snap, "control-plane", types.ControlPlaneServices, config.ServiceArgs.ForNode(nodeName), args)
 		if err != nil {
-			return fmt.Errorf("failed to update kube-controller-manager arguments: %w", err)
+			return fmt.Errorf("failed to apply service arguments: %w", err)
 		}
+		restartServices = append(restartServices, services...)
+	}
+
+	changedServices, err := args.Apply(c.snap)
+	if err != nil {
+		return fmt.Errorf("failed to update service arguments: %w", err)
+	}
+	restartServices = append(restartServices, changedServices...)
 
-		if mustRestart {
-			if err := c.snap.RestartServices(ctx, []string{"kube-controller-manager"}); err != nil {
-				return fmt.Errorf("failed to restart kube-controller-manager to apply configuration: %w", err)
-			}
+	if len(restartServices) > 0 {
+		slices.Sort(restartServices)
+		restartServices = slices.Compact(restartServices)
+		if err := c.snap.RestartServices(ctx, restartServices); err != nil {
+			return fmt.Errorf("failed to restart %v to apply configuration: %w", restartServices, err)
 		}
 	}
 
@@ -27,16 +27,22 @@ func (c *configProvider) getConfig(ctx context.Context) (types.ClusterConfig, er
 	return c.config, nil
 }
 
+func getNodeName(ctx context.Context) (string, error) {
+	return "cp-1", nil
+}
+
 func TestControlPlaneConfigController(t *testing.T) {
 	t.Run("ControlPlane", func(t *testing.T) {
 		dir := t.TempDir()
 
 		s := &mock.Snap{
 			Mock: mock.Mock{
-				EtcdPKIDir:          filepath.Join(dir, "etcd-pki"),
-				ServiceArgumentsDir: filepath.Join(dir, "args"),
-				UID:                 os.Getuid(),
-				GID:                 os.Getgid(),
+				EtcdPKIDir:            filepath.Join(dir, "etcd-pki"),
+				KubernetesPKIDir:      filepath.Join(dir, "pki"),
+				ServiceArgumentsDir:   filepath.Join(dir, "args"),
+				ServiceExtraConfigDir: filepath.Join(dir, "args", "conf.d"),
+				UID:                   os.Getuid(),
+				GID:                   os.Getgid(),
 			},
 		}
 
@@ -49,7 +55,7 @@ func TestControlPlaneConfigController(t *testing.T) {
 		triggerCh := make(chan time.Time)
 		configProvider := &configProvider{}
 
-		ctrl := controllers.NewControlPlaneConfigurationController(s, func() {}, triggerCh)
+		ctrl := controllers.NewControlPlaneConfigurationController(s, func() {}, triggerCh, getNodeName)
 		go ctrl.Run(ctx, configProvider.getConfig)
 
 		for _, tc := range []struct {
@@ -172,6 +178,192 @@ func TestControlPlaneConfigController(t *testing.T) {
 				},
 				expectServiceRestarts: []string{"kube-apiserver", "kube-controller-manager"},
 			},
+			{
+				name: "OIDC",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					APIServer: types.APIServer{
+						OIDCIssuerURL:   utils.Pointer("https://issuer.example.com"),
+						OIDCClientID:    utils.Pointer("k8s"),
+						OIDCGroupsClaim: utils.Pointer("groups"),
+						OIDCCACert:      utils.Pointer("CA DATA"),
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--oidc-issuer-url":   "https://issuer.example.com",
+					"--oidc-client-id":    "k8s",
+					"--oidc-groups-claim": "groups",
+					"--oidc-ca-file":      filepath.Join(dir, "pki", "oidc-ca.crt"),
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "pki", "oidc-ca.crt"): true,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "DisableOIDC",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--oidc-issuer-url":   "",
+					"--oidc-client-id":    "",
+					"--oidc-groups-claim": "",
+					"--oidc-ca-file":      "",
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "pki", "oidc-ca.crt"): false,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "Audit",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					APIServer: types.APIServer{
+						AuditPolicy:        utils.Pointer("kind: Policy"),
+						AuditLogPath:       utils.Pointer("/var/log/audit.log"),
+						AuditLogMaxBackups: utils.Pointer(5),
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--audit-policy-file":   filepath.Join(dir, "args", "conf.d", "audit-policy.yaml"),
+					"--audit-log-path":      "/var/log/audit.log",
+					"--audit-log-maxbackup": "5",
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "args", "conf.d", "audit-policy.yaml"):  true,
+					filepath.Join(dir, "args", "conf.d", "audit-webhook.conf"): false,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "DisableAudit",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--audit-policy-file":   "",
+					"--audit-log-path":      "",
+					"--audit-log-maxbackup": "",
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "args", "conf.d", "audit-policy.yaml"): false,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "SecretsEncryption",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					APIServer: types.APIServer{
+						SecretsEncryptionProvider: utils.Pointer("aescbc"),
+					},
+					Certificates: types.Certificates{
+						SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{{Name: "key-1", Provider: "aescbc", Secret: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}},
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--encryption-provider-config": filepath.Join(dir, "args", "conf.d", "encryption-config.yaml"),
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "args", "conf.d", "encryption-config.yaml"): true,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "DisableSecretsEncryption",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--encryption-provider-config": "",
+				},
+				expectFilesToExist: map[string]bool{
+					filepath.Join(dir, "args", "conf.d", "encryption-config.yaml"): false,
+				},
+				expectServiceRestarts: []string{"kube-apiserver"},
+			},
+			{
+				name: "ServiceArgs",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					ServiceArgs: &types.ServiceArgs{
+						ServiceArgsConfig: types.ServiceArgsConfig{
+							Args: map[string]map[string]*string{
+								"kube-apiserver": {"--v": utils.Pointer("3"), "--event-ttl": utils.Pointer("2h")},
+								"kubelet":        {"--v": utils.Pointer("3")},
+							},
+						},
+						Nodes: map[string]types.ServiceArgsConfig{
+							"cp-1": {Args: map[string]map[string]*string{
+								"kube-apiserver":          {"--v": utils.Pointer("5")},
+								"kube-controller-manager": {"--v": utils.Pointer("4")},
+							}},
+							"cp-2": {Args: map[string]map[string]*string{
+								"kube-apiserver": {"--v": utils.Pointer("9")},
+							}},
+						},
+					},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--v":         "5",
+					"--event-ttl": "2h",
+				},
+				expectKubeControllerManagerArgs: map[string]string{
+					"--v": "4",
+				},
+				expectServiceRestarts: []string{"kube-apiserver", "kube-controller-manager"},
+			},
+			{
+				name: "RemoveServiceArgs",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					ServiceArgs: &types.ServiceArgs{},
+				},
+				expectKubeAPIServerArgs: map[string]string{
+					"--v":         "",
+					"--event-ttl": "",
+				},
+				expectKubeControllerManagerArgs: map[string]string{
+					"--v": "",
+				},
+				expectServiceRestarts: []string{"kube-apiserver", "kube-controller-manager"},
+			},
+			{
+				name: "NoServiceArgsUpdates",
+				config: types.ClusterConfig{
+					Datastore: types.Datastore{
+						Type:            utils.Pointer("external"),
+						ExternalServers: utils.Pointer([]string{"http://127.0.0.1:2379"}),
+					},
+					ServiceArgs: &types.ServiceArgs{},
+				},
+			},
 		} {
 			t.Run(tc.name, func(t *testing.T) {
 				g := NewWithT(t)
@@ -265,7 +457,7 @@ func TestControlPlaneConfigController(t *testing.T) {
 		triggerCh := make(chan time.Time)
 		configProvider := &configProvider{}
 
-		ctrl := controllers.NewControlPlaneConfigurationController(s, func() {}, triggerCh)
+		ctrl := controllers.NewControlPlaneConfigurationController(s, func() {}, triggerCh, getNodeName)
 		go ctrl.Run(ctx, configProvider.getConfig)
 
 		// mark as worker node
@@ -1,18 +1,61 @@
 package csrsigning
 
 import (
+	"net"
+	"time"
+
 	apiv1_annotations "github.com/canonical/k8s-snap-api/api/v1/annotations/csrsigning"
 	"github.com/canonical/k8s/pkg/k8sd/types"
+	certv1 "k8s.io/api/certificates/v1"
+)
+
+// The csrsigning annotations are parsed by types.ClusterConfig.CSRSigningConfig, so that they are validated with the cluster configuration.
+const (
+	AnnotationApprovalAllowedUsages      = types.AnnotationCSRSigningApprovalAllowedUsages
+	AnnotationApprovalAllowedDNSNames    = types.AnnotationCSRSigningApprovalAllowedDNSNames
+	AnnotationApprovalAllowedIPRanges    = types.AnnotationCSRSigningApprovalAllowedIPRanges
+	AnnotationApprovalMatchNodeAddresses = types.AnnotationCSRSigningApprovalMatchNodeAddresses
+	AnnotationApprovalRateLimit          = types.AnnotationCSRSigningApprovalRateLimit
+	AnnotationMaxValidity                = types.AnnotationCSRSigningMaxValidity
 )
 
 type internalConfig struct {
-	autoApprove bool
+	autoApprove    bool
+	approvalPolicy approvalPolicy
+	maxValidity    time.Duration
 }
 
-func internalConfigFromAnnotations(annotations types.Annotations) internalConfig {
+// approvalPolicy is the policy that CSRs must satisfy to be auto-approved.
+// Empty fields do not restrict the CSRs.
+type approvalPolicy struct {
+	allowedUsages      []certv1.KeyUsage
+	allowedDNSNames    []string
+	allowedIPRanges    []*net.IPNet
+	matchNodeAddresses bool
+
+	rateLimit       int
+	rateLimitPeriod time.Duration
+}
+
+// internalConfigFromAnnotations parses the csrsigning configuration of the cluster.
+func internalConfigFromAnnotations(annotations types.Annotations) (internalConfig, error) {
 	var cfg internalConfig
 	if v, ok := annotations.Get(apiv1_annotations.AnnotationAutoApprove); ok && v == "true" {
 		cfg.autoApprove = true
 	}
-	return cfg
+
+	config, err := types.ClusterConfig{Annotations: annotations}.CSRSigningConfig()
+	if err != nil {
+		return internalConfig{}, err
+	}
+	for _, usage := range config.ApprovalAllowedUsages {
+		cfg.approvalPolicy.allowedUsages = append(cfg.approvalPolicy.allowedUsages, certv1.KeyUsage(usage))
+	}
+	cfg.approvalPolicy.allowedDNSNames = config.ApprovalAllowedDNSNames
+	cfg.approvalPolicy.allowedIPRanges = config.ApprovalAllowedIPRanges
+	cfg.approvalPolicy.matchNodeAddresses = config.ApprovalMatchNodeAddresses
+	cfg.approvalPolicy.rateLimit = config.ApprovalRateLimit
+	cfg.approvalPolicy.rateLimitPeriod = config.ApprovalRateLimitPeriod
+	cfg.maxValidity = config.MaxValidity
+	return cfg, nil
 }
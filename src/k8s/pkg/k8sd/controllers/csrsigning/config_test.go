@@ -0,0 +1,59 @@
+package csrsigning
+
+import (
+	"testing"
+	"time"
+
+	apiv1_annotations "github.com/canonical/k8s-snap-api/api/v1/annotations/csrsigning"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+	certv1 "k8s.io/api/certificates/v1"
+)
+
+func TestInternalConfigFromAnnotations(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		cfg, err := internalConfigFromAnnotations(types.Annotations{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cfg).To(Equal(internalConfig{}))
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		g := NewWithT(t)
+		cfg, err := internalConfigFromAnnotations(types.Annotations{
+			apiv1_annotations.AnnotationAutoApprove: "true",
+			AnnotationApprovalAllowedUsages:         "server auth, digital signature,,",
+			AnnotationApprovalAllowedDNSNames:       "*.example.com",
+			AnnotationApprovalAllowedIPRanges:       "10.0.0.0/8,fd00::/64",
+			AnnotationApprovalMatchNodeAddresses:    "true",
+			AnnotationApprovalRateLimit:             "5/1h",
+			AnnotationMaxValidity:                   "720h",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cfg.autoApprove).To(BeTrue())
+		g.Expect(cfg.approvalPolicy.allowedUsages).To(Equal([]certv1.KeyUsage{certv1.UsageServerAuth, certv1.UsageDigitalSignature}))
+		g.Expect(cfg.approvalPolicy.allowedDNSNames).To(Equal([]string{"*.example.com"}))
+		g.Expect(cfg.approvalPolicy.allowedIPRanges).To(HaveLen(2))
+		g.Expect(cfg.approvalPolicy.matchNodeAddresses).To(BeTrue())
+		g.Expect(cfg.approvalPolicy.rateLimit).To(Equal(5))
+		g.Expect(cfg.approvalPolicy.rateLimitPeriod).To(Equal(time.Hour))
+		g.Expect(cfg.maxValidity).To(Equal(720 * time.Hour))
+	})
+
+	for _, tc := range []struct {
+		name        string
+		annotations types.Annotations
+	}{
+		{name: "InvalidIPRange", annotations: types.Annotations{AnnotationApprovalAllowedIPRanges: "10.0.0.1"}},
+		{name: "InvalidRateLimitFormat", annotations: types.Annotations{AnnotationApprovalRateLimit: "5"}},
+		{name: "InvalidRateLimitCount", annotations: types.Annotations{AnnotationApprovalRateLimit: "0/1h"}},
+		{name: "InvalidRateLimitPeriod", annotations: types.Annotations{AnnotationApprovalRateLimit: "5/hour"}},
+		{name: "InvalidMaxValidity", annotations: types.Annotations{AnnotationMaxValidity: "-1h"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := internalConfigFromAnnotations(tc.annotations)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
@@ -3,6 +3,10 @@ package csrsigning
 import "time"
 
 const (
+	// defaultKubeletServingCertificateValidity is the validity of kubelet serving certificates that do not request an expiration,
+	// the same as the default of kube-controller-manager.
+	defaultKubeletServingCertificateValidity = 365 * 24 * time.Hour
+
 	// requeueAfterSigningFailure is the time to requeue requests when any step of the signing process failed.
 	requeueAfterSigningFailure = 3 * time.Second
 
@@ -16,4 +20,16 @@ const (
 	// missingKeyFailedMessage provides the failure message used when the
 	// controller is unable to sign the CSR due to a missing CA private key.
 	missingKeyFailedMessage = "The CSR could not be signed because the controller is missing the CA private key."
+
+	// approvedReason is the reason of the Approved condition and event of auto-approved CSRs.
+	approvedReason = "K8sdApprove"
+
+	// deniedReason is the reason of the Denied condition and event of CSRs that are not valid.
+	deniedReason = "K8sdDeny"
+
+	// policyDeniedReason is the reason of the Denied condition and event of CSRs that do not satisfy the approval policy.
+	policyDeniedReason = "K8sdPolicyDeny"
+
+	// rateLimitedReason is the reason of the event of CSRs that wait for the approval rate limit of their node.
+	rateLimitedReason = "K8sdRateLimited"
 )
@@ -90,6 +90,7 @@ func (c *Controller) Run(ctx context.Context, getClusterConfig func(context.Cont
 		Manager:            mgr,
 		Logger:             mgr.GetLogger(),
 		Client:             mgr.GetClient(),
+		Recorder:           mgr.GetEventRecorderFor("k8sd-csrsigning"),
 		managedSignerNames: managedSignerNames,
 
 		getClusterConfig:     getClusterConfig,
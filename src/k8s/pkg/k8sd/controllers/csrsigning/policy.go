@@ -0,0 +1,137 @@
+package csrsigning
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	certv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkApprovalPolicy returns an error if the CSR does not satisfy the approval policy.
+// checkApprovalPolicy expects a CSR that has already been validated with validateCSR.
+func checkApprovalPolicy(ctx context.Context, c client.Client, obj *certv1.CertificateSigningRequest, csr *x509.CertificateRequest, policy approvalPolicy) error {
+	if len(policy.allowedUsages) > 0 {
+		allowed := sets.New(policy.allowedUsages...)
+		for _, usage := range obj.Spec.Usages {
+			if !allowed.Has(usage) {
+				return fmt.Errorf("usage %q is not allowed", usage)
+			}
+		}
+	}
+
+	if len(policy.allowedDNSNames) > 0 {
+		for _, dnsName := range csr.DNSNames {
+			if !slices.ContainsFunc(policy.allowedDNSNames, func(pattern string) bool { return matchDNSName(pattern, dnsName) }) {
+				return fmt.Errorf("DNS name %q does not match any of the allowed patterns %v", dnsName, policy.allowedDNSNames)
+			}
+		}
+	}
+
+	if len(policy.allowedIPRanges) > 0 {
+		for _, ip := range csr.IPAddresses {
+			if !slices.ContainsFunc(policy.allowedIPRanges, func(ipNet *net.IPNet) bool { return ipNet.Contains(ip) }) {
+				return fmt.Errorf("IP address %s is not in any of the allowed ranges", ip)
+			}
+		}
+	}
+
+	if policy.matchNodeAddresses && len(csr.DNSNames)+len(csr.IPAddresses) > 0 {
+		nodeName := csrNodeName(obj)
+		node := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("node %q does not exist", nodeName)
+			}
+			return fmt.Errorf("failed to get node %q: %w", nodeName, err)
+		}
+
+		hostnames := sets.New(node.Name)
+		var ips []net.IP
+		for _, address := range node.Status.Addresses {
+			switch address.Type {
+			case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+				hostnames.Insert(address.Address)
+			case corev1.NodeInternalIP, corev1.NodeExternalIP:
+				if ip := net.ParseIP(address.Address); ip != nil {
+					ips = append(ips, ip)
+				}
+			}
+		}
+
+		for _, dnsName := range csr.DNSNames {
+			if !hostnames.Has(dnsName) {
+				return fmt.Errorf("DNS name %q is not an address of node %q", dnsName, nodeName)
+			}
+		}
+		for _, ip := range csr.IPAddresses {
+			if !slices.ContainsFunc(ips, ip.Equal) {
+				return fmt.Errorf("IP address %s is not an address of node %q", ip, nodeName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// csrNodeName returns the name of the node that requested the CSR.
+// CSRs created by k8sd specify the node name in the k8sd.io/node annotation, CSRs created by the kubelet
+// are requested with the node credentials.
+func csrNodeName(obj *certv1.CertificateSigningRequest) string {
+	if nodeName, ok := obj.Annotations["k8sd.io/node"]; ok {
+		return nodeName
+	}
+	return strings.TrimPrefix(obj.Spec.Username, "system:node:")
+}
+
+// checkApprovalRateLimit returns the time to wait before the CSR can be approved without exceeding the
+// approval rate limit of its node. checkApprovalRateLimit returns zero if the CSR can be approved now.
+func checkApprovalRateLimit(ctx context.Context, c client.Client, obj *certv1.CertificateSigningRequest, policy approvalPolicy, now time.Time) (time.Duration, error) {
+	if policy.rateLimit <= 0 {
+		return 0, nil
+	}
+
+	var csrs certv1.CertificateSigningRequestList
+	if err := c.List(ctx, &csrs); err != nil {
+		return 0, fmt.Errorf("failed to list CSRs: %w", err)
+	}
+
+	nodeName := csrNodeName(obj)
+	windowStart := now.Add(-policy.rateLimitPeriod)
+
+	var approvals []time.Time
+	for _, item := range csrs.Items {
+		if item.Name == obj.Name || csrNodeName(&item) != nodeName {
+			continue
+		}
+		for _, condition := range item.Status.Conditions {
+			if condition.Type == certv1.CertificateApproved && condition.Reason == approvedReason && condition.LastUpdateTime.Time.After(windowStart) {
+				approvals = append(approvals, condition.LastUpdateTime.Time)
+			}
+		}
+	}
+
+	if len(approvals) < policy.rateLimit {
+		return 0, nil
+	}
+
+	// wait until enough approvals fall out of the rate limit period
+	slices.SortFunc(approvals, func(a, b time.Time) int { return a.Compare(b) })
+	return approvals[len(approvals)-policy.rateLimit].Add(policy.rateLimitPeriod).Sub(now), nil
+}
+
+// matchDNSName reports whether the DNS name matches the glob pattern.
+// Wildcards only match within a single label, e.g. "*.example.com" does not match "a.b.example.com".
+func matchDNSName(pattern string, dnsName string) bool {
+	match, err := path.Match(strings.ReplaceAll(pattern, ".", "/"), strings.ReplaceAll(dnsName, ".", "/"))
+	return err == nil && match
+}
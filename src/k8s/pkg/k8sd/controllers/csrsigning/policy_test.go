@@ -0,0 +1,192 @@
+package csrsigning
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	certv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckApprovalPolicy(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node1.example.com"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node).Build()
+
+	_, ipRange, err := net.ParseCIDR("10.0.0.0/24")
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	for _, tc := range []struct {
+		name        string
+		node        string
+		usages      []certv1.KeyUsage
+		dnsNames    []string
+		ips         []net.IP
+		policy      approvalPolicy
+		expectError string
+	}{
+		{
+			name:     "EmptyPolicy",
+			usages:   []certv1.KeyUsage{certv1.UsageServerAuth, certv1.UsageCodeSigning},
+			dnsNames: []string{"anything"},
+			ips:      []net.IP{net.ParseIP("192.168.0.1")},
+		},
+		{
+			name:   "AllowedUsages",
+			usages: []certv1.KeyUsage{certv1.UsageServerAuth, certv1.UsageDigitalSignature},
+			policy: approvalPolicy{allowedUsages: []certv1.KeyUsage{certv1.UsageServerAuth, certv1.UsageDigitalSignature, certv1.UsageKeyEncipherment}},
+		},
+		{
+			name:        "DisallowedUsage",
+			usages:      []certv1.KeyUsage{certv1.UsageServerAuth, certv1.UsageCodeSigning},
+			policy:      approvalPolicy{allowedUsages: []certv1.KeyUsage{certv1.UsageServerAuth}},
+			expectError: `usage "code signing" is not allowed`,
+		},
+		{
+			name:     "AllowedDNSNames",
+			dnsNames: []string{"node1", "node1.example.com"},
+			policy:   approvalPolicy{allowedDNSNames: []string{"node*", "*.example.com"}},
+		},
+		{
+			name:        "DisallowedDNSName",
+			dnsNames:    []string{"node1", "a.node1.example.com"},
+			policy:      approvalPolicy{allowedDNSNames: []string{"node*", "*.example.com"}},
+			expectError: `DNS name "a.node1.example.com" does not match`,
+		},
+		{
+			name:   "AllowedIPRange",
+			ips:    []net.IP{net.ParseIP("10.0.0.10")},
+			policy: approvalPolicy{allowedIPRanges: []*net.IPNet{ipRange}},
+		},
+		{
+			name:        "DisallowedIPRange",
+			ips:         []net.IP{net.ParseIP("10.0.1.10")},
+			policy:      approvalPolicy{allowedIPRanges: []*net.IPNet{ipRange}},
+			expectError: "IP address 10.0.1.10 is not in any of the allowed ranges",
+		},
+		{
+			name:     "MatchNodeAddresses",
+			node:     "node1",
+			dnsNames: []string{"node1", "node1.example.com"},
+			ips:      []net.IP{net.ParseIP("10.0.0.1")},
+			policy:   approvalPolicy{matchNodeAddresses: true},
+		},
+		{
+			name:        "MatchNodeAddresses/WrongDNSName",
+			node:        "node1",
+			dnsNames:    []string{"node2"},
+			policy:      approvalPolicy{matchNodeAddresses: true},
+			expectError: `DNS name "node2" is not an address of node "node1"`,
+		},
+		{
+			name:        "MatchNodeAddresses/WrongIP",
+			node:        "node1",
+			ips:         []net.IP{net.ParseIP("10.0.0.2")},
+			policy:      approvalPolicy{matchNodeAddresses: true},
+			expectError: `IP address 10.0.0.2 is not an address of node "node1"`,
+		},
+		{
+			name:        "MatchNodeAddresses/NodeNotFound",
+			node:        "node2",
+			dnsNames:    []string{"node2"},
+			policy:      approvalPolicy{matchNodeAddresses: true},
+			expectError: `node "node2" does not exist`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &certv1.CertificateSigningRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"k8sd.io/node": tc.node},
+				},
+				Spec: certv1.CertificateSigningRequestSpec{Usages: tc.usages},
+			}
+			csr := &x509.CertificateRequest{DNSNames: tc.dnsNames, IPAddresses: tc.ips}
+
+			err := checkApprovalPolicy(context.Background(), c, obj, csr, tc.policy)
+			if tc.expectError == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectError)))
+			}
+		})
+	}
+}
+
+func TestCheckApprovalRateLimit(t *testing.T) {
+	// LastUpdateTime is stored with second precision
+	now := time.Now().Truncate(time.Second)
+
+	approvedCSR := func(name string, node string, approvedAt time.Time) *certv1.CertificateSigningRequest {
+		return &certv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{"k8sd.io/node": node},
+			},
+			Status: certv1.CertificateSigningRequestStatus{
+				Conditions: []certv1.CertificateSigningRequestCondition{
+					{
+						Type:           certv1.CertificateApproved,
+						Status:         corev1.ConditionTrue,
+						Reason:         approvedReason,
+						LastUpdateTime: metav1.NewTime(approvedAt),
+					},
+				},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		approvedCSR("csr-1", "node1", now.Add(-50*time.Minute)),
+		approvedCSR("csr-2", "node1", now.Add(-10*time.Minute)),
+		approvedCSR("csr-3", "node1", now.Add(-2*time.Hour)),
+		approvedCSR("csr-4", "node2", now.Add(-5*time.Minute)),
+	).Build()
+
+	newCSR := &certv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "csr-new",
+			Annotations: map[string]string{"k8sd.io/node": "node1"},
+		},
+	}
+
+	t.Run("NoLimit", func(t *testing.T) {
+		g := NewWithT(t)
+		wait, err := checkApprovalRateLimit(context.Background(), c, newCSR, approvalPolicy{}, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(wait).To(BeZero())
+	})
+
+	t.Run("BelowLimit", func(t *testing.T) {
+		g := NewWithT(t)
+		wait, err := checkApprovalRateLimit(context.Background(), c, newCSR, approvalPolicy{rateLimit: 3, rateLimitPeriod: time.Hour}, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(wait).To(BeZero())
+	})
+
+	t.Run("AboveLimit", func(t *testing.T) {
+		g := NewWithT(t)
+		wait, err := checkApprovalRateLimit(context.Background(), c, newCSR, approvalPolicy{rateLimit: 2, rateLimitPeriod: time.Hour}, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(wait).To(Equal(10 * time.Minute))
+
+		wait, err = checkApprovalRateLimit(context.Background(), c, newCSR, approvalPolicy{rateLimit: 1, rateLimitPeriod: time.Hour}, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(wait).To(Equal(50 * time.Minute))
+	})
+}
@@ -63,10 +63,33 @@ func (r *csrSigningReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "Failed to retrieve k8sd cluster configuration")
 		return ctrl.Result{}, err
 	}
-	internal := internalConfigFromAnnotations(config.Annotations)
+	// the configuration is validated when it is set, an invalid configuration only disables the auto-approval of CSRs.
+	// approved CSRs are still signed.
+	internal, err := internalConfigFromAnnotations(config.Annotations)
+	manualApproval := err != nil
+	if manualApproval {
+		log.Error(err, "Invalid csrsigning configuration, CSRs must be approved manually")
+	}
+
+	// kubelet serving CSRs are only handled while kubelet serving certificate rotation is enabled
+	if obj.Spec.SignerName == kubeletServingSignerName && !config.KubeletServerCertificateRotationEnabled() {
+		log.V(1).Info("Ignoring kubelet serving CSR, kubelet serving certificate rotation is disabled")
+		return ctrl.Result{}, nil
+	}
 
 	if !approved {
 		log.Info("CSR is not approved")
+		if manualApproval {
+			log.Info("Requeue while waiting for CSR to be approved")
+			return ctrl.Result{RequeueAfter: requeueAfterWaitingForApproved}, nil
+		}
+		if obj.Spec.SignerName == kubeletServingSignerName {
+			// kubelet serving CSRs are requested with the node credentials, and their addresses
+			// must match the addresses of the node.
+			policy := internal.approvalPolicy
+			policy.matchNodeAddresses = true
+			return r.reconcileAutoApprove(ctx, log, obj, nil, policy, r.Client, r.Recorder)
+		}
 		if internal.autoApprove {
 			log.V(1).Info("CSR auto-approval is enabled")
 			keyPEM := config.Certificates.GetK8sdPrivateKey()
@@ -79,7 +102,7 @@ func (r *csrSigningReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to load cluster RSA key: %w", err)
 			}
-			return r.reconcileAutoApprove(ctx, log, obj, priv, r.Client)
+			return r.reconcileAutoApprove(ctx, log, obj, priv, internal.approvalPolicy, r.Client, r.Recorder)
 		}
 
 		log.Info("Requeue while waiting for CSR to be approved")
@@ -100,17 +123,11 @@ func (r *csrSigningReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	notBefore := time.Now()
-	var notAfter time.Time
-
-	if obj.Spec.ExpirationSeconds != nil {
-		notAfter = utils.SecondsToExpirationDate(notBefore, int(*obj.Spec.ExpirationSeconds))
-	} else {
-		notAfter = time.Now().AddDate(10, 0, 0)
-	}
+	notAfter := certificateNotAfter(obj, notBefore, internal.maxValidity)
 
 	var crtPEM []byte
 	switch obj.Spec.SignerName {
-	case "k8sd.io/kubelet-serving":
+	case "k8sd.io/kubelet-serving", kubeletServingSignerName:
 		caCert, caKey, err := pkiutil.LoadCertificate(config.Certificates.GetCACert(), config.Certificates.GetCAKey())
 		if err != nil {
 			log.Error(err, "Failed to load CA certificate and key")
@@ -252,3 +269,22 @@ func setFailedCSR(csr *certv1.CertificateSigningRequest, reason string, message
 
 	csr.Status.Conditions = append(csr.Status.Conditions, failedCondition)
 }
+
+// certificateNotAfter returns the expiration date of the certificate signed for a CSR.
+// CSRs that do not request an expiration are signed for one year (kubelet serving certificates) or ten years.
+// The expiration date is capped by maxValidity, if set.
+func certificateNotAfter(obj *certv1.CertificateSigningRequest, notBefore time.Time, maxValidity time.Duration) time.Time {
+	var notAfter time.Time
+	switch {
+	case obj.Spec.ExpirationSeconds != nil:
+		notAfter = utils.SecondsToExpirationDate(notBefore, int(*obj.Spec.ExpirationSeconds))
+	case obj.Spec.SignerName == kubeletServingSignerName:
+		notAfter = notBefore.Add(defaultKubeletServingCertificateValidity)
+	default:
+		notAfter = notBefore.AddDate(10, 0, 0)
+	}
+	if maxValidity > 0 && notAfter.After(notBefore.Add(maxValidity)) {
+		notAfter = notBefore.Add(maxValidity)
+	}
+	return notAfter
+}
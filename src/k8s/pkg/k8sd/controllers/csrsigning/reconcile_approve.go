@@ -4,48 +4,90 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"time"
 
 	"github.com/canonical/k8s/pkg/log"
+	pkiutil "github.com/canonical/k8s/pkg/utils/pki"
 	certv1 "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func reconcileAutoApprove(ctx context.Context, log log.Logger, csr *certv1.CertificateSigningRequest,
-	priv *rsa.PrivateKey, client client.Client,
+	priv *rsa.PrivateKey, policy approvalPolicy, client client.Client, recorder record.EventRecorder,
 ) (ctrl.Result, error) {
 	var result certv1.RequestConditionType
+	var reason, message string
 
-	if err := validateCSR(csr, priv); err != nil {
+	var validateErr error
+	if csr.Spec.SignerName == kubeletServingSignerName {
+		validateErr = validateKubeletServingCSR(csr)
+	} else {
+		validateErr = validateCSR(csr, priv)
+	}
+
+	if err := validateErr; err != nil {
 		log.Error(err, "CSR is not valid")
 
 		result = certv1.CertificateDenied
-		csr.Status.Conditions = append(csr.Status.Conditions,
-			certv1.CertificateSigningRequestCondition{
-				Type:    certv1.CertificateDenied,
-				Status:  v1.ConditionTrue,
-				Reason:  "K8sdDeny",
-				Message: fmt.Sprintf("CSR is not valid: %v", err.Error()),
-			},
-		)
+		reason = deniedReason
+		message = fmt.Sprintf("CSR is not valid: %v", err.Error())
+	} else if err := checkCSRApprovalPolicy(ctx, client, csr, policy); err != nil {
+		log.Error(err, "CSR does not satisfy the approval policy")
+
+		result = certv1.CertificateDenied
+		reason = policyDeniedReason
+		message = fmt.Sprintf("CSR does not satisfy the approval policy: %v", err.Error())
 	} else {
+		wait, err := checkApprovalRateLimit(ctx, client, csr, policy, time.Now())
+		if err != nil {
+			log.Error(err, "Failed to check CSR approval rate limit")
+			return ctrl.Result{}, err
+		}
+		if wait > 0 {
+			log.WithValues("wait", wait).Info("CSR approval rate limit exceeded for node")
+			recorder.Eventf(csr, v1.EventTypeWarning, rateLimitedReason, "Approval rate limit exceeded for node %q, retrying in %v", csrNodeName(csr), wait.Round(time.Second))
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+
 		result = certv1.CertificateApproved
-		csr.Status.Conditions = append(csr.Status.Conditions,
-			certv1.CertificateSigningRequestCondition{
-				Type:    certv1.CertificateApproved,
-				Status:  v1.ConditionTrue,
-				Reason:  "K8sdApprove",
-				Message: "CSR approved by k8sd",
-			},
-		)
+		reason = approvedReason
+		message = "CSR approved by k8sd"
 	}
 
+	csr.Status.Conditions = append(csr.Status.Conditions,
+		certv1.CertificateSigningRequestCondition{
+			Type:           result,
+			Status:         v1.ConditionTrue,
+			Reason:         reason,
+			Message:        message,
+			LastUpdateTime: metav1.Now(),
+		},
+	)
+
 	log = log.WithValues("result", result)
 	if err := client.SubResource("approval").Update(ctx, csr); err != nil {
 		log.Error(err, "Failed to update CSR approval status")
 		return ctrl.Result{}, err
 	}
 	log.Info("Updated CSR approval status")
+
+	if result == certv1.CertificateApproved {
+		recorder.Event(csr, v1.EventTypeNormal, reason, message)
+	} else {
+		recorder.Event(csr, v1.EventTypeWarning, reason, message)
+	}
 	return ctrl.Result{}, nil
 }
+
+// checkCSRApprovalPolicy parses the certificate request of the CSR and checks it against the approval policy.
+func checkCSRApprovalPolicy(ctx context.Context, client client.Client, csr *certv1.CertificateSigningRequest, policy approvalPolicy) error {
+	certRequest, err := pkiutil.LoadCertificateRequest(string(csr.Spec.Request))
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+	return checkApprovalPolicy(ctx, client, csr, certRequest, policy)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509/pkix"
 	"errors"
+	"net"
 	"testing"
 
 	k8smock "github.com/canonical/k8s/pkg/k8sd/controllers/csrsigning/test"
@@ -15,7 +16,10 @@ import (
 	certv1 "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAutoApprove(t *testing.T) {
@@ -129,12 +133,15 @@ func TestAutoApprove(t *testing.T) {
 				nil, // we don't call get in reconcileAutoApprove
 			)
 
+			recorder := record.NewFakeRecorder(10)
 			result, err := reconcileAutoApprove(
 				context.Background(),
 				log.L(),
 				&tc.csr,
 				key,
+				approvalPolicy{},
 				k8sM,
+				recorder,
 			)
 
 			g := NewWithT(t)
@@ -142,8 +149,10 @@ func TestAutoApprove(t *testing.T) {
 			g.Expect(result).To(Equal(tc.expectResult))
 			if tc.expectErr == nil {
 				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(tc.expectCondition.Reason)))
 			} else {
 				g.Expect(err).To(MatchError(tc.expectErr))
+				g.Expect(recorder.Events).ToNot(Receive())
 			}
 			g.Expect(containsCondition(tc.csr.Status.Conditions, tc.expectCondition)).To(BeTrue(), "expected condition not found")
 		})
@@ -160,3 +169,95 @@ func containsCondition(cc []certv1.CertificateSigningRequestCondition, c certv1.
 	}
 	return false
 }
+
+func TestAutoApproveKubeletServing(t *testing.T) {
+	g := NewWithT(t)
+
+	csrPEM, _, err := pkiutil.GenerateCSR(
+		pkix.Name{
+			CommonName:   "system:node:node1",
+			Organization: []string{"system:nodes"},
+		},
+		2048,
+		[]string{"node1"},
+		[]net.IP{net.ParseIP("10.0.0.1")},
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeHostName, Address: "node1"},
+				{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		username     string
+		nodeAddress  string
+		expectReason string
+		expectType   certv1.RequestConditionType
+	}{
+		{
+			name:         "Valid",
+			username:     "system:node:node1",
+			nodeAddress:  "10.0.0.1",
+			expectType:   certv1.CertificateApproved,
+			expectReason: "K8sdApprove",
+		},
+		{
+			name:         "NotANode",
+			username:     "admin",
+			nodeAddress:  "10.0.0.1",
+			expectType:   certv1.CertificateDenied,
+			expectReason: "K8sdDeny",
+		},
+		{
+			name:         "AddressMismatch",
+			username:     "system:node:node1",
+			nodeAddress:  "10.0.0.2",
+			expectType:   certv1.CertificateDenied,
+			expectReason: "K8sdPolicyDeny",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			node := node.DeepCopy()
+			node.Status.Addresses[1].Address = tc.nodeAddress
+			csr := &certv1.CertificateSigningRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "csr-1"},
+				Spec: certv1.CertificateSigningRequestSpec{
+					Request:    []byte(csrPEM),
+					Username:   tc.username,
+					Groups:     []string{"system:nodes", "system:authenticated"},
+					SignerName: certv1.KubeletServingSignerName,
+					Usages:     []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node, csr).Build()
+
+			recorder := record.NewFakeRecorder(10)
+			result, err := reconcileAutoApprove(
+				context.Background(),
+				log.L(),
+				csr,
+				nil,
+				approvalPolicy{matchNodeAddresses: true},
+				c,
+				recorder,
+			)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(ctrl.Result{}))
+			g.Expect(containsCondition(csr.Status.Conditions, certv1.CertificateSigningRequestCondition{
+				Type:   tc.expectType,
+				Status: v1.ConditionTrue,
+				Reason: tc.expectReason,
+			})).To(BeTrue(), "expected condition not found")
+			g.Expect(recorder.Events).To(Receive(ContainSubstring(tc.expectReason)))
+		})
+	}
+}
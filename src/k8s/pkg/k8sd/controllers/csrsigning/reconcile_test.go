@@ -18,6 +18,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -305,7 +306,7 @@ func TestNotApprovedCSR(t *testing.T) {
 					},
 				}, nil
 			},
-			reconcileAutoApprove: func(ctx context.Context, l log.Logger, csr *certv1.CertificateSigningRequest, pk *rsa.PrivateKey, c client.Client) (ctrl.Result, error) {
+			reconcileAutoApprove: func(ctx context.Context, l log.Logger, csr *certv1.CertificateSigningRequest, pk *rsa.PrivateKey, p approvalPolicy, c client.Client, r record.EventRecorder) (ctrl.Result, error) {
 				called = true
 				return ctrl.Result{}, nil
 			},
@@ -315,6 +316,49 @@ func TestNotApprovedCSR(t *testing.T) {
 
 		g.Expect(called).To(BeTrue())
 	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		managedSigner := "managed-signer"
+		k8sM := k8smock.New(
+			t,
+			k8smock.NewSubResourceClientMock(nil),
+			certv1.CertificateSigningRequest{
+				Spec: certv1.CertificateSigningRequestSpec{
+					SignerName: managedSigner,
+				},
+			},
+			nil,
+		)
+
+		var called bool
+		reconciler := &csrSigningReconciler{
+			Client: k8sM,
+			managedSignerNames: map[string]struct{}{
+				managedSigner: {},
+			},
+			getClusterConfig: func(context.Context) (types.ClusterConfig, error) {
+				return types.ClusterConfig{
+					Annotations: map[string]string{
+						apiv1_annotations.AnnotationAutoApprove: "true",
+						AnnotationApprovalRateLimit:             "5",
+					},
+				}, nil
+			},
+			reconcileAutoApprove: func(ctx context.Context, l log.Logger, csr *certv1.CertificateSigningRequest, pk *rsa.PrivateKey, p approvalPolicy, c client.Client, r record.EventRecorder) (ctrl.Result, error) {
+				called = true
+				return ctrl.Result{}, nil
+			},
+		}
+
+		g := NewWithT(t)
+
+		result, err := reconciler.Reconcile(context.Background(), getDefaultRequest())
+
+		// CSRs are not auto-approved with an invalid configuration
+		g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: requeueAfterWaitingForApproved}))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(called).To(BeFalse())
+	})
 }
 
 func TestInvalidCSR(t *testing.T) {
@@ -507,36 +551,79 @@ func TestUpdateCSRSucceed(t *testing.T) {
 		},
 	}
 
-	k8sM := k8smock.New(
-		t,
-		k8smock.NewSubResourceClientMock(nil),
-		csr,
-		nil,
-	)
-
 	caCert, caKey, err := pkiutil.GenerateSelfSignedCA(pkix.Name{CommonName: "kubernetes-ca"}, time.Now(), time.Now().AddDate(10, 0, 0), 2048)
 	g.Expect(err).ToNot(HaveOccurred())
 
-	reconciler := &csrSigningReconciler{
-		Client: k8sM,
-		managedSignerNames: map[string]struct{}{
-			managedSigner: {},
-		},
-		getClusterConfig: func(context.Context) (types.ClusterConfig, error) {
-			return types.ClusterConfig{
-				Certificates: types.Certificates{
-					CACert: ptr.To(caCert),
-					CAKey:  ptr.To(caKey),
+	for _, tc := range []struct {
+		name        string
+		annotations types.Annotations
+	}{
+		{name: "Default"},
+		// approved CSRs are signed even if the csrsigning configuration is invalid
+		{name: "InvalidConfig", annotations: types.Annotations{AnnotationMaxValidity: "forever"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k8sM := k8smock.New(
+				t,
+				k8smock.NewSubResourceClientMock(nil),
+				csr,
+				nil,
+			)
+
+			reconciler := &csrSigningReconciler{
+				Client: k8sM,
+				managedSignerNames: map[string]struct{}{
+					managedSigner: {},
 				},
-			}, nil
-		},
-	}
+				getClusterConfig: func(context.Context) (types.ClusterConfig, error) {
+					return types.ClusterConfig{
+						Annotations: tc.annotations,
+						Certificates: types.Certificates{
+							CACert: ptr.To(caCert),
+							CAKey:  ptr.To(caKey),
+						},
+					}, nil
+				},
+			}
 
-	result, err := reconciler.Reconcile(context.Background(), getDefaultRequest())
+			result, err := reconciler.Reconcile(context.Background(), getDefaultRequest())
 
-	g.Expect(result).To(Equal(ctrl.Result{}))
-	g.Expect(err).ToNot(HaveOccurred())
-	k8sM.AssertUpdateCalled(t)
+			g.Expect(result).To(Equal(ctrl.Result{}))
+			g.Expect(err).ToNot(HaveOccurred())
+			k8sM.AssertUpdateCalled(t)
+		})
+	}
+}
+
+func TestCertificateNotAfter(t *testing.T) {
+	notBefore := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name              string
+		signerName        string
+		expirationSeconds *int32
+		maxValidity       time.Duration
+		expected          time.Time
+	}{
+		{name: "Default", signerName: "k8sd.io/kubelet-client", expected: notBefore.AddDate(10, 0, 0)},
+		{name: "KubeletServing", signerName: certv1.KubeletServingSignerName, expected: notBefore.Add(365 * 24 * time.Hour)},
+		{name: "ExpirationSeconds", signerName: certv1.KubeletServingSignerName, expirationSeconds: ptr.To(int32(3600)), expected: notBefore.Add(time.Hour)},
+		{name: "MaxValidity", signerName: "k8sd.io/kubelet-client", maxValidity: 720 * time.Hour, expected: notBefore.Add(720 * time.Hour)},
+		{name: "MaxValidityNotExceeded", signerName: "k8sd.io/kubelet-client", expirationSeconds: ptr.To(int32(3600)), maxValidity: 720 * time.Hour, expected: notBefore.Add(time.Hour)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &certv1.CertificateSigningRequest{
+				Spec: certv1.CertificateSigningRequestSpec{
+					SignerName:        tc.signerName,
+					ExpirationSeconds: tc.expirationSeconds,
+				},
+			}
+			g.Expect(certificateNotAfter(obj, notBefore, tc.maxValidity)).To(Equal(tc.expected))
+		})
+	}
 }
 
 func getDefaultRequest() ctrl.Request {
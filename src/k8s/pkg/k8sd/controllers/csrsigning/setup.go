@@ -8,6 +8,7 @@ import (
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/go-logr/logr"
 	certv1 "k8s.io/api/certificates/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -18,16 +19,22 @@ type csrSigningReconciler struct {
 	Manager            manager.Manager
 	Logger             logr.Logger
 	Client             client.Client
+	Recorder           record.EventRecorder
 	managedSignerNames map[string]struct{}
 
 	getClusterConfig     func(context.Context) (types.ClusterConfig, error)
-	reconcileAutoApprove func(context.Context, log.Logger, *certv1.CertificateSigningRequest, *rsa.PrivateKey, client.Client) (ctrl.Result, error)
+	reconcileAutoApprove func(context.Context, log.Logger, *certv1.CertificateSigningRequest, *rsa.PrivateKey, approvalPolicy, client.Client, record.EventRecorder) (ctrl.Result, error)
 }
 
+// kubeletServingSignerName is the signer of the serving certificates that kubelets request when
+// server certificate rotation is enabled.
+const kubeletServingSignerName = certv1.KubeletServingSignerName
+
 var managedSignerNames = map[string]struct{}{
 	"k8sd.io/kubelet-serving":   {},
 	"k8sd.io/kubelet-client":    {},
 	"k8sd.io/kube-proxy-client": {},
+	kubeletServingSignerName:    {},
 }
 
 func (r *csrSigningReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -6,6 +6,7 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/canonical/k8s/pkg/utils"
 	pkiutil "github.com/canonical/k8s/pkg/utils/pki"
@@ -102,3 +103,40 @@ func validateCSR(obj *certv1.CertificateSigningRequest, priv *rsa.PrivateKey) er
 	}
 	return nil
 }
+
+// validateKubeletServingCSR checks a kubelet serving CSR created by the kubelet and returns an error if it fails.
+// The requestor is authenticated by the node credentials, so the addresses of the CSR must be checked against the
+// Node object separately.
+func validateKubeletServingCSR(obj *certv1.CertificateSigningRequest) error {
+	csr, err := pkiutil.LoadCertificateRequest(string(obj.Spec.Request))
+	if err != nil {
+		return fmt.Errorf("failed to parse x509 certificate request: %w", err)
+	}
+
+	hostname, ok := strings.CutPrefix(obj.Spec.Username, "system:node:")
+	if !ok || hostname == "" {
+		return fmt.Errorf("CSR requestor %q is not a node", obj.Spec.Username)
+	}
+	if !sets.New(obj.Spec.Groups...).Has("system:nodes") {
+		return fmt.Errorf("CSR missing required group system:nodes")
+	}
+
+	// kubelets request ECDSA keys, so key encipherment is optional
+	allowedUsages := sets.New(certv1.UsageServerAuth, certv1.UsageDigitalSignature, certv1.UsageKeyEncipherment)
+	if usages := sets.New(obj.Spec.Usages...); !usages.Has(certv1.UsageServerAuth) || !allowedUsages.IsSuperset(usages) {
+		return fmt.Errorf("CSR usages %v must include %v and be a subset of %v", obj.Spec.Usages, certv1.UsageServerAuth, sets.List(allowedUsages))
+	}
+	if csr.Subject.CommonName != obj.Spec.Username {
+		return fmt.Errorf("CSR commonName %v must match %v", csr.Subject.CommonName, obj.Spec.Username)
+	}
+	if !sets.New(csr.Subject.Organization...).Equal(sets.New("system:nodes")) {
+		return fmt.Errorf("CSR organization %v must match %v", csr.Subject.Organization, []string{"system:nodes"})
+	}
+	if len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return fmt.Errorf("CSR must not contain email or URI SANs")
+	}
+	if len(csr.DNSNames) == 0 && len(csr.IPAddresses) == 0 {
+		return fmt.Errorf("CSR must contain at least one DNS or IP SAN")
+	}
+	return nil
+}
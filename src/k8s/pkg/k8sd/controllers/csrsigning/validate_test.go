@@ -222,3 +222,112 @@ func mustCreateEncryptedSignature(g Gomega, pub *rsa.PublicKey, csrPEM string) s
 
 	return base64.StdEncoding.EncodeToString(signature)
 }
+
+func TestValidateKubeletServingCSR(t *testing.T) {
+	g := NewWithT(t)
+
+	csrPEM, _, err := pkiutil.GenerateCSR(
+		pkix.Name{
+			CommonName:   "system:node:node1",
+			Organization: []string{"system:nodes"},
+		},
+		2048,
+		[]string{"node1"},
+		nil,
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	noSANsPEM, _, err := pkiutil.GenerateCSR(
+		pkix.Name{
+			CommonName:   "system:node:node1",
+			Organization: []string{"system:nodes"},
+		},
+		2048,
+		nil,
+		nil,
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, tc := range []struct {
+		name             string
+		request          string
+		username         string
+		groups           []string
+		usages           []certv1.KeyUsage
+		expectErrMessage string
+	}{
+		{
+			name:     "Valid",
+			request:  csrPEM,
+			username: "system:node:node1",
+			groups:   []string{"system:nodes", "system:authenticated"},
+			usages:   []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+		},
+		{
+			name:     "ValidKeyEncipherment",
+			request:  csrPEM,
+			username: "system:node:node1",
+			groups:   []string{"system:nodes"},
+			usages:   []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageKeyEncipherment, certv1.UsageServerAuth},
+		},
+		{
+			name:             "NotANode",
+			request:          csrPEM,
+			username:         "admin",
+			groups:           []string{"system:nodes"},
+			usages:           []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+			expectErrMessage: "is not a node",
+		},
+		{
+			name:             "MissingGroup",
+			request:          csrPEM,
+			username:         "system:node:node1",
+			groups:           []string{"system:authenticated"},
+			usages:           []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+			expectErrMessage: "missing required group",
+		},
+		{
+			name:             "ClientAuthUsage",
+			request:          csrPEM,
+			username:         "system:node:node1",
+			groups:           []string{"system:nodes"},
+			usages:           []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth, certv1.UsageClientAuth},
+			expectErrMessage: "usages",
+		},
+		{
+			name:             "CommonNameMismatch",
+			request:          csrPEM,
+			username:         "system:node:node2",
+			groups:           []string{"system:nodes"},
+			usages:           []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+			expectErrMessage: "commonName",
+		},
+		{
+			name:             "NoSANs",
+			request:          noSANsPEM,
+			username:         "system:node:node1",
+			groups:           []string{"system:nodes"},
+			usages:           []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageServerAuth},
+			expectErrMessage: "at least one DNS or IP SAN",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateKubeletServingCSR(&certv1.CertificateSigningRequest{
+				Spec: certv1.CertificateSigningRequestSpec{
+					Request:    []byte(tc.request),
+					Username:   tc.username,
+					Groups:     tc.groups,
+					SignerName: certv1.KubeletServingSignerName,
+					Usages:     tc.usages,
+				},
+			})
+			if tc.expectErrMessage == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectErrMessage)))
+			}
+		})
+	}
+}
@@ -185,6 +185,13 @@ func (c *FeatureController) reconcile(
 	}
 
 	status, applyErr := apply(cfg)
+	applied := types.FeatureCondition{Type: types.FeatureConditionApplied, Status: types.ConditionTrue, Reason: "Reconciled", Message: status.Message}
+	if applyErr != nil {
+		applied.Status = types.ConditionFalse
+		applied.Reason = "ApplyFailed"
+	}
+	status.SetCondition(applied)
+
 	if err := updateFeatureStatus(ctx, status); err != nil {
 		// NOTE (hue): status update errors are not returned but only logged. we might need some retry logic in the future.
 		log.FromContext(ctx).WithValues("message", status.Message, "applied-successfully", applyErr == nil).Error(err, "Failed to update feature status")
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/canonical/k8s/pkg/client/helm"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+)
+
+// driftCheckTimeout is the maximum amount of time the drift check of a single feature may take.
+const driftCheckTimeout = time.Minute
+
+// FeatureDriftController periodically compares the manifests of the enabled features with the live objects
+// on the cluster and records the result in the "InSync" condition of the feature status.
+// Depending on the drift policy of the cluster, drifted features are re-applied by the feature controller.
+type FeatureDriftController struct {
+	snap      snap.Snap
+	waitReady func()
+	triggerCh <-chan time.Time
+	charts    map[types.FeatureName][]helm.InstallableChart
+	// reconciledCh is used to notify that the controller has finished its reconciliation loop.
+	reconciledCh chan struct{}
+}
+
+// NewFeatureDriftController creates a new controller.
+// triggerCh is typically a `time.NewTicker(<duration>).C`.
+// charts maps each feature to the Helm charts that it deploys.
+func NewFeatureDriftController(snap snap.Snap, waitReady func(), triggerCh <-chan time.Time, charts map[types.FeatureName][]helm.InstallableChart) *FeatureDriftController {
+	return &FeatureDriftController{
+		snap:         snap,
+		waitReady:    waitReady,
+		triggerCh:    triggerCh,
+		charts:       charts,
+		reconciledCh: make(chan struct{}, 1),
+	}
+}
+
+// Run starts the controller.
+// Run accepts a function that retrieves the cluster configuration, a function that retrieves the current
+// feature statuses, a function that sets a condition on the status of a feature, a function that acquires
+// the cluster-wide repair lease and a function that triggers the feature controller to re-apply a feature.
+// Drifted features are only repaired by the control plane node that holds the repair lease, so that the
+// control plane nodes do not re-apply the same feature at the same time.
+// Run will loop every time the trigger channel is.
+func (c *FeatureDriftController) Run(
+	ctx context.Context,
+	getClusterConfig func(context.Context) (types.ClusterConfig, error),
+	getFeatureStatuses func(context.Context) (map[types.FeatureName]types.FeatureStatus, error),
+	setFeatureCondition func(context.Context, types.FeatureName, types.FeatureCondition) error,
+	acquireRepairLease func(context.Context) (bool, error),
+	repairFeature func(types.FeatureName),
+) {
+	c.waitReady()
+
+	ctx = log.NewContext(ctx, log.FromContext(ctx).WithValues("controller", "feature-drift"))
+	log := log.FromContext(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.triggerCh:
+		}
+
+		if isWorker, err := snaputil.IsWorker(c.snap); err != nil {
+			log.Error(err, "Failed to check if running on a worker node")
+			continue
+		} else if isWorker {
+			log.Info("Stopping on worker node")
+			return
+		}
+
+		if err := c.reconcile(ctx, getClusterConfig, getFeatureStatuses, setFeatureCondition, acquireRepairLease, repairFeature); err != nil {
+			log.Error(err, "Failed to reconcile feature drift")
+		}
+
+		select {
+		case c.reconciledCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *FeatureDriftController) reconcile(
+	ctx context.Context,
+	getClusterConfig func(context.Context) (types.ClusterConfig, error),
+	getFeatureStatuses func(context.Context) (map[types.FeatureName]types.FeatureStatus, error),
+	setFeatureCondition func(context.Context, types.FeatureName, types.FeatureCondition) error,
+	acquireRepairLease func(context.Context) (bool, error),
+	repairFeature func(types.FeatureName),
+) error {
+	config, err := getClusterConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve cluster configuration: %w", err)
+	}
+	statuses, err := getFeatureStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get feature statuses: %w", err)
+	}
+
+	// the repair lease is only acquired if there is anything to repair
+	var leaseChecked, leaseAcquired bool
+	holdsRepairLease := func() bool {
+		if !leaseChecked {
+			leaseChecked = true
+			var err error
+			if leaseAcquired, err = acquireRepairLease(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to acquire feature repair lease")
+			}
+		}
+		return leaseAcquired
+	}
+
+	policy := config.FeatureDriftPolicy()
+	for name, charts := range c.charts {
+		status, ok := statuses[name]
+		if !ok {
+			continue
+		}
+
+		var condition types.FeatureCondition
+		if status.Enabled && len(charts) > 0 && policy != types.FeatureDriftPolicyIgnore {
+			var drifted bool
+			condition, drifted = c.checkDrift(ctx, name, charts)
+			if drifted && policy == types.FeatureDriftPolicyRepair && holdsRepairLease() {
+				log.FromContext(ctx).Info("Repairing drifted feature", "feature", name, "objects", condition.Message)
+				condition.Reason = "Repairing"
+				repairFeature(name)
+			}
+		} else {
+			// drift is not checked, but a previous result should not linger around
+			previous, ok := status.GetCondition(types.FeatureConditionInSync)
+			if !ok || previous.Status == types.ConditionUnknown {
+				continue
+			}
+			condition = types.FeatureCondition{Type: types.FeatureConditionInSync, Status: types.ConditionUnknown, Reason: "NotChecked"}
+		}
+
+		if err := setFeatureCondition(ctx, name, condition); err != nil {
+			log.FromContext(ctx).WithValues("feature", name).Error(err, "Failed to update feature drift")
+		}
+	}
+
+	return nil
+}
+
+// checkDrift compares the charts of a feature with the live objects on the cluster.
+// checkDrift returns the "InSync" condition of the feature and true if any objects were modified or deleted.
+func (c *FeatureDriftController) checkDrift(ctx context.Context, name types.FeatureName, charts []helm.InstallableChart) (types.FeatureCondition, bool) {
+	ctx, cancel := context.WithTimeout(ctx, driftCheckTimeout)
+	defer cancel()
+
+	var drifted []string
+	for _, chart := range charts {
+		objects, err := c.snap.HelmClient().Drift(ctx, chart)
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Feature drift check failed", "feature", name, "chart", chart.Name, "error", err)
+			return types.FeatureCondition{Type: types.FeatureConditionInSync, Status: types.ConditionUnknown, Reason: "CheckFailed", Message: err.Error()}, false
+		}
+		for _, object := range objects {
+			drifted = append(drifted, object.String())
+		}
+	}
+
+	if len(drifted) > 0 {
+		return types.FeatureCondition{Type: types.FeatureConditionInSync, Status: types.ConditionFalse, Reason: "Drifted", Message: strings.Join(drifted, ", ")}, true
+	}
+	return types.FeatureCondition{Type: types.FeatureConditionInSync, Status: types.ConditionTrue, Reason: "InSync"}, false
+}
+
+// ReconciledCh returns the channel where the controller pushes when a reconciliation loop is finished.
+func (c *FeatureDriftController) ReconciledCh() <-chan struct{} {
+	return c.reconciledCh
+}
@@ -0,0 +1,128 @@
+package controllers_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/client/helm"
+	helmmock "github.com/canonical/k8s/pkg/client/helm/mock"
+	"github.com/canonical/k8s/pkg/k8sd/controllers"
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+)
+
+func TestFeatureDriftController(t *testing.T) {
+	chartDNS := helm.InstallableChart{Name: "ck-dns", Namespace: "kube-system"}
+	chartNetwork := helm.InstallableChart{Name: "ck-network", Namespace: "kube-system"}
+	chartGateway := helm.InstallableChart{Name: "ck-gateway", Namespace: "kube-system"}
+	charts := map[types.FeatureName][]helm.InstallableChart{
+		features.DNS:     {chartDNS},
+		features.Network: {chartNetwork},
+		features.Gateway: {chartGateway},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		policy          string
+		leaseHeld       bool
+		expectCondition string
+		expectRepaired  []types.FeatureName
+	}{
+		{name: "Warn", policy: "", expectCondition: types.ConditionFalse},
+		{name: "Repair", policy: types.FeatureDriftPolicyRepair, leaseHeld: true, expectCondition: types.ConditionFalse, expectRepaired: []types.FeatureName{features.DNS}},
+		{name: "RepairOnOtherNode", policy: types.FeatureDriftPolicyRepair, expectCondition: types.ConditionFalse},
+		{name: "Ignore", policy: types.FeatureDriftPolicyIgnore, expectCondition: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			helmM := &helmmock.Mock{
+				DriftObjects: map[string][]helm.DriftedObject{
+					chartDNS.Name: {{Kind: "Deployment", Namespace: "kube-system", Name: "coredns", Reason: helm.DriftReasonModified}},
+				},
+			}
+			s := &mock.Snap{
+				Mock: mock.Mock{
+					LockFilesDir: t.TempDir(),
+					HelmClient:   helmM,
+				},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			triggerCh := make(chan time.Time)
+			provider := &featureStatusProvider{
+				statuses: map[types.FeatureName]types.FeatureStatus{
+					features.DNS:     {Enabled: true},
+					features.Network: {Enabled: true},
+					features.Gateway: {
+						Enabled:    false,
+						Conditions: []types.FeatureCondition{{Type: types.FeatureConditionInSync, Status: types.ConditionFalse}},
+					},
+				},
+			}
+
+			var mu sync.Mutex
+			var repaired []types.FeatureName
+			getClusterConfig := func(context.Context) (types.ClusterConfig, error) {
+				return types.ClusterConfig{Annotations: types.Annotations{types.AnnotationFeatureDriftPolicy: tc.policy}}, nil
+			}
+			acquireRepairLease := func(context.Context) (bool, error) {
+				return tc.leaseHeld, nil
+			}
+			repairFeature := func(name types.FeatureName) {
+				mu.Lock()
+				defer mu.Unlock()
+				repaired = append(repaired, name)
+			}
+
+			ctrl := controllers.NewFeatureDriftController(s, func() {}, triggerCh, charts)
+			go ctrl.Run(ctx, getClusterConfig, provider.get, provider.setCondition, acquireRepairLease, repairFeature)
+
+			select {
+			case triggerCh <- time.Now():
+			case <-time.After(channelSendTimeout):
+				g.Fail("Timed out while attempting to trigger controller reconcile loop")
+			}
+
+			select {
+			case <-ctrl.ReconciledCh():
+			case <-time.After(channelSendTimeout):
+				g.Fail("Time out while waiting for the reconcile to complete")
+			}
+
+			statuses, err := provider.get(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			condition, ok := statuses[features.DNS].GetCondition(types.FeatureConditionInSync)
+			if tc.expectCondition == "" {
+				g.Expect(ok).To(BeFalse())
+				g.Expect(helmM.DriftCalledWith).To(BeEmpty())
+			} else {
+				g.Expect(ok).To(BeTrue())
+				g.Expect(condition.Status).To(Equal(tc.expectCondition))
+				g.Expect(condition.Message).To(Equal("Deployment kube-system/coredns (modified)"))
+
+				condition, ok = statuses[features.Network].GetCondition(types.FeatureConditionInSync)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(condition.Status).To(Equal(types.ConditionTrue))
+
+				g.Expect(helmM.DriftCalledWith).To(ConsistOf(chartDNS, chartNetwork))
+			}
+
+			// disabled features are not checked, and their previous result is reset
+			condition, ok = statuses[features.Gateway].GetCondition(types.FeatureConditionInSync)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(condition.Status).To(Equal(types.ConditionUnknown))
+
+			mu.Lock()
+			defer mu.Unlock()
+			g.Expect(repaired).To(Equal(tc.expectRepaired))
+		})
+	}
+}
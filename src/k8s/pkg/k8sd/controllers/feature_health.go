@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+)
+
+// healthCheckTimeout is the maximum amount of time a single feature health check may take.
+const healthCheckTimeout = 30 * time.Second
+
+// FeatureHealthController periodically runs the health checks of the enabled features
+// and records the result in the "Healthy" condition of the feature status.
+type FeatureHealthController struct {
+	snap         snap.Snap
+	waitReady    func()
+	triggerCh    <-chan time.Time
+	healthChecks map[types.FeatureName]func(context.Context, snap.Snap) error
+	// reconciledCh is used to notify that the controller has finished its reconciliation loop.
+	reconciledCh chan struct{}
+}
+
+// NewFeatureHealthController creates a new controller.
+// triggerCh is typically a `time.NewTicker(<duration>).C`.
+// healthChecks maps each feature to the function that checks its health.
+func NewFeatureHealthController(snap snap.Snap, waitReady func(), triggerCh <-chan time.Time, healthChecks map[types.FeatureName]func(context.Context, snap.Snap) error) *FeatureHealthController {
+	return &FeatureHealthController{
+		snap:         snap,
+		waitReady:    waitReady,
+		triggerCh:    triggerCh,
+		healthChecks: healthChecks,
+		reconciledCh: make(chan struct{}, 1),
+	}
+}
+
+// Run starts the controller.
+// Run accepts a function that retrieves the current feature statuses and a function that
+// sets a condition on the status of a feature.
+// Run will loop every time the trigger channel is.
+func (c *FeatureHealthController) Run(
+	ctx context.Context,
+	getFeatureStatuses func(context.Context) (map[types.FeatureName]types.FeatureStatus, error),
+	setFeatureCondition func(context.Context, types.FeatureName, types.FeatureCondition) error,
+) {
+	c.waitReady()
+
+	ctx = log.NewContext(ctx, log.FromContext(ctx).WithValues("controller", "feature-health"))
+	log := log.FromContext(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.triggerCh:
+		}
+
+		if isWorker, err := snaputil.IsWorker(c.snap); err != nil {
+			log.Error(err, "Failed to check if running on a worker node")
+			continue
+		} else if isWorker {
+			log.Info("Stopping on worker node")
+			return
+		}
+
+		if err := c.reconcile(ctx, getFeatureStatuses, setFeatureCondition); err != nil {
+			log.Error(err, "Failed to reconcile feature health")
+		}
+
+		select {
+		case c.reconciledCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *FeatureHealthController) reconcile(
+	ctx context.Context,
+	getFeatureStatuses func(context.Context) (map[types.FeatureName]types.FeatureStatus, error),
+	setFeatureCondition func(context.Context, types.FeatureName, types.FeatureCondition) error,
+) error {
+	statuses, err := getFeatureStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get feature statuses: %w", err)
+	}
+
+	for name, check := range c.healthChecks {
+		status, ok := statuses[name]
+		if !ok {
+			continue
+		}
+
+		var condition types.FeatureCondition
+		if status.Enabled {
+			condition = c.checkHealth(ctx, name, check)
+		} else {
+			// a disabled feature is not checked, but a previous health result should not linger around
+			previous, ok := status.GetCondition(types.FeatureConditionHealthy)
+			if !ok || previous.Status == types.ConditionUnknown {
+				continue
+			}
+			condition = types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionUnknown, Reason: "FeatureDisabled"}
+		}
+
+		if err := setFeatureCondition(ctx, name, condition); err != nil {
+			log.FromContext(ctx).WithValues("feature", name).Error(err, "Failed to update feature health")
+		}
+	}
+
+	return nil
+}
+
+func (c *FeatureHealthController) checkHealth(ctx context.Context, name types.FeatureName, check func(context.Context, snap.Snap) error) types.FeatureCondition {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if err := check(ctx, c.snap); err != nil {
+		log.FromContext(ctx).V(1).Info("Feature health check failed", "feature", name, "error", err)
+		return types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionFalse, Reason: "CheckFailed", Message: err.Error()}
+	}
+	return types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue, Reason: "CheckPassed"}
+}
+
+// ReconciledCh returns the channel where the controller pushes when a reconciliation loop is finished.
+func (c *FeatureHealthController) ReconciledCh() <-chan struct{} {
+	return c.reconciledCh
+}
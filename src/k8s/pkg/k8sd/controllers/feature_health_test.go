@@ -0,0 +1,105 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/controllers"
+	"github.com/canonical/k8s/pkg/k8sd/features"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+)
+
+type featureStatusProvider struct {
+	mu       sync.Mutex
+	statuses map[types.FeatureName]types.FeatureStatus
+}
+
+func (p *featureStatusProvider) get(_ context.Context) (map[types.FeatureName]types.FeatureStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := make(map[types.FeatureName]types.FeatureStatus, len(p.statuses))
+	for name, status := range p.statuses {
+		status.Conditions = append([]types.FeatureCondition(nil), status.Conditions...)
+		result[name] = status
+	}
+	return result, nil
+}
+
+func (p *featureStatusProvider) setCondition(_ context.Context, name types.FeatureName, condition types.FeatureCondition) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := p.statuses[name]
+	status.SetCondition(condition)
+	p.statuses[name] = status
+	return nil
+}
+
+func TestFeatureHealthController(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			LockFilesDir: t.TempDir(),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	triggerCh := make(chan time.Time)
+	provider := &featureStatusProvider{
+		statuses: map[types.FeatureName]types.FeatureStatus{
+			features.Network: {Enabled: true},
+			features.DNS:     {Enabled: true},
+			features.Gateway: {
+				Enabled:    false,
+				Conditions: []types.FeatureCondition{{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue}},
+			},
+		},
+	}
+
+	ctrl := controllers.NewFeatureHealthController(s, func() {}, triggerCh, map[types.FeatureName]func(context.Context, snap.Snap) error{
+		features.Network: func(context.Context, snap.Snap) error { return nil },
+		features.DNS:     func(context.Context, snap.Snap) error { return fmt.Errorf("coredns pods not yet ready") },
+		features.Gateway: func(context.Context, snap.Snap) error { return fmt.Errorf("should not be called") },
+		// no status yet, must be skipped
+		features.Ingress: func(context.Context, snap.Snap) error { return nil },
+	})
+	go ctrl.Run(ctx, provider.get, provider.setCondition)
+
+	select {
+	case triggerCh <- time.Now():
+	case <-time.After(channelSendTimeout):
+		g.Fail("Timed out while attempting to trigger controller reconcile loop")
+	}
+
+	select {
+	case <-ctrl.ReconciledCh():
+	case <-time.After(channelSendTimeout):
+		g.Fail("Time out while waiting for the reconcile to complete")
+	}
+
+	statuses, err := provider.get(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(statuses[features.Network].Health).To(Equal(types.FeatureHealthHealthy))
+
+	g.Expect(statuses[features.DNS].Health).To(Equal(types.FeatureHealthUnhealthy))
+	condition, ok := statuses[features.DNS].GetCondition(types.FeatureConditionHealthy)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(condition.Status).To(Equal(types.ConditionFalse))
+	g.Expect(condition.Message).To(Equal("coredns pods not yet ready"))
+
+	g.Expect(statuses[features.Gateway].Health).To(Equal(types.FeatureHealthUnknown))
+	condition, ok = statuses[features.Gateway].GetCondition(types.FeatureConditionHealthy)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(condition.Reason).To(Equal("FeatureDisabled"))
+
+	g.Expect(statuses).ToNot(HaveKey(features.Ingress))
+}
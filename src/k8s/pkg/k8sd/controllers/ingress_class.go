@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ciliumIngressController is the controller of the IngressClasses that are served by the Cilium ingress controller.
+	ciliumIngressController = "cilium.io/ingress-controller"
+	// ciliumIngressClassName is the only IngressClass that the Cilium ingress controller reconciles on its own.
+	ciliumIngressClassName = "cilium"
+	// legacyIngressClassAnnotation selects the IngressClass of an Ingress. It takes precedence over
+	// spec.ingressClassName for the Cilium ingress controller.
+	legacyIngressClassAnnotation = "kubernetes.io/ingress.class"
+	// ingressClassAnnotation records the custom IngressClass of an Ingress that was handed over to the Cilium ingress controller.
+	ingressClassAnnotation = "k8sd.io/ingress-class"
+)
+
+// IngressClassController hands the Ingress resources of custom IngressClasses of the Cilium ingress controller over
+// to Cilium. The cilium-operator only reconciles Ingress resources of the "cilium" IngressClass, so Ingress resources
+// of other IngressClasses with the "cilium.io/ingress-controller" controller are marked with the legacy
+// "kubernetes.io/ingress.class: cilium" annotation.
+type IngressClassController struct {
+	snap      snap.Snap
+	waitReady func()
+	triggerCh <-chan time.Time
+	// reconciledCh is used to notify that the controller has finished its reconciliation loop.
+	reconciledCh chan struct{}
+}
+
+// NewIngressClassController creates a new controller.
+// triggerCh is typically a `time.NewTicker(<duration>).C`.
+func NewIngressClassController(snap snap.Snap, waitReady func(), triggerCh <-chan time.Time) *IngressClassController {
+	return &IngressClassController{
+		snap:         snap,
+		waitReady:    waitReady,
+		triggerCh:    triggerCh,
+		reconciledCh: make(chan struct{}, 1),
+	}
+}
+
+// Run starts the controller.
+// Run will loop every time the trigger channel is.
+func (c *IngressClassController) Run(ctx context.Context) {
+	c.waitReady()
+
+	ctx = log.NewContext(ctx, log.FromContext(ctx).WithValues("controller", "ingress-class"))
+	log := log.FromContext(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.triggerCh:
+		}
+
+		if isWorker, err := snaputil.IsWorker(c.snap); err != nil {
+			log.Error(err, "Failed to check if running on a worker node")
+			continue
+		} else if isWorker {
+			log.Info("Stopping on worker node")
+			return
+		}
+
+		client, err := c.snap.KubernetesClient("")
+		if err != nil {
+			log.Error(err, "Failed to create a Kubernetes client")
+			continue
+		}
+
+		if err := c.reconcile(ctx, client); err != nil {
+			log.Error(err, "Failed to reconcile Ingress resources of custom IngressClasses")
+		}
+
+		select {
+		case c.reconciledCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *IngressClassController) reconcile(ctx context.Context, client *kubernetes.Client) error {
+	classes, err := client.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list IngressClasses: %w", err)
+	}
+	customClasses := make(map[string]struct{}, len(classes.Items))
+	for _, class := range classes.Items {
+		if class.Spec.Controller == ciliumIngressController && class.Name != ciliumIngressClassName {
+			customClasses[class.Name] = struct{}{}
+		}
+	}
+
+	ingresses, err := client.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+
+	var errs []error
+	for _, ingress := range ingresses.Items {
+		className := ptr.Deref(ingress.Spec.IngressClassName, "")
+		_, isCustomClass := customClasses[className]
+		handedOverClass, isHandedOver := ingress.Annotations[ingressClassAnnotation]
+
+		switch {
+		case isCustomClass && (handedOverClass != className || ingress.Annotations[legacyIngressClassAnnotation] != ciliumIngressClassName):
+			if ingress.Annotations == nil {
+				ingress.Annotations = make(map[string]string, 2)
+			}
+			ingress.Annotations[legacyIngressClassAnnotation] = ciliumIngressClassName
+			ingress.Annotations[ingressClassAnnotation] = className
+		case !isCustomClass && isHandedOver:
+			// the Ingress moved to another IngressClass or the custom IngressClass was removed
+			if ingress.Annotations[legacyIngressClassAnnotation] == ciliumIngressClassName {
+				delete(ingress.Annotations, legacyIngressClassAnnotation)
+			}
+			delete(ingress.Annotations, ingressClassAnnotation)
+		default:
+			continue
+		}
+
+		if _, err := client.NetworkingV1().Ingresses(ingress.Namespace).Update(ctx, &ingress, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update Ingress %s/%s: %w", ingress.Namespace, ingress.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReconciledCh returns the channel where the controller pushes when a reconciliation loop is finished.
+func (c *IngressClassController) ReconciledCh() <-chan struct{} {
+	return c.reconciledCh
+}
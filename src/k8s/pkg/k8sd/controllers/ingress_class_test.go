@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/k8sd/controllers"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestIngressClassController(t *testing.T) {
+	g := NewWithT(t)
+
+	clientset := fake.NewSimpleClientset(
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "cilium.io/ingress-controller"},
+		},
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "cilium.io/ingress-controller"},
+		},
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: ptr.To("web")},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium", Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: ptr.To("cilium")},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: ptr.To("nginx")},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "moved",
+				Namespace:   "default",
+				Annotations: map[string]string{"kubernetes.io/ingress.class": "cilium", "k8sd.io/ingress-class": "web"},
+			},
+			Spec: networkingv1.IngressSpec{IngressClassName: ptr.To("nginx")},
+		},
+	)
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			LockFilesDir:     t.TempDir(),
+			KubernetesClient: &kubernetes.Client{Interface: clientset},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	triggerCh := make(chan time.Time)
+	ctrl := controllers.NewIngressClassController(s, func() {}, triggerCh)
+	go ctrl.Run(ctx)
+
+	select {
+	case triggerCh <- time.Now():
+	case <-time.After(channelSendTimeout):
+		g.Fail("Timed out while attempting to trigger controller reconcile loop")
+	}
+
+	select {
+	case <-ctrl.ReconciledCh():
+	case <-time.After(channelSendTimeout):
+		g.Fail("Time out while waiting for the reconcile to complete")
+	}
+
+	for name, expectedAnnotations := range map[string]map[string]string{
+		"custom": {"kubernetes.io/ingress.class": "cilium", "k8sd.io/ingress-class": "web"},
+		"cilium": nil,
+		"other":  nil,
+		"moved":  {},
+	} {
+		ingress, err := clientset.NetworkingV1().Ingresses("default").Get(ctx, name, metav1.GetOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ingress.Annotations).To(Equal(expectedAnnotations), "Ingress %s", name)
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/canonical/k8s/pkg/k8sd/setup"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/snap"
-	snaputil "github.com/canonical/k8s/pkg/snap/util"
 	"github.com/canonical/k8s/pkg/utils/control"
 	v1 "k8s.io/api/core/v1"
 )
@@ -19,13 +20,16 @@ type NodeConfigurationController struct {
 	waitReady func()
 	// reconciledCh is used to notify that the controller has finished its reconciliation loop.
 	reconciledCh chan struct{}
+	// getNodeName is used to find the node pool of the local node.
+	getNodeName func(ctx context.Context) (string, error)
 }
 
-func NewNodeConfigurationController(snap snap.Snap, waitReady func()) *NodeConfigurationController {
+func NewNodeConfigurationController(snap snap.Snap, waitReady func(), getNodeName func(ctx context.Context) (string, error)) *NodeConfigurationController {
 	return &NodeConfigurationController{
 		snap:         snap,
 		waitReady:    waitReady,
 		reconciledCh: make(chan struct{}, 1),
+		getNodeName:  getNodeName,
 	}
 }
 
@@ -101,16 +105,84 @@ func (c *NodeConfigurationController) reconcile(ctx context.Context, configMap *
 		}
 	}
 
-	mustRestartKubelet, err := snaputil.UpdateServiceArguments(c.snap, "kubelet", updateArgs, deleteArgs)
+	// kubelet serving certificates are requested with CSRs, signed by the csrsigning controller
+	switch {
+	case config.RotateServerCertificates == nil:
+	case *config.RotateServerCertificates:
+		updateArgs["--rotate-server-certificates"] = "true"
+	default:
+		deleteArgs = append(deleteArgs, "--rotate-server-certificates")
+	}
+
+	// the arguments of all sources are collected and applied once, so that they do not overwrite each other
+	args := setup.ServiceArguments{}
+	args.Set("kubelet", updateArgs, deleteArgs)
+
+	var restartServices []string
+
+	// cluster-wide kubelet configuration, an empty value removes the configuration
+	if config.Config != nil {
+		configChanged, err := setup.KubeletConfigDropIn(c.snap, *config.Config)
+		if err != nil {
+			return fmt.Errorf("failed to apply kubelet configuration: %w", err)
+		}
+		if configChanged {
+			restartServices = append(restartServices, "kubelet")
+		}
+	}
+
+	// cluster-wide service arguments, only if distributed by the control plane.
+	// these are set before the node pool configuration, so that node pools can override them.
+	serviceArgs, ok, err := types.ServiceArgsFromConfigMap(configMap.Data, key)
+	if err != nil {
+		return fmt.Errorf("failed to parse configmap data to service arguments: %w", err)
+	}
+	if ok {
+		nodeName, err := c.getNodeName(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get node name: %w", err)
+		}
+		services, err := setup.ServiceArgs(c.snap, "node", types.NodeServices, serviceArgs.ForNode(nodeName), args)
+		if err != nil {
+			return fmt.Errorf("failed to apply service arguments: %w", err)
+		}
+		restartServices = append(restartServices, services...)
+	}
+
+	// node pool configuration, only if distributed by the control plane
+	nodePools, ok, err := types.NodePoolsFromConfigMap(configMap.Data, key)
+	if err != nil {
+		return fmt.Errorf("failed to parse configmap data to node pools config: %w", err)
+	}
+	if ok {
+		nodeName, err := c.getNodeName(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get node name: %w", err)
+		}
+		var nodePool *types.NodePool
+		if pool, isMember := nodePools.PoolForNode(nodeName); isMember {
+			nodePool = &pool
+		}
+		services, err := setup.NodePool(c.snap, nodePool, args)
+		if err != nil {
+			return fmt.Errorf("failed to apply node pool configuration: %w", err)
+		}
+		restartServices = append(restartServices, services...)
+	}
+
+	changedServices, err := args.Apply(c.snap)
 	if err != nil {
-		return fmt.Errorf("failed to update kubelet arguments: %w", err)
+		return fmt.Errorf("failed to update service arguments: %w", err)
 	}
+	restartServices = append(restartServices, changedServices...)
+	slices.Sort(restartServices)
+	restartServices = slices.Compact(restartServices)
 
-	if mustRestartKubelet {
+	if len(restartServices) > 0 {
 		// This may fail if other controllers try to restart the services at the same time, hence the retry.
 		if err := control.RetryFor(ctx, 5, 5*time.Second, func() error {
-			if err := c.snap.RestartServices(ctx, []string{"kubelet"}); err != nil {
-				return fmt.Errorf("failed to restart kubelet to apply node configuration: %w", err)
+			if err := c.snap.RestartServices(ctx, restartServices); err != nil {
+				return fmt.Errorf("failed to restart %v to apply node configuration: %w", restartServices, err)
 			}
 			return nil
 		}); err != nil {
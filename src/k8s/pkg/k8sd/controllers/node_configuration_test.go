@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"maps"
 	"os"
 	"path/filepath"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/snap/mock"
 	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +37,8 @@ func TestConfigPropagation(t *testing.T) {
 	wrongPrivKey, err := rsa.GenerateKey(rand.Reader, 4096)
 	g.Expect(err).To(Not(HaveOccurred()))
 
+	dir := t.TempDir()
+
 	tests := []struct {
 		name          string
 		configmap     *corev1.ConfigMap
@@ -42,6 +46,12 @@ func TestConfigPropagation(t *testing.T) {
 		expectRestart bool
 		privKey       *rsa.PrivateKey
 		pubKey        *rsa.PublicKey
+		// nodePools is added to the configmap data
+		nodePools *types.NodePools
+		// serviceArgs is added to the configmap data
+		serviceArgs *types.ServiceArgs
+		// expectRestartServices overrides the services that are expected to be restarted
+		expectRestartServices []string
 	}{
 		{
 			name: "Initial",
@@ -177,6 +187,267 @@ func TestConfigPropagation(t *testing.T) {
 			pubKey:        &privKey.PublicKey,
 			expectRestart: false,
 		},
+		{
+			name: "KubeletConfig",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"kubelet-config": `{"maxPods":200}`,
+				},
+			},
+			expectArgs: map[string]string{
+				"--cluster-dns": "10.152.1.1",
+				"--config-dir":  filepath.Join(dir, "args", "conf.d", "kubelet.conf.d"),
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "KubeletConfigUnchanged",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"kubelet-config": `{"maxPods":200}`,
+				},
+			},
+			expectArgs: map[string]string{
+				"--config-dir": filepath.Join(dir, "args", "conf.d", "kubelet.conf.d"),
+			},
+			privKey: privKey,
+			pubKey:  &privKey.PublicKey,
+		},
+		{
+			name: "RemoveKubeletConfig",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"kubelet-config": "",
+				},
+			},
+			expectArgs: map[string]string{
+				"--config-dir": "",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "RotateServerCertificates",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"rotate-server-certificates": "true",
+				},
+			},
+			expectArgs: map[string]string{
+				"--rotate-server-certificates": "true",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "DisableRotateServerCertificates",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"rotate-server-certificates": "false",
+				},
+			},
+			expectArgs: map[string]string{
+				"--rotate-server-certificates": "",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "NodePool",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}},
+					{Name: "edge", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("20")}},
+				},
+				Members: map[string]string{"node1": "gpu", "node2": "edge"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "NodePoolUnchanged",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}},
+				},
+				Members: map[string]string{"node1": "gpu"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey: privKey,
+			pubKey:  &privKey.PublicKey,
+		},
+		{
+			name: "NodePoolFiles",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}, ExtraNodeConfigFiles: map[string]string{"file": "content"}},
+				},
+				Members: map[string]string{"node1": "gpu"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey:               privKey,
+			pubKey:                &privKey.PublicKey,
+			expectRestartServices: []string{"kubelet", "containerd", "kube-proxy"},
+		},
+		{
+			name: "NodePoolInvalidSignature",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			nodePools: &types.NodePools{},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey: wrongPrivKey,
+			pubKey:  &privKey.PublicKey,
+		},
+		{
+			name: "RemoveFromNodePool",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}},
+				},
+				Members: map[string]string{"node2": "gpu"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "",
+			},
+			privKey:               privKey,
+			pubKey:                &privKey.PublicKey,
+			expectRestartServices: []string{"kubelet", "containerd", "kube-proxy"},
+		},
+		{
+			name: "ServiceArgs",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			serviceArgs: &types.ServiceArgs{
+				ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2"), "--max-pods": utils.Pointer("80")}},
+				},
+				Nodes: map[string]types.ServiceArgsConfig{
+					"node1": {Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("4")}}},
+					"node2": {Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("6")}}},
+				},
+			},
+			expectArgs: map[string]string{
+				"--v":        "4",
+				"--max-pods": "80",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "ServiceArgsUnchanged",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			serviceArgs: &types.ServiceArgs{
+				ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2"), "--max-pods": utils.Pointer("80")}},
+				},
+				Nodes: map[string]types.ServiceArgsConfig{
+					"node1": {Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("4")}}},
+				},
+			},
+			expectArgs: map[string]string{
+				"--v":        "4",
+				"--max-pods": "80",
+			},
+			privKey: privKey,
+			pubKey:  &privKey.PublicKey,
+		},
+		{
+			name: "RemoveServiceArgs",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			serviceArgs: &types.ServiceArgs{},
+			expectArgs: map[string]string{
+				"--v":        "",
+				"--max-pods": "",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			name: "ServiceArgsAndNodePool",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			serviceArgs: &types.ServiceArgs{
+				ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--max-pods": utils.Pointer("80")}},
+				},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}},
+				},
+				Members: map[string]string{"node1": "gpu"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey:       privKey,
+			pubKey:        &privKey.PublicKey,
+			expectRestart: true,
+		},
+		{
+			// both set the same argument, which must not restart the services on every reconcile
+			name: "ServiceArgsAndNodePoolUnchanged",
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8sd-config", Namespace: "kube-system"},
+			},
+			serviceArgs: &types.ServiceArgs{
+				ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--max-pods": utils.Pointer("80")}},
+				},
+			},
+			nodePools: &types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50")}},
+				},
+				Members: map[string]string{"node1": "gpu"},
+			},
+			expectArgs: map[string]string{
+				"--max-pods": "50",
+			},
+			privKey: privKey,
+			pubKey:  &privKey.PublicKey,
+		},
 	}
 
 	clientset := fake.NewSimpleClientset()
@@ -185,16 +456,17 @@ func TestConfigPropagation(t *testing.T) {
 
 	s := &mock.Snap{
 		Mock: mock.Mock{
-			ServiceArgumentsDir:  filepath.Join(t.TempDir(), "args"),
-			UID:                  os.Getuid(),
-			GID:                  os.Getgid(),
-			KubernetesNodeClient: &kubernetes.Client{Interface: clientset},
+			ServiceArgumentsDir:   filepath.Join(dir, "args"),
+			ServiceExtraConfigDir: filepath.Join(dir, "args", "conf.d"),
+			UID:                   os.Getuid(),
+			GID:                   os.Getgid(),
+			KubernetesNodeClient:  &kubernetes.Client{Interface: clientset},
 		},
 	}
 
 	g.Expect(setup.EnsureAllDirectories(s)).To(Succeed())
 
-	ctrl := controllers.NewNodeConfigurationController(s, func() {})
+	ctrl := controllers.NewNodeConfigurationController(s, func() {}, func(ctx context.Context) (string, error) { return "node1", nil })
 
 	keyCh := make(chan *rsa.PublicKey)
 
@@ -214,6 +486,22 @@ func TestConfigPropagation(t *testing.T) {
 				tc.configmap.Data, err = kubelet.ToConfigMap(tc.privKey)
 				g.Expect(err).To(Not(HaveOccurred()))
 			}
+			if tc.nodePools != nil {
+				nodePoolsData, err := tc.nodePools.ToConfigMap(tc.privKey)
+				g.Expect(err).To(Not(HaveOccurred()))
+				if tc.configmap.Data == nil {
+					tc.configmap.Data = make(map[string]string)
+				}
+				maps.Copy(tc.configmap.Data, nodePoolsData)
+			}
+			if tc.serviceArgs != nil {
+				serviceArgsData, err := tc.serviceArgs.ToConfigMap(tc.privKey)
+				g.Expect(err).To(Not(HaveOccurred()))
+				if tc.configmap.Data == nil {
+					tc.configmap.Data = make(map[string]string)
+				}
+				maps.Copy(tc.configmap.Data, serviceArgsData)
+			}
 
 			watcher.Add(tc.configmap)
 
@@ -231,7 +519,9 @@ func TestConfigPropagation(t *testing.T) {
 				g.Expect(val).To(Equal(evalue))
 			}
 
-			if tc.expectRestart {
+			if tc.expectRestartServices != nil {
+				g.Expect(s.RestartServicesCalledWith[0]).To(ConsistOf(tc.expectRestartServices))
+			} else if tc.expectRestart {
 				g.Expect(s.RestartServicesCalledWith[0]).To(Equal([]string{"kubelet"}))
 			} else {
 				g.Expect(s.RestartServicesCalledWith).To(BeEmpty())
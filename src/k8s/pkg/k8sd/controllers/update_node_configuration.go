@@ -3,13 +3,17 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/canonical/k8s/pkg/client/kubernetes"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/snap"
 	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
 	pkiutil "github.com/canonical/k8s/pkg/utils/pki"
+	v1 "k8s.io/api/core/v1"
 )
 
 // UpdateNodeConfigurationController asynchronously performs updates of the cluster config.
@@ -35,11 +39,15 @@ func NewUpdateNodeConfigurationController(snap snap.Snap, waitReady func(), trig
 	}
 }
 
+// nodePoolRetryInterval is the interval to retry configuring the labels and taints of node pool members
+// that are not registered yet.
+const nodePoolRetryInterval = 30 * time.Second
+
 // Run starts the controller.
 // Run accepts a context to manage the lifecycle of the controller.
-// Run accepts a function that retrieves the current cluster configuration.
+// Run accepts functions that retrieve the current cluster configuration and node pools.
 // Run will loop everytime the TriggerCh is triggered.
-func (c *UpdateNodeConfigurationController) Run(ctx context.Context, getClusterConfig func(context.Context) (types.ClusterConfig, error)) {
+func (c *UpdateNodeConfigurationController) Run(ctx context.Context, getClusterConfig func(context.Context) (types.ClusterConfig, error), getNodePools func(context.Context) (types.NodePools, error)) {
 	ctx = log.NewContext(ctx, log.FromContext(ctx).WithValues("controller", "update-node-configuration"))
 	log := log.FromContext(ctx)
 
@@ -47,12 +55,15 @@ func (c *UpdateNodeConfigurationController) Run(ctx context.Context, getClusterC
 	c.waitReady()
 	log.V(1).Info("Starting update node configuration controller")
 
+	var retryCh <-chan time.Time
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-c.triggerCh:
+		case <-retryCh:
 		}
+		retryCh = nil
 
 		if isWorker, err := snaputil.IsWorker(c.snap); err != nil {
 			log.Error(err, "Failed to check if running on a worker node")
@@ -68,15 +79,29 @@ func (c *UpdateNodeConfigurationController) Run(ctx context.Context, getClusterC
 			continue
 		}
 
+		nodePools, err := getNodePools(ctx)
+		if err != nil {
+			log.Error(err, "Failed to retrieve node pools")
+			continue
+		}
+
 		client, err := getNewK8sClientWithRetries(ctx, c.snap, true)
 		if err != nil {
 			log.Error(err, "Failed to create a Kubernetes client")
 		}
 
-		if err := c.reconcile(ctx, client, config); err != nil {
+		if err := c.reconcile(ctx, client, config, nodePools); err != nil {
 			log.Error(err, "Failed to reconcile cluster configuration")
 		}
 
+		if pending, err := c.reconcileNodePools(ctx, client, nodePools); err != nil {
+			log.Error(err, "Failed to reconcile node pools")
+			retryCh = time.After(nodePoolRetryInterval)
+		} else if pending {
+			// node pool members are configured as soon as they are registered
+			retryCh = time.After(nodePoolRetryInterval)
+		}
+
 		// notify downstream that the reconciliation loop is done.
 		select {
 		case c.reconciledCh <- struct{}{}:
@@ -85,7 +110,7 @@ func (c *UpdateNodeConfigurationController) Run(ctx context.Context, getClusterC
 	}
 }
 
-func (c *UpdateNodeConfigurationController) reconcile(ctx context.Context, client *kubernetes.Client, config types.ClusterConfig) error {
+func (c *UpdateNodeConfigurationController) reconcile(ctx context.Context, client *kubernetes.Client, config types.ClusterConfig, nodePools types.NodePools) error {
 	log := log.FromContext(ctx)
 	log.V(1).Info("Reconciling node configuration")
 
@@ -95,10 +120,27 @@ func (c *UpdateNodeConfigurationController) reconcile(ctx context.Context, clien
 		return fmt.Errorf("failed to load cluster RSA key: %w", err)
 	}
 
-	cmData, err := config.Kubelet.ToConfigMap(key)
+	kubelet := config.Kubelet
+	kubelet.RotateServerCertificates = utils.Pointer(config.KubeletServerCertificateRotationEnabled())
+
+	cmData, err := kubelet.ToConfigMap(key)
 	if err != nil {
 		return fmt.Errorf("failed to format kubelet configmap data: %w", err)
 	}
+	nodePoolsData, err := nodePools.ToConfigMap(key)
+	if err != nil {
+		return fmt.Errorf("failed to format node pools configmap data: %w", err)
+	}
+	maps.Copy(cmData, nodePoolsData)
+	// only the arguments of the node services are distributed, the control plane services are configured by the control plane nodes
+	if config.ServiceArgs != nil {
+		serviceArgsData, err := config.ServiceArgs.ForServices(types.NodeServices).ToConfigMap(key)
+		if err != nil {
+			return fmt.Errorf("failed to format service arguments configmap data: %w", err)
+		}
+		maps.Copy(cmData, serviceArgsData)
+	}
+
 	if _, err := client.UpdateConfigMap(ctx, "kube-system", "k8sd-config", cmData); err != nil {
 		return fmt.Errorf("failed to update node config: %w", err)
 	}
@@ -106,6 +148,53 @@ func (c *UpdateNodeConfigurationController) reconcile(ctx context.Context, clien
 	return nil
 }
 
+// reconcileNodePools sets the labels and taints of the node pools on the Kubernetes nodes of their members.
+// reconcileNodePools removes nodes that are no longer members of a node pool from their node pool.
+// reconcileNodePools returns true if any node pool members are not registered in Kubernetes yet.
+func (c *UpdateNodeConfigurationController) reconcileNodePools(ctx context.Context, client *kubernetes.Client, nodePools types.NodePools) (bool, error) {
+	log := log.FromContext(ctx)
+
+	var pending bool
+	for nodeName := range nodePools.Members {
+		pool, ok := nodePools.PoolForNode(nodeName)
+		if !ok {
+			continue
+		}
+		taints := make([]v1.Taint, 0, len(pool.Taints))
+		for _, s := range pool.Taints {
+			taint, err := types.ParseTaint(s)
+			if err != nil {
+				return false, fmt.Errorf("invalid taint %q of node pool %q: %w", s, pool.Name, err)
+			}
+			taints = append(taints, taint)
+		}
+
+		found, err := client.ApplyNodePool(ctx, nodeName, pool.Name, pool.Labels, taints)
+		if err != nil {
+			return false, fmt.Errorf("failed to apply node pool %q on node %q: %w", pool.Name, nodeName, err)
+		}
+		if !found {
+			log.V(1).Info("Node pool member is not registered yet", "node", nodeName, "pool", pool.Name)
+			pending = true
+		}
+	}
+
+	nodeNames, err := client.ListNodePoolNodes(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to list node pool members: %w", err)
+	}
+	for _, nodeName := range nodeNames {
+		if _, ok := nodePools.PoolForNode(nodeName); ok {
+			continue
+		}
+		if _, err := client.ApplyNodePool(ctx, nodeName, "", nil, nil); err != nil {
+			return false, fmt.Errorf("failed to remove node %q from its node pool: %w", nodeName, err)
+		}
+	}
+
+	return pending, nil
+}
+
 // ReconciledCh returns the channel where the controller pushes when a reconciliation loop is finished.
 func (c *UpdateNodeConfigurationController) ReconciledCh() <-chan struct{} {
 	return c.reconciledCh
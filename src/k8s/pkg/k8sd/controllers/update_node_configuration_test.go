@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"context"
 	"crypto/rsa"
+	"maps"
 	"os"
 	"path/filepath"
 	"testing"
@@ -30,7 +31,10 @@ func TestUpdateNodeConfigurationController(t *testing.T) {
 		name            string
 		initialConfig   types.ClusterConfig
 		expectedConfig  types.ClusterConfig
+		nodePools       types.NodePools
 		expectedFailure bool
+		// expectRotateServerCertificates is the kubelet serving certificate rotation setting distributed to the nodes
+		expectRotateServerCertificates bool
 	}{
 		{
 			name:          "ControlPlane_DefaultConfig",
@@ -57,10 +61,46 @@ func TestUpdateNodeConfigurationController(t *testing.T) {
 			expectedFailure: false,
 		},
 		{
+			name:          "ControlPlane_NodePools",
+			initialConfig: types.ClusterConfig{},
+			expectedConfig: types.ClusterConfig{
+				Kubelet: types.Kubelet{
+					ClusterDomain: utils.Pointer("cluster.local"),
+				},
+				Certificates: types.Certificates{
+					K8sdPublicKey:  utils.Pointer(pubPEM),
+					K8sdPrivateKey: utils.Pointer(privPEM),
+				},
+			},
+			nodePools: types.NodePools{
+				Pools: []types.NodePool{
+					{Name: "gpu", Labels: map[string]string{"accelerator": "nvidia"}, Taints: []string{"gpu=true:NoSchedule"}},
+				},
+				Members: map[string]string{"node1": "gpu", "node2": "gpu"},
+			},
+			expectedFailure: false,
+		},
+		{
+			name:          "ControlPlane_RotateServerCertificates",
+			initialConfig: types.ClusterConfig{},
+			expectedConfig: types.ClusterConfig{
+				Kubelet: types.Kubelet{
+					ClusterDomain: utils.Pointer("cluster.local"),
+				},
+				Certificates: types.Certificates{
+					CAKey:          utils.Pointer("ca-key"),
+					K8sdPublicKey:  utils.Pointer(pubPEM),
+					K8sdPrivateKey: utils.Pointer(privPEM),
+				},
+			},
+			expectRotateServerCertificates: true,
+		},
+		{
+			// the node pools configuration is always distributed, even if empty
 			name:            "ControlPlane_EmptyConfig",
 			initialConfig:   types.ClusterConfig{},
 			expectedConfig:  types.ClusterConfig{},
-			expectedFailure: true,
+			expectedFailure: false,
 		},
 	}
 
@@ -83,7 +123,7 @@ func TestUpdateNodeConfigurationController(t *testing.T) {
 				},
 				Data: kubeletConfigMap,
 			}
-			clientset := fake.NewSimpleClientset(configMap)
+			clientset := fake.NewSimpleClientset(configMap, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
 
 			s := &mock.Snap{
 				Mock: mock.Mock{
@@ -98,7 +138,7 @@ func TestUpdateNodeConfigurationController(t *testing.T) {
 			defer close(triggerCh)
 
 			ctrl := controllers.NewUpdateNodeConfigurationController(s, func() {}, triggerCh)
-			go ctrl.Run(ctx, configProvider.getConfig)
+			go ctrl.Run(ctx, configProvider.getConfig, func(ctx context.Context) (types.NodePools, error) { return tc.nodePools, nil })
 
 			select {
 			case triggerCh <- struct{}{}:
@@ -123,13 +163,30 @@ func TestUpdateNodeConfigurationController(t *testing.T) {
 				priv = privKey
 			}
 
-			expectedConfigMap, err := tc.expectedConfig.Kubelet.ToConfigMap(priv)
+			expectedKubelet := tc.expectedConfig.Kubelet
+			expectedKubelet.RotateServerCertificates = utils.Pointer(tc.expectRotateServerCertificates)
+			expectedConfigMap, err := expectedKubelet.ToConfigMap(priv)
+			g.Expect(err).ToNot(HaveOccurred())
+			nodePoolsConfigMap, err := tc.nodePools.ToConfigMap(priv)
 			g.Expect(err).ToNot(HaveOccurred())
+			maps.Copy(expectedConfigMap, nodePoolsConfigMap)
 			if tc.expectedFailure {
 				g.Expect(result.Data).ToNot(Equal(expectedConfigMap))
 			} else {
 				g.Expect(result.Data).To(Equal(expectedConfigMap))
 			}
+
+			node, err := clientset.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+			g.Expect(err).ToNot(HaveOccurred())
+			if pool, ok := tc.nodePools.PoolForNode("node1"); ok {
+				g.Expect(node.Labels).To(HaveKeyWithValue("k8sd.io/node-pool", pool.Name))
+				for key, value := range pool.Labels {
+					g.Expect(node.Labels).To(HaveKeyWithValue(key, value))
+				}
+				g.Expect(node.Spec.Taints).To(HaveLen(len(pool.Taints)))
+			} else {
+				g.Expect(node.Labels).To(BeEmpty())
+			}
 		})
 	}
 }
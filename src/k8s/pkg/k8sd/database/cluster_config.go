@@ -57,6 +57,27 @@ func GetClusterConfig(ctx context.Context, tx *sql.Tx) (types.ClusterConfig, err
 		return types.ClusterConfig{}, fmt.Errorf("failed to retrieve v1alpha2 config: %w", err)
 	}
 
+	return parseClusterConfig(s)
+}
+
+// ClusterConfigQuery is a read-only query that retrieves the cluster configuration, see ClusterConfigFromRows.
+var ClusterConfigQuery = mustReadQuery("cluster-configs", "select-v1alpha2.sql")
+
+// ClusterConfigFromRows decodes the cluster configuration from the rows returned by ClusterConfigQuery.
+func ClusterConfigFromRows(rows [][]any) (types.ClusterConfig, error) {
+	if len(rows) == 0 {
+		return types.ClusterConfig{}, nil
+	}
+
+	var s string
+	if err := sliceScanner(rows[0])(&s); err != nil {
+		return types.ClusterConfig{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return parseClusterConfig(s)
+}
+
+// parseClusterConfig parses a cluster configuration, as stored in the database.
+func parseClusterConfig(s string) (types.ClusterConfig, error) {
 	var clusterConfig types.ClusterConfig
 	if err := json.Unmarshal([]byte(s), &clusterConfig); err != nil {
 		return types.ClusterConfig{}, fmt.Errorf("failed to parse v1alpha2 config: %w", err)
@@ -64,3 +85,28 @@ func GetClusterConfig(ctx context.Context, tx *sql.Tx) (types.ClusterConfig, err
 
 	return clusterConfig, nil
 }
+
+// RedactClusterConfig returns a copy of the cluster configuration with the private keys replaced by RedactedValue.
+func RedactClusterConfig(config types.ClusterConfig) types.ClusterConfig {
+	c := &config.Certificates
+	d := &config.Datastore
+	for _, v := range []**string{
+		&c.CAKey, &c.ClientCAKey, &c.FrontProxyCAKey, &c.ServiceAccountKey,
+		&c.APIServerKubeletClientKey, &c.AdminClientKey, &c.K8sdPrivateKey,
+		&d.K8sDqliteKey, &d.ExternalClientKey,
+	} {
+		if *v != nil {
+			redacted := RedactedValue
+			*v = &redacted
+		}
+	}
+	if c.SecretsEncryptionKeys != nil {
+		keys := make([]types.SecretsEncryptionKey, 0, len(*c.SecretsEncryptionKeys))
+		for _, key := range *c.SecretsEncryptionKeys {
+			key.Secret = RedactedValue
+			keys = append(keys, key)
+		}
+		c.SecretsEncryptionKeys = &keys
+	}
+	return config
+}
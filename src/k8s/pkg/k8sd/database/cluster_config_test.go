@@ -121,3 +121,37 @@ func TestClusterConfig(t *testing.T) {
 		})
 	})
 }
+
+func TestRedactClusterConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	config := types.ClusterConfig{
+		Certificates: types.Certificates{
+			CACert:         utils.Pointer("CA CERT"),
+			CAKey:          utils.Pointer("CA KEY"),
+			K8sdPublicKey:  utils.Pointer("PUBLIC KEY"),
+			K8sdPrivateKey: utils.Pointer("PRIVATE KEY"),
+			SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{
+				{Name: "key1", Provider: "aescbc", Secret: "SECRET"},
+			},
+		},
+		Datastore: types.Datastore{
+			ExternalClientKey: utils.Pointer("CLIENT KEY"),
+		},
+	}
+
+	redacted := database.RedactClusterConfig(config)
+	g.Expect(redacted.Certificates.CACert).To(Equal(utils.Pointer("CA CERT")))
+	g.Expect(redacted.Certificates.CAKey).To(Equal(utils.Pointer(database.RedactedValue)))
+	g.Expect(redacted.Certificates.K8sdPublicKey).To(Equal(utils.Pointer("PUBLIC KEY")))
+	g.Expect(redacted.Certificates.K8sdPrivateKey).To(Equal(utils.Pointer(database.RedactedValue)))
+	g.Expect(redacted.Certificates.ClientCAKey).To(BeNil())
+	g.Expect(redacted.Datastore.ExternalClientKey).To(Equal(utils.Pointer(database.RedactedValue)))
+	g.Expect(*redacted.Certificates.SecretsEncryptionKeys).To(Equal([]types.SecretsEncryptionKey{
+		{Name: "key1", Provider: "aescbc", Secret: database.RedactedValue},
+	}))
+
+	// the original configuration is not modified
+	g.Expect(config.Certificates.CAKey).To(Equal(utils.Pointer("CA KEY")))
+	g.Expect((*config.Certificates.SecretsEncryptionKeys)[0].Secret).To(Equal("SECRET"))
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -23,12 +24,19 @@ func SetFeatureStatus(ctx context.Context, tx *sql.Tx, name types.FeatureName, s
 		return fmt.Errorf("failed to prepare upsert statement: %w", err)
 	}
 
+	conditions, err := json.Marshal(status.Conditions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature conditions: %w", err)
+	}
+
 	if _, err := upsertTxStmt.ExecContext(ctx,
 		name,
 		status.Message,
 		status.Version,
 		status.UpdatedAt.Format(time.RFC3339),
 		status.Enabled,
+		status.Health,
+		string(conditions),
 	); err != nil {
 		return fmt.Errorf("failed to execute upsert statement: %w", err)
 	}
@@ -51,21 +59,11 @@ func GetFeatureStatuses(ctx context.Context, tx *sql.Tx) (map[types.FeatureName]
 	result := make(map[types.FeatureName]types.FeatureStatus)
 
 	for rows.Next() {
-		var (
-			name   string
-			ts     string
-			status types.FeatureStatus
-		)
-
-		if err := rows.Scan(&name, &status.Message, &status.Version, &ts, &status.Enabled); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+		name, status, err := scanFeatureStatus(ctx, rows.Scan)
+		if err != nil {
+			return nil, err
 		}
-
-		if status.UpdatedAt, err = time.Parse(time.RFC3339, ts); err != nil {
-			log.FromContext(ctx).Error(err, "failed to parse time", "original", ts)
-		}
-
-		result[types.FeatureName(name)] = status
+		result[name] = status
 	}
 
 	if rows.Err() != nil {
@@ -74,3 +72,46 @@ func GetFeatureStatuses(ctx context.Context, tx *sql.Tx) (map[types.FeatureName]
 
 	return result, nil
 }
+
+// FeatureStatusQuery is a read-only query that retrieves the status of all features, see FeatureStatusesFromRows.
+var FeatureStatusQuery = mustReadQuery("feature-status", "select.sql")
+
+// FeatureStatusesFromRows decodes the feature statuses from the rows returned by FeatureStatusQuery.
+func FeatureStatusesFromRows(ctx context.Context, rows [][]any) (map[types.FeatureName]types.FeatureStatus, error) {
+	result := make(map[types.FeatureName]types.FeatureStatus, len(rows))
+	for _, row := range rows {
+		name, status, err := scanFeatureStatus(ctx, sliceScanner(row))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = status
+	}
+	return result, nil
+}
+
+// scanFeatureStatus scans a row of the feature status select query.
+func scanFeatureStatus(ctx context.Context, scan rowScanner) (types.FeatureName, types.FeatureStatus, error) {
+	var (
+		name       string
+		ts         string
+		health     string
+		conditions string
+		status     types.FeatureStatus
+	)
+
+	if err := scan(&name, &status.Message, &status.Version, &ts, &status.Enabled, &health, &conditions); err != nil {
+		return "", types.FeatureStatus{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+	status.Health = types.FeatureHealth(health)
+
+	var err error
+	if status.UpdatedAt, err = time.Parse(time.RFC3339, ts); err != nil {
+		log.FromContext(ctx).Error(err, "failed to parse time", "original", ts)
+	}
+
+	if err := json.Unmarshal([]byte(conditions), &status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "failed to parse feature conditions", "original", conditions)
+	}
+
+	return types.FeatureName(name), status, nil
+}
@@ -110,6 +110,31 @@ func TestFeatureStatus(t *testing.T) {
 				g.Expect(ss[features.Gateway].Version).To(Equal(gatewayStatus.Version))
 				g.Expect(ss[features.Gateway].UpdatedAt).To(Equal(gatewayStatus.UpdatedAt))
 			})
+			t.Run("Conditions", func(t *testing.T) {
+				g := NewWithT(t)
+
+				status := networkStatus
+				status.SetCondition(types.FeatureCondition{
+					Type:               types.FeatureConditionHealthy,
+					Status:             types.ConditionFalse,
+					Reason:             "CheckFailed",
+					Message:            "cilium pods not yet ready",
+					LastTransitionTime: t0,
+				})
+
+				err := database.SetFeatureStatus(ctx, tx, features.Network, status)
+				g.Expect(err).To(Not(HaveOccurred()))
+
+				ss, err := database.GetFeatureStatuses(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(ss[features.Network].Health).To(Equal(types.FeatureHealthUnhealthy))
+				g.Expect(ss[features.Network].Conditions).To(HaveLen(1))
+				g.Expect(ss[features.Network].Conditions[0].Reason).To(Equal("CheckFailed"))
+				g.Expect(ss[features.Network].Conditions[0].LastTransitionTime.Equal(t0)).To(BeTrue())
+
+				// statuses without conditions are unaffected
+				g.Expect(ss[features.DNS].Conditions).To(BeEmpty())
+			})
 
 			return nil
 		})
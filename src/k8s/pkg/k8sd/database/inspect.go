@@ -0,0 +1,376 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Schema maps the name of each k8sd table to its columns.
+type Schema map[string][]string
+
+var (
+	createTableRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+TABLE\s+(\w+)\s*\((.*)\)\s*;?\s*$`)
+	addColumnRegexp   = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(\w+)\s+ADD\s+COLUMN\s+(\w+)`)
+	dropTableRegexp   = regexp.MustCompile(`(?is)^\s*DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(\w+)`)
+	columnNameRegexp  = regexp.MustCompile(`^\w+`)
+)
+
+// LoadSchema computes the schema of the k8sd tables from the migrations in sql/migrations.
+func LoadSchema() (Schema, error) {
+	dirs, err := sqlMigrations.ReadDir("sql/migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	schema := make(Schema)
+	for _, dir := range dirs {
+		dirPath := path.Join("sql/migrations", dir.Name())
+		// migration files are numbered, and each directory only has migrations for its own tables
+		files, err := fs.Glob(sqlMigrations, path.Join(dirPath, "*.sql"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list migrations in %s: %w", dirPath, err)
+		}
+		slices.Sort(files)
+
+		for _, file := range files {
+			b, err := sqlMigrations.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+			}
+			if err := schema.applyMigration(string(b)); err != nil {
+				return nil, fmt.Errorf("failed to parse migration %s: %w", file, err)
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+// applyMigration updates the schema with a migration.
+func (s Schema) applyMigration(migration string) error {
+	if m := createTableRegexp.FindStringSubmatch(migration); m != nil {
+		var columns []string
+		for _, definition := range splitColumnDefinitions(m[2]) {
+			name := columnNameRegexp.FindString(definition)
+			switch strings.ToUpper(name) {
+			case "UNIQUE", "PRIMARY", "FOREIGN", "CHECK", "CONSTRAINT":
+				// table constraint
+			default:
+				columns = append(columns, name)
+			}
+		}
+		s[m[1]] = columns
+		return nil
+	}
+	if m := addColumnRegexp.FindStringSubmatch(migration); m != nil {
+		if _, ok := s[m[1]]; !ok {
+			return fmt.Errorf("table %q does not exist", m[1])
+		}
+		s[m[1]] = append(s[m[1]], m[2])
+		return nil
+	}
+	if m := dropTableRegexp.FindStringSubmatch(migration); m != nil {
+		delete(s, m[1])
+		return nil
+	}
+	return fmt.Errorf("unsupported migration statement")
+}
+
+// splitColumnDefinitions splits the body of a CREATE TABLE statement on the commas that are not in parentheses.
+func splitColumnDefinitions(body string) []string {
+	var (
+		definitions []string
+		depth       int
+		start       int
+	)
+	for i, c := range body {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				definitions = append(definitions, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	definitions = append(definitions, body[start:])
+
+	result := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition = strings.TrimSpace(definition); definition != "" {
+			result = append(result, definition)
+		}
+	}
+	return result
+}
+
+// Tables returns the names of the tables in the schema, in alphabetical order.
+func (s Schema) Tables() []string {
+	tables := make([]string, 0, len(s))
+	for table := range s {
+		tables = append(tables, table)
+	}
+	slices.Sort(tables)
+	return tables
+}
+
+var (
+	// forbiddenQueryKeywords are keywords that are not allowed in read-only queries.
+	forbiddenQueryKeywords = []string{
+		"INSERT", "UPDATE", "DELETE", "REPLACE", "UPSERT", "CREATE", "DROP", "ALTER", "ATTACH", "DETACH",
+		"PRAGMA", "VACUUM", "REINDEX", "ANALYZE", "BEGIN", "COMMIT", "ROLLBACK", "SAVEPOINT", "RELEASE", "LOAD_EXTENSION",
+	}
+	queryTokenRegexp = regexp.MustCompile(`'(?:[^']|'')*'|"(?:[^"]|"")*"|[A-Za-z_][A-Za-z0-9_]*|\S`)
+)
+
+// ValidateReadOnlyQuery returns an error if the query is not a single SELECT statement that only reads
+// tables of the schema.
+func (s Schema) ValidateReadOnlyQuery(query string) error {
+	_, _, err := s.parseReadOnlyQuery(query)
+	return err
+}
+
+// tableReference is a reference to a table in a query.
+type tableReference struct {
+	// name is the name of the table.
+	name string
+	// start and end are the position of the table name in the query.
+	start, end int
+	// aliased is true if the reference is followed by an alias.
+	aliased bool
+}
+
+// notAliasKeywords are the keywords that may follow a table reference and are not an alias of the table.
+var notAliasKeywords = []string{
+	"WHERE", "JOIN", "LEFT", "RIGHT", "FULL", "INNER", "OUTER", "CROSS", "NATURAL", "ON", "USING",
+	"GROUP", "ORDER", "LIMIT", "HAVING", "UNION", "EXCEPT", "INTERSECT", "WINDOW", "INDEXED", "NOT",
+}
+
+// parseReadOnlyQuery validates a read-only query, see ValidateReadOnlyQuery.
+// parseReadOnlyQuery returns the trimmed query and the references to the tables it reads.
+func (s Schema) parseReadOnlyQuery(query string) (string, []tableReference, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if query == "" {
+		return "", nil, fmt.Errorf("query is empty")
+	}
+	if strings.Contains(query, "--") || strings.Contains(query, "/*") {
+		return "", nil, fmt.Errorf("comments are not allowed")
+	}
+
+	positions := queryTokenRegexp.FindAllStringIndex(query, -1)
+	tokens := make([]string, 0, len(positions))
+	for _, position := range positions {
+		tokens = append(tokens, query[position[0]:position[1]])
+	}
+	if len(tokens) == 0 || !strings.EqualFold(tokens[0], "SELECT") {
+		return "", nil, fmt.Errorf("only SELECT queries are allowed")
+	}
+
+	// tables are referenced after FROM and JOIN, and after commas in the FROM clause.
+	// inFrom tracks the FROM clauses of the query and its subqueries, by nesting level.
+	var (
+		tables      []tableReference
+		expectTable bool
+		inFrom      = []bool{false}
+	)
+	for idx, token := range tokens {
+		upper := strings.ToUpper(token)
+		depth := len(inFrom) - 1
+		switch {
+		case token == ";":
+			return "", nil, fmt.Errorf("only a single statement is allowed")
+		case slices.Contains(forbiddenQueryKeywords, upper):
+			return "", nil, fmt.Errorf("%s is not allowed", upper)
+		case token == "(":
+			inFrom = append(inFrom, false)
+			expectTable = false
+		case token == ")":
+			if depth == 0 {
+				return "", nil, fmt.Errorf("unbalanced parentheses")
+			}
+			inFrom = inFrom[:depth]
+		case upper == "FROM" || upper == "JOIN":
+			inFrom[depth] = true
+			expectTable = true
+		case token == "," && inFrom[depth]:
+			expectTable = true
+		case upper == "WHERE" || upper == "GROUP" || upper == "ORDER" || upper == "LIMIT" || upper == "HAVING" || upper == "UNION":
+			inFrom[depth] = false
+			expectTable = false
+		case expectTable:
+			table := strings.Trim(token, "\"`")
+			if _, ok := s[table]; !ok {
+				return "", nil, fmt.Errorf("table %q is not one of the k8sd tables %v", table, s.Tables())
+			}
+			ref := tableReference{name: table, start: positions[idx][0], end: positions[idx][1]}
+			if idx+1 < len(tokens) {
+				next := tokens[idx+1]
+				ref.aliased = strings.HasPrefix(next, `"`) || columnNameRegexp.MatchString(next) && !slices.Contains(notAliasKeywords, strings.ToUpper(next))
+			}
+			tables = append(tables, ref)
+			expectTable = false
+		}
+	}
+	if len(inFrom) != 1 {
+		return "", nil, fmt.Errorf("unbalanced parentheses")
+	}
+
+	return query, tables, nil
+}
+
+// RedactedValue replaces secrets in the results of read-only queries.
+const RedactedValue = "<redacted>"
+
+// redactedColumns are the columns of the k8sd tables that hold secrets.
+// The value column of cluster_configs holds the cluster configuration, of which only the private keys are redacted.
+var redactedColumns = map[string][]string{
+	"cluster_configs":        {"value"},
+	"kubernetes_auth_tokens": {"token"},
+	"worker_tokens":          {"token"},
+}
+
+// ReadOnlyQuery runs a read-only query, see ValidateReadOnlyQuery, and returns the columns and rows of the result.
+// The secrets in the k8sd tables are redacted, see RedactClusterConfig.
+func (s Schema) ReadOnlyQuery(ctx context.Context, tx *sql.Tx, query string) ([]string, [][]any, error) {
+	config, err := GetClusterConfig(ctx, tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cluster config: %w", err)
+	}
+	b, err := json.Marshal(RedactClusterConfig(config))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode cluster config: %w", err)
+	}
+
+	query, args, err := s.redactReadOnlyQuery(query, string(b))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	var result [][]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for idx := range values {
+			pointers[idx] = &values[idx]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		for idx, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[idx] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+	return columns, result, nil
+}
+
+// redactReadOnlyQuery validates a read-only query and replaces the references to tables with secrets with subqueries
+// that return the redacted values instead, so that the secrets can not be read through functions or expressions either.
+// redactedClusterConfig is the redacted cluster configuration that replaces the stored one.
+// redactReadOnlyQuery returns the query and its arguments.
+func (s Schema) redactReadOnlyQuery(query string, redactedClusterConfig string) (string, []any, error) {
+	query, tables, err := s.parseReadOnlyQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		b    strings.Builder
+		args []any
+		last int
+	)
+	for _, table := range tables {
+		secrets, ok := redactedColumns[table.name]
+		if !ok {
+			continue
+		}
+
+		columns := make([]string, 0, len(s[table.name]))
+		for _, column := range s[table.name] {
+			switch {
+			case table.name == "cluster_configs" && column == "value":
+				// the other keys hold tokens, e.g. the Cluster API token
+				columns = append(columns, fmt.Sprintf("CASE key WHEN 'v1alpha2' THEN ? ELSE '%s' END AS value", RedactedValue))
+				args = append(args, redactedClusterConfig)
+			case slices.Contains(secrets, column):
+				columns = append(columns, fmt.Sprintf("'%s' AS %s", RedactedValue, column))
+			default:
+				columns = append(columns, column)
+			}
+		}
+
+		b.WriteString(query[last:table.start])
+		fmt.Fprintf(&b, "(SELECT %s FROM %s)", strings.Join(columns, ", "), table.name)
+		if !table.aliased {
+			fmt.Fprintf(&b, " AS %s", table.name)
+		}
+		last = table.end
+	}
+	b.WriteString(query[last:])
+
+	return b.String(), args, nil
+}
+
+// rowScanner copies the columns of a row into the values pointed at by dest, like sql.Rows.Scan.
+type rowScanner func(dest ...any) error
+
+// sliceScanner returns a rowScanner for a row that was returned by the SQL API of the daemon.
+// The values of the row are decoded from JSON, so only strings, numbers, booleans and nil are expected.
+// Booleans are stored as integers, so numbers can be scanned as booleans.
+func sliceScanner(row []any) rowScanner {
+	return func(dest ...any) error {
+		if len(dest) != len(row) {
+			return fmt.Errorf("expected %d destination arguments, got %d", len(row), len(dest))
+		}
+		for i, value := range row {
+			switch d := dest[i].(type) {
+			case *string:
+				switch v := value.(type) {
+				case string:
+					*d = v
+				case nil:
+					*d = ""
+				default:
+					*d = fmt.Sprint(v)
+				}
+			case *bool:
+				switch v := value.(type) {
+				case bool:
+					*d = v
+				case float64:
+					*d = v != 0
+				default:
+					return fmt.Errorf("column %d: cannot convert %T to bool", i, value)
+				}
+			default:
+				return fmt.Errorf("column %d: unsupported destination type %T", i, dest[i])
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRedactReadOnlyQuery(t *testing.T) {
+	schema, err := LoadSchema()
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	const (
+		clusterConfigsSubquery = "(SELECT id, key, CASE key WHEN 'v1alpha2' THEN ? ELSE '<redacted>' END AS value FROM cluster_configs)"
+		workerTokensSubquery   = "(SELECT id, name, '<redacted>' AS token, expiry, multi_use, allowed_cidrs, pool FROM worker_tokens)"
+	)
+
+	for _, tc := range []struct {
+		name          string
+		query         string
+		expectedQuery string
+		expectedArgs  []any
+		expectError   bool
+	}{
+		{
+			name:          "NoSecrets",
+			query:         "SELECT * FROM feature_status;",
+			expectedQuery: "SELECT * FROM feature_status",
+		},
+		{
+			name:          "ClusterConfigs",
+			query:         "SELECT value FROM cluster_configs WHERE key = 'v1alpha2'",
+			expectedQuery: "SELECT value FROM " + clusterConfigsSubquery + " AS cluster_configs WHERE key = 'v1alpha2'",
+			expectedArgs:  []any{"CONFIG"},
+		},
+		{
+			name:          "Alias",
+			query:         "SELECT c.value FROM cluster_configs AS c",
+			expectedQuery: "SELECT c.value FROM " + clusterConfigsSubquery + " AS c",
+			expectedArgs:  []any{"CONFIG"},
+		},
+		{
+			name:          "Functions",
+			query:         "SELECT substr(token, 1, 5) FROM worker_tokens w, node_pools",
+			expectedQuery: "SELECT substr(token, 1, 5) FROM " + workerTokensSubquery + " w, node_pools",
+		},
+		{
+			name:          "Subquery",
+			query:         "SELECT name FROM node_pools WHERE name IN (SELECT pool FROM worker_tokens)",
+			expectedQuery: "SELECT name FROM node_pools WHERE name IN (SELECT pool FROM " + workerTokensSubquery + " AS worker_tokens)",
+		},
+		{
+			name:        "Invalid",
+			query:       "DELETE FROM worker_tokens",
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			query, args, err := schema.redactReadOnlyQuery(tc.query, "CONFIG")
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(query).To(Equal(tc.expectedQuery))
+			g.Expect(args).To(Equal(tc.expectedArgs))
+		})
+	}
+}
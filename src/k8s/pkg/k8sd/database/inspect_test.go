@@ -0,0 +1,177 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	testenv "github.com/canonical/k8s/pkg/utils/microcluster"
+	"github.com/canonical/microcluster/v2/state"
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	schema, err := database.LoadSchema()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(schema.Tables()).To(Equal([]string{
+		"cluster_configs",
+		"feature_status",
+		"kubernetes_auth_tokens",
+		"node_pool_members",
+		"node_pools",
+		"restart_leases",
+		"worker_tokens",
+	}))
+	g.Expect(schema["feature_status"]).To(Equal([]string{"id", "name", "message", "version", "timestamp", "enabled", "health", "conditions"}))
+	g.Expect(schema["restart_leases"]).To(Equal([]string{"id", "name", "holder", "expiry"}))
+	g.Expect(schema["worker_tokens"]).To(Equal([]string{"id", "name", "token", "expiry", "multi_use", "allowed_cidrs", "pool"}))
+}
+
+func TestValidateReadOnlyQuery(t *testing.T) {
+	schema, err := database.LoadSchema()
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	for _, tc := range []struct {
+		name        string
+		query       string
+		expectError string
+	}{
+		{name: "Select", query: "SELECT * FROM feature_status"},
+		{name: "TrailingSemicolon", query: "select name, enabled from feature_status where enabled = 1;"},
+		{name: "Join", query: "SELECT p.name, m.node_name FROM node_pools AS p JOIN node_pool_members m ON m.pool = p.name"},
+		{name: "MultipleTables", query: "SELECT COUNT(*) FROM node_pools, node_pool_members"},
+		{name: "Subquery", query: "SELECT * FROM (SELECT name FROM node_pools) AS p, node_pool_members"},
+		{name: "QuotedTable", query: `SELECT * FROM "cluster_configs" WHERE key = 'v1alpha2'`},
+		{name: "KeywordInString", query: "SELECT * FROM feature_status WHERE message = 'DELETE; DROP'"},
+		{name: "Empty", query: " ; ", expectError: "query is empty"},
+		{name: "Insert", query: "INSERT INTO node_pools (name, config) VALUES ('a', '{}')", expectError: "only SELECT queries are allowed"},
+		{name: "Pragma", query: "PRAGMA table_info(node_pools)", expectError: "only SELECT queries are allowed"},
+		{name: "MultipleStatements", query: "SELECT * FROM node_pools; DELETE FROM node_pools", expectError: "only a single statement is allowed"},
+		{name: "Comment", query: "SELECT * FROM node_pools -- comment", expectError: "comments are not allowed"},
+		{name: "UnknownTable", query: "SELECT * FROM sqlite_master", expectError: `table "sqlite_master" is not one of the k8sd tables`},
+		{name: "DroppedTable", query: "SELECT * FROM worker_nodes", expectError: `table "worker_nodes" is not one of the k8sd tables`},
+		{name: "UnknownTableAfterSubquery", query: "SELECT * FROM (SELECT name FROM node_pools) AS p, sqlite_master", expectError: `table "sqlite_master" is not one of the k8sd tables`},
+		{name: "UnknownTableInSubquery", query: "SELECT * FROM node_pools WHERE name IN (SELECT name FROM sqlite_master)", expectError: `table "sqlite_master" is not one of the k8sd tables`},
+		{name: "UnbalancedParentheses", query: "SELECT COUNT(* FROM node_pools", expectError: "unbalanced parentheses"},
+		{name: "LoadExtension", query: "SELECT load_extension('x') FROM node_pools", expectError: "LOAD_EXTENSION is not allowed"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := schema.ValidateReadOnlyQuery(tc.query)
+			if tc.expectError == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectError)))
+			}
+		})
+	}
+}
+
+func TestReadOnlyQuery(t *testing.T) {
+	testenv.WithState(t, func(ctx context.Context, s state.State) {
+		schema, err := database.LoadSchema()
+		NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+		err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := database.SetClusterConfig(ctx, tx, types.ClusterConfig{
+				Certificates: types.Certificates{CACert: utils.Pointer("CA CERT"), CAKey: utils.Pointer("CA KEY")},
+			}); err != nil {
+				return err
+			}
+			_, err := database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{Name: "worker", Expiry: time.Now().Add(time.Hour)})
+			return err
+		})
+		NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+		t.Run("ClusterConfig", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				columns, rows, err := schema.ReadOnlyQuery(ctx, tx, "SELECT c.value, substr(c.value, 1) FROM cluster_configs AS c WHERE c.key = 'v1alpha2'")
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(columns).To(HaveLen(2))
+				g.Expect(rows).To(HaveLen(1))
+				for _, value := range rows[0] {
+					g.Expect(value).To(ContainSubstring("CA CERT"))
+					g.Expect(value).To(ContainSubstring(database.RedactedValue))
+					g.Expect(value).ToNot(ContainSubstring("CA KEY"))
+				}
+				return nil
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+
+		t.Run("WorkerTokens", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				_, rows, err := schema.ReadOnlyQuery(ctx, tx, "SELECT name, token FROM worker_tokens")
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(rows).To(ConsistOf([]any{"worker", database.RedactedValue}))
+				return nil
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				_, _, err := schema.ReadOnlyQuery(ctx, tx, "DELETE FROM worker_tokens")
+				g.Expect(err).To(HaveOccurred())
+				return nil
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	})
+}
+
+func TestClusterConfigFromRows(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		g := NewWithT(t)
+		config, err := database.ClusterConfigFromRows(nil)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config).To(Equal(types.ClusterConfig{}))
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		g := NewWithT(t)
+		config, err := database.ClusterConfigFromRows([][]any{{`{"certificates":{"ca-crt":"CA"},"network":{"enabled":true}}`}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config).To(Equal(types.ClusterConfig{
+			Certificates: types.Certificates{CACert: utils.Pointer("CA")},
+			Network:      types.Network{Enabled: utils.Pointer(true)},
+		}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := database.ClusterConfigFromRows([][]any{{"{"}})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestFeatureStatusesFromRows(t *testing.T) {
+	g := NewWithT(t)
+
+	t0, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	// rows are decoded from JSON, booleans are stored as integers
+	statuses, err := database.FeatureStatusesFromRows(context.Background(), [][]any{
+		{"network", "enabled", "1.2.3", t0.Format(time.RFC3339), float64(1), "healthy", `[{"type":"Ready","status":"True"}]`},
+		{"dns", "disabled", "4.5.6", t0.Format(time.RFC3339), float64(0), "", "[]"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(2))
+	g.Expect(statuses["network"].Enabled).To(BeTrue())
+	g.Expect(statuses["network"].Version).To(Equal("1.2.3"))
+	g.Expect(statuses["network"].UpdatedAt).To(Equal(t0))
+	g.Expect(statuses["network"].Conditions).To(HaveLen(1))
+	g.Expect(statuses["dns"].Enabled).To(BeFalse())
+	g.Expect(statuses["dns"].Message).To(Equal("disabled"))
+
+	_, err = database.FeatureStatusesFromRows(context.Background(), [][]any{{"network"}})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -2,25 +2,40 @@ package database
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
 	_ "embed"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
 
 	"github.com/canonical/microcluster/v2/cluster"
 )
 
+// ErrTokenNotFound is returned when the requested k8s auth token does not exist.
+var ErrTokenNotFound = errors.New("token not found")
+
 var k8sdTokensStmts = map[string]int{
-	"insert-token":       MustPrepareStatement("kubernetes-auth-tokens", "insert-token.sql"),
-	"select-by-token":    MustPrepareStatement("kubernetes-auth-tokens", "select-by-token.sql"),
-	"select-by-username": MustPrepareStatement("kubernetes-auth-tokens", "select-by-username.sql"),
-	"delete-by-token":    MustPrepareStatement("kubernetes-auth-tokens", "delete-by-token.sql"),
-	"delete-by-username": MustPrepareStatement("kubernetes-auth-tokens", "delete-by-username.sql"),
+	"insert-token":        MustPrepareStatement("kubernetes-auth-tokens", "insert-token.sql"),
+	"select-by-token":     MustPrepareStatement("kubernetes-auth-tokens", "select-by-token.sql"),
+	"select-all":          MustPrepareStatement("kubernetes-auth-tokens", "select-all.sql"),
+	"update-last-used-at": MustPrepareStatement("kubernetes-auth-tokens", "update-last-used-at.sql"),
+	"update-token":        MustPrepareStatement("kubernetes-auth-tokens", "update-token.sql"),
+	"delete-by-id":        MustPrepareStatement("kubernetes-auth-tokens", "delete-by-id.sql"),
+	"delete-by-token":     MustPrepareStatement("kubernetes-auth-tokens", "delete-by-token.sql"),
+	"delete-by-username":  MustPrepareStatement("kubernetes-auth-tokens", "delete-by-username.sql"),
 }
 
+// lastUsedAtUpdateInterval is the minimum interval between updates of the last used time of a token.
+const lastUsedAtUpdateInterval = time.Minute
+
 func groupsToString(inGroups []string) (string, error) {
 	groupMap := make(map[string]struct{}, len(inGroups))
 	groups := make([]string, 0, len(inGroups))
@@ -45,61 +60,232 @@ func groupsToList(inGroups string) []string {
 	return strings.Split(inGroups, ",")
 }
 
+// hashToken returns the representation of a token that is stored in the database.
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
 // CheckToken returns the username and groups of a token (if valid).
-// CheckToken returns an error in case the token is not valid.
+// CheckToken returns an error in case the token is not valid or has expired.
+// CheckToken records the time the token was last used.
 func CheckToken(ctx context.Context, tx *sql.Tx, token string) (string, []string, error) {
 	txStmt, err := cluster.Stmt(tx, k8sdTokensStmts["select-by-token"])
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
-	var username, groupsString string
-	if err := txStmt.QueryRowContext(ctx, token).Scan(&username, &groupsString); err != nil {
+	var (
+		id                 int64
+		username, groups   string
+		expiry, lastUsedAt sql.NullTime
+	)
+	if err := txStmt.QueryRowContext(ctx, hashToken(token)).Scan(&id, &username, &groups, &expiry, &lastUsedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return "", nil, fmt.Errorf("invalid token")
 		}
 		return "", nil, fmt.Errorf("failed to check token: %w", err)
 	}
 
-	return username, groupsToList(groupsString), nil
+	now := time.Now()
+	if expiry.Valid && now.After(expiry.Time) {
+		return "", nil, fmt.Errorf("token expired")
+	}
+
+	// avoid a database write for every single authentication request
+	if !lastUsedAt.Valid || now.Sub(lastUsedAt.Time) > lastUsedAtUpdateInterval {
+		updateTxStmt, err := cluster.Stmt(tx, k8sdTokensStmts["update-last-used-at"])
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to prepare update statement: %w", err)
+		}
+		if _, err := updateTxStmt.ExecContext(ctx, now, id); err != nil {
+			return "", nil, fmt.Errorf("failed to update token last used time: %w", err)
+		}
+	}
+
+	return username, groupsToList(groups), nil
+}
+
+// CreateToken creates a new token for the specified identity (username and groups).
+// A zero ttl means that the token never expires.
+// CreateToken returns the ID of the token and the token itself. Only a hash of the token is stored,
+// so it cannot be retrieved again later.
+// CreateToken returns an error in case the username is empty or a token could not be generated.
+func CreateToken(ctx context.Context, tx *sql.Tx, username string, groups []string, description string, ttl time.Duration) (int64, string, error) {
+	if username == "" {
+		return 0, "", fmt.Errorf("username cannot be empty")
+	}
+	if ttl < 0 {
+		return 0, "", fmt.Errorf("ttl cannot be negative")
+	}
+	groupsString, err := groupsToString(groups)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid groups: %w", err)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return 0, "", err
+	}
+
+	var expiry sql.NullTime
+	if ttl > 0 {
+		expiry = sql.NullTime{Time: time.Now().Add(ttl), Valid: true}
+	}
+
+	id, err := insertToken(ctx, tx, username, groupsString, token, description, expiry)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, token, nil
 }
 
-// GetOrCreateToken returns a token that matches the specified identify (username and groups).
-// GetOrCreateToken will create an existing token (if available).
-// GetOrCreateToken will create a new token otherwise.
-// GetOrCreateToken returns an error in case the auth is empty or a token could not be generated.
-func GetOrCreateToken(ctx context.Context, tx *sql.Tx, username string, groups []string) (string, error) {
+// GetOrCreateToken returns a token that never expires for the specified identity (username and groups) and description.
+// GetOrCreateToken returns the existing token, if one was created by GetOrCreateToken before and has not been revoked,
+// so that repeated requests do not pile up tokens. GetOrCreateToken creates a new token otherwise.
+// Only a hash of the token is stored. The token is derived from its ID and the k8sd private key of the cluster,
+// so that it can be returned again. If the cluster has no k8sd private key, a new token is always created.
+// GetOrCreateToken returns the ID of the token and the token itself.
+func GetOrCreateToken(ctx context.Context, tx *sql.Tx, username string, groups []string, description string) (int64, string, error) {
 	if username == "" {
-		return "", fmt.Errorf("username cannot be empty")
+		return 0, "", fmt.Errorf("username cannot be empty")
 	}
 	groupsString, err := groupsToString(groups)
 	if err != nil {
-		return "", fmt.Errorf("invalid groups: %w", err)
+		return 0, "", fmt.Errorf("invalid groups: %w", err)
+	}
+
+	config, err := GetClusterConfig(ctx, tx)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get cluster config: %w", err)
+	}
+	key := config.Certificates.GetK8sdPrivateKey()
+	if key == "" {
+		return CreateToken(ctx, tx, username, groups, description, 0)
+	}
+
+	tokens, err := ListTokens(ctx, tx)
+	if err != nil {
+		return 0, "", err
+	}
+	for _, existing := range tokens {
+		if existing.Username != username || strings.Join(existing.Groups, ",") != groupsString || existing.Description != description || !existing.Expiry.IsZero() {
+			continue
+		}
+		// tokens created with CreateToken match the identity too, but not the derived token
+		token := derivedToken(key, existing.ID)
+		if id, err := GetTokenID(ctx, tx, token); err == nil && id == existing.ID {
+			return id, token, nil
+		}
+	}
+
+	// the token is derived from the ID, which is only known after the insert
+	placeholder, err := randomToken()
+	if err != nil {
+		return 0, "", err
+	}
+	id, err := insertToken(ctx, tx, username, groupsString, placeholder, description, sql.NullTime{})
+	if err != nil {
+		return 0, "", err
 	}
-	selectTxStmt, err := cluster.Stmt(tx, k8sdTokensStmts["select-by-username"])
+	token := derivedToken(key, id)
+
+	updateTxStmt, err := cluster.Stmt(tx, k8sdTokensStmts["update-token"])
 	if err != nil {
-		return "", fmt.Errorf("failed to prepare select statement: %w", err)
+		return 0, "", fmt.Errorf("failed to prepare update statement: %w", err)
 	}
-	var token string
-	if selectTxStmt.QueryRowContext(ctx, username, groupsString).Scan(&token) == nil {
-		return token, nil
+	if _, err := updateTxStmt.ExecContext(ctx, hashToken(token), id); err != nil {
+		return 0, "", fmt.Errorf("update token query failed: %w", err)
 	}
+	return id, token, nil
+}
 
-	// generate random bytes for the token
+// randomToken generates a new random token.
+func randomToken() (string, error) {
 	b := make([]byte, 20)
 	if _, err := rand.Read(b); err != nil {
 		return "", fmt.Errorf("is the system entropy low? failed to get random bytes: %w", err)
 	}
-	token = fmt.Sprintf("token::%s", hex.EncodeToString(b))
+	return fmt.Sprintf("token::%s", hex.EncodeToString(b)), nil
+}
 
+// derivedToken returns the token with the specified ID that is created by GetOrCreateToken.
+// derivedToken returns tokens of the same format as randomToken.
+func derivedToken(key string, id int64) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "kubernetes-auth-token:%d", id)
+	return fmt.Sprintf("token::%s", hex.EncodeToString(mac.Sum(nil)[:20]))
+}
+
+// insertToken stores the hash of a new token and returns its ID.
+func insertToken(ctx context.Context, tx *sql.Tx, username string, groupsString string, token string, description string, expiry sql.NullTime) (int64, error) {
 	insertTxStmt, err := cluster.Stmt(tx, k8sdTokensStmts["insert-token"])
 	if err != nil {
-		return "", fmt.Errorf("failed to prepare insert statement: %w", err)
+		return 0, fmt.Errorf("failed to prepare insert statement: %w", err)
 	}
-	if _, err := insertTxStmt.ExecContext(ctx, username, groupsString, token); err != nil {
-		return "", fmt.Errorf("insert token query failed: %w", err)
+	result, err := insertTxStmt.ExecContext(ctx, username, groupsString, hashToken(token), description, time.Now(), expiry)
+	if err != nil {
+		return 0, fmt.Errorf("insert token query failed: %w", err)
 	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve token id: %w", err)
+	}
+	return id, nil
+}
+
+// ListTokens returns the description of all tokens.
+func ListTokens(ctx context.Context, tx *sql.Tx) ([]types.KubernetesAuthToken, error) {
+	txStmt, err := cluster.Stmt(tx, k8sdTokensStmts["select-all"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	rows, err := txStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []types.KubernetesAuthToken
+	for rows.Next() {
+		var (
+			token                         types.KubernetesAuthToken
+			groups                        string
+			createdAt, expiry, lastUsedAt sql.NullTime
+		)
+		if err := rows.Scan(&token.ID, &token.Username, &groups, &token.Description, &createdAt, &expiry, &lastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to parse row: %w", err)
+		}
+		token.Groups = groupsToList(groups)
+		token.CreatedAt = createdAt.Time
+		token.Expiry = expiry.Time
+		token.LastUsedAt = lastUsedAt.Time
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	return tokens, nil
+}
 
-	return token, nil
+// GetTokenID returns the ID of the specified token.
+// GetTokenID returns an error if the token is not valid.
+func GetTokenID(ctx context.Context, tx *sql.Tx, token string) (int64, error) {
+	txStmt, err := cluster.Stmt(tx, k8sdTokensStmts["select-by-token"])
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	var (
+		id                 int64
+		username, groups   string
+		expiry, lastUsedAt sql.NullTime
+	)
+	if err := txStmt.QueryRowContext(ctx, hashToken(token)).Scan(&id, &username, &groups, &expiry, &lastUsedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("invalid token: %w", ErrTokenNotFound)
+		}
+		return 0, fmt.Errorf("failed to retrieve token: %w", err)
+	}
+	return id, nil
 }
 
 // DeleteToken deletes the specified token (if any).
@@ -113,8 +299,78 @@ func DeleteToken(ctx context.Context, tx *sql.Tx, token string) error {
 	if err != nil {
 		return fmt.Errorf("failed to prepare delete statement: %w", err)
 	}
-	if _, err := deleteTxStmt.ExecContext(ctx, token); err != nil {
+	if _, err := deleteTxStmt.ExecContext(ctx, hashToken(token)); err != nil {
 		return fmt.Errorf("delete token query failed: %w", err)
 	}
 	return nil
 }
+
+// DeleteTokenByID deletes the token with the specified ID.
+// DeleteTokenByID returns an error if no such token exists.
+func DeleteTokenByID(ctx context.Context, tx *sql.Tx, id int64) error {
+	deleteTxStmt, err := cluster.Stmt(tx, k8sdTokensStmts["delete-by-id"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	result, err := deleteTxStmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete token query failed: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check deleted tokens: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("token %d does not exist: %w", id, ErrTokenNotFound)
+	}
+	return nil
+}
+
+// DeleteExpiredTokens removes all tokens that have expired.
+// DeleteExpiredTokens returns the IDs of the removed tokens, so that any resources created for them can be cleaned up.
+func DeleteExpiredTokens(ctx context.Context, tx *sql.Tx) ([]int64, error) {
+	tokens, err := ListTokens(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var ids []int64
+	for _, token := range tokens {
+		if token.Expiry.IsZero() || now.Before(token.Expiry) {
+			continue
+		}
+		if err := DeleteTokenByID(ctx, tx, token.ID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, token.ID)
+	}
+	return ids, nil
+}
+
+// schemaHashKubernetesAuthTokens replaces the plaintext tokens created before tokens were hashed
+// with their hash, so that existing tokens remain valid.
+func schemaHashKubernetesAuthTokens(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, token FROM kubernetes_auth_tokens")
+	if err != nil {
+		return fmt.Errorf("failed to list kubernetes auth tokens: %w", err)
+	}
+	tokens := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var token string
+		if err := rows.Scan(&id, &token); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to parse row: %w", err)
+		}
+		tokens[id] = token
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list kubernetes auth tokens: %w", err)
+	}
+
+	for id, token := range tokens {
+		if _, err := tx.ExecContext(ctx, "UPDATE kubernetes_auth_tokens SET token = ? WHERE id = ?", hashToken(token), id); err != nil {
+			return fmt.Errorf("failed to hash kubernetes auth token %d: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDerivedToken(t *testing.T) {
+	g := NewWithT(t)
+
+	random, err := randomToken()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	token := derivedToken("key", 1)
+	g.Expect(token).To(HavePrefix("token::"))
+	g.Expect(token).To(HaveLen(len(random)))
+	g.Expect(derivedToken("key", 1)).To(Equal(token))
+	g.Expect(derivedToken("key", 2)).ToNot(Equal(token))
+	g.Expect(derivedToken("other-key", 1)).ToNot(Equal(token))
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
 	testenv "github.com/canonical/k8s/pkg/utils/microcluster"
 	"github.com/canonical/microcluster/v2/state"
 	. "github.com/onsi/gomega"
@@ -15,20 +18,23 @@ func TestKubernetesAuthTokens(t *testing.T) {
 	testenv.WithState(t, func(ctx context.Context, s state.State) {
 		var token1, token2 string
 
-		t.Run("GetOrCreateToken", func(t *testing.T) {
+		var id1, id2 int64
+
+		t.Run("CreateToken", func(t *testing.T) {
 			g := NewWithT(t)
 			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
 				var err error
 
-				token1, err = database.GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"})
+				id1, token1, err = database.CreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "first token", 0)
 				g.Expect(err).To(Not(HaveOccurred()))
 				g.Expect(token1).To(Not(BeEmpty()))
 
-				token2, err = database.GetOrCreateToken(ctx, tx, "user2", []string{"group1", "group2"})
+				id2, token2, err = database.CreateToken(ctx, tx, "user2", []string{"group1", "group2"}, "", time.Hour)
 				g.Expect(err).To(Not(HaveOccurred()))
 				g.Expect(token2).To(Not(BeEmpty()))
 
 				g.Expect(token1).To(Not(Equal(token2)))
+				g.Expect(id1).To(Not(Equal(id2)))
 				return nil
 			})
 			g.Expect(err).To(Not(HaveOccurred()))
@@ -36,15 +42,51 @@ func TestKubernetesAuthTokens(t *testing.T) {
 			t.Run("Existing", func(t *testing.T) {
 				g := NewWithT(t)
 				err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
-					token, err := database.GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"})
+					// tokens are not stored, so a new one is always created
+					id, token, err := database.CreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "", 0)
 					g.Expect(err).To(Not(HaveOccurred()))
-					g.Expect(token).To(Equal(token1))
+					g.Expect(token).To(Not(Equal(token1)))
+
+					return database.DeleteTokenByID(ctx, tx, id)
+				})
+				g.Expect(err).To(Not(HaveOccurred()))
+			})
+
+			t.Run("Invalid", func(t *testing.T) {
+				g := NewWithT(t)
+				err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+					_, _, err := database.CreateToken(ctx, tx, "", nil, "", 0)
+					g.Expect(err).To(HaveOccurred())
+
+					_, _, err = database.CreateToken(ctx, tx, "user1", nil, "", -time.Hour)
+					g.Expect(err).To(HaveOccurred())
 					return nil
 				})
 				g.Expect(err).To(Not(HaveOccurred()))
 			})
 		})
 
+		t.Run("ListTokens", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				tokens, err := database.ListTokens(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(tokens).To(HaveLen(2))
+
+				g.Expect(tokens[0].ID).To(Equal(id1))
+				g.Expect(tokens[0].Username).To(Equal("user1"))
+				g.Expect(tokens[0].Groups).To(ConsistOf("group1", "group2"))
+				g.Expect(tokens[0].Description).To(Equal("first token"))
+				g.Expect(tokens[0].CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				g.Expect(tokens[0].Expiry).To(BeZero())
+
+				g.Expect(tokens[1].ID).To(Equal(id2))
+				g.Expect(tokens[1].Expiry).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+				return nil
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+		})
+
 		t.Run("CheckToken", func(t *testing.T) {
 			t.Run("user1", func(t *testing.T) {
 				g := NewWithT(t)
@@ -70,6 +112,71 @@ func TestKubernetesAuthTokens(t *testing.T) {
 			})
 		})
 
+		t.Run("Expired", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				id, token, err := database.CreateToken(ctx, tx, "user3", nil, "", time.Nanosecond)
+				g.Expect(err).To(Not(HaveOccurred()))
+				time.Sleep(time.Millisecond)
+
+				_, _, err = database.CheckToken(ctx, tx, token)
+				g.Expect(err).To(MatchError(ContainSubstring("expired")))
+
+				newID, _, err := database.CreateToken(ctx, tx, "user3", nil, "", 0)
+				g.Expect(err).To(Not(HaveOccurred()))
+
+				// only expired tokens are cleaned up
+				ids, err := database.DeleteExpiredTokens(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(ids).To(ConsistOf(id))
+				g.Expect(database.DeleteTokenByID(ctx, tx, id)).To(HaveOccurred())
+
+				return database.DeleteTokenByID(ctx, tx, newID)
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+		})
+
+		t.Run("LastUsedAt", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				tokens, err := database.ListTokens(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				return nil
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+		})
+
+		t.Run("GetTokenID", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				id, err := database.GetTokenID(ctx, tx, token1)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(id).To(Equal(id1))
+
+				_, err = database.GetTokenID(ctx, tx, "invalid-token")
+				g.Expect(err).To(MatchError(database.ErrTokenNotFound))
+				return nil
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+		})
+
+		t.Run("DeleteTokenByID", func(t *testing.T) {
+			g := NewWithT(t)
+			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				err := database.DeleteTokenByID(ctx, tx, id1)
+				g.Expect(err).To(Not(HaveOccurred()))
+
+				_, _, err = database.CheckToken(ctx, tx, token1)
+				g.Expect(err).To(HaveOccurred())
+
+				err = database.DeleteTokenByID(ctx, tx, id1)
+				g.Expect(err).To(MatchError(database.ErrTokenNotFound))
+				return nil
+			})
+			g.Expect(err).To(Not(HaveOccurred()))
+		})
+
 		t.Run("DeleteToken", func(t *testing.T) {
 			g := NewWithT(t)
 			err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
@@ -86,3 +193,54 @@ func TestKubernetesAuthTokens(t *testing.T) {
 		})
 	})
 }
+
+func TestGetOrCreateToken(t *testing.T) {
+	testenv.WithState(t, func(ctx context.Context, s state.State) {
+		err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			_, err := database.SetClusterConfig(ctx, tx, types.ClusterConfig{
+				Certificates: types.Certificates{K8sdPrivateKey: utils.Pointer("PRIVATE KEY")},
+			})
+			return err
+		})
+		NewWithT(t).Expect(err).To(Not(HaveOccurred()))
+
+		g := NewWithT(t)
+		err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			id, token, err := database.GetOrCreateToken(ctx, tx, "user1", []string{"group2", "group1"}, "kubeconfig")
+			g.Expect(err).To(Not(HaveOccurred()))
+
+			// the same identity and description gets the same token
+			sameID, sameToken, err := database.GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "kubeconfig")
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(sameID).To(Equal(id))
+			g.Expect(sameToken).To(Equal(token))
+
+			username, groups, err := database.CheckToken(ctx, tx, token)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(username).To(Equal("user1"))
+			g.Expect(groups).To(ConsistOf("group1", "group2"))
+
+			// a different description gets a different token
+			otherID, otherToken, err := database.GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "other")
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(otherID).To(Not(Equal(id)))
+			g.Expect(otherToken).To(Not(Equal(token)))
+
+			// tokens created with CreateToken are not reused
+			createdID, _, err := database.CreateToken(ctx, tx, "user2", nil, "", 0)
+			g.Expect(err).To(Not(HaveOccurred()))
+			newID, _, err := database.GetOrCreateToken(ctx, tx, "user2", nil, "")
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(newID).To(Not(Equal(createdID)))
+
+			// revoked tokens are not returned again
+			g.Expect(database.DeleteTokenByID(ctx, tx, id)).To(Succeed())
+			newID, newToken, err := database.GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "kubeconfig")
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(newID).To(Not(Equal(id)))
+			g.Expect(newToken).To(Not(Equal(token)))
+			return nil
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+	})
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/microcluster/v2/cluster"
+)
+
+var nodePoolStmts = map[string]int{
+	"upsert":        MustPrepareStatement("node-pools", "upsert.sql"),
+	"select":        MustPrepareStatement("node-pools", "select.sql"),
+	"delete":        MustPrepareStatement("node-pools", "delete.sql"),
+	"upsert-member": MustPrepareStatement("node-pools", "upsert-member.sql"),
+	"select-member": MustPrepareStatement("node-pools", "select-members.sql"),
+	"delete-member": MustPrepareStatement("node-pools", "delete-member.sql"),
+}
+
+// SetNodePool creates a node pool, or replaces the configuration of an existing node pool.
+func SetNodePool(ctx context.Context, tx *sql.Tx, pool types.NodePool) error {
+	upsertTxStmt, err := cluster.Stmt(tx, nodePoolStmts["upsert"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert statement: %w", err)
+	}
+
+	b, err := json.Marshal(pool)
+	if err != nil {
+		return fmt.Errorf("failed to marshal node pool: %w", err)
+	}
+
+	if _, err := upsertTxStmt.ExecContext(ctx, pool.Name, string(b)); err != nil {
+		return fmt.Errorf("failed to execute upsert statement: %w", err)
+	}
+	return nil
+}
+
+// GetNodePools returns all node pools, sorted by name.
+func GetNodePools(ctx context.Context, tx *sql.Tx) ([]types.NodePool, error) {
+	selectTxStmt, err := cluster.Stmt(tx, nodePoolStmts["select"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare select statement: %w", err)
+	}
+
+	rows, err := selectTxStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select statement: %w", err)
+	}
+	defer rows.Close()
+
+	var pools []types.NodePool
+	for rows.Next() {
+		var (
+			name   string
+			config string
+			pool   types.NodePool
+		)
+		if err := rows.Scan(&name, &config); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(config), &pool); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration of node pool %q: %w", name, err)
+		}
+		pool.Name = name
+		pools = append(pools, pool)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return pools, nil
+}
+
+// DeleteNodePool deletes the specified node pool.
+func DeleteNodePool(ctx context.Context, tx *sql.Tx, name string) error {
+	deleteTxStmt, err := cluster.Stmt(tx, nodePoolStmts["delete"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	if _, err := deleteTxStmt.ExecContext(ctx, name); err != nil {
+		return fmt.Errorf("failed to execute delete statement: %w", err)
+	}
+	return nil
+}
+
+// SetNodePoolMember sets the node pool of the specified node.
+func SetNodePoolMember(ctx context.Context, tx *sql.Tx, nodeName string, pool string) error {
+	upsertTxStmt, err := cluster.Stmt(tx, nodePoolStmts["upsert-member"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert statement: %w", err)
+	}
+	if _, err := upsertTxStmt.ExecContext(ctx, nodeName, pool); err != nil {
+		return fmt.Errorf("failed to execute upsert statement: %w", err)
+	}
+	return nil
+}
+
+// GetNodePoolMembers returns a map of node names to the name of their node pool.
+func GetNodePoolMembers(ctx context.Context, tx *sql.Tx) (map[string]string, error) {
+	selectTxStmt, err := cluster.Stmt(tx, nodePoolStmts["select-member"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare select statement: %w", err)
+	}
+
+	rows, err := selectTxStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select statement: %w", err)
+	}
+	defer rows.Close()
+
+	members := make(map[string]string)
+	for rows.Next() {
+		var nodeName, pool string
+		if err := rows.Scan(&nodeName, &pool); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		members[nodeName] = pool
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return members, nil
+}
+
+// DeleteNodePoolMember removes the specified node from its node pool.
+func DeleteNodePoolMember(ctx context.Context, tx *sql.Tx, nodeName string) error {
+	deleteTxStmt, err := cluster.Stmt(tx, nodePoolStmts["delete-member"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	if _, err := deleteTxStmt.ExecContext(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to execute delete statement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	testenv "github.com/canonical/k8s/pkg/utils/microcluster"
+	"github.com/canonical/microcluster/v2/state"
+	. "github.com/onsi/gomega"
+)
+
+func TestNodePools(t *testing.T) {
+	testenv.WithState(t, func(ctx context.Context, s state.State) {
+		_ = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			gpu := types.NodePool{
+				Name:                 "gpu",
+				Labels:               map[string]string{"accelerator": "nvidia"},
+				Taints:               []string{"gpu=true:NoSchedule"},
+				ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50"), "--v": nil},
+			}
+			edge := types.NodePool{
+				Name:                 "edge",
+				ExtraNodeConfigFiles: map[string]string{"file": "content"},
+			}
+
+			t.Run("ReturnNothingInitially", func(t *testing.T) {
+				g := NewWithT(t)
+				pools, err := database.GetNodePools(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(pools).To(BeEmpty())
+
+				members, err := database.GetNodePoolMembers(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(members).To(BeEmpty())
+			})
+
+			t.Run("SetNodePools", func(t *testing.T) {
+				g := NewWithT(t)
+				g.Expect(database.SetNodePool(ctx, tx, gpu)).To(Succeed())
+				g.Expect(database.SetNodePool(ctx, tx, edge)).To(Succeed())
+
+				pools, err := database.GetNodePools(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(pools).To(Equal([]types.NodePool{edge, gpu}))
+			})
+
+			t.Run("UpdateNodePool", func(t *testing.T) {
+				g := NewWithT(t)
+				gpu.Labels = map[string]string{"accelerator": "amd"}
+				g.Expect(database.SetNodePool(ctx, tx, gpu)).To(Succeed())
+
+				pools, err := database.GetNodePools(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(pools).To(Equal([]types.NodePool{edge, gpu}))
+			})
+
+			t.Run("Members", func(t *testing.T) {
+				g := NewWithT(t)
+				g.Expect(database.SetNodePoolMember(ctx, tx, "node1", "gpu")).To(Succeed())
+				g.Expect(database.SetNodePoolMember(ctx, tx, "node2", "gpu")).To(Succeed())
+				g.Expect(database.SetNodePoolMember(ctx, tx, "node2", "edge")).To(Succeed())
+
+				members, err := database.GetNodePoolMembers(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(members).To(Equal(map[string]string{"node1": "gpu", "node2": "edge"}))
+
+				g.Expect(database.DeleteNodePoolMember(ctx, tx, "node1")).To(Succeed())
+				members, err = database.GetNodePoolMembers(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(members).To(Equal(map[string]string{"node2": "edge"}))
+			})
+
+			t.Run("DeleteNodePool", func(t *testing.T) {
+				g := NewWithT(t)
+				g.Expect(database.DeleteNodePool(ctx, tx, "edge")).To(Succeed())
+
+				pools, err := database.GetNodePools(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(pools).To(Equal([]types.NodePool{gpu}))
+			})
+
+			return nil
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/canonical/microcluster/v2/cluster"
+)
+
+var restartLeaseStmts = map[string]int{
+	"select": MustPrepareStatement("restart-leases", "select.sql"),
+	"upsert": MustPrepareStatement("restart-leases", "upsert.sql"),
+	"delete": MustPrepareStatement("restart-leases", "delete.sql"),
+}
+
+// AcquireRestartLease attempts to take the named restart lease for holder until expiry.
+// AcquireRestartLease renews the lease if it is already held by holder.
+// AcquireRestartLease returns false if the lease is held by a different holder and has not expired yet.
+func AcquireRestartLease(ctx context.Context, tx *sql.Tx, name string, holder string, expiry time.Time) (bool, error) {
+	selectTxStmt, err := cluster.Stmt(tx, restartLeaseStmts["select"])
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare select statement: %w", err)
+	}
+
+	var (
+		currentHolder string
+		currentExpiry time.Time
+	)
+	if err := selectTxStmt.QueryRowContext(ctx, name).Scan(&currentHolder, &currentExpiry); err == nil {
+		if currentHolder != holder && time.Now().Before(currentExpiry) {
+			return false, nil
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to execute select statement: %w", err)
+	}
+
+	upsertTxStmt, err := cluster.Stmt(tx, restartLeaseStmts["upsert"])
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare upsert statement: %w", err)
+	}
+	if _, err := upsertTxStmt.ExecContext(ctx, name, holder, expiry); err != nil {
+		return false, fmt.Errorf("failed to execute upsert statement: %w", err)
+	}
+	return true, nil
+}
+
+// ReleaseRestartLease releases the named restart lease, if it is held by holder.
+func ReleaseRestartLease(ctx context.Context, tx *sql.Tx, name string, holder string) error {
+	deleteTxStmt, err := cluster.Stmt(tx, restartLeaseStmts["delete"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	if _, err := deleteTxStmt.ExecContext(ctx, name, holder); err != nil {
+		return fmt.Errorf("failed to execute delete statement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	testenv "github.com/canonical/k8s/pkg/utils/microcluster"
+	"github.com/canonical/microcluster/v2/state"
+	. "github.com/onsi/gomega"
+)
+
+func TestRestartLeases(t *testing.T) {
+	testenv.WithState(t, func(ctx context.Context, s state.State) {
+		_ = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			t.Run("Acquire", func(t *testing.T) {
+				g := NewWithT(t)
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node1", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeTrue())
+			})
+
+			t.Run("Renew", func(t *testing.T) {
+				g := NewWithT(t)
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node1", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeTrue())
+			})
+
+			t.Run("HeldByOtherNode", func(t *testing.T) {
+				g := NewWithT(t)
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node2", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeFalse())
+
+				// other leases are independent
+				acquired, err = database.AcquireRestartLease(ctx, tx, "other", "node2", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeTrue())
+			})
+
+			t.Run("ReleaseByOtherNode", func(t *testing.T) {
+				g := NewWithT(t)
+				g.Expect(database.ReleaseRestartLease(ctx, tx, "lease", "node2")).To(Succeed())
+
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node2", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeFalse())
+			})
+
+			t.Run("Release", func(t *testing.T) {
+				g := NewWithT(t)
+				g.Expect(database.ReleaseRestartLease(ctx, tx, "lease", "node1")).To(Succeed())
+
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node2", time.Now().Add(-time.Second))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeTrue())
+			})
+
+			t.Run("Expired", func(t *testing.T) {
+				g := NewWithT(t)
+				acquired, err := database.AcquireRestartLease(ctx, tx, "lease", "node1", time.Now().Add(time.Minute))
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(acquired).To(BeTrue())
+			})
+			return nil
+		})
+	})
+}
@@ -23,6 +23,19 @@ var (
 		schemaApplyMigration("feature-status", "000-feature-status.sql"),
 		schemaApplyMigration("worker-tokens", "001-add-expiry.sql"),
 		schemaApplyMigration("worker-nodes", "001-delete.sql"),
+		schemaApplyMigration("feature-status", "001-add-health.sql"),
+		schemaApplyMigration("feature-status", "002-add-conditions.sql"),
+		schemaApplyMigration("kubernetes-auth-tokens", "001-add-description.sql"),
+		schemaApplyMigration("kubernetes-auth-tokens", "002-add-created-at.sql"),
+		schemaApplyMigration("kubernetes-auth-tokens", "003-add-expiry.sql"),
+		schemaApplyMigration("kubernetes-auth-tokens", "004-add-last-used-at.sql"),
+		schemaHashKubernetesAuthTokens,
+		schemaApplyMigration("node-pools", "000-create.sql"),
+		schemaApplyMigration("node-pools", "001-create-members.sql"),
+		schemaApplyMigration("restart-leases", "000-create.sql"),
+		schemaApplyMigration("worker-tokens", "002-add-multi-use.sql"),
+		schemaApplyMigration("worker-tokens", "003-add-allowed-cidrs.sql"),
+		schemaApplyMigration("worker-tokens", "004-add-pool.sql"),
 	}
 
 	//go:embed sql/migrations
@@ -48,10 +61,15 @@ func schemaApplyMigration(migrationPath ...string) schema.Update {
 
 // MustPrepareStatement reads and registers a SQL query.
 func MustPrepareStatement(queryPath ...string) int {
+	return cluster.RegisterStmt(mustReadQuery(queryPath...))
+}
+
+// mustReadQuery reads a SQL query.
+func mustReadQuery(queryPath ...string) string {
 	path := filepath.Join(append([]string{"sql", "queries"}, queryPath...)...)
 	b, err := sqlQueries.ReadFile(path)
 	if err != nil {
 		panic(fmt.Errorf("invalid query file %s: %w", path, err))
 	}
-	return cluster.RegisterStmt(string(b))
+	return string(b)
 }
@@ -0,0 +1,2 @@
+ALTER TABLE feature_status
+ADD COLUMN conditions TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE kubernetes_auth_tokens
+ADD COLUMN last_used_at DATETIME;
@@ -0,0 +1,6 @@
+CREATE TABLE node_pools (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name        TEXT UNIQUE NOT NULL,
+    config      TEXT NOT NULL,
+    UNIQUE(name)
+)
@@ -0,0 +1,6 @@
+CREATE TABLE node_pool_members (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    node_name   TEXT UNIQUE NOT NULL,
+    pool        TEXT NOT NULL,
+    UNIQUE(node_name)
+)
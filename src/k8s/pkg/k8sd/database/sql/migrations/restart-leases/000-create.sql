@@ -0,0 +1,6 @@
+CREATE TABLE restart_leases (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name        TEXT UNIQUE NOT NULL,
+    holder      TEXT NOT NULL,
+    expiry      DATETIME NOT NULL
+)
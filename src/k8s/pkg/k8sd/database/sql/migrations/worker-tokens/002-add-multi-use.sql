@@ -0,0 +1,2 @@
+ALTER TABLE worker_tokens
+ADD COLUMN multi_use BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE worker_tokens
+ADD COLUMN allowed_cidrs TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE worker_tokens
+ADD COLUMN pool TEXT NOT NULL DEFAULT '';
@@ -1,4 +1,4 @@
 SELECT
-    name, message, version, timestamp, enabled
+    name, message, version, timestamp, enabled, health, conditions
 FROM
     feature_status
@@ -1,9 +1,11 @@
 INSERT INTO
-    feature_status(name, message, version, timestamp, enabled)
+    feature_status(name, message, version, timestamp, enabled, health, conditions)
 VALUES
-    (?, ?, ?, ?, ?)
+    (?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(name) DO UPDATE SET
     message=excluded.message,
     version=excluded.version,
     timestamp=excluded.timestamp,
-    enabled=excluded.enabled;
+    enabled=excluded.enabled,
+    health=excluded.health,
+    conditions=excluded.conditions;
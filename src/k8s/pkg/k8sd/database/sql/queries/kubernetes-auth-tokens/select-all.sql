@@ -0,0 +1,6 @@
+SELECT
+    t.id, t.username, t.groups, t.description, t.created_at, t.expiry, t.last_used_at
+FROM
+    kubernetes_auth_tokens AS t
+ORDER BY
+    t.id
@@ -1,5 +1,5 @@
 SELECT
-    username, groups
+    t.id, t.username, t.groups, t.expiry, t.last_used_at
 FROM
     kubernetes_auth_tokens AS t
 WHERE
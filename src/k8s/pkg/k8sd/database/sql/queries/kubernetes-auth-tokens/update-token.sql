@@ -0,0 +1,6 @@
+UPDATE
+    kubernetes_auth_tokens
+SET
+    token = ?
+WHERE
+    ( id = ? )
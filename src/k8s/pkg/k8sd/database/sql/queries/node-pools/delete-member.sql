@@ -0,0 +1,4 @@
+DELETE FROM
+    node_pool_members
+WHERE
+    node_name = ?
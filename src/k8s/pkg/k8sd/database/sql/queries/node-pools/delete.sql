@@ -0,0 +1,4 @@
+DELETE FROM
+    node_pools
+WHERE
+    name = ?
@@ -0,0 +1,4 @@
+SELECT
+    node_name, pool
+FROM
+    node_pool_members
@@ -0,0 +1,6 @@
+SELECT
+    name, config
+FROM
+    node_pools
+ORDER BY
+    name
@@ -0,0 +1,6 @@
+INSERT INTO
+    node_pool_members(node_name, pool)
+VALUES
+    (?, ?)
+ON CONFLICT(node_name) DO UPDATE SET
+    pool=excluded.pool;
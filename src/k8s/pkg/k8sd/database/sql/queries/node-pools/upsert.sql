@@ -0,0 +1,6 @@
+INSERT INTO
+    node_pools(name, config)
+VALUES
+    (?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    config=excluded.config;
@@ -0,0 +1,4 @@
+DELETE FROM
+    restart_leases
+WHERE
+    name = ? AND holder = ?
@@ -0,0 +1,6 @@
+SELECT
+    holder, expiry
+FROM
+    restart_leases
+WHERE
+    name = ?
@@ -0,0 +1,7 @@
+INSERT INTO
+    restart_leases(name, holder, expiry)
+VALUES
+    (?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    holder=excluded.holder,
+    expiry=excluded.expiry;
@@ -0,0 +1,4 @@
+DELETE FROM
+    worker_tokens AS t
+WHERE
+    ( t.id = ? )
@@ -1,4 +1,4 @@
 INSERT INTO
-    worker_tokens(name, token, expiry)
+    worker_tokens(name, token, expiry, multi_use, allowed_cidrs, pool)
 VALUES
-    ( ?, ?, ? )
+    ( ?, ?, ?, ?, ?, ? )
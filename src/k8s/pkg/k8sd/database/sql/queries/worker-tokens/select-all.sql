@@ -0,0 +1,6 @@
+SELECT
+    t.id, t.name, t.expiry, t.multi_use, t.allowed_cidrs, t.pool
+FROM
+    worker_tokens AS t
+ORDER BY
+    t.id
@@ -1,5 +1,5 @@
 SELECT
-    t.name, t.expiry
+    t.id, t.name, t.expiry, t.multi_use, t.allowed_cidrs, t.pool
 FROM
     worker_tokens AS t
 WHERE
@@ -4,27 +4,94 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/microcluster/v2/state"
 )
 
-// GetOrCreateAuthToken returns a k8s auth token based on the provided username/groups.
-func GetOrCreateAuthToken(ctx context.Context, state state.State, username string, groups []string) (string, error) {
+// CreateAuthToken creates a new k8s auth token for the provided username/groups.
+// A zero ttl means that the token never expires.
+func CreateAuthToken(ctx context.Context, state state.State, username string, groups []string, description string, ttl time.Duration) (int64, string, error) {
+	var id int64
 	var token string
 	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		token, err = database.GetOrCreateToken(ctx, tx, username, groups)
+		id, token, err = database.CreateToken(ctx, tx, username, groups, description, ttl)
 		return err
 	}); err != nil {
-		return "", fmt.Errorf("database transaction failed: %w", err)
+		return 0, "", fmt.Errorf("database transaction failed: %w", err)
 	}
-	return token, nil
+	return id, token, nil
 }
 
-func RevokeAuthToken(ctx context.Context, state state.State, token string) error {
+// GetOrCreateAuthToken is like CreateAuthToken, but tokens that never expire are reused for the same
+// username/groups and description, see database.GetOrCreateToken.
+func GetOrCreateAuthToken(ctx context.Context, state state.State, username string, groups []string, description string, ttl time.Duration) (int64, string, error) {
+	if ttl != 0 {
+		return CreateAuthToken(ctx, state, username, groups, description, ttl)
+	}
+	var id int64
+	var token string
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		id, token, err = database.GetOrCreateToken(ctx, tx, username, groups, description)
+		return err
+	}); err != nil {
+		return 0, "", fmt.Errorf("database transaction failed: %w", err)
+	}
+	return id, token, nil
+}
+
+// DeleteExpiredAuthTokens removes all k8s auth tokens that have expired and returns their IDs.
+func DeleteExpiredAuthTokens(ctx context.Context, state state.State) ([]int64, error) {
+	var ids []int64
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		ids, err = database.DeleteExpiredTokens(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("database transaction failed: %w", err)
+	}
+	return ids, nil
+}
+
+// ListAuthTokens returns the description of all k8s auth tokens.
+func ListAuthTokens(ctx context.Context, state state.State) ([]types.KubernetesAuthToken, error) {
+	var tokens []types.KubernetesAuthToken
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		tokens, err = database.ListTokens(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("database transaction failed: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeAuthToken revokes the specified k8s auth token and returns its ID.
+func RevokeAuthToken(ctx context.Context, state state.State, token string) (int64, error) {
+	var id int64
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		if id, err = database.GetTokenID(ctx, tx, token); err != nil {
+			return fmt.Errorf("failed to retrieve token from database: %w", err)
+		}
+		if err := database.DeleteTokenByID(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to delete token from database: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("database transaction failed: %w", err)
+	}
+	return id, nil
+}
+
+// RevokeAuthTokenByID revokes the k8s auth token with the specified ID.
+func RevokeAuthTokenByID(ctx context.Context, state state.State, id int64) error {
 	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		if err := database.DeleteToken(ctx, tx, token); err != nil {
+		if err := database.DeleteTokenByID(ctx, tx, id); err != nil {
 			return fmt.Errorf("failed to delete token from database: %w", err)
 		}
 		return nil
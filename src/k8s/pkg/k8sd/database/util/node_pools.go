@@ -0,0 +1,39 @@
+package databaseutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+// GetNodePools is a convenience wrapper around the database calls to get the node pools and their members.
+func GetNodePools(ctx context.Context, state state.State) (types.NodePools, error) {
+	var nodePools types.NodePools
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		if nodePools.Pools, err = database.GetNodePools(ctx, tx); err != nil {
+			return fmt.Errorf("failed to get node pools from database: %w", err)
+		}
+		if nodePools.Members, err = database.GetNodePoolMembers(ctx, tx); err != nil {
+			return fmt.Errorf("failed to get node pool members from database: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return types.NodePools{}, fmt.Errorf("database transaction failed: %w", err)
+	}
+	return nodePools, nil
+}
+
+// RemoveNodePoolMember is a convenience wrapper around the database call to remove a node from its node pool.
+func RemoveNodePoolMember(ctx context.Context, state state.State, nodeName string) error {
+	if err := state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteNodePoolMember(ctx, tx, nodeName)
+	}); err != nil {
+		return fmt.Errorf("database transaction failed: %w", err)
+	}
+	return nil
+}
@@ -7,38 +7,110 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/microcluster/v2/cluster"
 )
 
 var workerStmts = map[string]int{
-	"insert-token": MustPrepareStatement("worker-tokens", "insert.sql"),
-	"select-token": MustPrepareStatement("worker-tokens", "select.sql"),
-	"delete-token": MustPrepareStatement("worker-tokens", "delete-by-token.sql"),
+	"insert-token":          MustPrepareStatement("worker-tokens", "insert.sql"),
+	"select-token":          MustPrepareStatement("worker-tokens", "select.sql"),
+	"select-all-tokens":     MustPrepareStatement("worker-tokens", "select-all.sql"),
+	"delete-token":          MustPrepareStatement("worker-tokens", "delete-by-token.sql"),
+	"delete-token-by-id":    MustPrepareStatement("worker-tokens", "delete-by-id.sql"),
+	"delete-tokens-by-name": MustPrepareStatement("worker-tokens", "delete-by-name.sql"),
 }
 
 // CheckWorkerNodeToken returns true if the specified token can be used to join the specified node on the cluster.
 // CheckWorkerNodeToken will return true if the token is empty or if the token is associated with the specified node
 // and has not expired.
 func CheckWorkerNodeToken(ctx context.Context, tx *sql.Tx, nodeName string, token string) (bool, error) {
+	record, exists, err := GetWorkerNodeToken(ctx, tx, token)
+	if err != nil || !exists {
+		return false, err
+	}
+	isValidToken := record.Name == "" || subtle.ConstantTimeCompare([]byte(nodeName), []byte(record.Name)) == 1
+	notExpired := time.Now().Before(record.Expiry)
+	return isValidToken && notExpired, nil
+}
+
+// GetWorkerNodeToken returns the description of the specified worker node token.
+// GetWorkerNodeToken returns false if the token does not exist.
+func GetWorkerNodeToken(ctx context.Context, tx *sql.Tx, token string) (types.JoinToken, bool, error) {
 	selectTxStmt, err := cluster.Stmt(tx, workerStmts["select-token"])
 	if err != nil {
-		return false, fmt.Errorf("failed to prepare select statement: %w", err)
+		return types.JoinToken{}, false, fmt.Errorf("failed to prepare select statement: %w", err)
+	}
+	record, err := scanWorkerNodeToken(selectTxStmt.QueryRowContext(ctx, token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return types.JoinToken{}, false, nil
+		}
+		return types.JoinToken{}, false, fmt.Errorf("select token query failed: %w", err)
+	}
+	return record, true, nil
+}
+
+// ListWorkerNodeTokens returns the description of all worker node tokens that have not expired.
+func ListWorkerNodeTokens(ctx context.Context, tx *sql.Tx) ([]types.JoinToken, error) {
+	selectTxStmt, err := cluster.Stmt(tx, workerStmts["select-all-tokens"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare select statement: %w", err)
 	}
-	var tokenNodeName string
-	var expiry time.Time
-	if selectTxStmt.QueryRowContext(ctx, token).Scan(&tokenNodeName, &expiry) == nil {
-		isValidToken := tokenNodeName == "" || subtle.ConstantTimeCompare([]byte(nodeName), []byte(tokenNodeName)) == 1
-		notExpired := time.Now().Before(expiry)
-		return isValidToken && notExpired, nil
+	rows, err := selectTxStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
 	}
-	return false, nil
+	defer rows.Close()
+
+	var tokens []types.JoinToken
+	now := time.Now()
+	for rows.Next() {
+		token, err := scanWorkerNodeToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse row: %w", err)
+		}
+		if now.Before(token.Expiry) {
+			tokens = append(tokens, token)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// scanWorkerNodeToken parses a row of the worker_tokens table.
+func scanWorkerNodeToken(row interface{ Scan(...any) error }) (types.JoinToken, error) {
+	token := types.JoinToken{Worker: true}
+	var allowedCIDRs string
+	if err := row.Scan(&token.ID, &token.Name, &token.Expiry, &token.MultiUse, &allowedCIDRs, &token.Pool); err != nil {
+		return types.JoinToken{}, err
+	}
+	if allowedCIDRs != "" {
+		token.AllowedCIDRs = strings.Split(allowedCIDRs, ",")
+	}
+	return token, nil
 }
 
 // GetOrCreateWorkerNodeToken returns a token that can be used to join a worker node on the cluster.
 // GetOrCreateWorkerNodeToken will return the existing token, if one already exists for the node.
 func GetOrCreateWorkerNodeToken(ctx context.Context, tx *sql.Tx, nodeName string, expiry time.Time) (string, error) {
+	return CreateWorkerNodeToken(ctx, tx, types.JoinToken{Name: nodeName, Expiry: expiry})
+}
+
+// CreateWorkerNodeToken creates a worker node token with the scope of the specified description.
+// The ID and Worker fields of the description are ignored. Expired worker node tokens are removed from the database.
+func CreateWorkerNodeToken(ctx context.Context, tx *sql.Tx, scope types.JoinToken) (string, error) {
+	if err := scope.ValidateAllowedCIDRs(); err != nil {
+		return "", err
+	}
+	if err := deleteExpiredWorkerNodeTokens(ctx, tx); err != nil {
+		return "", fmt.Errorf("failed to delete expired tokens: %w", err)
+	}
+
 	insertTxStmt, err := cluster.Stmt(tx, workerStmts["insert-token"])
 	if err != nil {
 		return "", fmt.Errorf("failed to prepare insert statement: %w", err)
@@ -50,7 +122,7 @@ func GetOrCreateWorkerNodeToken(ctx context.Context, tx *sql.Tx, nodeName string
 		return "", fmt.Errorf("is the system entropy low? failed to get random bytes: %w", err)
 	}
 	token := fmt.Sprintf("worker::%s", hex.EncodeToString(b))
-	if _, err := insertTxStmt.ExecContext(ctx, nodeName, token, expiry); err != nil {
+	if _, err := insertTxStmt.ExecContext(ctx, scope.Name, token, scope.Expiry, scope.MultiUse, strings.Join(scope.AllowedCIDRs, ","), scope.Pool); err != nil {
 		return "", fmt.Errorf("insert token query failed: %w", err)
 	}
 	return token, nil
@@ -67,3 +139,76 @@ func DeleteWorkerNodeToken(ctx context.Context, tx *sql.Tx, nodeName string) err
 	}
 	return nil
 }
+
+// DeleteWorkerNodeTokenByID deletes the worker node token with the specified ID.
+// DeleteWorkerNodeTokenByID returns an error if no such token exists.
+func DeleteWorkerNodeTokenByID(ctx context.Context, tx *sql.Tx, id int64) error {
+	deleteTxStmt, err := cluster.Stmt(tx, workerStmts["delete-token-by-id"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	result, err := deleteTxStmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete token query failed: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check deleted tokens: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("worker node token %d does not exist", id)
+	}
+	return nil
+}
+
+// DeleteWorkerNodeTokensByName deletes all worker node tokens for the specified node name.
+// DeleteWorkerNodeTokensByName returns an error if no such token exists.
+func DeleteWorkerNodeTokensByName(ctx context.Context, tx *sql.Tx, nodeName string) error {
+	deleteTxStmt, err := cluster.Stmt(tx, workerStmts["delete-tokens-by-name"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
+	result, err := deleteTxStmt.ExecContext(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("delete token query failed: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check deleted tokens: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("no worker node token exists for %q", nodeName)
+	}
+	return nil
+}
+
+// deleteExpiredWorkerNodeTokens removes all worker node tokens that have expired.
+func deleteExpiredWorkerNodeTokens(ctx context.Context, tx *sql.Tx) error {
+	selectTxStmt, err := cluster.Stmt(tx, workerStmts["select-all-tokens"])
+	if err != nil {
+		return fmt.Errorf("failed to prepare select statement: %w", err)
+	}
+	rows, err := selectTxStmt.QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tokens: %w", err)
+	}
+	var expired []int64
+	now := time.Now()
+	for rows.Next() {
+		token, err := scanWorkerNodeToken(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to parse row: %w", err)
+		}
+		if !now.Before(token.Expiry) {
+			expired = append(expired, token.ID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	for _, id := range expired {
+		if err := DeleteWorkerNodeTokenByID(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
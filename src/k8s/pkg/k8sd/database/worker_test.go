@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	testenv "github.com/canonical/k8s/pkg/utils/microcluster"
 	"github.com/canonical/microcluster/v2/state"
 	. "github.com/onsi/gomega"
@@ -100,3 +101,68 @@ func TestWorkerNodeToken(t *testing.T) {
 		})
 	})
 }
+
+func TestWorkerNodeTokenScope(t *testing.T) {
+	testenv.WithState(t, func(ctx context.Context, s state.State) {
+		_ = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+			t.Run("Scope", func(t *testing.T) {
+				g := NewWithT(t)
+				token, err := database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{
+					Expiry:       expiry,
+					MultiUse:     true,
+					AllowedCIDRs: []string{"10.0.0.0/24", "fd00::/64"},
+					Pool:         "gpu",
+				})
+				g.Expect(err).To(Not(HaveOccurred()))
+
+				record, exists, err := database.GetWorkerNodeToken(ctx, tx, token)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(exists).To(BeTrue())
+				g.Expect(record.Worker).To(BeTrue())
+				g.Expect(record.Name).To(BeEmpty())
+				g.Expect(record.Expiry).To(BeTemporally("==", expiry))
+				g.Expect(record.MultiUse).To(BeTrue())
+				g.Expect(record.AllowedCIDRs).To(Equal([]string{"10.0.0.0/24", "fd00::/64"}))
+				g.Expect(record.Pool).To(Equal("gpu"))
+
+				g.Expect(database.DeleteWorkerNodeTokenByID(ctx, tx, record.ID)).To(Succeed())
+				_, exists, err = database.GetWorkerNodeToken(ctx, tx, token)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(exists).To(BeFalse())
+
+				g.Expect(database.DeleteWorkerNodeTokenByID(ctx, tx, record.ID)).ToNot(Succeed())
+			})
+
+			t.Run("InvalidCIDR", func(t *testing.T) {
+				g := NewWithT(t)
+				_, err := database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{Expiry: expiry, AllowedCIDRs: []string{"10.0.0.1"}})
+				g.Expect(err).To(HaveOccurred())
+			})
+
+			t.Run("List", func(t *testing.T) {
+				g := NewWithT(t)
+				_, err := database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{Name: "listnode", Expiry: expiry})
+				g.Expect(err).To(Not(HaveOccurred()))
+				_, err = database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{Name: "listnode", Expiry: expiry})
+				g.Expect(err).To(Not(HaveOccurred()))
+				_, err = database.CreateWorkerNodeToken(ctx, tx, types.JoinToken{Name: "expirednode", Expiry: time.Now().Add(-time.Hour)})
+				g.Expect(err).To(Not(HaveOccurred()))
+
+				tokens, err := database.ListWorkerNodeTokens(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(tokens).To(ContainElement(HaveField("Name", "listnode")))
+				g.Expect(tokens).ToNot(ContainElement(HaveField("Name", "expirednode")))
+
+				g.Expect(database.DeleteWorkerNodeTokensByName(ctx, tx, "listnode")).To(Succeed())
+				tokens, err = database.ListWorkerNodeTokens(ctx, tx)
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(tokens).ToNot(ContainElement(HaveField("Name", "listnode")))
+
+				g.Expect(database.DeleteWorkerNodeTokensByName(ctx, tx, "listnode")).ToNot(Succeed())
+			})
+			return nil
+		})
+	})
+}
@@ -21,20 +21,27 @@ var (
 		ManifestPath: filepath.Join("charts", "ck-loadbalancer"),
 	}
 
-	// chartGateway represents manifests to deploy Gateway API CRDs.
-	chartGateway = helm.InstallableChart{
+	// ChartGateway represents manifests to deploy Gateway API CRDs.
+	ChartGateway = helm.InstallableChart{
 		Name:         "ck-gateway",
 		Namespace:    "kube-system",
 		ManifestPath: filepath.Join("charts", "gateway-api-1.2.0.tgz"),
 	}
 
-	// chartGatewayClass represents a manifest to deploy a GatewayClass called ck-gateway.
-	chartGatewayClass = helm.InstallableChart{
+	// ChartGatewayClass represents a manifest to deploy a GatewayClass called ck-gateway.
+	ChartGatewayClass = helm.InstallableChart{
 		Name:         "ck-gateway-class",
 		Namespace:    "default",
 		ManifestPath: filepath.Join("charts", "ck-gateway-cilium"),
 	}
 
+	// ChartIngressClass represents a manifest to deploy a custom IngressClass for the Cilium ingress controller.
+	ChartIngressClass = helm.InstallableChart{
+		Name:         "ck-ingress-class",
+		Namespace:    "kube-system",
+		ManifestPath: filepath.Join("charts", "ck-ingress-cilium"),
+	}
+
 	// ciliumAgentImageRepo represents the image to use for cilium-agent.
 	ciliumAgentImageRepo = "ghcr.io/canonical/cilium"
 
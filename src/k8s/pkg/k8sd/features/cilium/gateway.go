@@ -3,6 +3,7 @@ package cilium
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/canonical/k8s/pkg/client/helm"
 	"github.com/canonical/k8s/pkg/k8sd/types"
@@ -17,23 +18,42 @@ const (
 // ApplyGateway assumes that the managed Cilium CNI is already installed on the cluster. It will fail if that is not the case.
 // ApplyGateway will deploy the Gateway API CRDs on the cluster and enable the GatewayAPI controllers on Cilium, when gateway.Enabled is true.
 // ApplyGateway will remove the Gateway API CRDs from the cluster and disable the GatewayAPI controllers on Cilium, when gateway.Enabled is false.
+// ApplyGateway will install the Gateway API CRDs of the configured release channel.
+// ApplyGateway will create, update or delete the managed Gateway according to the gateway configuration.
 // ApplyGateway will rollout restart the Cilium pods in case any Cilium configuration was changed.
+// ApplyGateway will rollout restart the cilium-operator in case the Gateway API CRDs were changed.
 // ApplyGateway will always return a FeatureStatus indicating the current status of the
 // deployment.
 // ApplyGateway returns an error if anything fails. The error is also wrapped in the .Message field of the
 // returned FeatureStatus.
 func ApplyGateway(ctx context.Context, snap snap.Snap, gateway types.Gateway, network types.Network, _ types.Annotations) (types.FeatureStatus, error) {
 	if gateway.GetEnabled() {
-		return enableGateway(ctx, snap)
+		return enableGateway(ctx, snap, gateway)
 	}
 	return disableGateway(ctx, snap, network)
 }
 
-func enableGateway(ctx context.Context, snap snap.Snap) (types.FeatureStatus, error) {
+func enableGateway(ctx context.Context, snap snap.Snap, gateway types.Gateway) (types.FeatureStatus, error) {
 	m := snap.HelmClient()
 
+	gatewayClassValues, err := managedGatewayValues(gateway)
+	if err != nil {
+		err = fmt.Errorf("failed to configure managed gateway: %w", err)
+		return types.FeatureStatus{
+			Enabled: false,
+			Version: CiliumAgentImageTag,
+			Message: fmt.Sprintf(GatewayDeployFailedMsgTmpl, err),
+		}, err
+	}
+
+	crdChannel := types.GatewayCRDChannelStandard
+	if gateway.ExperimentalCRDs() {
+		crdChannel = types.GatewayCRDChannelExperimental
+	}
+
 	// Install Gateway API CRDs
-	if _, err := m.Apply(ctx, chartGateway, helm.StatePresent, nil); err != nil {
+	crdsChanged, err := m.Apply(ctx, ChartGateway, helm.StatePresent, map[string]any{"channel": crdChannel})
+	if err != nil {
 		err = fmt.Errorf("failed to install Gateway API CRDs: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -42,8 +62,8 @@ func enableGateway(ctx context.Context, snap snap.Snap) (types.FeatureStatus, er
 		}, err
 	}
 
-	// Apply our GatewayClass named ck-gateway
-	if _, err := m.Apply(ctx, chartGatewayClass, helm.StatePresent, nil); err != nil {
+	// Apply our GatewayClass named ck-gateway and the managed Gateway
+	if _, err := m.Apply(ctx, ChartGatewayClass, helm.StatePresent, gatewayClassValues); err != nil {
 		err = fmt.Errorf("failed to install Gateway API GatewayClass: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -73,7 +93,7 @@ func enableGateway(ctx context.Context, snap snap.Snap) (types.FeatureStatus, er
 		}, err
 	}
 
-	if !changed {
+	if !changed && !crdsChanged {
 		return types.FeatureStatus{
 			Enabled: true,
 			Version: CiliumAgentImageTag,
@@ -81,7 +101,12 @@ func enableGateway(ctx context.Context, snap snap.Snap) (types.FeatureStatus, er
 		}, nil
 	}
 
-	if err := rolloutRestartCilium(ctx, snap, 3); err != nil {
+	// the cilium-operator only watches the Gateway API CRDs that exist when it starts
+	restart := rolloutRestartCiliumOperator
+	if changed {
+		restart = rolloutRestartCilium
+	}
+	if err := restart(ctx, snap, 3); err != nil {
 		err = fmt.Errorf("failed to rollout restart cilium to enable Gateway API: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -97,11 +122,101 @@ func enableGateway(ctx context.Context, snap snap.Snap) (types.FeatureStatus, er
 	}, nil
 }
 
+// managedGatewayValues returns the values of the ck-gateway-cilium chart for the managed Gateway.
+func managedGatewayValues(gateway types.Gateway) (map[string]any, error) {
+	if !gateway.GetManaged() {
+		return map[string]any{
+			"gateway": map[string]any{
+				"enabled":   false,
+				"listeners": []any{},
+				"addresses": []any{},
+			},
+		}, nil
+	}
+
+	listeners, err := gateway.ManagedListeners()
+	if err != nil {
+		return nil, fmt.Errorf("invalid listeners: %w", err)
+	}
+
+	allowedRoutes := map[string]any{}
+	switch from, namespaces := gateway.ManagedAllowedRoutes(); from {
+	case "Selector":
+		allowedRoutes["namespaces"] = map[string]any{
+			"from": from,
+			"selector": map[string]any{
+				"matchExpressions": []any{
+					map[string]any{
+						"key":      "kubernetes.io/metadata.name",
+						"operator": "In",
+						"values":   namespaces,
+					},
+				},
+			},
+		}
+	default:
+		allowedRoutes["namespaces"] = map[string]any{"from": from}
+	}
+
+	// listeners match all hostnames if none are configured
+	hostnames := gateway.ManagedHostnames()
+	if len(hostnames) == 0 {
+		hostnames = []string{""}
+	}
+
+	var gatewayListeners []any
+	for _, listener := range listeners {
+		for idx, hostname := range hostnames {
+			name := fmt.Sprintf("%s-%d", strings.ToLower(listener.Protocol), listener.Port)
+			if len(hostnames) > 1 {
+				name = fmt.Sprintf("%s-%d", name, idx)
+			}
+
+			gatewayListener := map[string]any{
+				"name":          name,
+				"protocol":      listener.Protocol,
+				"port":          listener.Port,
+				"allowedRoutes": allowedRoutes,
+			}
+			if hostname != "" {
+				gatewayListener["hostname"] = hostname
+			}
+			switch listener.Protocol {
+			case "HTTPS":
+				gatewayListener["tls"] = map[string]any{
+					"mode": "Terminate",
+					"certificateRefs": []any{
+						map[string]any{"kind": "Secret", "name": gateway.GetTLSSecret()},
+					},
+				}
+			case "TLS":
+				gatewayListener["tls"] = map[string]any{"mode": "Passthrough"}
+			}
+			gatewayListeners = append(gatewayListeners, gatewayListener)
+		}
+	}
+
+	addresses := []any{}
+	if v := gateway.GetLoadBalancerIP(); v != "" {
+		addresses = append(addresses, map[string]any{"type": "IPAddress", "value": v})
+	}
+
+	return map[string]any{
+		"gateway": map[string]any{
+			"enabled":   true,
+			"name":      types.ManagedGatewayName,
+			"namespace": types.ManagedGatewayNamespace,
+			"listeners": gatewayListeners,
+			"addresses": addresses,
+		},
+	}, nil
+}
+
 func disableGateway(ctx context.Context, snap snap.Snap, network types.Network) (types.FeatureStatus, error) {
 	m := snap.HelmClient()
 
 	// Delete our GatewayClass named ck-gateway
-	if _, err := m.Apply(ctx, chartGatewayClass, helm.StateDeleted, nil); err != nil {
+	if _, err := m.Apply(ctx, ChartGatewayClass, helm.StateDeleted, nil); err != nil {
 		err = fmt.Errorf("failed to delete Gateway API GatewayClass: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -122,7 +237,7 @@ func disableGateway(ctx context.Context, snap snap.Snap, network types.Network)
 
 	// Remove Gateway CRDs if the Gateway feature is disabled.
 	// This is done after the Cilium update as cilium requires the CRDs to be present for cleanups.
-	if _, err := m.Apply(ctx, chartGateway, helm.StateDeleted, nil); err != nil {
+	if _, err := m.Apply(ctx, ChartGateway, helm.StateDeleted, nil); err != nil {
 		err = fmt.Errorf("failed to delete Gateway API CRDs: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
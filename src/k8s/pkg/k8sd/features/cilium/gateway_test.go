@@ -268,3 +268,149 @@ func TestGatewayDisabled(t *testing.T) {
 		g.Expect(status.Message).To(Equal(cilium.DisabledMsg))
 	})
 }
+
+func TestGatewayManaged(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		gateway := types.Gateway{
+			Enabled: ptr.To(true),
+		}
+
+		_, err := cilium.ApplyGateway(context.Background(), snapM, gateway, types.Network{}, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(3))
+
+		g.Expect(helmM.ApplyCalledWith[0].Values).To(Equal(map[string]any{"channel": types.GatewayCRDChannelExperimental}))
+		g.Expect(helmM.ApplyCalledWith[1].Values["gateway"].(map[string]any)["enabled"]).To(BeFalse())
+	})
+
+	t.Run("Managed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		gateway := types.Gateway{
+			Enabled:                ptr.To(true),
+			CRDChannel:             ptr.To(types.GatewayCRDChannelStandard),
+			Managed:                ptr.To(true),
+			Hostnames:              ptr.To("a.example.com,b.example.com"),
+			TLSSecret:              ptr.To("gateway-tls"),
+			AllowedRouteNamespaces: ptr.To("team-a,team-b"),
+			LoadBalancerIP:         ptr.To("10.0.0.10"),
+		}
+
+		_, err := cilium.ApplyGateway(context.Background(), snapM, gateway, types.Network{}, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(3))
+
+		g.Expect(helmM.ApplyCalledWith[0].Values).To(Equal(map[string]any{"channel": types.GatewayCRDChannelStandard}))
+
+		values := helmM.ApplyCalledWith[1].Values["gateway"].(map[string]any)
+		g.Expect(values["enabled"]).To(BeTrue())
+		g.Expect(values["name"]).To(Equal(types.ManagedGatewayName))
+		g.Expect(values["namespace"]).To(Equal(types.ManagedGatewayNamespace))
+		g.Expect(values["addresses"]).To(Equal([]any{map[string]any{"type": "IPAddress", "value": "10.0.0.10"}}))
+
+		// HTTP:80 and HTTPS:443 for each hostname
+		listeners := values["listeners"].([]any)
+		g.Expect(listeners).To(HaveLen(4))
+		httpListener := listeners[0].(map[string]any)
+		g.Expect(httpListener).To(HaveKeyWithValue("name", "http-80-0"))
+		g.Expect(httpListener).To(HaveKeyWithValue("hostname", "a.example.com"))
+		g.Expect(httpListener).ToNot(HaveKey("tls"))
+		g.Expect(httpListener["allowedRoutes"]).To(Equal(map[string]any{
+			"namespaces": map[string]any{
+				"from": "Selector",
+				"selector": map[string]any{
+					"matchExpressions": []any{
+						map[string]any{
+							"key":      "kubernetes.io/metadata.name",
+							"operator": "In",
+							"values":   []string{"team-a", "team-b"},
+						},
+					},
+				},
+			},
+		}))
+		httpsListener := listeners[3].(map[string]any)
+		g.Expect(httpsListener).To(HaveKeyWithValue("name", "https-443-1"))
+		g.Expect(httpsListener).To(HaveKeyWithValue("hostname", "b.example.com"))
+		g.Expect(httpsListener).To(HaveKeyWithValue("port", 443))
+		g.Expect(httpsListener["tls"]).To(Equal(map[string]any{
+			"mode":            "Terminate",
+			"certificateRefs": []any{map[string]any{"kind": "Secret", "name": "gateway-tls"}},
+		}))
+	})
+
+	t.Run("InvalidListeners", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		gateway := types.Gateway{
+			Enabled:   ptr.To(true),
+			Managed:   ptr.To(true),
+			Listeners: ptr.To("UDP:53"),
+		}
+
+		status, err := cilium.ApplyGateway(context.Background(), snapM, gateway, types.Network{}, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status.Enabled).To(BeFalse())
+		g.Expect(helmM.ApplyCalledWith).To(BeEmpty())
+	})
+}
+
+func TestGatewayCRDsChanged(t *testing.T) {
+	g := NewWithT(t)
+
+	// only the Gateway API CRDs changed, so only the cilium-operator is restarted
+	helmM := &applyChangedMock{changed: map[string]bool{"ck-gateway": true}}
+	clientset := fake.NewSimpleClientset(
+		&v1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cilium-operator",
+				Namespace: "kube-system",
+			},
+		},
+	)
+	snapM := &snapmock.Snap{
+		Mock: snapmock.Mock{
+			HelmClient: helmM,
+			KubernetesClient: &kubernetes.Client{
+				Interface: clientset,
+			},
+		},
+	}
+	gateway := types.Gateway{
+		Enabled: ptr.To(true),
+	}
+
+	status, err := cilium.ApplyGateway(context.Background(), snapM, gateway, types.Network{}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Enabled).To(BeTrue())
+}
+
+// applyChangedMock is a helm client that reports changes for specific releases.
+type applyChangedMock struct {
+	helmmock.Mock
+	changed map[string]bool
+}
+
+func (m *applyChangedMock) Apply(_ context.Context, c helm.InstallableChart, _ helm.State, _ map[string]any) (bool, error) {
+	return m.changed[c.Name], nil
+}
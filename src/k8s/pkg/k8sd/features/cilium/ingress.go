@@ -7,6 +7,8 @@ import (
 	"github.com/canonical/k8s/pkg/client/helm"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/snap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -15,46 +17,118 @@ const (
 
 	IngressOptionEnabled                          = "enabled"
 	IngressOptionLoadBalancerMode                 = "loadbalancerMode"
-	IngressOptionLoadBalancerModeShared           = "shared" // loadbalancerMode: "shared"
+	IngressOptionLoadBalancerModeShared           = "shared"    // loadbalancerMode: "shared"
+	IngressOptionLoadBalancerModeDedicated        = "dedicated" // loadbalancerMode: "dedicated"
+	IngressOptionDefault                          = "default"
+	IngressOptionService                          = "service"
+	IngressOptionHostNetwork                      = "hostNetwork"
 	IngressOptionDefaultSecretName                = "defaultSecretName"
 	IngressOptionDefaultSecretNamespace           = "defaultSecretNamespace"
 	IngressOptionDefaultSecretNamespaceKubeSystem = "kube-system" // defaultSecretNamespace: "kube-system"
 	IngressOptionEnableProxyProtocol              = "enableProxyProtocol"
+
+	IngressServiceTypeLoadBalancer = "LoadBalancer"
+	IngressServiceTypeNodePort     = "NodePort"
 )
 
 // ApplyIngress assumes that the managed Cilium CNI is already installed on the cluster. It will fail if that is not the case.
 // ApplyIngress will enable Cilium's ingress controller when ingress.Enabled is true.
 // ApplyIngress will disable Cilium's ingress controller when ingress.Enabled is false.
-// ApplyIngress will rollout restart the Cilium pods in case any Cilium configuration was changed.
+// ApplyIngress will rollout restart the cilium-operator in case any ingress configuration was changed.
+// ApplyIngress will also rollout restart the Cilium agents when the ingress controller is first enabled.
+// ApplyIngress will deploy an additional IngressClass for the Cilium ingress controller if a custom class name is configured.
 // ApplyIngress will always return a FeatureStatus indicating the current status of the
 // deployment.
 // ApplyIngress returns an error if anything fails. The error is also wrapped in the .Message field of the
 // returned FeatureStatus.
-func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, network types.Network, _ types.Annotations) (types.FeatureStatus, error) {
+func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, network types.Network, annotations types.Annotations) (types.FeatureStatus, error) {
+	config, err := internalIngressConfig(annotations)
+	if err != nil {
+		err = fmt.Errorf("failed to parse annotations: %w", err)
+		return types.FeatureStatus{
+			Enabled: false,
+			Version: CiliumAgentImageTag,
+			Message: fmt.Sprintf(IngressDeployFailedMsgTmpl, err),
+		}, err
+	}
+
 	m := snap.HelmClient()
 	var values map[string]any
 	if ingress.GetEnabled() {
+		matchLabels := make(map[string]any, len(config.hostNetworkNodeSelector))
+		for key, value := range config.hostNetworkNodeSelector {
+			matchLabels[key] = value
+		}
+		var insecureNodePort, secureNodePort any
+		if config.httpNodePort != 0 {
+			insecureNodePort = config.httpNodePort
+		}
+		if config.httpsNodePort != 0 {
+			secureNodePort = config.httpsNodePort
+		}
+
 		values = map[string]any{
 			"ingressController": map[string]any{
 				IngressOptionEnabled:                true,
-				IngressOptionLoadBalancerMode:       IngressOptionLoadBalancerModeShared,
+				IngressOptionDefault:                config.defaultClass && config.className == "",
+				IngressOptionLoadBalancerMode:       config.loadBalancerMode,
 				IngressOptionDefaultSecretNamespace: IngressOptionDefaultSecretNamespaceKubeSystem,
 				IngressOptionDefaultSecretName:      ingress.GetDefaultTLSSecret(),
 				IngressOptionEnableProxyProtocol:    ingress.GetEnableProxyProtocol(),
+				IngressOptionService: map[string]any{
+					"type":             config.serviceType,
+					"insecureNodePort": insecureNodePort,
+					"secureNodePort":   secureNodePort,
+				},
+				IngressOptionHostNetwork: map[string]any{
+					"enabled":            config.hostNetwork,
+					"sharedListenerPort": config.hostNetworkPort,
+					"nodes": map[string]any{
+						"matchLabels": matchLabels,
+					},
+				},
 			},
 		}
 	} else {
 		values = map[string]any{
 			"ingressController": map[string]any{
 				IngressOptionEnabled:                false,
+				IngressOptionDefault:                false,
 				IngressOptionLoadBalancerMode:       "",
 				IngressOptionDefaultSecretNamespace: "",
 				IngressOptionDefaultSecretName:      "",
 				IngressOptionEnableProxyProtocol:    false,
+				IngressOptionService: map[string]any{
+					"type":             IngressServiceTypeLoadBalancer,
+					"insecureNodePort": nil,
+					"secureNodePort":   nil,
+				},
+				IngressOptionHostNetwork: map[string]any{
+					"enabled":            false,
+					"sharedListenerPort": ingressDefaultHostNetworkPort,
+					"nodes": map[string]any{
+						"matchLabels": map[string]any{},
+					},
+				},
 			},
 		}
 	}
 
+	// The Cilium agents only need to be restarted when the ingress controller is first enabled, as that enables the
+	// Envoy proxy on the agents. All other ingress configuration is reconciled by the cilium-operator.
+	var restartAgents bool
+	if ingress.GetEnabled() && network.GetEnabled() {
+		restartAgents, err = ingressRequiresAgentRestart(ctx, snap)
+		if err != nil {
+			err = fmt.Errorf("failed to check cilium agent configuration: %w", err)
+			return types.FeatureStatus{
+				Enabled: false,
+				Version: CiliumAgentImageTag,
+				Message: fmt.Sprintf(IngressDeployFailedMsgTmpl, err),
+			}, err
+		}
+	}
+
 	changed, err := m.Apply(ctx, ChartCilium, helm.StateUpgradeOnlyOrDeleted(network.GetEnabled()), values)
 	if err != nil {
 		if network.GetEnabled() {
@@ -74,6 +148,33 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, ne
 		}
 	}
 
+	// The Cilium chart always creates the "cilium" IngressClass and the cilium-operator only reconciles Ingress resources
+	// of that class. A custom IngressClass is deployed separately and the IngressClassController of k8sd hands its
+	// Ingress resources over to the Cilium ingress controller.
+	customClass := ingress.GetEnabled() && network.GetEnabled() && config.className != ""
+	classValues := map[string]any{
+		"ingressClass": map[string]any{
+			"name":    config.className,
+			"default": config.defaultClass,
+		},
+	}
+	if _, err := m.Apply(ctx, ChartIngressClass, helm.StatePresentOrDeleted(customClass), classValues); err != nil {
+		if customClass {
+			err = fmt.Errorf("failed to install IngressClass %q: %w", config.className, err)
+			return types.FeatureStatus{
+				Enabled: false,
+				Version: CiliumAgentImageTag,
+				Message: fmt.Sprintf(IngressDeployFailedMsgTmpl, err),
+			}, err
+		}
+		err = fmt.Errorf("failed to delete custom IngressClass: %w", err)
+		return types.FeatureStatus{
+			Enabled: false,
+			Version: CiliumAgentImageTag,
+			Message: fmt.Sprintf(IngressDeleteFailedMsgTmpl, err),
+		}, err
+	}
+
 	if !changed {
 		if ingress.GetEnabled() {
 			return types.FeatureStatus{
@@ -98,7 +199,11 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, ne
 		}, nil
 	}
 
-	if err := rolloutRestartCilium(ctx, snap, 3); err != nil {
+	restart := rolloutRestartCiliumOperator
+	if restartAgents {
+		restart = rolloutRestartCilium
+	}
+	if err := restart(ctx, snap, 3); err != nil {
 		err = fmt.Errorf("failed to rollout restart cilium to apply ingress: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -113,3 +218,21 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, ne
 		Message: EnabledMsg,
 	}, nil
 }
+
+// ingressRequiresAgentRestart returns true if the ingress controller is not yet enabled in the configuration of the Cilium agents.
+func ingressRequiresAgentRestart(ctx context.Context, snap snap.Snap) (bool, error) {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return false, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "cilium-config", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get cilium-config configmap: %w", err)
+	}
+
+	return configMap.Data["enable-ingress-controller"] != "true", nil
+}
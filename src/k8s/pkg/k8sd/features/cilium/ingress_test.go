@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/canonical/k8s/pkg/client/helm"
 	helmmock "github.com/canonical/k8s/pkg/client/helm/mock"
 	"github.com/canonical/k8s/pkg/client/kubernetes"
 	"github.com/canonical/k8s/pkg/k8sd/features/cilium"
@@ -13,6 +14,7 @@ import (
 	snapmock "github.com/canonical/k8s/pkg/snap/mock"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/ptr"
@@ -106,7 +108,13 @@ func TestIngress(t *testing.T) {
 			g.Expect(status.Enabled).To(Equal(tc.statusEnabled))
 			g.Expect(status.Message).To(Equal(tc.statusMsg))
 			g.Expect(status.Version).To(Equal(cilium.CiliumAgentImageTag))
-			g.Expect(helmM.ApplyCalledWith).To(HaveLen(1))
+			if tc.helmErr == nil {
+				g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
+				g.Expect(helmM.ApplyCalledWith[1].Chart).To(Equal(cilium.ChartIngressClass))
+				g.Expect(helmM.ApplyCalledWith[1].State).To(Equal(helm.StateDeleted))
+			} else {
+				g.Expect(helmM.ApplyCalledWith).To(HaveLen(1))
+			}
 
 			callArgs := helmM.ApplyCalledWith[0]
 			g.Expect(callArgs.Chart).To(Equal(cilium.ChartCilium))
@@ -149,7 +157,7 @@ func TestIngressRollout(t *testing.T) {
 		g.Expect(status.Enabled).To(BeFalse())
 		g.Expect(status.Message).To(Equal(fmt.Sprintf(cilium.IngressDeployFailedMsgTmpl, err)))
 		g.Expect(status.Version).To(Equal(cilium.CiliumAgentImageTag))
-		g.Expect(helmM.ApplyCalledWith).To(HaveLen(1))
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
 
 		callArgs := helmM.ApplyCalledWith[0]
 		g.Expect(callArgs.Chart).To(Equal(cilium.ChartCilium))
@@ -195,7 +203,7 @@ func TestIngressRollout(t *testing.T) {
 		g.Expect(status.Enabled).To(BeTrue())
 		g.Expect(status.Message).To(Equal(cilium.EnabledMsg))
 		g.Expect(status.Version).To(Equal(cilium.CiliumAgentImageTag))
-		g.Expect(helmM.ApplyCalledWith).To(HaveLen(1))
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
 
 		callArgs := helmM.ApplyCalledWith[0]
 		g.Expect(callArgs.Chart).To(Equal(cilium.ChartCilium))
@@ -203,6 +211,198 @@ func TestIngressRollout(t *testing.T) {
 	})
 }
 
+func TestIngressRolloutOperatorOnly(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		ingressInConfig string
+		expectErr       bool
+	}{
+		// the cilium daemonset does not exist, so restarting the agents fails
+		{name: "IngressAlreadyEnabled", ingressInConfig: "true", expectErr: false},
+		{name: "IngressFirstEnabled", ingressInConfig: "false", expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			helmM := &helmmock.Mock{
+				ApplyChanged: true,
+			}
+			clientset := fake.NewSimpleClientset(
+				&v1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cilium-operator",
+						Namespace: "kube-system",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cilium-config",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{"enable-ingress-controller": tc.ingressInConfig},
+				},
+			)
+			snapM := &snapmock.Snap{
+				Mock: snapmock.Mock{
+					HelmClient: helmM,
+					KubernetesClient: &kubernetes.Client{
+						Interface: clientset,
+					},
+				},
+			}
+			network := types.Network{
+				Enabled: ptr.To(true),
+			}
+			ingress := types.Ingress{
+				Enabled: ptr.To(true),
+			}
+
+			status, err := cilium.ApplyIngress(context.Background(), snapM, ingress, network, nil)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(status.Enabled).To(BeFalse())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(status.Enabled).To(BeTrue())
+				g.Expect(status.Message).To(Equal(cilium.EnabledMsg))
+			}
+		})
+	}
+}
+
+func TestIngressAnnotations(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		ingress := types.Ingress{
+			Enabled: ptr.To(true),
+		}
+		annotations := types.Annotations{
+			cilium.AnnotationIngressLoadBalancerMode:        "dedicated",
+			cilium.AnnotationIngressDefaultClass:            "true",
+			cilium.AnnotationIngressHostNetwork:             "true",
+			cilium.AnnotationIngressHostNetworkPort:         "8443",
+			cilium.AnnotationIngressHostNetworkNodeSelector: "ingress=true, zone=a",
+		}
+
+		status, err := cilium.ApplyIngress(context.Background(), snapM, ingress, types.Network{}, annotations)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(status.Enabled).To(BeTrue())
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
+
+		ingressController := helmM.ApplyCalledWith[0].Values["ingressController"].(map[string]any)
+		g.Expect(ingressController[cilium.IngressOptionLoadBalancerMode]).To(Equal(cilium.IngressOptionLoadBalancerModeDedicated))
+		g.Expect(ingressController[cilium.IngressOptionDefault]).To(BeTrue())
+		g.Expect(ingressController[cilium.IngressOptionHostNetwork]).To(Equal(map[string]any{
+			"enabled":            true,
+			"sharedListenerPort": 8443,
+			"nodes": map[string]any{
+				"matchLabels": map[string]any{"ingress": "true", "zone": "a"},
+			},
+		}))
+	})
+
+	t.Run("NodePort", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		ingress := types.Ingress{
+			Enabled: ptr.To(true),
+		}
+		annotations := types.Annotations{
+			cilium.AnnotationIngressServiceType:   "NodePort",
+			cilium.AnnotationIngressHTTPNodePort:  "30080",
+			cilium.AnnotationIngressHTTPSNodePort: "30443",
+		}
+
+		_, err := cilium.ApplyIngress(context.Background(), snapM, ingress, types.Network{}, annotations)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
+
+		ingressController := helmM.ApplyCalledWith[0].Values["ingressController"].(map[string]any)
+		g.Expect(ingressController[cilium.IngressOptionService]).To(Equal(map[string]any{
+			"type":             "NodePort",
+			"insecureNodePort": 30080,
+			"secureNodePort":   30443,
+		}))
+	})
+
+	t.Run("ClassName", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium-config", Namespace: "kube-system"},
+			Data:       map[string]string{"enable-ingress-controller": "true"},
+		})
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient:       helmM,
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+		ingress := types.Ingress{
+			Enabled: ptr.To(true),
+		}
+		network := types.Network{
+			Enabled: ptr.To(true),
+		}
+		annotations := types.Annotations{
+			cilium.AnnotationIngressClassName:    "web",
+			cilium.AnnotationIngressDefaultClass: "true",
+		}
+
+		_, err := cilium.ApplyIngress(context.Background(), snapM, ingress, network, annotations)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(helmM.ApplyCalledWith).To(HaveLen(2))
+
+		// the custom IngressClass is the default instead of the "cilium" IngressClass
+		ingressController := helmM.ApplyCalledWith[0].Values["ingressController"].(map[string]any)
+		g.Expect(ingressController[cilium.IngressOptionDefault]).To(BeFalse())
+
+		callArgs := helmM.ApplyCalledWith[1]
+		g.Expect(callArgs.Chart).To(Equal(cilium.ChartIngressClass))
+		g.Expect(callArgs.State).To(Equal(helm.StatePresent))
+		g.Expect(callArgs.Values).To(Equal(map[string]any{
+			"ingressClass": map[string]any{"name": "web", "default": true},
+		}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		helmM := &helmmock.Mock{}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				HelmClient: helmM,
+			},
+		}
+		ingress := types.Ingress{
+			Enabled: ptr.To(true),
+		}
+		annotations := types.Annotations{
+			cilium.AnnotationIngressLoadBalancerMode: "invalid",
+		}
+
+		status, err := cilium.ApplyIngress(context.Background(), snapM, ingress, types.Network{}, annotations)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status.Enabled).To(BeFalse())
+		g.Expect(status.Message).To(Equal(fmt.Sprintf(cilium.IngressDeployFailedMsgTmpl, err)))
+		g.Expect(helmM.ApplyCalledWith).To(BeEmpty())
+	})
+}
+
 func validateIngressValues(g Gomega, values map[string]any, ingress types.Ingress) {
 	ingressController, ok := values["ingressController"].(map[string]any)
 	g.Expect(ok).To(BeTrue())
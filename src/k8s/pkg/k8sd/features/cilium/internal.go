@@ -9,6 +9,7 @@ import (
 
 	apiv1_annotations "github.com/canonical/k8s-snap-api/api/v1/annotations/cilium"
 	"github.com/canonical/k8s/pkg/k8sd/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -107,3 +108,151 @@ func internalConfig(annotations types.Annotations) (config, error) {
 
 	return c, nil
 }
+
+const (
+	// AnnotationIngressPrefix is the common prefix of the annotations that configure the Cilium ingress controller.
+	AnnotationIngressPrefix = "k8sd/v1alpha1/cilium/ingress/"
+	// AnnotationIngressLoadBalancerMode is the load balancer mode of the Cilium ingress controller, one of "shared"
+	// (a single load balancer service for all Ingress resources) or "dedicated" (one service per Ingress resource).
+	AnnotationIngressLoadBalancerMode = "k8sd/v1alpha1/cilium/ingress/load-balancer-mode"
+	// AnnotationIngressDefaultClass marks the IngressClass of the Cilium ingress controller as the default IngressClass of the cluster.
+	AnnotationIngressDefaultClass = "k8sd/v1alpha1/cilium/ingress/default-class"
+	// AnnotationIngressClassName is the name of the IngressClass of the Cilium ingress controller. Defaults to "cilium".
+	AnnotationIngressClassName = "k8sd/v1alpha1/cilium/ingress/class-name"
+	// AnnotationIngressServiceType is the type of the shared ingress service, one of "LoadBalancer" or "NodePort".
+	AnnotationIngressServiceType = "k8sd/v1alpha1/cilium/ingress/service-type"
+	// AnnotationIngressHTTPNodePort is the node port of the shared ingress service for HTTP traffic.
+	AnnotationIngressHTTPNodePort = "k8sd/v1alpha1/cilium/ingress/http-node-port"
+	// AnnotationIngressHTTPSNodePort is the node port of the shared ingress service for HTTPS traffic.
+	AnnotationIngressHTTPSNodePort = "k8sd/v1alpha1/cilium/ingress/https-node-port"
+	// AnnotationIngressHostNetwork exposes the ingress controller on the host network of the nodes, for clusters without a load balancer.
+	AnnotationIngressHostNetwork = "k8sd/v1alpha1/cilium/ingress/host-network"
+	// AnnotationIngressHostNetworkPort is the port on which the shared ingress listens on the host network. Defaults to 8080.
+	AnnotationIngressHostNetworkPort = "k8sd/v1alpha1/cilium/ingress/host-network-port"
+	// AnnotationIngressHostNetworkNodeSelector is a comma-separated list of key=value node labels that select the nodes
+	// on which the ingress listens in host network mode. All nodes are selected if empty.
+	AnnotationIngressHostNetworkNodeSelector = "k8sd/v1alpha1/cilium/ingress/host-network-node-selector"
+
+	// ingressDefaultHostNetworkPort is the default shared listener port of the ingress in host network mode.
+	ingressDefaultHostNetworkPort = 8080
+)
+
+type ingressConfig struct {
+	loadBalancerMode string
+	defaultClass     bool
+	// className is the name of a custom IngressClass, if any.
+	className               string
+	serviceType             string
+	httpNodePort            int
+	httpsNodePort           int
+	hostNetwork             bool
+	hostNetworkPort         int
+	hostNetworkNodeSelector map[string]string
+}
+
+func validateNodeSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(selector, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%q is not a key=value label", item)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+func internalIngressConfig(annotations types.Annotations) (ingressConfig, error) {
+	c := ingressConfig{
+		loadBalancerMode: IngressOptionLoadBalancerModeShared,
+		serviceType:      IngressServiceTypeLoadBalancer,
+		hostNetworkPort:  ingressDefaultHostNetworkPort,
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressLoadBalancerMode); ok {
+		if v != IngressOptionLoadBalancerModeShared && v != IngressOptionLoadBalancerModeDedicated {
+			return ingressConfig{}, fmt.Errorf("invalid ingress load balancer mode %q: must be one of %s, %s", v, IngressOptionLoadBalancerModeShared, IngressOptionLoadBalancerModeDedicated)
+		}
+		c.loadBalancerMode = v
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressDefaultClass); ok {
+		defaultClass, err := strconv.ParseBool(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress default class: %w", err)
+		}
+		c.defaultClass = defaultClass
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressClassName); ok && v != ingressClassName {
+		if errs := validation.IsDNS1123Subdomain(v); len(errs) > 0 {
+			return ingressConfig{}, fmt.Errorf("invalid ingress class name %q: %s", v, strings.Join(errs, ", "))
+		}
+		c.className = v
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressServiceType); ok {
+		if v != IngressServiceTypeLoadBalancer && v != IngressServiceTypeNodePort {
+			return ingressConfig{}, fmt.Errorf("invalid ingress service type %q: must be one of %s, %s", v, IngressServiceTypeLoadBalancer, IngressServiceTypeNodePort)
+		}
+		c.serviceType = v
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressHTTPNodePort); ok {
+		port, err := validatePort(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress HTTP node port: %w", err)
+		}
+		c.httpNodePort = port
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressHTTPSNodePort); ok {
+		port, err := validatePort(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress HTTPS node port: %w", err)
+		}
+		c.httpsNodePort = port
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressHostNetwork); ok {
+		hostNetwork, err := strconv.ParseBool(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress host network: %w", err)
+		}
+		c.hostNetwork = hostNetwork
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressHostNetworkPort); ok {
+		port, err := validatePort(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress host network port: %w", err)
+		}
+		c.hostNetworkPort = port
+	}
+
+	if v, ok := annotations.Get(AnnotationIngressHostNetworkNodeSelector); ok {
+		labels, err := validateNodeSelector(v)
+		if err != nil {
+			return ingressConfig{}, fmt.Errorf("failed to parse ingress host network node selector: %w", err)
+		}
+		c.hostNetworkNodeSelector = labels
+	}
+
+	// check: node ports only apply to the shared ingress service
+	if c.httpNodePort != 0 || c.httpsNodePort != 0 || c.serviceType != IngressServiceTypeLoadBalancer {
+		if c.loadBalancerMode != IngressOptionLoadBalancerModeShared {
+			return ingressConfig{}, fmt.Errorf("ingress service type and node ports require the %s load balancer mode", IngressOptionLoadBalancerModeShared)
+		}
+		if c.hostNetwork {
+			return ingressConfig{}, fmt.Errorf("ingress service type and node ports cannot be set in host network mode")
+		}
+	}
+	if !c.hostNetwork && (c.hostNetworkPort != ingressDefaultHostNetworkPort || len(c.hostNetworkNodeSelector) > 0) {
+		return ingressConfig{}, fmt.Errorf("ingress host network port and node selector require host network mode")
+	}
+
+	return c, nil
+}
@@ -199,3 +199,151 @@ func TestInternalConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestInternalIngressConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		annotations    map[string]string
+		expectedConfig ingressConfig
+		expectError    bool
+	}{
+		{
+			name:        "Empty",
+			annotations: map[string]string{},
+			expectedConfig: ingressConfig{
+				loadBalancerMode: IngressOptionLoadBalancerModeShared,
+				serviceType:      IngressServiceTypeLoadBalancer,
+				hostNetworkPort:  ingressDefaultHostNetworkPort,
+			},
+		},
+		{
+			name: "Dedicated",
+			annotations: map[string]string{
+				AnnotationIngressLoadBalancerMode: "dedicated",
+				AnnotationIngressDefaultClass:     "true",
+			},
+			expectedConfig: ingressConfig{
+				loadBalancerMode: IngressOptionLoadBalancerModeDedicated,
+				defaultClass:     true,
+				serviceType:      IngressServiceTypeLoadBalancer,
+				hostNetworkPort:  ingressDefaultHostNetworkPort,
+			},
+		},
+		{
+			name: "ClassName",
+			annotations: map[string]string{
+				AnnotationIngressClassName:    "web",
+				AnnotationIngressDefaultClass: "true",
+			},
+			expectedConfig: ingressConfig{
+				loadBalancerMode: IngressOptionLoadBalancerModeShared,
+				defaultClass:     true,
+				className:        "web",
+				serviceType:      IngressServiceTypeLoadBalancer,
+				hostNetworkPort:  ingressDefaultHostNetworkPort,
+			},
+		},
+		{
+			name:        "DefaultClassName",
+			annotations: map[string]string{AnnotationIngressClassName: "cilium"},
+			expectedConfig: ingressConfig{
+				loadBalancerMode: IngressOptionLoadBalancerModeShared,
+				serviceType:      IngressServiceTypeLoadBalancer,
+				hostNetworkPort:  ingressDefaultHostNetworkPort,
+			},
+		},
+		{
+			name: "NodePort",
+			annotations: map[string]string{
+				AnnotationIngressServiceType:   "NodePort",
+				AnnotationIngressHTTPNodePort:  "30080",
+				AnnotationIngressHTTPSNodePort: "30443",
+			},
+			expectedConfig: ingressConfig{
+				loadBalancerMode: IngressOptionLoadBalancerModeShared,
+				serviceType:      IngressServiceTypeNodePort,
+				httpNodePort:     30080,
+				httpsNodePort:    30443,
+				hostNetworkPort:  ingressDefaultHostNetworkPort,
+			},
+		},
+		{
+			name: "HostNetwork",
+			annotations: map[string]string{
+				AnnotationIngressHostNetwork:             "true",
+				AnnotationIngressHostNetworkPort:         "80",
+				AnnotationIngressHostNetworkNodeSelector: "role=ingress",
+			},
+			expectedConfig: ingressConfig{
+				loadBalancerMode:        IngressOptionLoadBalancerModeShared,
+				serviceType:             IngressServiceTypeLoadBalancer,
+				hostNetwork:             true,
+				hostNetworkPort:         80,
+				hostNetworkNodeSelector: map[string]string{"role": "ingress"},
+			},
+		},
+		{
+			name:        "InvalidLoadBalancerMode",
+			annotations: map[string]string{AnnotationIngressLoadBalancerMode: "none"},
+			expectError: true,
+		},
+		{
+			name:        "InvalidDefaultClass",
+			annotations: map[string]string{AnnotationIngressDefaultClass: "maybe"},
+			expectError: true,
+		},
+		{
+			name:        "InvalidClassName",
+			annotations: map[string]string{AnnotationIngressClassName: "Not_Valid"},
+			expectError: true,
+		},
+		{
+			name:        "InvalidServiceType",
+			annotations: map[string]string{AnnotationIngressServiceType: "ClusterIP"},
+			expectError: true,
+		},
+		{
+			name:        "InvalidNodePort",
+			annotations: map[string]string{AnnotationIngressHTTPNodePort: "70000"},
+			expectError: true,
+		},
+		{
+			name:        "InvalidNodeSelector",
+			annotations: map[string]string{AnnotationIngressHostNetwork: "true", AnnotationIngressHostNetworkNodeSelector: "role"},
+			expectError: true,
+		},
+		{
+			name: "NodePortWithDedicatedMode",
+			annotations: map[string]string{
+				AnnotationIngressLoadBalancerMode: "dedicated",
+				AnnotationIngressHTTPNodePort:     "30080",
+			},
+			expectError: true,
+		},
+		{
+			name: "NodePortWithHostNetwork",
+			annotations: map[string]string{
+				AnnotationIngressHostNetwork: "true",
+				AnnotationIngressServiceType: "NodePort",
+			},
+			expectError: true,
+		},
+		{
+			name:        "HostNetworkPortWithoutHostNetwork",
+			annotations: map[string]string{AnnotationIngressHostNetworkPort: "80"},
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			parsed, err := internalIngressConfig(tc.annotations)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(parsed).To(Equal(tc.expectedConfig))
+			}
+		})
+	}
+}
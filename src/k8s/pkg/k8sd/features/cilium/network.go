@@ -334,27 +334,40 @@ func ApplyNetwork(ctx context.Context, snap snap.Snap, s state.State, apiserver
 }
 
 func rolloutRestartCilium(ctx context.Context, snap snap.Snap, attempts int) error {
+	if err := rolloutRestartCiliumOperator(ctx, snap, attempts); err != nil {
+		return err
+	}
+
 	client, err := snap.KubernetesClient("")
 	if err != nil {
 		return fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
 	if err := control.RetryFor(ctx, attempts, 0, func() error {
-		if err := client.RestartDeployment(ctx, "cilium-operator", "kube-system"); err != nil {
-			return fmt.Errorf("failed to restart cilium-operator deployment: %w", err)
+		if err := client.RestartDaemonset(ctx, "cilium", "kube-system"); err != nil {
+			return fmt.Errorf("failed to restart cilium daemonset: %w", err)
 		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("failed to restart cilium-operator deployment after %d attempts: %w", attempts, err)
+		return fmt.Errorf("failed to restart cilium daemonset after %d attempts: %w", attempts, err)
+	}
+
+	return nil
+}
+
+func rolloutRestartCiliumOperator(ctx context.Context, snap snap.Snap, attempts int) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
 	if err := control.RetryFor(ctx, attempts, 0, func() error {
-		if err := client.RestartDaemonset(ctx, "cilium", "kube-system"); err != nil {
-			return fmt.Errorf("failed to restart cilium daemonset: %w", err)
+		if err := client.RestartDeployment(ctx, "cilium-operator", "kube-system"); err != nil {
+			return fmt.Errorf("failed to restart cilium-operator deployment: %w", err)
 		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("failed to restart cilium daemonset after %d attempts: %w", attempts, err)
+		return fmt.Errorf("failed to restart cilium-operator deployment after %d attempts: %w", attempts, err)
 	}
 
 	return nil
@@ -11,6 +11,11 @@ import (
 const (
 	DisabledMsg = "disabled"
 	EnabledMsg  = "enabled"
+
+	// ingressClassName is the name of the IngressClass created by the Cilium ingress controller.
+	ingressClassName = "cilium"
+	// gatewayAPIGroupVersion is the group version of the Gateway API resources installed by the gateway feature.
+	gatewayAPIGroupVersion = "gateway.networking.k8s.io/v1"
 )
 
 func CheckNetwork(ctx context.Context, snap snap.Snap) error {
@@ -36,3 +41,53 @@ func CheckNetwork(ctx context.Context, snap snap.Snap) error {
 
 	return nil
 }
+
+// CheckIngress checks that the Cilium pods are ready and the Cilium IngressClass exists.
+func CheckIngress(ctx context.Context, snap snap.Snap) error {
+	if err := CheckNetwork(ctx, snap); err != nil {
+		return err
+	}
+
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	if _, err := client.NetworkingV1().IngressClasses().Get(ctx, ingressClassName, metav1.GetOptions{}); err != nil {
+		return fmt.Errorf("failed to get IngressClass %q: %w", ingressClassName, err)
+	}
+
+	return nil
+}
+
+// CheckGateway checks that the Cilium pods are ready and the Gateway API CRDs are present.
+func CheckGateway(ctx context.Context, snap snap.Snap) error {
+	if err := CheckNetwork(ctx, snap); err != nil {
+		return err
+	}
+
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	resources, err := client.ListResourcesForGroupVersion(gatewayAPIGroupVersion)
+	if err != nil {
+		return fmt.Errorf("gateway API CRDs not present: %w", err)
+	}
+
+	for _, required := range []string{"gatewayclasses", "gateways", "httproutes"} {
+		found := false
+		for _, resource := range resources.APIResources {
+			if resource.Name == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("resource %q not found in %s", required, gatewayAPIGroupVersion)
+		}
+	}
+
+	return nil
+}
@@ -10,7 +10,9 @@ import (
 	snapmock "github.com/canonical/k8s/pkg/snap/mock"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -122,3 +124,115 @@ func TestCheckNetwork(t *testing.T) {
 		g.Expect(err).NotTo(HaveOccurred())
 	})
 }
+
+// readyCiliumPods returns ready cilium and cilium-operator pods.
+func readyCiliumPods() []runtime.Object {
+	var objects []runtime.Object
+	for name, labels := range map[string]map[string]string{
+		"operator": {"io.cilium/app": "operator"},
+		"cilium":   {"k8s-app": "cilium"},
+	} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kube-system",
+				Labels:    labels,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		})
+	}
+	return objects
+}
+
+func TestCheckIngress(t *testing.T) {
+	t.Run("noIngressClass", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(readyCiliumPods()...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := cilium.CheckIngress(context.Background(), snapM)
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("ingressClassPresent", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(append(readyCiliumPods(), &networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium"},
+		})...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := cilium.CheckIngress(context.Background(), snapM)
+		g.Expect(err).NotTo(HaveOccurred())
+	})
+}
+
+func TestCheckGateway(t *testing.T) {
+	t.Run("noCRDs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(readyCiliumPods()...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := cilium.CheckGateway(context.Background(), snapM)
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("missingResource", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(readyCiliumPods()...)
+		clientset.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "gateway.networking.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "gatewayclasses"}, {Name: "gateways"}},
+			},
+		}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := cilium.CheckGateway(context.Background(), snapM)
+		g.Expect(err).To(MatchError(ContainSubstring("httproutes")))
+	})
+
+	t.Run("crdsPresent", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(readyCiliumPods()...)
+		clientset.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "gateway.networking.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "gatewayclasses"}, {Name: "gateways"}, {Name: "httproutes"}},
+			},
+		}
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := cilium.CheckGateway(context.Background(), snapM)
+		g.Expect(err).NotTo(HaveOccurred())
+	})
+}
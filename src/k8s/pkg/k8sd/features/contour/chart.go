@@ -7,28 +7,28 @@ import (
 )
 
 var (
-	// chartContour represents manifests to deploy Contour.
+	// ChartContour represents manifests to deploy Contour.
 	// This excludes shared CRDs.
-	chartContour = helm.InstallableChart{
+	ChartContour = helm.InstallableChart{
 		Name:         "ck-ingress",
 		Namespace:    "projectcontour",
 		ManifestPath: filepath.Join("charts", "contour-17.0.4.tgz"),
 	}
-	// chartGateway represents manifests to deploy Contour Gateway.
+	// ChartGateway represents manifests to deploy Contour Gateway.
 	// This excludes shared CRDs.
-	chartGateway = helm.InstallableChart{
+	ChartGateway = helm.InstallableChart{
 		Name:         "ck-gateway",
 		Namespace:    "projectcontour",
 		ManifestPath: filepath.Join("charts", "ck-gateway-contour-1.28.2.tgz"),
 	}
-	// chartDefaultTLS represents manifests to deploy a delegation resource for the default TLS secret.
-	chartDefaultTLS = helm.InstallableChart{
+	// ChartDefaultTLS represents manifests to deploy a delegation resource for the default TLS secret.
+	ChartDefaultTLS = helm.InstallableChart{
 		Name:         "ck-ingress-tls",
 		Namespace:    "projectcontour-root",
 		ManifestPath: filepath.Join("charts", "ck-ingress-tls"),
 	}
-	// chartCommonContourCRDS represents manifests to deploy common Contour CRDs.
-	chartCommonContourCRDS = helm.InstallableChart{
+	// ChartCommonContourCRDS represents manifests to deploy common Contour CRDs.
+	ChartCommonContourCRDS = helm.InstallableChart{
 		Name:         "ck-contour-common",
 		Namespace:    "projectcontour",
 		ManifestPath: filepath.Join("charts", "ck-contour-common-1.28.2.tgz"),
@@ -28,7 +28,7 @@ func ApplyGateway(ctx context.Context, snap snap.Snap, gateway types.Gateway, ne
 	m := snap.HelmClient()
 
 	if !gateway.GetEnabled() {
-		if _, err := m.Apply(ctx, chartGateway, helm.StateDeleted, nil); err != nil {
+		if _, err := m.Apply(ctx, ChartGateway, helm.StateDeleted, nil); err != nil {
 			err = fmt.Errorf("failed to uninstall the contour gateway chart: %w", err)
 			return types.FeatureStatus{
 				Enabled: false,
@@ -77,7 +77,7 @@ func ApplyGateway(ctx context.Context, snap snap.Snap, gateway types.Gateway, ne
 		},
 	}
 
-	if _, err := m.Apply(ctx, chartGateway, helm.StatePresent, values); err != nil {
+	if _, err := m.Apply(ctx, ChartGateway, helm.StatePresent, values); err != nil {
 		err = fmt.Errorf("failed to install the contour gateway chart: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
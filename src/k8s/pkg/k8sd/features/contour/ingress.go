@@ -29,7 +29,7 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, _
 	m := snap.HelmClient()
 
 	if !ingress.GetEnabled() {
-		if _, err := m.Apply(ctx, chartContour, helm.StateDeleted, nil); err != nil {
+		if _, err := m.Apply(ctx, ChartContour, helm.StateDeleted, nil); err != nil {
 			err = fmt.Errorf("failed to uninstall ingress: %w", err)
 			return types.FeatureStatus{
 				Enabled: false,
@@ -102,7 +102,7 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, _
 		contour["extraArgs"] = []string{"--use-proxy-protocol"}
 	}
 
-	changed, err := m.Apply(ctx, chartContour, helm.StatePresent, values)
+	changed, err := m.Apply(ctx, ChartContour, helm.StatePresent, values)
 	if err != nil {
 		err = fmt.Errorf("failed to enable ingress: %w", err)
 		return types.FeatureStatus{
@@ -130,7 +130,7 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, _
 		values = map[string]any{
 			"defaultTLSSecret": ingress.GetDefaultTLSSecret(),
 		}
-		if _, err := m.Apply(ctx, chartDefaultTLS, helm.StatePresent, values); err != nil {
+		if _, err := m.Apply(ctx, ChartDefaultTLS, helm.StatePresent, values); err != nil {
 			err = fmt.Errorf("failed to install the delegation resource for default TLS secret: %w", err)
 			return types.FeatureStatus{
 				Enabled: false,
@@ -145,7 +145,7 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, _
 		}, nil
 	}
 
-	if _, err := m.Apply(ctx, chartDefaultTLS, helm.StateDeleted, nil); err != nil {
+	if _, err := m.Apply(ctx, ChartDefaultTLS, helm.StateDeleted, nil); err != nil {
 		err = fmt.Errorf("failed to uninstall the delegation resource for default TLS secret: %w", err)
 		return types.FeatureStatus{
 			Enabled: false,
@@ -167,13 +167,13 @@ func ApplyIngress(ctx context.Context, snap snap.Snap, ingress types.Ingress, _
 func applyCommonContourCRDS(ctx context.Context, snap snap.Snap, enabled bool) error {
 	m := snap.HelmClient()
 	if enabled {
-		if _, err := m.Apply(ctx, chartCommonContourCRDS, helm.StatePresent, nil); err != nil {
+		if _, err := m.Apply(ctx, ChartCommonContourCRDS, helm.StatePresent, nil); err != nil {
 			return fmt.Errorf("failed to install common CRDS: %w", err)
 		}
 		return nil
 	}
 
-	if _, err := m.Apply(ctx, chartCommonContourCRDS, helm.StateDeleted, nil); err != nil {
+	if _, err := m.Apply(ctx, ChartCommonContourCRDS, helm.StateDeleted, nil); err != nil {
 		return fmt.Errorf("failed to uninstall common CRDS: %w", err)
 	}
 
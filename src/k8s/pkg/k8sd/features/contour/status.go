@@ -0,0 +1,51 @@
+package contour
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/snap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gatewayAPIGroupVersion is the group version of the Gateway API resources installed by the gateway feature.
+const gatewayAPIGroupVersion = "gateway.networking.k8s.io/v1"
+
+// CheckIngress checks that the Contour and Envoy pods are ready.
+func CheckIngress(ctx context.Context, snap snap.Snap) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	for _, check := range []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+	}{
+		{name: "contour", namespace: ChartContour.Namespace, labels: map[string]string{"app.kubernetes.io/name": "contour", "app.kubernetes.io/component": "contour"}},
+		{name: "envoy", namespace: ChartContour.Namespace, labels: map[string]string{"app.kubernetes.io/name": "contour", "app.kubernetes.io/component": "envoy"}},
+	} {
+		if err := client.CheckForReadyPods(ctx, check.namespace, metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: check.labels}),
+		}); err != nil {
+			return fmt.Errorf("%v pods not yet ready: %w", check.name, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckGateway checks that the Gateway API CRDs are present.
+func CheckGateway(ctx context.Context, snap snap.Snap) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	if _, err := client.ListResourcesForGroupVersion(gatewayAPIGroupVersion); err != nil {
+		return fmt.Errorf("gateway API CRDs not present: %w", err)
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package features
 
 import (
+	"github.com/canonical/k8s/pkg/client/helm"
 	"github.com/canonical/k8s/pkg/k8sd/features/cilium"
 	"github.com/canonical/k8s/pkg/k8sd/features/coredns"
 	"github.com/canonical/k8s/pkg/k8sd/features/localpv"
 	"github.com/canonical/k8s/pkg/k8sd/features/metallb"
 	metrics_server "github.com/canonical/k8s/pkg/k8sd/features/metrics-server"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 // Default implements the Canonical Kubernetes built-in features.
@@ -26,8 +28,25 @@ var Implementation Interface = &implementation{
 
 // StatusChecks implements the Canonical Kubernetes built-in feature status checks.
 var StatusChecks StatusInterface = &statusChecks{
-	checkNetwork: cilium.CheckNetwork,
-	checkDNS:     coredns.CheckDNS,
+	checkNetwork:       cilium.CheckNetwork,
+	checkDNS:           coredns.CheckDNS,
+	checkGateway:       cilium.CheckGateway,
+	checkIngress:       cilium.CheckIngress,
+	checkLoadBalancer:  metallb.CheckLoadBalancer,
+	checkLocalStorage:  localpv.CheckLocalStorage,
+	checkMetricsServer: metrics_server.CheckMetricsServer,
+}
+
+// Charts are the Helm charts deployed by each built-in feature, used for drift detection and rollbacks.
+// Ingress is configured through the Cilium chart of the network feature. Its optional custom IngressClass
+// (cilium.ChartIngressClass) is not always installed, so it is not checked for drift.
+var Charts = map[types.FeatureName][]helm.InstallableChart{
+	Network:       {cilium.ChartCilium},
+	Gateway:       {cilium.ChartGateway, cilium.ChartGatewayClass},
+	LoadBalancer:  {metallb.ChartMetalLB, metallb.ChartMetalLBLoadBalancer},
+	DNS:           {coredns.Chart},
+	LocalStorage:  {localpv.Chart},
+	MetricsServer: {metrics_server.Chart},
 }
 
 var Cleanup CleanupInterface = &cleanup{
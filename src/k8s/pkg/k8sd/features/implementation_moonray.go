@@ -3,12 +3,14 @@
 package features
 
 import (
+	"github.com/canonical/k8s/pkg/client/helm"
 	"github.com/canonical/k8s/pkg/k8sd/features/calico"
 	"github.com/canonical/k8s/pkg/k8sd/features/contour"
 	"github.com/canonical/k8s/pkg/k8sd/features/coredns"
 	"github.com/canonical/k8s/pkg/k8sd/features/localpv"
 	"github.com/canonical/k8s/pkg/k8sd/features/metallb"
 	metrics_server "github.com/canonical/k8s/pkg/k8sd/features/metrics-server"
+	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
 // Implementation contains the moonray features for Canonical Kubernetes.
@@ -26,8 +28,26 @@ var Implementation Interface = &implementation{
 // StatusChecks implements the Canonical Kubernetes moonray feature status checks.
 // TODO: Replace default by moonray.
 var StatusChecks StatusInterface = &statusChecks{
-	checkNetwork: calico.CheckNetwork,
-	checkDNS:     coredns.CheckDNS,
+	checkNetwork:       calico.CheckNetwork,
+	checkDNS:           coredns.CheckDNS,
+	checkGateway:       contour.CheckGateway,
+	checkIngress:       contour.CheckIngress,
+	checkLoadBalancer:  metallb.CheckLoadBalancer,
+	checkLocalStorage:  localpv.CheckLocalStorage,
+	checkMetricsServer: metrics_server.CheckMetricsServer,
+}
+
+// Charts are the Helm charts deployed by each moonray feature, used for drift detection and rollbacks.
+// The Contour CRDs are shared by ingress and gateway and are not included.
+// TODO: Replace default by moonray.
+var Charts = map[types.FeatureName][]helm.InstallableChart{
+	Network:       {calico.ChartCalico},
+	Gateway:       {contour.ChartGateway},
+	Ingress:       {contour.ChartContour, contour.ChartDefaultTLS},
+	LoadBalancer:  {metallb.ChartMetalLB, metallb.ChartMetalLBLoadBalancer},
+	DNS:           {coredns.Chart},
+	LocalStorage:  {localpv.Chart},
+	MetricsServer: {metrics_server.Chart},
 }
 
 var Cleanup CleanupInterface = &cleanup{
@@ -0,0 +1,41 @@
+package localpv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/snap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// storageClassName is the name of the StorageClass created by the rawfile-localpv chart.
+const storageClassName = "csi-rawfile-default"
+
+// CheckLocalStorage checks that the rawfile-localpv pods are ready and the StorageClass exists.
+func CheckLocalStorage(ctx context.Context, snap snap.Snap) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	for _, check := range []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+	}{
+		{name: "rawfile-csi-controller", namespace: Chart.Namespace, labels: map[string]string{"app.kubernetes.io/name": "rawfile-csi", "component": "controller"}},
+		{name: "rawfile-csi-node", namespace: Chart.Namespace, labels: map[string]string{"app.kubernetes.io/name": "rawfile-csi", "component": "node"}},
+	} {
+		if err := client.CheckForReadyPods(ctx, check.namespace, metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: check.labels}),
+		}); err != nil {
+			return fmt.Errorf("%v pods not yet ready: %w", check.name, err)
+		}
+	}
+
+	if _, err := client.StorageV1().StorageClasses().Get(ctx, storageClassName, metav1.GetOptions{}); err != nil {
+		return fmt.Errorf("failed to get StorageClass %q: %w", storageClassName, err)
+	}
+
+	return nil
+}
@@ -21,6 +21,9 @@ var (
 		ManifestPath: filepath.Join("charts", "ck-loadbalancer"),
 	}
 
+	// managedAddressPool is the name of the IPAddressPool created by ChartMetalLBLoadBalancer.
+	managedAddressPool = "metallb-loadbalancer-ck-loadbalancer"
+
 	// controllerImageRepo is the image to use for metallb-controller.
 	controllerImageRepo = "ghcr.io/canonical/metallb-controller"
 
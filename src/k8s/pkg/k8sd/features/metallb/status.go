@@ -0,0 +1,60 @@
+package metallb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/snap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// addressPoolAnnotations are the annotations MetalLB uses to request an address from a specific pool.
+var addressPoolAnnotations = []string{"metallb.io/address-pool", "metallb.universe.tf/address-pool"}
+
+// usesManagedAddressPool returns true if the service is handled by MetalLB and gets its address from the managed pool.
+// Services with a custom load balancer class or requesting a user-defined pool are not our concern.
+func usesManagedAddressPool(svc corev1.Service) bool {
+	if svc.Spec.LoadBalancerClass != nil {
+		return false
+	}
+	for _, annotation := range addressPoolAnnotations {
+		if pool, ok := svc.Annotations[annotation]; ok {
+			return pool == managedAddressPool
+		}
+	}
+	return true
+}
+
+// CheckLoadBalancer checks that the MetalLB pods are ready and that all LoadBalancer services using the managed address pool have an address allocated.
+func CheckLoadBalancer(ctx context.Context, snap snap.Snap) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	for _, check := range []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+	}{
+		{name: "metallb-controller", namespace: ChartMetalLB.Namespace, labels: map[string]string{"app.kubernetes.io/name": "metallb", "app.kubernetes.io/component": "controller"}},
+		{name: "metallb-speaker", namespace: ChartMetalLB.Namespace, labels: map[string]string{"app.kubernetes.io/name": "metallb", "app.kubernetes.io/component": "speaker"}},
+	} {
+		if err := client.CheckForReadyPods(ctx, check.namespace, metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: check.labels}),
+		}); err != nil {
+			return fmt.Errorf("%v pods not yet ready: %w", check.name, err)
+		}
+	}
+
+	pending, err := client.ListPendingLoadBalancerServices(ctx, usesManagedAddressPool)
+	if err != nil {
+		return fmt.Errorf("failed to check LoadBalancer services: %w", err)
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("LoadBalancer services %v have no address allocated", pending)
+	}
+
+	return nil
+}
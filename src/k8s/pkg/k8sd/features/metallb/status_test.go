@@ -0,0 +1,133 @@
+package metallb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/k8sd/features/metallb"
+	snapmock "github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func readyMetalLBPods() []runtime.Object {
+	var objects []runtime.Object
+	for _, component := range []string{"controller", "speaker"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      component,
+				Namespace: "metallb-system",
+				Labels:    map[string]string{"app.kubernetes.io/name": "metallb", "app.kubernetes.io/component": component},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		})
+	}
+	return objects
+}
+
+func TestCheckLoadBalancer(t *testing.T) {
+	t.Run("noPods", func(t *testing.T) {
+		g := NewWithT(t)
+
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: fake.NewSimpleClientset()},
+			},
+		}
+
+		g.Expect(metallb.CheckLoadBalancer(context.Background(), snapM)).ToNot(Succeed())
+	})
+
+	t.Run("pendingService", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(append(readyMetalLBPods(), &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		})...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := metallb.CheckLoadBalancer(context.Background(), snapM)
+		g.Expect(err).To(MatchError(ContainSubstring("default/web")))
+	})
+
+	t.Run("ignoresUnmanagedServices", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(append(readyMetalLBPods(),
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-class", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: ptr.To("example.com/lb")},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "other-pool",
+					Namespace:   "default",
+					Annotations: map[string]string{"metallb.io/address-pool": "custom-pool"},
+				},
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+		)...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		g.Expect(metallb.CheckLoadBalancer(context.Background(), snapM)).To(Succeed())
+	})
+
+	t.Run("pendingManagedPoolService", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(append(readyMetalLBPods(), &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "default",
+				Annotations: map[string]string{"metallb.universe.tf/address-pool": "metallb-loadbalancer-ck-loadbalancer"},
+			},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		})...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		err := metallb.CheckLoadBalancer(context.Background(), snapM)
+		g.Expect(err).To(MatchError(ContainSubstring("default/web")))
+	})
+
+	t.Run("healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clientset := fake.NewSimpleClientset(append(readyMetalLBPods(), &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.10"}}},
+			},
+		})...)
+		snapM := &snapmock.Snap{
+			Mock: snapmock.Mock{
+				KubernetesClient: &kubernetes.Client{Interface: clientset},
+			},
+		}
+
+		g.Expect(metallb.CheckLoadBalancer(context.Background(), snapM)).To(Succeed())
+	})
+}
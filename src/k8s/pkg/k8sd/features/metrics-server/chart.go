@@ -7,8 +7,8 @@ import (
 )
 
 var (
-	// chart represents manifests to deploy metrics-server.
-	chart = helm.InstallableChart{
+	// Chart represents manifests to deploy metrics-server.
+	Chart = helm.InstallableChart{
 		Name:         "metrics-server",
 		Namespace:    "kube-system",
 		ManifestPath: filepath.Join("charts", "metrics-server-3.12.2.tgz"),
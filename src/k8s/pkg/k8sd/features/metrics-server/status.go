@@ -0,0 +1,25 @@
+package metrics_server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/k8s/pkg/snap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CheckMetricsServer checks that the metrics-server pods are ready.
+func CheckMetricsServer(ctx context.Context, snap snap.Snap) error {
+	client, err := snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	if err := client.CheckForReadyPods(ctx, Chart.Namespace, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "metrics-server"}}),
+	}); err != nil {
+		return fmt.Errorf("metrics-server pods not yet ready: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package features
 import (
 	"context"
 
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/snap"
 )
 
@@ -12,12 +13,27 @@ type StatusInterface interface {
 	CheckDNS(context.Context, snap.Snap) error
 	// CheckNetwork checks the status of the Network feature.
 	CheckNetwork(context.Context, snap.Snap) error
+	// CheckGateway checks the status of the Gateway feature.
+	CheckGateway(context.Context, snap.Snap) error
+	// CheckIngress checks the status of the Ingress feature.
+	CheckIngress(context.Context, snap.Snap) error
+	// CheckLoadBalancer checks the status of the LoadBalancer feature.
+	CheckLoadBalancer(context.Context, snap.Snap) error
+	// CheckLocalStorage checks the status of the Local Storage feature.
+	CheckLocalStorage(context.Context, snap.Snap) error
+	// CheckMetricsServer checks the status of the Metrics Server feature.
+	CheckMetricsServer(context.Context, snap.Snap) error
 }
 
 // statusChecks implements the StatusInterface.
 type statusChecks struct {
-	checkDNS     func(context.Context, snap.Snap) error
-	checkNetwork func(context.Context, snap.Snap) error
+	checkDNS           func(context.Context, snap.Snap) error
+	checkNetwork       func(context.Context, snap.Snap) error
+	checkGateway       func(context.Context, snap.Snap) error
+	checkIngress       func(context.Context, snap.Snap) error
+	checkLoadBalancer  func(context.Context, snap.Snap) error
+	checkLocalStorage  func(context.Context, snap.Snap) error
+	checkMetricsServer func(context.Context, snap.Snap) error
 }
 
 func (s *statusChecks) CheckDNS(ctx context.Context, snap snap.Snap) error {
@@ -27,3 +43,36 @@ func (s *statusChecks) CheckDNS(ctx context.Context, snap snap.Snap) error {
 func (s *statusChecks) CheckNetwork(ctx context.Context, snap snap.Snap) error {
 	return s.checkNetwork(ctx, snap)
 }
+
+func (s *statusChecks) CheckGateway(ctx context.Context, snap snap.Snap) error {
+	return s.checkGateway(ctx, snap)
+}
+
+func (s *statusChecks) CheckIngress(ctx context.Context, snap snap.Snap) error {
+	return s.checkIngress(ctx, snap)
+}
+
+func (s *statusChecks) CheckLoadBalancer(ctx context.Context, snap snap.Snap) error {
+	return s.checkLoadBalancer(ctx, snap)
+}
+
+func (s *statusChecks) CheckLocalStorage(ctx context.Context, snap snap.Snap) error {
+	return s.checkLocalStorage(ctx, snap)
+}
+
+func (s *statusChecks) CheckMetricsServer(ctx context.Context, snap snap.Snap) error {
+	return s.checkMetricsServer(ctx, snap)
+}
+
+// HealthChecks returns the health check of each built-in feature, keyed by feature name.
+func HealthChecks(s StatusInterface) map[types.FeatureName]func(context.Context, snap.Snap) error {
+	return map[types.FeatureName]func(context.Context, snap.Snap) error{
+		DNS:           s.CheckDNS,
+		Network:       s.CheckNetwork,
+		Gateway:       s.CheckGateway,
+		Ingress:       s.CheckIngress,
+		LoadBalancer:  s.CheckLoadBalancer,
+		LocalStorage:  s.CheckLocalStorage,
+		MetricsServer: s.CheckMetricsServer,
+	}
+}
@@ -0,0 +1,94 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// redactedValue replaces certificates and private keys in the inspection reports.
+const redactedValue = "<redacted>"
+
+// defaultNamespaces are the namespaces that are inspected, unless all namespaces are requested.
+// Other namespaces are skipped by default, as they may contain private user data.
+var defaultNamespaces = []string{"default", "kube-system"}
+
+// SanitizeClusterConfig returns a copy of the cluster configuration with all certificates and private keys redacted.
+func SanitizeClusterConfig(config types.ClusterConfig) types.ClusterConfig {
+	c := &config.Certificates
+	d := &config.Datastore
+	for _, v := range []**string{
+		&c.CACert, &c.CAKey, &c.ClientCACert, &c.ClientCAKey, &c.FrontProxyCACert, &c.FrontProxyCAKey,
+		&c.ServiceAccountKey, &c.APIServerKubeletClientCert, &c.APIServerKubeletClientKey,
+		&c.AdminClientCert, &c.AdminClientKey, &c.K8sdPublicKey, &c.K8sdPrivateKey,
+		&d.K8sDqliteCert, &d.K8sDqliteKey, &d.ExternalCACert, &d.ExternalClientCert, &d.ExternalClientKey,
+	} {
+		if *v != nil {
+			redacted := redactedValue
+			*v = &redacted
+		}
+	}
+	if c.SecretsEncryptionKeys != nil {
+		keys := make([]types.SecretsEncryptionKey, 0, len(*c.SecretsEncryptionKeys))
+		for _, key := range *c.SecretsEncryptionKeys {
+			key.Secret = redactedValue
+			keys = append(keys, key)
+		}
+		c.SecretsEncryptionKeys = &keys
+	}
+	return config
+}
+
+// CollectKubernetes collects the Kubernetes objects and events of the cluster.
+// Secrets and ConfigMaps are not collected, as they may contain credentials.
+//
+// The report contains:
+// - kubernetes/nodes.yaml and kubernetes/namespaces.yaml
+// - kubernetes/<namespace>/<resource>.yaml: the pods, services, deployments, daemonsets, statefulsets and events
+// of the namespace.
+func CollectKubernetes(ctx context.Context, client *kubernetes.Client, opts types.InspectOptions) types.InspectionReport {
+	var report types.InspectionReport
+
+	if nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err != nil {
+		report.AddError("failed to list nodes: %v", err)
+	} else {
+		report.AddYAML("kubernetes/nodes.yaml", nodes)
+	}
+
+	namespaces := defaultNamespaces
+	if list, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err != nil {
+		report.AddError("failed to list namespaces: %v", err)
+	} else {
+		report.AddYAML("kubernetes/namespaces.yaml", list)
+		if opts.AllNamespaces {
+			namespaces = make([]string, 0, len(list.Items))
+			for _, namespace := range list.Items {
+				namespaces = append(namespaces, namespace.Name)
+			}
+		}
+	}
+
+	for _, namespace := range namespaces {
+		for resource, list := range map[string]func() (any, error){
+			"pods":         func() (any, error) { return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{}) },
+			"services":     func() (any, error) { return client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{}) },
+			"events":       func() (any, error) { return client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{}) },
+			"deployments":  func() (any, error) { return client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{}) },
+			"daemonsets":   func() (any, error) { return client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{}) },
+			"statefulsets": func() (any, error) { return client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{}) },
+		} {
+			obj, err := list()
+			if err != nil {
+				report.AddError("failed to list %s in namespace %s: %v", resource, namespace, err)
+				continue
+			}
+			report.AddYAML(filepath.Join("kubernetes", namespace, fmt.Sprintf("%s.yaml", resource)), obj)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,77 @@
+package inspect_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/k8sd/inspect"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSanitizeClusterConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	keys := []types.SecretsEncryptionKey{{Name: "key1", Provider: "aescbc", Secret: "c2VjcmV0"}}
+	config := types.ClusterConfig{
+		Certificates: types.Certificates{
+			CACert:                utils.Pointer("CA CERT"),
+			CAKey:                 utils.Pointer("CA KEY"),
+			K8sdPrivateKey:        utils.Pointer("K8SD KEY"),
+			SecretsEncryptionKeys: &keys,
+		},
+		Datastore: types.Datastore{
+			Type:              utils.Pointer("external"),
+			ExternalServers:   utils.Pointer([]string{"https://10.0.0.1:2379"}),
+			ExternalClientKey: utils.Pointer("CLIENT KEY"),
+		},
+	}
+
+	sanitized := inspect.SanitizeClusterConfig(config)
+	g.Expect(sanitized.Certificates.GetCACert()).To(Equal("<redacted>"))
+	g.Expect(sanitized.Certificates.GetCAKey()).To(Equal("<redacted>"))
+	g.Expect(sanitized.Certificates.GetK8sdPrivateKey()).To(Equal("<redacted>"))
+	g.Expect(sanitized.Certificates.ClientCACert).To(BeNil())
+	g.Expect(sanitized.Certificates.GetSecretsEncryptionKeys()).To(Equal([]types.SecretsEncryptionKey{{Name: "key1", Provider: "aescbc", Secret: "<redacted>"}}))
+	g.Expect(sanitized.Datastore.GetExternalClientKey()).To(Equal("<redacted>"))
+	g.Expect(sanitized.Datastore.GetExternalServers()).To(Equal([]string{"https://10.0.0.1:2379"}))
+
+	// the original config is left unchanged
+	g.Expect(config.Certificates.GetCACert()).To(Equal("CA CERT"))
+	g.Expect(keys[0].Secret).To(Equal("c2VjcmV0"))
+}
+
+func TestCollectKubernetes(t *testing.T) {
+	client := &kubernetes.Client{Interface: fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "private"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "private"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+	)}
+
+	t.Run("DefaultNamespaces", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := inspect.CollectKubernetes(context.Background(), client, types.InspectOptions{})
+		g.Expect(report.Errors).To(BeEmpty())
+		g.Expect(string(report.Files["kubernetes/nodes.yaml"])).To(ContainSubstring("name: node1"))
+		g.Expect(string(report.Files["kubernetes/kube-system/pods.yaml"])).To(ContainSubstring("name: coredns"))
+		g.Expect(report.Files).To(HaveKey("kubernetes/default/events.yaml"))
+		g.Expect(report.Files).ToNot(HaveKey("kubernetes/private/pods.yaml"))
+	})
+
+	t.Run("AllNamespaces", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := inspect.CollectKubernetes(context.Background(), client, types.InspectOptions{AllNamespaces: true})
+		g.Expect(report.Errors).To(BeEmpty())
+		g.Expect(string(report.Files["kubernetes/private/pods.yaml"])).To(ContainSubstring("name: app"))
+	})
+}
@@ -0,0 +1,180 @@
+// Package inspect collects inspection reports for troubleshooting clusters.
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+var (
+	// services are the k8s services that are inspected on each node.
+	services = []string{
+		"containerd",
+		"k8s-apiserver-proxy",
+		"k8s-dqlite",
+		"k8sd",
+		"kube-apiserver",
+		"kube-controller-manager",
+		"kube-proxy",
+		"kube-scheduler",
+		"kubelet",
+	}
+
+	// systemCommands are the commands that collect system and network diagnostics, by file name.
+	systemCommands = []struct {
+		name    string
+		command []string
+	}{
+		{name: "uname", command: []string{"uname", "-a"}},
+		{name: "uptime", command: []string{"uptime"}},
+		{name: "ps", command: []string{"ps", "-ef"}},
+		{name: "disk-usage", command: []string{"df", "-h"}},
+		{name: "memory-usage", command: []string{"free", "-m"}},
+		{name: "swap", command: []string{"swapon"}},
+		{name: "loaded-kernel-modules", command: []string{"lsmod"}},
+		{name: "dmesg", command: []string{"dmesg", "-T"}},
+		{name: "ip-a", command: []string{"ip", "a"}},
+		{name: "ip-r", command: []string{"ip", "r"}},
+		{name: "iptables", command: []string{"iptables-save"}},
+		{name: "ip6tables", command: []string{"ip6tables-save"}},
+		{name: "ss-plntu", command: []string{"ss", "-plntu"}},
+	}
+
+	// systemFiles are the files that are copied from the host, by file name.
+	systemFiles = map[string]string{
+		"os-release":  "/etc/os-release",
+		"proc-mounts": "/proc/mounts",
+	}
+
+	// runCommand runs the system diagnostic commands.
+	runCommand = utils.RunCommand
+)
+
+// CollectNode collects the information of the local node. Failures to collect parts of the report are recorded in
+// the report, so that as much information as possible is collected from broken nodes.
+//
+// The report contains:
+// - services.yaml: the state of the k8s services.
+// - services/<service>.log: the latest log entries of the k8s services.
+// - args/: the arguments of the k8s services.
+// - k8sd/ and k8s-dqlite/: the dqlite cluster members, as known by the local node.
+// - system/: system and network diagnostics.
+// - core-dumps/: the core dumps, if opts.CoreDumpDir is set.
+func CollectNode(ctx context.Context, snap snap.Snap, opts types.InspectOptions) types.InspectionReport {
+	var report types.InspectionReport
+
+	states := make(map[string]types.ServiceState, len(services))
+	for _, service := range services {
+		switch active, err := snap.ServiceActive(ctx, service); {
+		case err != nil:
+			states[service] = types.ServiceStateUnknown
+		case active:
+			states[service] = types.ServiceStateActive
+		default:
+			states[service] = types.ServiceStateInactive
+		}
+
+		logs, err := snap.ServiceLogs(ctx, service, opts.NumLogEntries)
+		if err != nil {
+			report.AddError("failed to get logs of service %s: %v", service, err)
+			continue
+		}
+		report.AddFile(fmt.Sprintf("services/%s.log", service), logs)
+	}
+	report.AddYAML("services.yaml", states)
+
+	addDir(&report, "args", snap.ServiceArgumentsDir(), true)
+
+	for dir, stateDir := range map[string]string{
+		"k8sd":       filepath.Join(snap.K8sdStateDir(), "database"),
+		"k8s-dqlite": snap.K8sDqliteStateDir(),
+	} {
+		for _, file := range []string{"cluster.yaml", "info.yaml"} {
+			addFile(&report, filepath.Join(dir, file), filepath.Join(stateDir, file))
+		}
+	}
+
+	for _, c := range systemCommands {
+		collectCommand(ctx, &report, filepath.Join("system", c.name), c.command, opts)
+	}
+	for name, hostPath := range systemFiles {
+		addFile(&report, filepath.Join("system", name), hostPath)
+	}
+
+	if opts.CoreDumpDir != "" {
+		addDir(&report, "core-dumps", opts.CoreDumpDir, false)
+	}
+
+	return report
+}
+
+// collectCommand adds the combined output of a command to the report. The output is added even if the command fails.
+func collectCommand(ctx context.Context, report *types.InspectionReport, reportPath string, command []string, opts types.InspectOptions) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var b bytes.Buffer
+	if err := runCommand(ctx, command, func(c *exec.Cmd) {
+		c.Stdout = &b
+		c.Stderr = &b
+	}); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			report.AddError("command %q timed out after %v", strings.Join(command, " "), opts.Timeout)
+		} else {
+			report.AddError("command %q failed: %v", strings.Join(command, " "), err)
+		}
+	}
+	report.AddFile(reportPath, b.Bytes())
+}
+
+// addFile adds a file of the host to the report. Missing files are ignored.
+func addFile(report *types.InspectionReport, reportPath string, hostPath string) {
+	b, err := os.ReadFile(hostPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			report.AddError("failed to read %s: %v", hostPath, err)
+		}
+		return
+	}
+	report.AddFile(reportPath, b)
+}
+
+// addDir adds the files of a directory of the host to the report. Missing directories are ignored.
+func addDir(report *types.InspectionReport, reportDir string, hostDir string, recursive bool) {
+	if err := filepath.WalkDir(hostDir, func(hostPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if hostPath != hostDir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(hostDir, hostPath)
+		if err != nil {
+			return err
+		}
+		addFile(report, filepath.Join(reportDir, rel), hostPath)
+		return nil
+	}); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		report.AddError("failed to read %s: %v", hostDir, err)
+	}
+}
@@ -0,0 +1,72 @@
+package inspect
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	. "github.com/onsi/gomega"
+)
+
+func TestCollectNode(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	argsDir := filepath.Join(dir, "args")
+	coreDumpDir := filepath.Join(dir, "crash")
+	for file, content := range map[string]string{
+		filepath.Join(argsDir, "kubelet"):                   "--node-ip=10.0.0.1\n",
+		filepath.Join(argsDir, "conf.d", "extra"):           "extra\n",
+		filepath.Join(dir, "k8sd", "database", "info.yaml"): "name: node1\n",
+		filepath.Join(coreDumpDir, "kubelet.crash"):         "core",
+		filepath.Join(coreDumpDir, "nested", "skipped"):     "skipped",
+	} {
+		g.Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+		g.Expect(os.WriteFile(file, []byte(content), 0o600)).To(Succeed())
+	}
+
+	originalRunCommand := runCommand
+	t.Cleanup(func() { runCommand = originalRunCommand })
+	runCommand = func(ctx context.Context, command []string, opts ...func(c *exec.Cmd)) error {
+		c := &exec.Cmd{}
+		for _, o := range opts {
+			o(c)
+		}
+		_, _ = c.Stdout.Write([]byte("output of " + strings.Join(command, " ")))
+		if command[0] == "iptables-save" {
+			return errors.New("command failed")
+		}
+		return nil
+	}
+
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			ServiceArgumentsDir: argsDir,
+			K8sdStateDir:        filepath.Join(dir, "k8sd"),
+			K8sDqliteStateDir:   filepath.Join(dir, "k8s-dqlite"),
+			ServiceActive:       map[string]bool{"kubelet": true},
+			ServiceLogs:         map[string][]byte{"kubelet": []byte("kubelet logs")},
+		},
+	}
+
+	report := CollectNode(context.Background(), s, types.InspectOptions{CoreDumpDir: coreDumpDir})
+
+	g.Expect(report.Files).To(HaveKeyWithValue("services/kubelet.log", []byte("kubelet logs")))
+	g.Expect(string(report.Files["services.yaml"])).To(ContainSubstring("kubelet: active"))
+	g.Expect(string(report.Files["services.yaml"])).To(ContainSubstring("kube-proxy: inactive"))
+	g.Expect(report.Files).To(HaveKeyWithValue("args/kubelet", []byte("--node-ip=10.0.0.1\n")))
+	g.Expect(report.Files).To(HaveKeyWithValue("args/conf.d/extra", []byte("extra\n")))
+	g.Expect(report.Files).To(HaveKeyWithValue("k8sd/info.yaml", []byte("name: node1\n")))
+	g.Expect(report.Files).ToNot(HaveKey("k8sd/cluster.yaml"))
+	g.Expect(report.Files).To(HaveKeyWithValue("system/uname", []byte("output of uname -a")))
+	g.Expect(report.Files).To(HaveKeyWithValue("system/iptables", []byte("output of iptables-save")))
+	g.Expect(report.Files).To(HaveKeyWithValue("core-dumps/kubelet.crash", []byte("core")))
+	g.Expect(report.Files).ToNot(HaveKey("core-dumps/nested/skipped"))
+	g.Expect(report.Errors).To(ConsistOf(`command "iptables-save" failed: command failed`))
+}
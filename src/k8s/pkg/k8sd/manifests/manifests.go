@@ -0,0 +1,116 @@
+// Package manifests contains the Helm charts of the Canonical Kubernetes built-in features.
+// The charts are embedded into the k8sd binary, so that features can be deployed without the snap layout.
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+//go:generate sh -c "find charts -type f | LC_ALL=C sort | xargs sha256sum > SHA256SUMS"
+
+// embedded contains the Helm charts, under the "charts" directory.
+// "all:" is required to include files like "templates/_helpers.tpl" and ".helmignore".
+//
+//go:embed all:charts
+var embedded embed.FS
+
+// checksums contains the SHA-256 checksums of all embedded files, as generated by "go generate".
+//
+//go:embed SHA256SUMS
+var checksums []byte
+
+// FS returns a filesystem with the Helm charts of the built-in features, under the "charts" directory.
+// If overrideDir is not empty, charts that exist in overrideDir take precedence over the embedded charts.
+// This can be used to hotfix a chart without rebuilding k8sd, e.g. by placing "charts/<chart>.tgz" in overrideDir.
+func FS(overrideDir string) fs.FS {
+	if overrideDir == "" {
+		return embedded
+	}
+	return &overlayFS{override: os.DirFS(overrideDir), base: embedded}
+}
+
+// overlayFS serves files from override if they exist, and from base otherwise.
+type overlayFS struct {
+	override fs.FS
+	base     fs.FS
+}
+
+// Open implements fs.FS.
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.override.Open(name)
+	if err == nil {
+		return f, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return o.base.Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS, so that a chart directory is read consistently from a single filesystem.
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(o.override, name)
+	if err == nil {
+		return entries, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return fs.ReadDir(o.base, name)
+}
+
+// Verify checks that the embedded charts match the checksums that were generated at build time.
+// Verify returns an error if any file was added, removed or changed without regenerating the checksums.
+func Verify() error {
+	expected := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		sum, name, ok := strings.Cut(line, "  ")
+		if !ok {
+			return fmt.Errorf("invalid checksum line %q", line)
+		}
+		expected[name] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read checksums: %w", err)
+	}
+
+	if err := fs.WalkDir(embedded, "charts", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := fs.ReadFile(embedded, name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		sum := sha256.Sum256(b)
+		switch want, ok := expected[name]; {
+		case !ok:
+			return fmt.Errorf("%s has no checksum", name)
+		case want != hex.EncodeToString(sum[:]):
+			return fmt.Errorf("%s does not match its checksum", name)
+		}
+		delete(expected, name)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(expected) > 0 {
+		return fmt.Errorf("%s have checksums but are not embedded", strings.Join(slices.Sorted(maps.Keys(expected)), ", "))
+	}
+	return nil
+}
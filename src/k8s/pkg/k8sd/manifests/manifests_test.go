@@ -0,0 +1,57 @@
+package manifests_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/manifests"
+	. "github.com/onsi/gomega"
+)
+
+func TestVerify(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(manifests.Verify()).To(Succeed(), "checksums are outdated, run \"go generate ./pkg/k8sd/manifests\"")
+}
+
+func TestFS(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b, err := fs.ReadFile(manifests.FS(""), "charts/ck-loadbalancer/Chart.yaml")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(string(b)).To(ContainSubstring("name: ck-loadbalancer"))
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.MkdirAll(filepath.Join(dir, "charts", "ck-loadbalancer"), 0o755)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(dir, "charts", "ck-loadbalancer", "Chart.yaml"), []byte("name: hotfix\n"), 0o644)).To(Succeed())
+
+		fsys := manifests.FS(dir)
+
+		b, err := fs.ReadFile(fsys, "charts/ck-loadbalancer/Chart.yaml")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(string(b)).To(Equal("name: hotfix\n"))
+
+		// the overridden chart directory is read from the override directory only
+		entries, err := fs.ReadDir(fsys, "charts/ck-loadbalancer")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(entries).To(HaveLen(1))
+
+		// other charts are still read from the embedded charts
+		b, err = fs.ReadFile(fsys, "charts/ck-gateway-cilium/Chart.yaml")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(string(b)).To(ContainSubstring("name: ck-gateway-cilium"))
+	})
+
+	t.Run("MissingOverrideDirectory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := fs.Stat(manifests.FS(filepath.Join(t.TempDir(), "missing")), "charts/ck-loadbalancer")
+		g.Expect(err).To(Not(HaveOccurred()))
+	})
+}
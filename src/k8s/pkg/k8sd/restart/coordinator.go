@@ -0,0 +1,169 @@
+// Package restart coordinates restarts of the quorum-sensitive services across the control plane nodes.
+package restart
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/canonical/k8s/pkg/log"
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+)
+
+// QuorumServices are the services that serve the datastores and the Kubernetes API. Restarting them on multiple
+// control plane nodes at the same time can lose the datastore quorum or make the Kubernetes API unavailable.
+var QuorumServices = []string{"k8s-dqlite", "k8sd", "kube-apiserver"}
+
+// Lease is a cluster-wide lease that is held by at most one node at a time.
+type Lease interface {
+	// Acquire attempts to take the lease for holder, or renews it if already held by holder.
+	// Acquire returns false if the lease is held by a different holder and has not expired yet.
+	Acquire(ctx context.Context, holder string, duration time.Duration) (bool, error)
+	// Release releases the lease, if it is held by holder.
+	Release(ctx context.Context, holder string) error
+}
+
+// Options are the options of the restart Coordinator.
+type Options struct {
+	// Snap is used to restart the services.
+	Snap snap.Snap
+	// GetNodeName returns the name of the local node, which is used as the holder of the lease.
+	GetNodeName func(ctx context.Context) (string, error)
+	// LeaseDuration is how long the lease is held if it is not released, e.g. because k8sd restarted itself
+	// or the services did not become healthy. Defaults to 5 minutes.
+	LeaseDuration time.Duration
+	// RetryInterval is the interval to retry acquiring the lease while it is held by another node. Defaults to 5 seconds.
+	RetryInterval time.Duration
+	// HealthTimeout is the max time to wait for the services to become healthy after a restart. Defaults to 3 minutes.
+	HealthTimeout time.Duration
+}
+
+// Coordinator is a snap.Snap that serializes restarts of the QuorumServices across the control plane nodes.
+// Restarts of other services, and restarts on worker nodes, are not coordinated.
+type Coordinator struct {
+	snap.Snap
+
+	getNodeName   func(ctx context.Context) (string, error)
+	leaseDuration time.Duration
+	retryInterval time.Duration
+	healthTimeout time.Duration
+
+	mu    sync.RWMutex
+	lease Lease
+}
+
+// New creates a new restart coordinator.
+func New(opts Options) *Coordinator {
+	if opts.LeaseDuration == 0 {
+		opts.LeaseDuration = 5 * time.Minute
+	}
+	if opts.RetryInterval == 0 {
+		opts.RetryInterval = 5 * time.Second
+	}
+	if opts.HealthTimeout == 0 {
+		opts.HealthTimeout = 3 * time.Minute
+	}
+	return &Coordinator{
+		Snap:          opts.Snap,
+		getNodeName:   opts.GetNodeName,
+		leaseDuration: opts.LeaseDuration,
+		retryInterval: opts.RetryInterval,
+		healthTimeout: opts.HealthTimeout,
+	}
+}
+
+// Start enables the coordination of restarts with the specified lease. Restarts before Start are not coordinated.
+// Start releases the lease if it is still held by the local node, since k8sd cannot release the lease after it restarts itself.
+func (c *Coordinator) Start(ctx context.Context, lease Lease) error {
+	holder, err := c.getNodeName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get node name: %w", err)
+	}
+	if err := lease.Release(ctx, holder); err != nil {
+		return fmt.Errorf("failed to release restart lease: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lease = lease
+	return nil
+}
+
+// RestartServices restarts the services on the local node.
+// If any of the services is one of the QuorumServices, RestartServices waits until the local node holds the restart lease,
+// restarts the services and waits until they are healthy before releasing the lease to the next node.
+// If the services do not become healthy, the lease is not released, so that other nodes do not restart their services until it expires.
+func (c *Coordinator) RestartServices(ctx context.Context, services []string, extraSnapArgs ...string) error {
+	c.mu.RLock()
+	lease := c.lease
+	c.mu.RUnlock()
+
+	if lease == nil || !slices.ContainsFunc(services, func(service string) bool { return slices.Contains(QuorumServices, service) }) {
+		return c.Snap.RestartServices(ctx, services, extraSnapArgs...)
+	}
+	if isWorker, err := snaputil.IsWorker(c.Snap); err != nil {
+		return fmt.Errorf("failed to check if running on a worker node: %w", err)
+	} else if isWorker {
+		return c.Snap.RestartServices(ctx, services, extraSnapArgs...)
+	}
+
+	holder, err := c.getNodeName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get node name: %w", err)
+	}
+
+	log := log.FromContext(ctx).WithValues("services", services)
+	for {
+		acquired, err := lease.Acquire(ctx, holder, c.leaseDuration)
+		if err != nil {
+			log.Error(err, "Failed to acquire restart lease")
+		} else if acquired {
+			break
+		} else {
+			log.V(1).Info("Waiting for another node to finish restarting its services")
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to acquire restart lease: %w", ctx.Err())
+		case <-time.After(c.retryInterval):
+		}
+	}
+
+	if err := c.Snap.RestartServices(ctx, services, extraSnapArgs...); err != nil {
+		return err
+	}
+
+	if err := c.waitHealthy(ctx, services); err != nil {
+		return fmt.Errorf("services did not become healthy after restart: %w", err)
+	}
+
+	if err := lease.Release(ctx, holder); err != nil {
+		return fmt.Errorf("failed to release restart lease: %w", err)
+	}
+	return nil
+}
+
+// waitHealthy waits until the restarted services are healthy.
+func (c *Coordinator) waitHealthy(ctx context.Context, services []string) error {
+	// kube-apiserver serves the kubernetes endpoint from k8s-dqlite, so the endpoint is available once both are healthy.
+	// there is no need to wait for k8sd, as the restart of k8sd terminates this process.
+	if !slices.Contains(services, "kube-apiserver") && !slices.Contains(services, "k8s-dqlite") {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.healthTimeout)
+	defer cancel()
+
+	client, err := c.Snap.KubernetesClient("")
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	if err := client.WaitKubernetesEndpointAvailable(ctx); err != nil {
+		return fmt.Errorf("kubernetes endpoint did not become available: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package restart_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/client/kubernetes"
+	"github.com/canonical/k8s/pkg/k8sd/restart"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeLease is an in-memory restart.Lease.
+type fakeLease struct {
+	mu       sync.Mutex
+	holder   string
+	acquired []string
+}
+
+func (l *fakeLease) Acquire(_ context.Context, holder string, _ time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder != "" && l.holder != holder {
+		return false, nil
+	}
+	l.holder = holder
+	l.acquired = append(l.acquired, holder)
+	return true, nil
+}
+
+func (l *fakeLease) Release(_ context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == holder {
+		l.holder = ""
+	}
+	return nil
+}
+
+func (l *fakeLease) get() (string, []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holder, l.acquired
+}
+
+func newCoordinator(t *testing.T, healthy bool) (*restart.Coordinator, *mock.Snap) {
+	dir := t.TempDir()
+	clientset := fake.NewSimpleClientset()
+	if healthy {
+		clientset = fake.NewSimpleClientset(&corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"}})
+	}
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			LockFilesDir:     filepath.Join(dir, "lock"),
+			KubernetesClient: &kubernetes.Client{Interface: clientset},
+		},
+	}
+	NewWithT(t).Expect(os.MkdirAll(s.Mock.LockFilesDir, 0o700)).To(Succeed())
+
+	return restart.New(restart.Options{
+		Snap:          s,
+		GetNodeName:   func(ctx context.Context) (string, error) { return "node1", nil },
+		RetryInterval: 10 * time.Millisecond,
+		HealthTimeout: 2 * time.Second,
+	}), s
+}
+
+func TestCoordinator(t *testing.T) {
+	t.Run("NotStarted", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, true)
+
+		g.Expect(c.RestartServices(context.Background(), []string{"kube-apiserver"})).To(Succeed())
+		g.Expect(s.RestartServicesCalledWith).To(Equal([][]string{{"kube-apiserver"}}))
+	})
+
+	t.Run("Start", func(t *testing.T) {
+		g := NewWithT(t)
+		c, _ := newCoordinator(t, true)
+
+		// the lease is still held by the local node after k8sd restarted itself
+		lease := &fakeLease{holder: "node1"}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		holder, _ := lease.get()
+		g.Expect(holder).To(BeEmpty())
+	})
+
+	t.Run("NotQuorumServices", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, true)
+		lease := &fakeLease{}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		g.Expect(c.RestartServices(context.Background(), []string{"kubelet", "kube-proxy"})).To(Succeed())
+		g.Expect(s.RestartServicesCalledWith).To(Equal([][]string{{"kubelet", "kube-proxy"}}))
+
+		_, acquired := lease.get()
+		g.Expect(acquired).To(BeEmpty())
+	})
+
+	t.Run("Worker", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, true)
+		g.Expect(snaputil.MarkAsWorkerNode(s, true)).To(Succeed())
+		lease := &fakeLease{holder: "node2"}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		g.Expect(c.RestartServices(context.Background(), []string{"k8sd"})).To(Succeed())
+		g.Expect(s.RestartServicesCalledWith).To(Equal([][]string{{"k8sd"}}))
+	})
+
+	t.Run("WaitForLease", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, true)
+		lease := &fakeLease{holder: "node2"}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		doneCh := make(chan error, 1)
+		go func() {
+			doneCh <- c.RestartServices(context.Background(), []string{"kube-apiserver"})
+		}()
+
+		g.Consistently(doneCh, 100*time.Millisecond).ShouldNot(Receive())
+		g.Expect(lease.Release(context.Background(), "node2")).To(Succeed())
+
+		g.Eventually(doneCh, 5*time.Second).Should(Receive(BeNil()))
+		g.Expect(s.RestartServicesCalledWith).To(Equal([][]string{{"kube-apiserver"}}))
+
+		// the lease is released once the services are healthy
+		holder, acquired := lease.get()
+		g.Expect(holder).To(BeEmpty())
+		g.Expect(acquired).To(Equal([]string{"node1"}))
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, false)
+		lease := &fakeLease{}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		g.Expect(c.RestartServices(context.Background(), []string{"k8s-dqlite"})).ToNot(Succeed())
+		g.Expect(s.RestartServicesCalledWith).To(Equal([][]string{{"k8s-dqlite"}}))
+
+		// the lease is kept until it expires, so that other nodes do not restart their services
+		holder, _ := lease.get()
+		g.Expect(holder).To(Equal("node1"))
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		g := NewWithT(t)
+		c, s := newCoordinator(t, true)
+		lease := &fakeLease{holder: "node2"}
+		g.Expect(c.Start(context.Background(), lease)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		g.Expect(c.RestartServices(ctx, []string{"kube-apiserver"})).ToNot(Succeed())
+		g.Expect(s.RestartServicesCalledWith).To(BeEmpty())
+	})
+}
@@ -0,0 +1,45 @@
+package restart
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/database"
+	"github.com/canonical/microcluster/v2/state"
+)
+
+// databaseLeaseName is the name of the restart lease of the QuorumServices in the k8sd database.
+const databaseLeaseName = "quorum-services"
+
+type databaseLease struct {
+	state state.State
+}
+
+// NewDatabaseLease returns a Lease that is stored in the k8sd database.
+// The k8sd database is used instead of a Kubernetes Lease, so that restarts can be coordinated while the Kubernetes API is unavailable.
+func NewDatabaseLease(s state.State) Lease {
+	return &databaseLease{state: s}
+}
+
+func (l *databaseLease) Acquire(ctx context.Context, holder string, duration time.Duration) (bool, error) {
+	var acquired bool
+	if err := l.state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		acquired, err = database.AcquireRestartLease(ctx, tx, databaseLeaseName, holder, time.Now().Add(duration))
+		return err
+	}); err != nil {
+		return false, fmt.Errorf("database transaction to acquire restart lease failed: %w", err)
+	}
+	return acquired, nil
+}
+
+func (l *databaseLease) Release(ctx context.Context, holder string) error {
+	if err := l.state.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.ReleaseRestartLease(ctx, tx, databaseLeaseName, holder)
+	}); err != nil {
+		return fmt.Errorf("database transaction to release restart lease failed: %w", err)
+	}
+	return nil
+}
@@ -307,3 +307,12 @@ func ReadControlPlanePKI(snap snap.Snap, certificates *pki.ControlPlanePKI, read
 
 	return nil
 }
+
+// EnsureOIDCPKI ensures the CA certificate of the OpenID Connect issuer is present
+// and has the correct content, permissions and ownership. The file is removed if caCert is empty.
+// It returns true if the file was updated and any error that occurred.
+func EnsureOIDCPKI(snap snap.Snap, caCert string) (bool, error) {
+	return ensureFiles(snap.UID(), snap.GID(), 0o600, map[string]string{
+		filepath.Join(snap.KubernetesPKIDir(), "oidc-ca.crt"): caCert,
+	})
+}
@@ -0,0 +1,75 @@
+package setup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+// KubeletServiceFile is the systemd unit file of the kubelet service. The file permissions are part of the CIS benchmark.
+var KubeletServiceFile = "/etc/systemd/system/snap.k8s.kubelet.service"
+
+// hardeningServiceArgs returns the arguments of each service for a hardening profile.
+// The admission plugins of the kube-apiserver are part of the cluster configuration, see types.APIServer.
+func hardeningServiceArgs(profile string) map[string]map[string]string {
+	switch profile {
+	case types.HardeningProfileCIS:
+		return map[string]map[string]string{
+			"kube-controller-manager": {
+				"--bind-address": "127.0.0.1",
+			},
+			"kube-scheduler": {
+				"--bind-address": "127.0.0.1",
+			},
+			"kubelet": {
+				"--protect-kernel-defaults":           "true",
+				"--streaming-connection-idle-timeout": "5m",
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// HardeningServiceArgs returns the extra arguments of a service with the arguments of the hardening profile.
+// The extra arguments of the node are applied on top of the hardening profile, so that they can still override it.
+func HardeningServiceArgs(profile string, service string, extraArgs map[string]*string) map[string]*string {
+	args := hardeningServiceArgs(profile)[service]
+	if len(args) == 0 {
+		return extraArgs
+	}
+
+	result := make(map[string]*string, len(args)+len(extraArgs))
+	for key, value := range args {
+		result[key] = utils.Pointer(value)
+	}
+	for key, value := range extraArgs {
+		result[key] = value
+	}
+	return result
+}
+
+// Hardening applies the file permissions of a hardening profile on the local node.
+// The service arguments of the hardening profile are applied separately, see HardeningServiceArgs.
+func Hardening(snap snap.Snap, profile string, controlPlane bool) error {
+	if profile != types.HardeningProfileCIS {
+		return nil
+	}
+
+	if controlPlane {
+		if err := os.Chmod(snap.K8sDqliteStateDir(), 0o700); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to set permissions of the k8s-dqlite data directory: %w", err)
+		}
+	}
+
+	// NOTE: the kubelet service file is managed by snapd, so its permissions must be set again after snap refreshes.
+	if err := os.Chmod(KubeletServiceFile, 0o600); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to set permissions of the kubelet service file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package setup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestHardeningServiceArgs(t *testing.T) {
+	t.Run("NoProfile", func(t *testing.T) {
+		g := NewWithT(t)
+		extraArgs := map[string]*string{"--v": utils.Pointer("3")}
+		g.Expect(setup.HardeningServiceArgs("", "kube-apiserver", extraArgs)).To(Equal(extraArgs))
+	})
+
+	t.Run("CIS", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(setup.HardeningServiceArgs("cis", "kube-apiserver", nil)).To(BeEmpty())
+		g.Expect(setup.HardeningServiceArgs("cis", "kubelet", nil)).To(HaveKeyWithValue("--protect-kernel-defaults", utils.Pointer("true")))
+		g.Expect(setup.HardeningServiceArgs("cis", "kube-scheduler", nil)).To(HaveKeyWithValue("--bind-address", utils.Pointer("127.0.0.1")))
+	})
+
+	t.Run("ExtraArgsOverride", func(t *testing.T) {
+		g := NewWithT(t)
+		args := setup.HardeningServiceArgs("cis", "kube-controller-manager", map[string]*string{
+			"--bind-address": utils.Pointer("0.0.0.0"),
+			"--v":            nil,
+		})
+		g.Expect(args).To(HaveKeyWithValue("--bind-address", utils.Pointer("0.0.0.0")))
+		g.Expect(args).To(HaveKeyWithValue("--v", BeNil()))
+	})
+}
+
+func TestHardening(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	s := &mock.Snap{Mock: mock.Mock{
+		K8sDqliteStateDir: filepath.Join(dir, "k8s-dqlite"),
+	}}
+	g.Expect(os.MkdirAll(s.Mock.K8sDqliteStateDir, 0o755)).To(Succeed())
+
+	kubeletServiceFile := setup.KubeletServiceFile
+	setup.KubeletServiceFile = filepath.Join(dir, "snap.k8s.kubelet.service")
+	t.Cleanup(func() { setup.KubeletServiceFile = kubeletServiceFile })
+	g.Expect(os.WriteFile(setup.KubeletServiceFile, nil, 0o644)).To(Succeed())
+
+	g.Expect(setup.Hardening(s, "cis", true)).To(Succeed())
+
+	for path, mode := range map[string]os.FileMode{
+		s.Mock.K8sDqliteStateDir: 0o700,
+		setup.KubeletServiceFile: 0o600,
+	} {
+		info, err := os.Stat(path)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(info.Mode().Perm()).To(Equal(mode), path)
+	}
+}
@@ -19,10 +19,24 @@ type apiserverAuthTokenWebhookTemplateConfig struct {
 	CAPath string
 }
 
+type apiserverAdmissionControlConfigTemplateConfig struct {
+	PodSecurity              *apiserverPodSecurityTemplateConfig
+	EventRateLimitConfigPath string
+}
+
+type apiserverPodSecurityTemplateConfig struct {
+	Enforce          string
+	Audit            string
+	Warn             string
+	ExemptNamespaces []string
+}
+
 var SupportedDatastores = []string{"k8s-dqlite", "external"}
 
 var (
-	apiserverAuthTokenWebhookTemplate = mustTemplate("apiserver", "auth-token-webhook.conf")
+	apiserverAuthTokenWebhookTemplate       = mustTemplate("apiserver", "auth-token-webhook.conf")
+	apiserverAdmissionControlConfigTemplate = mustTemplate("apiserver", "admission-control-config.yaml")
+	apiserverEventRateLimitConfigTemplate   = mustTemplate("apiserver", "event-rate-limit-config.yaml")
 
 	apiserverTLSCipherSuites = []string{
 		"TLS_AES_128_GCM_SHA256",
@@ -50,7 +64,7 @@ var (
 )
 
 // KubeAPIServer configures kube-apiserver on the local node.
-func KubeAPIServer(snap snap.Snap, securePort int, nodeIP net.IP, serviceCIDR string, authWebhookURL string, enableFrontProxy bool, datastore types.Datastore, authorizationMode string, extraArgs map[string]*string) error {
+func KubeAPIServer(snap snap.Snap, nodeIP net.IP, serviceCIDR string, authWebhookURL string, enableFrontProxy bool, datastore types.Datastore, apiServer types.APIServer, extraArgs map[string]*string) error {
 	authTokenWebhookConfigFile := filepath.Join(snap.ServiceExtraConfigDir(), "auth-token-webhook.conf")
 	authTokenWebhookFile, err := os.OpenFile(authTokenWebhookConfigFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
@@ -69,16 +83,15 @@ func KubeAPIServer(snap snap.Snap, securePort int, nodeIP net.IP, serviceCIDR st
 		"--anonymous-auth":                           "false",
 		"--allow-privileged":                         "true",
 		"--authentication-token-webhook-config-file": authTokenWebhookConfigFile,
-		"--authorization-mode":                       authorizationMode,
+		"--authorization-mode":                       apiServer.GetAuthorizationMode(),
 		"--client-ca-file":                           filepath.Join(snap.KubernetesPKIDir(), "client-ca.crt"),
-		"--enable-admission-plugins":                 "NodeRestriction",
 		"--kubelet-certificate-authority":            filepath.Join(snap.KubernetesPKIDir(), "ca.crt"),
 		"--kubelet-client-certificate":               filepath.Join(snap.KubernetesPKIDir(), "apiserver-kubelet-client.crt"),
 		"--kubelet-client-key":                       filepath.Join(snap.KubernetesPKIDir(), "apiserver-kubelet-client.key"),
 		"--kubelet-preferred-address-types":          "InternalIP,Hostname,InternalDNS,ExternalDNS,ExternalIP",
 		"--profiling":                                "false",
 		"--request-timeout":                          "300s",
-		"--secure-port":                              strconv.Itoa(securePort),
+		"--secure-port":                              strconv.Itoa(apiServer.GetSecurePort()),
 		"--service-account-issuer":                   "https://kubernetes.default.svc",
 		"--service-account-key-file":                 filepath.Join(snap.KubernetesPKIDir(), "serviceaccount.key"),
 		"--service-account-signing-key-file":         filepath.Join(snap.KubernetesPKIDir(), "serviceaccount.key"),
@@ -104,6 +117,33 @@ func KubeAPIServer(snap snap.Snap, securePort int, nodeIP net.IP, serviceCIDR st
 		args[key] = val
 	}
 
+	if _, err := EnsureOIDCPKI(snap, apiServer.GetOIDCCACert()); err != nil {
+		return fmt.Errorf("failed to write OIDC CA certificate: %w", err)
+	}
+	oidcUpdateArgs, oidcDeleteArgs := apiServer.ToKubeAPIServerOIDCArguments(snap)
+	for key, val := range oidcUpdateArgs {
+		args[key] = val
+	}
+	deleteArgs = append(deleteArgs, oidcDeleteArgs...)
+
+	if _, err := EnsureKubeAPIServerAuditConfig(snap, apiServer.GetAuditPolicy(), apiServer.GetAuditWebhookConfig()); err != nil {
+		return fmt.Errorf("failed to write audit configuration: %w", err)
+	}
+	auditUpdateArgs, auditDeleteArgs := apiServer.ToKubeAPIServerAuditArguments(snap)
+	for key, val := range auditUpdateArgs {
+		args[key] = val
+	}
+	deleteArgs = append(deleteArgs, auditDeleteArgs...)
+
+	if _, err := EnsureKubeAPIServerAdmissionConfig(snap, apiServer); err != nil {
+		return fmt.Errorf("failed to write admission configuration: %w", err)
+	}
+	admissionUpdateArgs, admissionDeleteArgs := apiServer.ToKubeAPIServerAdmissionArguments(snap)
+	for key, val := range admissionUpdateArgs {
+		args[key] = val
+	}
+	deleteArgs = append(deleteArgs, admissionDeleteArgs...)
+
 	if enableFrontProxy {
 		args["--requestheader-client-ca-file"] = filepath.Join(snap.KubernetesPKIDir(), "front-proxy-ca.crt")
 		args["--requestheader-allowed-names"] = "front-proxy-client"
@@ -124,3 +164,57 @@ func KubeAPIServer(snap snap.Snap, securePort int, nodeIP net.IP, serviceCIDR st
 	}
 	return nil
 }
+
+// EnsureKubeAPIServerAuditConfig ensures the audit policy and audit webhook configuration files of the kube-apiserver
+// are present and have the correct content, permissions and ownership. Files with empty content are removed.
+// It returns true if one or more files were updated and any error that occurred.
+func EnsureKubeAPIServerAuditConfig(snap snap.Snap, policy string, webhookConfig string) (bool, error) {
+	return ensureFiles(snap.UID(), snap.GID(), 0o600, map[string]string{
+		filepath.Join(snap.ServiceExtraConfigDir(), "audit-policy.yaml"):  policy,
+		filepath.Join(snap.ServiceExtraConfigDir(), "audit-webhook.conf"): webhookConfig,
+	})
+}
+
+// EnsureKubeAPIServerAdmissionConfig ensures the admission control configuration files of the kube-apiserver are present
+// and have the correct content, permissions and ownership. The configuration includes the Pod Security Admission defaults
+// and the EventRateLimit configuration, if enabled. Files that are not needed are removed.
+// It returns true if one or more files were updated and any error that occurred.
+func EnsureKubeAPIServerAdmissionConfig(snap snap.Snap, apiServer types.APIServer) (bool, error) {
+	var (
+		admissionControlConfig string
+		eventRateLimitConfig   string
+		eventRateLimitPath     = filepath.Join(snap.ServiceExtraConfigDir(), "event-rate-limit-config.yaml")
+	)
+
+	if apiServer.AdmissionConfigEnabled() {
+		var config apiserverAdmissionControlConfigTemplateConfig
+		if apiServer.PodSecurityConfigured() {
+			config.PodSecurity = &apiserverPodSecurityTemplateConfig{
+				Enforce:          apiServer.GetPodSecurityEnforce(),
+				Audit:            apiServer.GetPodSecurityAudit(),
+				Warn:             apiServer.GetPodSecurityWarn(),
+				ExemptNamespaces: apiServer.PodSecurityExemptNamespaceList(),
+			}
+		}
+		if apiServer.EventRateLimitEnabled() {
+			config.EventRateLimitConfigPath = eventRateLimitPath
+
+			var b strings.Builder
+			if err := apiserverEventRateLimitConfigTemplate.Execute(&b, nil); err != nil {
+				return false, fmt.Errorf("failed to render event-rate-limit-config.yaml: %w", err)
+			}
+			eventRateLimitConfig = b.String()
+		}
+
+		var b strings.Builder
+		if err := apiserverAdmissionControlConfigTemplate.Execute(&b, config); err != nil {
+			return false, fmt.Errorf("failed to render admission-control-config.yaml: %w", err)
+		}
+		admissionControlConfig = b.String()
+	}
+
+	return ensureFiles(snap.UID(), snap.GID(), 0o600, map[string]string{
+		filepath.Join(snap.ServiceExtraConfigDir(), "admission-control-config.yaml"): admissionControlConfig,
+		eventRateLimitPath: eventRateLimitConfig,
+	})
+}
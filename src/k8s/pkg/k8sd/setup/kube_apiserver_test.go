@@ -37,7 +37,7 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
 
 		// Call the KubeAPIServer setup function with mock arguments
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", true, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, "Node,RBAC", nil)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", true, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)).To(Succeed())
 
 		// Ensure the kube-apiserver arguments file has the expected arguments and values
 		tests := []struct {
@@ -98,7 +98,7 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
 
 		// Call the KubeAPIServer setup function with mock arguments
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, "Node,RBAC", nil)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)).To(Succeed())
 
 		// Ensure the kube-apiserver arguments file has the expected arguments and values
 		tests := []struct {
@@ -157,7 +157,7 @@ func TestKubeAPIServer(t *testing.T) {
 			"--my-extra-arg":     utils.Pointer("my-extra-val"),
 		}
 		// Call the KubeAPIServer setup function with mock arguments
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", true, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, "Node,RBAC", extraArgs)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", true, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, extraArgs)).To(Succeed())
 
 		// Ensure the kube-apiserver arguments file has the expected arguments and values
 		tests := []struct {
@@ -220,7 +220,7 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
 
 		// Setup without proxy to simplify argument list
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24,fd01::/64", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("external"), ExternalServers: utils.Pointer([]string{"datastoreurl1", "datastoreurl2"})}, "Node,RBAC", nil)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24,fd01::/64", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("external"), ExternalServers: utils.Pointer([]string{"datastoreurl1", "datastoreurl2"})}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)).To(Succeed())
 
 		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--service-cluster-ip-range")).To(Equal("10.0.0.0/24,fd01::/64"))
 		_, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
@@ -233,13 +233,117 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
 
 		// Setup without proxy to simplify argument list
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("external"), ExternalServers: utils.Pointer([]string{"datastoreurl1", "datastoreurl2"})}, "Node,RBAC", nil)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("external"), ExternalServers: utils.Pointer([]string{"datastoreurl1", "datastoreurl2"})}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)).To(Succeed())
 
 		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--etcd-servers")).To(Equal("datastoreurl1,datastoreurl2"))
 		_, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
 		g.Expect(err).ToNot(HaveOccurred())
 	})
 
+	t.Run("ArgsOIDC", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
+
+		apiServer := types.APIServer{
+			SecurePort:        utils.Pointer(6443),
+			AuthorizationMode: utils.Pointer("Node,RBAC"),
+			OIDCIssuerURL:     utils.Pointer("https://issuer.example.com"),
+			OIDCClientID:      utils.Pointer("k8s"),
+			OIDCUsernameClaim: utils.Pointer("email"),
+			OIDCCACert:        utils.Pointer("CA DATA"),
+		}
+
+		// Setup without proxy to simplify argument list
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, apiServer, nil)).To(Succeed())
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--oidc-issuer-url")).To(Equal("https://issuer.example.com"))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--oidc-client-id")).To(Equal("k8s"))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--oidc-username-claim")).To(Equal("email"))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--oidc-ca-file")).To(Equal(filepath.Join(s.Mock.KubernetesPKIDir, "oidc-ca.crt")))
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.KubernetesPKIDir, "oidc-ca.crt"))).To(BeEquivalentTo("CA DATA"))
+
+		args, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(args).ToNot(HaveKey("--oidc-groups-claim"))
+	})
+
+	t.Run("ArgsAudit", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
+
+		apiServer := types.APIServer{
+			SecurePort:         utils.Pointer(6443),
+			AuthorizationMode:  utils.Pointer("Node,RBAC"),
+			AuditPolicy:        utils.Pointer("kind: Policy"),
+			AuditWebhookConfig: utils.Pointer("kind: Config"),
+		}
+
+		// Setup without proxy to simplify argument list
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, apiServer, nil)).To(Succeed())
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--audit-policy-file")).To(Equal(filepath.Join(s.Mock.ServiceExtraConfigDir, "audit-policy.yaml")))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--audit-webhook-config-file")).To(Equal(filepath.Join(s.Mock.ServiceExtraConfigDir, "audit-webhook.conf")))
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "audit-policy.yaml"))).To(BeEquivalentTo("kind: Policy"))
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "audit-webhook.conf"))).To(BeEquivalentTo("kind: Config"))
+
+		args, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(args).ToNot(HaveKey("--audit-log-path"))
+	})
+
+	t.Run("ArgsAdmission", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
+
+		apiServer := types.APIServer{
+			SecurePort:                  utils.Pointer(6443),
+			AuthorizationMode:           utils.Pointer("Node,RBAC"),
+			AdmissionPlugins:            utils.Pointer("NodeRestriction,EventRateLimit"),
+			PodSecurityEnforce:          utils.Pointer("baseline"),
+			PodSecurityWarn:             utils.Pointer("restricted"),
+			PodSecurityExemptNamespaces: utils.Pointer("kube-system, monitoring"),
+		}
+
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, apiServer, nil)).To(Succeed())
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--enable-admission-plugins")).To(Equal("NodeRestriction,EventRateLimit"))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--admission-control-config-file")).To(Equal(filepath.Join(s.Mock.ServiceExtraConfigDir, "admission-control-config.yaml")))
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "admission-control-config.yaml"))).To(BeEquivalentTo(fmt.Sprintf(`apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+  - name: PodSecurity
+    configuration:
+      apiVersion: pod-security.admission.config.k8s.io/v1
+      kind: PodSecurityConfiguration
+      defaults:
+        enforce: "baseline"
+        enforce-version: "latest"
+        warn: "restricted"
+        warn-version: "latest"
+      exemptions:
+        usernames: []
+        runtimeClasses: []
+        namespaces: ["kube-system", "monitoring"]
+  - name: EventRateLimit
+    path: %s
+`, filepath.Join(s.Mock.ServiceExtraConfigDir, "event-rate-limit-config.yaml"))))
+		g.Expect(filepath.Join(s.Mock.ServiceExtraConfigDir, "event-rate-limit-config.yaml")).To(BeAnExistingFile())
+
+		// disabling the admission configuration removes the files and the argument
+		apiServer = types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, apiServer, nil)).To(Succeed())
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--enable-admission-plugins")).To(Equal("NodeRestriction"))
+		args, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(args).ToNot(HaveKey("--admission-control-config-file"))
+		g.Expect(filepath.Join(s.Mock.ServiceExtraConfigDir, "admission-control-config.yaml")).ToNot(BeAnExistingFile())
+		g.Expect(filepath.Join(s.Mock.ServiceExtraConfigDir, "event-rate-limit-config.yaml")).ToNot(BeAnExistingFile())
+	})
+
 	t.Run("UnsupportedDatastore", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -247,7 +351,7 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
 
 		// Attempt to configure kube-apiserver with an unsupported datastore
-		err := setup.KubeAPIServer(s, 6443, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("unsupported")}, "Node,RBAC", nil)
+		err := setup.KubeAPIServer(s, net.ParseIP("192.168.0.1"), "10.0.0.0/24", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("unsupported")}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(err).To(MatchError(ContainSubstring("unsupported datastore")))
 	})
@@ -259,7 +363,7 @@ func TestKubeAPIServer(t *testing.T) {
 		s := mustSetupSnapAndDirectories(t, setKubeletMock)
 		s.Mock.Hostname = "dev"
 
-		g.Expect(setup.KubeAPIServer(s, 6443, net.ParseIP("2001:db8::"), "fd98::/108", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, "Node,RBAC", nil)).To(Succeed())
+		g.Expect(setup.KubeAPIServer(s, net.ParseIP("2001:db8::"), "fd98::/108", "https://auth-webhook.url", false, types.Datastore{Type: utils.Pointer("k8s-dqlite")}, types.APIServer{SecurePort: utils.Pointer(6443), AuthorizationMode: utils.Pointer("Node,RBAC")}, nil)).To(Succeed())
 
 		tests := []struct {
 			key         string
@@ -25,12 +25,17 @@ func KubeControllerManager(snap snap.Snap, extraArgs map[string]*string) error {
 		"--tls-min-version":                  "VersionTLS12",
 		"--use-service-account-credentials":  "true",
 	}
-	// enable cluster-signing if certificates are available
+	// enable cluster-signing if certificates are available.
+	// the signers are configured individually, as kubernetes.io/kubelet-serving CSRs are signed by k8sd.
 	if _, err := os.Stat(filepath.Join(snap.KubernetesPKIDir(), "ca.key")); err == nil {
-		args["--cluster-signing-cert-file"] = filepath.Join(snap.KubernetesPKIDir(), "ca.crt")
-		args["--cluster-signing-key-file"] = filepath.Join(snap.KubernetesPKIDir(), "ca.key")
+		for _, signer := range []string{"kube-apiserver-client", "kubelet-client", "legacy-unknown"} {
+			args[fmt.Sprintf("--cluster-signing-%s-cert-file", signer)] = filepath.Join(snap.KubernetesPKIDir(), "ca.crt")
+			args[fmt.Sprintf("--cluster-signing-%s-key-file", signer)] = filepath.Join(snap.KubernetesPKIDir(), "ca.key")
+		}
 	}
-	if _, err := snaputil.UpdateServiceArguments(snap, "kube-controller-manager", args, nil); err != nil {
+	// the default signer cannot be set together with the individual signers
+	deleteArgs := []string{"--cluster-signing-cert-file", "--cluster-signing-key-file"}
+	if _, err := snaputil.UpdateServiceArguments(snap, "kube-controller-manager", args, deleteArgs); err != nil {
 		return fmt.Errorf("failed to render arguments file: %w", err)
 	}
 	// Apply extra arguments after the defaults, so they can override them.
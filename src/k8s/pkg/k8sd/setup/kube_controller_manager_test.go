@@ -27,7 +27,7 @@ func TestKubeControllerManager(t *testing.T) {
 		// Create a mock snap
 		s := mustSetupSnapAndDirectories(t, setKubeControllerManagerMock)
 
-		// Create ca.key so that cluster-signing-cert-file and cluster-signing-key-file are added to the arguments
+		// Create ca.key so that the cluster-signing cert and key files are added to the arguments
 		os.Create(filepath.Join(s.Mock.KubernetesPKIDir, "ca.key"))
 
 		// Call the kube controller manager setup function
@@ -49,8 +49,12 @@ func TestKubeControllerManager(t *testing.T) {
 			{key: "--terminated-pod-gc-threshold", expectedVal: "12500"},
 			{key: "--tls-min-version", expectedVal: "VersionTLS12"},
 			{key: "--use-service-account-credentials", expectedVal: "true"},
-			{key: "--cluster-signing-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
-			{key: "--cluster-signing-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-kube-apiserver-client-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-kube-apiserver-client-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-kubelet-client-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-kubelet-client-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-legacy-unknown-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-legacy-unknown-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
 		}
 		for _, tc := range tests {
 			t.Run(tc.key, func(t *testing.T) {
@@ -126,7 +130,7 @@ func TestKubeControllerManager(t *testing.T) {
 		// Create a mock snap
 		s := mustSetupSnapAndDirectories(t, setKubeControllerManagerMock)
 
-		// Create ca.key so that cluster-signing-cert-file and cluster-signing-key-file are added to the arguments
+		// Create ca.key so that the cluster-signing cert and key files are added to the arguments
 		os.Create(filepath.Join(s.Mock.KubernetesPKIDir, "ca.key"))
 
 		extraArgs := map[string]*string{
@@ -152,8 +156,12 @@ func TestKubeControllerManager(t *testing.T) {
 			{key: "--terminated-pod-gc-threshold", expectedVal: "12500"},
 			{key: "--tls-min-version", expectedVal: "VersionTLS12"},
 			{key: "--use-service-account-credentials", expectedVal: "true"},
-			{key: "--cluster-signing-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
-			{key: "--cluster-signing-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-kube-apiserver-client-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-kube-apiserver-client-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-kubelet-client-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-kubelet-client-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
+			{key: "--cluster-signing-legacy-unknown-cert-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.crt")},
+			{key: "--cluster-signing-legacy-unknown-key-file", expectedVal: filepath.Join(s.Mock.KubernetesPKIDir, "ca.key")},
 			{key: "--my-extra-arg", expectedVal: "my-extra-val"},
 		}
 		for _, tc := range tests {
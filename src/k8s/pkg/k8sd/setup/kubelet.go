@@ -1,11 +1,14 @@
 package setup
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/snap"
 	snaputil "github.com/canonical/k8s/pkg/snap/util"
 	"github.com/canonical/k8s/pkg/utils"
@@ -29,6 +32,9 @@ var (
 		"k8sd.io/role=control-plane",             // mark as k8sd control plane node
 	}
 
+	// kubeletDefaultEvictionHard is the default hard eviction threshold of the kubelet.
+	kubeletDefaultEvictionHard = "memory.available<100Mi,nodefs.available<1Gi,imagefs.available<1Gi"
+
 	kubeletWorkerLabels = []string{
 		"node-role.kubernetes.io/worker=", // mark node with role "worker"
 		"k8sd.io/role=worker",             // mark as k8sd worker node
@@ -55,7 +61,7 @@ func kubelet(snap snap.Snap, hostname string, nodeIPs []net.IP, clusterDNS strin
 		"--containerd":                   snap.ContainerdSocketPath(),
 		"--container-runtime-endpoint":   snap.ContainerdSocketPath(),
 		"--cgroup-driver":                "systemd",
-		"--eviction-hard":                "memory.available<100Mi,nodefs.available<1Gi,imagefs.available<1Gi",
+		"--eviction-hard":                kubeletDefaultEvictionHard,
 		"--fail-swap-on":                 "false",
 		"--kubeconfig":                   filepath.Join(snap.KubernetesConfigDir(), "kubelet.conf"),
 		"--node-labels":                  strings.Join(labels, ","),
@@ -101,3 +107,65 @@ func kubelet(snap snap.Snap, hostname string, nodeIPs []net.IP, clusterDNS strin
 	}
 	return nil
 }
+
+// KubeletConfigDropIn configures the cluster-wide kubelet configuration on the local node.
+// config is a normalized KubeletConfiguration document, as set in the cluster configuration. An empty config
+// removes the drop-in configuration file.
+// Kubelet arguments take precedence over the configuration file, so arguments that conflict with the configuration
+// are removed, and restored to their defaults when the configuration no longer sets them.
+// It returns true if the configuration file or the kubelet arguments were updated and any error that occurred.
+func KubeletConfigDropIn(snap snap.Snap, config string) (bool, error) {
+	dropInDir := filepath.Join(snap.ServiceExtraConfigDir(), "kubelet.conf.d")
+
+	var (
+		contents   string
+		kubeletCfg types.KubeletConfiguration
+	)
+	if config != "" {
+		var err error
+		if kubeletCfg, err = types.ParseKubeletConfiguration(config); err != nil {
+			return false, fmt.Errorf("failed to parse kubelet configuration: %w", err)
+		}
+		kubeletCfg.APIVersion = types.KubeletConfigurationAPIVersion
+		kubeletCfg.Kind = types.KubeletConfigurationKind
+
+		b, err := json.MarshalIndent(kubeletCfg, "", "  ")
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal kubelet configuration: %w", err)
+		}
+		contents = string(b) + "\n"
+
+		if err := os.MkdirAll(dropInDir, 0o700); err != nil {
+			return false, fmt.Errorf("failed to create kubelet configuration directory: %w", err)
+		}
+	}
+
+	fileChanged, err := ensureFiles(snap.UID(), snap.GID(), 0o600, map[string]string{
+		filepath.Join(dropInDir, "10-k8sd.conf"): contents,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to write kubelet configuration: %w", err)
+	}
+
+	updateArgs := make(map[string]string)
+	var deleteArgs []string
+	if contents != "" {
+		updateArgs["--config-dir"] = dropInDir
+	} else if v, err := snaputil.GetServiceArgument(snap, "kubelet", "--config-dir"); err == nil && v == dropInDir {
+		deleteArgs = append(deleteArgs, "--config-dir")
+	}
+
+	if len(kubeletCfg.EvictionHard) > 0 {
+		deleteArgs = append(deleteArgs, "--eviction-hard")
+	} else if v, err := snaputil.GetServiceArgument(snap, "kubelet", "--eviction-hard"); fileChanged && err == nil && v == "" {
+		// only restore the default after the configuration changed, so that it does not override extra arguments
+		updateArgs["--eviction-hard"] = kubeletDefaultEvictionHard
+	}
+
+	argsChanged, err := snaputil.UpdateServiceArguments(snap, "kubelet", updateArgs, deleteArgs)
+	if err != nil {
+		return false, fmt.Errorf("failed to update kubelet arguments: %w", err)
+	}
+
+	return fileChanged || argsChanged, nil
+}
@@ -2,6 +2,7 @@ package setup_test
 
 import (
 	"net"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -435,3 +436,70 @@ func TestKubelet(t *testing.T) {
 		}
 	})
 }
+
+func TestKubeletConfigDropIn(t *testing.T) {
+	g := NewWithT(t)
+
+	s := mustSetupSnapAndDirectories(t, setKubeletMock)
+	s.Mock.ServiceExtraConfigDir = filepath.Join(t.TempDir(), "args", "conf.d")
+	dropInDir := filepath.Join(s.Mock.ServiceExtraConfigDir, "kubelet.conf.d")
+	dropInFile := filepath.Join(dropInDir, "10-k8sd.conf")
+
+	g.Expect(setup.KubeletWorker(s, "dev", []net.IP{net.ParseIP("192.168.0.1")}, "10.152.1.1", "test-cluster.local", "", nil)).To(Succeed())
+
+	t.Run("Set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		changed, err := setup.KubeletConfigDropIn(s, `{"maxPods":200,"evictionHard":{"memory.available":"500Mi"}}`)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(changed).To(BeTrue())
+
+		g.Expect(os.ReadFile(dropInFile)).To(MatchJSON(`{
+			"apiVersion": "kubelet.config.k8s.io/v1beta1",
+			"kind": "KubeletConfiguration",
+			"maxPods": 200,
+			"evictionHard": {"memory.available": "500Mi"}
+		}`))
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--config-dir")).To(Equal(dropInDir))
+		// the evictionHard configuration would be overridden by the kubelet argument
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--eviction-hard")).To(BeEmpty())
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		g := NewWithT(t)
+
+		changed, err := setup.KubeletConfigDropIn(s, `{"maxPods":200,"evictionHard":{"memory.available":"500Mi"}}`)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(changed).To(BeFalse())
+	})
+
+	t.Run("RestoreEvictionHard", func(t *testing.T) {
+		g := NewWithT(t)
+
+		changed, err := setup.KubeletConfigDropIn(s, `{"maxPods":200}`)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(changed).To(BeTrue())
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--eviction-hard")).To(Equal("memory.available<100Mi,nodefs.available<1Gi,imagefs.available<1Gi"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		g := NewWithT(t)
+
+		changed, err := setup.KubeletConfigDropIn(s, "")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(changed).To(BeTrue())
+		g.Expect(dropInFile).ToNot(BeAnExistingFile())
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--config-dir")).To(BeEmpty())
+
+		changed, err = setup.KubeletConfigDropIn(s, "")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(changed).To(BeFalse())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := setup.KubeletConfigDropIn(s, `{"clusterDNS":["10.0.0.1"]}`)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
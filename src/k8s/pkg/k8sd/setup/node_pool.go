@@ -0,0 +1,108 @@
+package setup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+// nodePoolStateFile is the file where the last applied node pool configuration is kept.
+func nodePoolStateFile(snap snap.Snap) string {
+	return filepath.Join(snap.ServiceArgumentsDir(), "node-pool.json")
+}
+
+// NodePool applies the extra service arguments and configuration files of a node pool on the local node.
+// The extra arguments are set in args, where they take precedence over the arguments that are already set.
+// NodePool keeps track of the applied configuration, so that arguments and files that are no longer part
+// of the node pool are removed. Removed arguments that are already set in args are kept.
+// A nil pool removes any previously applied node pool configuration.
+// NodePool returns the list of services that must be restarted because their configuration files changed.
+func NodePool(snap snap.Snap, pool *types.NodePool, args ServiceArguments) ([]string, error) {
+	var previous, next types.NodePool
+	if b, err := os.ReadFile(nodePoolStateFile(snap)); err == nil {
+		if err := json.Unmarshal(b, &previous); err != nil {
+			return nil, fmt.Errorf("failed to parse previously applied node pool configuration: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read previously applied node pool configuration: %w", err)
+	}
+	if pool != nil {
+		next = *pool
+	}
+
+	var restartServices []string
+	for _, loop := range []struct {
+		service  string
+		previous map[string]*string
+		next     map[string]*string
+	}{
+		{service: "kubelet", previous: previous.ExtraNodeKubeletArgs, next: next.ExtraNodeKubeletArgs},
+		{service: "containerd", previous: previous.ExtraNodeContainerdArgs, next: next.ExtraNodeContainerdArgs},
+		{service: "kube-proxy", previous: previous.ExtraNodeKubeProxyArgs, next: next.ExtraNodeKubeProxyArgs},
+	} {
+		// arguments that are no longer part of the node pool are removed
+		for key := range loop.previous {
+			if _, ok := loop.next[key]; !ok {
+				args.setDefault(loop.service, key, nil)
+			}
+		}
+		for key, value := range loop.next {
+			args.set(loop.service, key, value)
+		}
+	}
+
+	filesChanged := false
+	for filename := range previous.ExtraNodeConfigFiles {
+		if _, ok := next.ExtraNodeConfigFiles[filename]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(snap.ServiceExtraConfigDir(), filename)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove file %s: %w", filename, err)
+		}
+		filesChanged = true
+	}
+	writeFiles := make(map[string]string)
+	for filename, content := range next.ExtraNodeConfigFiles {
+		if b, err := os.ReadFile(filepath.Join(snap.ServiceExtraConfigDir(), filename)); err == nil && string(b) == content {
+			continue
+		}
+		writeFiles[filename] = content
+	}
+	if len(writeFiles) > 0 {
+		if err := ExtraNodeConfigFiles(snap, writeFiles); err != nil {
+			return nil, fmt.Errorf("failed to write extra configuration files: %w", err)
+		}
+		filesChanged = true
+	}
+	if filesChanged {
+		// the files may be referenced by the arguments of any of the services
+		for _, service := range []string{"kubelet", "containerd", "kube-proxy"} {
+			if !slices.Contains(restartServices, service) {
+				restartServices = append(restartServices, service)
+			}
+		}
+	}
+
+	if pool == nil {
+		if err := os.Remove(nodePoolStateFile(snap)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove applied node pool configuration: %w", err)
+		}
+		return restartServices, nil
+	}
+	b, err := json.Marshal(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node pool configuration: %w", err)
+	}
+	if err := utils.WriteFile(nodePoolStateFile(snap), b, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write applied node pool configuration: %w", err)
+	}
+
+	return restartServices, nil
+}
@@ -0,0 +1,113 @@
+package setup_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+// applyNodePool applies a node pool configuration the same way as the node configuration controller.
+func applyNodePool(s snap.Snap, pool *types.NodePool) ([]string, error) {
+	args := setup.ServiceArguments{}
+	restart, err := setup.NodePool(s, pool, args)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := args.Apply(s)
+	if err != nil {
+		return nil, err
+	}
+	return mergeServices(restart, changed), nil
+}
+
+// mergeServices returns the sorted list of unique services.
+func mergeServices(a []string, b []string) []string {
+	services := slices.Concat(a, b)
+	slices.Sort(services)
+	return slices.Compact(services)
+}
+
+func TestNodePool(t *testing.T) {
+	dir := t.TempDir()
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			ServiceArgumentsDir:   filepath.Join(dir, "args"),
+			ServiceExtraConfigDir: filepath.Join(dir, "args", "conf.d"),
+			UID:                   os.Getuid(),
+			GID:                   os.Getgid(),
+		},
+	}
+	g := NewWithT(t)
+	g.Expect(os.MkdirAll(s.Mock.ServiceExtraConfigDir, 0o700)).To(Succeed())
+
+	_, err := snaputil.UpdateServiceArguments(s, "kubelet", map[string]string{"--v": "2", "--max-pods": "110"}, nil)
+	g.Expect(err).To(Not(HaveOccurred()))
+
+	t.Run("Set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyNodePool(s, &types.NodePool{
+			Name:                   "gpu",
+			ExtraNodeKubeletArgs:   map[string]*string{"--max-pods": utils.Pointer("50"), "--v": nil},
+			ExtraNodeKubeProxyArgs: map[string]*string{"--conntrack-max-per-core": utils.Pointer("0")},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(ConsistOf("kubelet", "kube-proxy"))
+
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--max-pods")).To(Equal("50"))
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--v")).To(BeEmpty())
+		g.Expect(snaputil.GetServiceArgument(s, "kube-proxy", "--conntrack-max-per-core")).To(Equal("0"))
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyNodePool(s, &types.NodePool{
+			Name:                   "gpu",
+			ExtraNodeKubeletArgs:   map[string]*string{"--max-pods": utils.Pointer("50"), "--v": nil},
+			ExtraNodeKubeProxyArgs: map[string]*string{"--conntrack-max-per-core": utils.Pointer("0")},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(BeEmpty())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyNodePool(s, &types.NodePool{
+			Name:                 "gpu",
+			ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50"), "--v": nil},
+			ExtraNodeConfigFiles: map[string]string{"file": "content"},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(ConsistOf("kubelet", "containerd", "kube-proxy"))
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-proxy", "--conntrack-max-per-core")).To(BeEmpty())
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "file"))).To(BeEquivalentTo("content"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyNodePool(s, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(ConsistOf("kubelet", "containerd", "kube-proxy"))
+
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--max-pods")).To(BeEmpty())
+		g.Expect(filepath.Join(s.Mock.ServiceExtraConfigDir, "file")).ToNot(BeAnExistingFile())
+		g.Expect(filepath.Join(s.Mock.ServiceArgumentsDir, "node-pool.json")).ToNot(BeAnExistingFile())
+
+		restart, err = applyNodePool(s, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(BeEmpty())
+	})
+}
@@ -0,0 +1,158 @@
+package setup
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"gopkg.in/yaml.v2"
+)
+
+type encryptionConfiguration struct {
+	APIVersion string                            `yaml:"apiVersion"`
+	Kind       string                            `yaml:"kind"`
+	Resources  []encryptionConfigurationResource `yaml:"resources"`
+}
+
+type encryptionConfigurationResource struct {
+	Resources []string                          `yaml:"resources"`
+	Providers []encryptionConfigurationProvider `yaml:"providers"`
+}
+
+type encryptionConfigurationProvider struct {
+	AESCBC    *encryptionConfigurationKeys `yaml:"aescbc,omitempty"`
+	AESGCM    *encryptionConfigurationKeys `yaml:"aesgcm,omitempty"`
+	Secretbox *encryptionConfigurationKeys `yaml:"secretbox,omitempty"`
+	KMS       *encryptionConfigurationKMS  `yaml:"kms,omitempty"`
+	Identity  *struct{}                    `yaml:"identity,omitempty"`
+}
+
+type encryptionConfigurationKeys struct {
+	Keys []encryptionConfigurationKey `yaml:"keys"`
+}
+
+type encryptionConfigurationKey struct {
+	Name   string `yaml:"name"`
+	Secret string `yaml:"secret"`
+}
+
+type encryptionConfigurationKMS struct {
+	APIVersion string `yaml:"apiVersion"`
+	Name       string `yaml:"name"`
+	Endpoint   string `yaml:"endpoint"`
+	Timeout    string `yaml:"timeout"`
+}
+
+// renderEncryptionConfiguration renders the EncryptionConfiguration of the kube-apiserver.
+// The configured provider is used to encrypt Secrets. All keys, the KMS plugin (if configured)
+// and the identity provider are used to decrypt Secrets that were written with previous configurations.
+func renderEncryptionConfiguration(config types.ClusterConfig) (string, error) {
+	provider := config.APIServer.GetSecretsEncryptionProvider()
+
+	kms := encryptionConfigurationProvider{KMS: &encryptionConfigurationKMS{
+		APIVersion: "v2",
+		Name:       "k8sd-kms",
+		Endpoint:   fmt.Sprintf("unix://%s", config.APIServer.GetSecretsEncryptionKMSEndpoint()),
+		Timeout:    "3s",
+	}}
+	identity := encryptionConfigurationProvider{Identity: &struct{}{}}
+
+	var providers []encryptionConfigurationProvider
+	switch provider {
+	case "":
+		providers = append(providers, identity)
+	case "kms":
+		providers = append(providers, kms)
+	}
+
+	// group consecutive keys of the same provider, preserving the order of the keys
+	for _, key := range config.Certificates.GetSecretsEncryptionKeys() {
+		var last *encryptionConfigurationKeys
+		if len(providers) > 0 {
+			switch p := providers[len(providers)-1]; key.Provider {
+			case "aescbc":
+				last = p.AESCBC
+			case "aesgcm":
+				last = p.AESGCM
+			case "secretbox":
+				last = p.Secretbox
+			}
+		}
+		if last == nil {
+			last = &encryptionConfigurationKeys{}
+			switch key.Provider {
+			case "aescbc":
+				providers = append(providers, encryptionConfigurationProvider{AESCBC: last})
+			case "aesgcm":
+				providers = append(providers, encryptionConfigurationProvider{AESGCM: last})
+			case "secretbox":
+				providers = append(providers, encryptionConfigurationProvider{Secretbox: last})
+			default:
+				return "", fmt.Errorf("secrets encryption key %s has unsupported provider %q", key.Name, key.Provider)
+			}
+		}
+		last.Keys = append(last.Keys, encryptionConfigurationKey{Name: key.Name, Secret: key.Secret})
+	}
+
+	if provider != "kms" && config.APIServer.GetSecretsEncryptionKMSEndpoint() != "" {
+		providers = append(providers, kms)
+	}
+	if provider != "" {
+		providers = append(providers, identity)
+	}
+
+	b, err := yaml.Marshal(encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+		Resources: []encryptionConfigurationResource{{
+			Resources: []string{"secrets"},
+			Providers: providers,
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encryption configuration: %w", err)
+	}
+	return string(b), nil
+}
+
+// SecretsEncryption configures the encryption of Secrets at rest for the kube-apiserver on the local node.
+// SecretsEncryption writes the encryption provider configuration and updates the kube-apiserver arguments.
+// The configuration is removed if secrets encryption is not enabled.
+// It returns true if the configuration or the arguments were updated and any error that occurred.
+func SecretsEncryption(snap snap.Snap, config types.ClusterConfig) (bool, error) {
+	fileChanged, err := SecretsEncryptionConfig(snap, config)
+	if err != nil {
+		return false, err
+	}
+
+	updateArgs, deleteArgs := config.ToKubeAPIServerSecretsEncryptionArguments(snap)
+	argsChanged, err := snaputil.UpdateServiceArguments(snap, "kube-apiserver", updateArgs, deleteArgs)
+	if err != nil {
+		return false, fmt.Errorf("failed to update kube-apiserver arguments: %w", err)
+	}
+
+	return fileChanged || argsChanged, nil
+}
+
+// SecretsEncryptionConfig writes the encryption provider configuration of the kube-apiserver on the local node,
+// without updating the kube-apiserver arguments. The configuration is removed if secrets encryption is not enabled.
+// It returns true if the configuration was updated and any error that occurred.
+func SecretsEncryptionConfig(snap snap.Snap, config types.ClusterConfig) (bool, error) {
+	var encryptionConfig string
+	if config.SecretsEncryptionEnabled() {
+		var err error
+		if encryptionConfig, err = renderEncryptionConfiguration(config); err != nil {
+			return false, err
+		}
+	}
+
+	changed, err := ensureFiles(snap.UID(), snap.GID(), 0o600, map[string]string{
+		filepath.Join(snap.ServiceExtraConfigDir(), "encryption-config.yaml"): encryptionConfig,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to write encryption configuration: %w", err)
+	}
+	return changed, nil
+}
@@ -0,0 +1,137 @@
+package setup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestSecretsEncryption(t *testing.T) {
+	keyA := types.SecretsEncryptionKey{Name: "key-a", Provider: "aescbc", Secret: "c2VjcmV0LWE="}
+	keyB := types.SecretsEncryptionKey{Name: "key-b", Provider: "aescbc", Secret: "c2VjcmV0LWI="}
+	keyC := types.SecretsEncryptionKey{Name: "key-c", Provider: "secretbox", Secret: "c2VjcmV0LWM="}
+
+	for _, tc := range []struct {
+		name           string
+		config         types.ClusterConfig
+		expectedConfig string
+	}{
+		{
+			name: "Provider",
+			config: types.ClusterConfig{
+				APIServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("secretbox")},
+				Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{keyC, keyA, keyB}},
+			},
+			expectedConfig: `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - secretbox:
+      keys:
+      - name: key-c
+        secret: c2VjcmV0LWM=
+  - aescbc:
+      keys:
+      - name: key-a
+        secret: c2VjcmV0LWE=
+      - name: key-b
+        secret: c2VjcmV0LWI=
+  - identity: {}
+`,
+		},
+		{
+			name: "Disabling",
+			config: types.ClusterConfig{
+				Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{keyA}},
+			},
+			expectedConfig: `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - identity: {}
+  - aescbc:
+      keys:
+      - name: key-a
+        secret: c2VjcmV0LWE=
+`,
+		},
+		{
+			name: "KMS",
+			config: types.ClusterConfig{
+				APIServer: types.APIServer{
+					SecretsEncryptionProvider:    utils.Pointer("kms"),
+					SecretsEncryptionKMSEndpoint: utils.Pointer("/run/kms/kms.sock"),
+				},
+				Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{keyA}},
+			},
+			expectedConfig: `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - kms:
+      apiVersion: v2
+      name: k8sd-kms
+      endpoint: unix:///run/kms/kms.sock
+      timeout: 3s
+  - aescbc:
+      keys:
+      - name: key-a
+        secret: c2VjcmV0LWE=
+  - identity: {}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
+			configFile := filepath.Join(s.Mock.ServiceExtraConfigDir, "encryption-config.yaml")
+
+			changed, err := setup.SecretsEncryption(s, tc.config)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeTrue())
+			g.Expect(os.ReadFile(configFile)).To(BeEquivalentTo(tc.expectedConfig))
+			g.Expect(snaputil.GetServiceArgument(s, "kube-apiserver", "--encryption-provider-config")).To(Equal(configFile))
+
+			t.Run("NoChanges", func(t *testing.T) {
+				g := NewWithT(t)
+
+				changed, err := setup.SecretsEncryption(s, tc.config)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(changed).To(BeFalse())
+			})
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := mustSetupSnapAndDirectories(t, setKubeAPIServerMock)
+		configFile := filepath.Join(s.Mock.ServiceExtraConfigDir, "encryption-config.yaml")
+
+		_, err := setup.SecretsEncryption(s, types.ClusterConfig{APIServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")}, Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{keyA}}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(configFile).To(BeAnExistingFile())
+
+		changed, err := setup.SecretsEncryption(s, types.ClusterConfig{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeTrue())
+		g.Expect(configFile).ToNot(BeAnExistingFile())
+
+		args, err := utils.ParseArgumentFile(filepath.Join(s.Mock.ServiceArgumentsDir, "kube-apiserver"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(args).ToNot(HaveKey("--encryption-provider-config"))
+	})
+}
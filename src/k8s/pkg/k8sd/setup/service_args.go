@@ -0,0 +1,141 @@
+package setup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+// serviceArgsStateFile is the file where the last applied cluster-wide service arguments of a scope are kept.
+func serviceArgsStateFile(snap snap.Snap, scope string) string {
+	return filepath.Join(snap.ServiceArgumentsDir(), fmt.Sprintf("service-args-%s.json", scope))
+}
+
+// serviceArgsState is the last applied cluster-wide service arguments configuration of a scope.
+type serviceArgsState struct {
+	types.ServiceArgsConfig
+
+	// Originals are the values of the arguments before they were first overridden, keyed by service name.
+	// A nil value means that the argument was not set. Originals are restored when the override is removed.
+	Originals map[string]map[string]*string `json:"originals,omitempty"`
+}
+
+// ServiceArgs applies the cluster-wide extra arguments and configuration files of the specified services on the local node.
+// Control plane and node services are reconciled by different controllers, so each one uses a separate scope to keep track
+// of the applied configuration.
+// The extra arguments are set in args, where they take precedence over the arguments that are already set. The value of an
+// argument before it is first overridden is kept, and restored when the override is removed, unless the argument is already
+// set in args. Configuration files that are no longer part of the configuration are removed.
+// Services that are not installed on the local node (have no arguments file) are skipped.
+// ServiceArgs returns the list of services that must be restarted because their configuration files changed.
+func ServiceArgs(snap snap.Snap, scope string, services []string, config types.ServiceArgsConfig, args ServiceArguments) ([]string, error) {
+	var previous serviceArgsState
+	previousState, err := os.ReadFile(serviceArgsStateFile(snap, scope))
+	if err == nil {
+		if err := json.Unmarshal(previousState, &previous); err != nil {
+			return nil, fmt.Errorf("failed to parse previously applied service arguments: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read previously applied service arguments: %w", err)
+	}
+
+	next := serviceArgsState{ServiceArgsConfig: config}
+	var presentServices, restartServices []string
+	for _, service := range services {
+		if _, err := os.Stat(filepath.Join(snap.ServiceArgumentsDir(), service)); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to check %s arguments: %w", service, err)
+		}
+		presentServices = append(presentServices, service)
+
+		current, err := readServiceArguments(snap, service)
+		if err != nil {
+			return nil, err
+		}
+		for key := range config.Args[service] {
+			original, recorded := previous.Originals[service][key]
+			if _, applied := previous.Args[service][key]; !recorded && !applied {
+				// first override of the argument, keep its current value
+				if value, ok := current[key]; ok {
+					original = utils.Pointer(value)
+				}
+				recorded = true
+			}
+			if recorded {
+				if next.Originals == nil {
+					next.Originals = make(map[string]map[string]*string)
+				}
+				if next.Originals[service] == nil {
+					next.Originals[service] = make(map[string]*string)
+				}
+				next.Originals[service][key] = original
+			}
+		}
+
+		// overrides that are no longer part of the configuration are reverted to their original value
+		for key := range previous.Args[service] {
+			if _, ok := config.Args[service][key]; !ok {
+				args.setDefault(service, key, previous.Originals[service][key])
+			}
+		}
+		for key, value := range config.Args[service] {
+			args.set(service, key, value)
+		}
+	}
+
+	filesChanged := false
+	for filename := range previous.ConfigFiles {
+		if _, ok := config.ConfigFiles[filename]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(snap.ServiceExtraConfigDir(), filename)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove file %s: %w", filename, err)
+		}
+		filesChanged = true
+	}
+	writeFiles := make(map[string]string)
+	for filename, content := range config.ConfigFiles {
+		if b, err := os.ReadFile(filepath.Join(snap.ServiceExtraConfigDir(), filename)); err == nil && string(b) == content {
+			continue
+		}
+		writeFiles[filename] = content
+	}
+	if len(writeFiles) > 0 {
+		if err := ExtraNodeConfigFiles(snap, writeFiles); err != nil {
+			return nil, fmt.Errorf("failed to write extra configuration files: %w", err)
+		}
+		filesChanged = true
+	}
+	if filesChanged {
+		// the files may be referenced by the arguments of any of the services
+		for _, service := range presentServices {
+			if !slices.Contains(restartServices, service) {
+				restartServices = append(restartServices, service)
+			}
+		}
+	}
+
+	b, err := json.Marshal(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal service arguments: %w", err)
+	}
+	// ServiceArgs runs periodically on the control plane nodes, avoid rewriting the file if nothing changed
+	if bytes.Equal(b, previousState) {
+		return restartServices, nil
+	}
+	if err := utils.WriteFile(serviceArgsStateFile(snap, scope), b, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write applied service arguments: %w", err)
+	}
+
+	return restartServices, nil
+}
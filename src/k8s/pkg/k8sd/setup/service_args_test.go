@@ -0,0 +1,145 @@
+package setup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/setup"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+// applyServiceArgs applies the node service arguments on top of the managed arguments,
+// the same way as the node configuration controller.
+func applyServiceArgs(s snap.Snap, args setup.ServiceArguments, config types.ServiceArgsConfig) ([]string, error) {
+	restart, err := setup.ServiceArgs(s, "node", types.NodeServices, config, args)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := args.Apply(s)
+	if err != nil {
+		return nil, err
+	}
+	return mergeServices(restart, changed), nil
+}
+
+func TestServiceArgs(t *testing.T) {
+	dir := t.TempDir()
+	s := &mock.Snap{
+		Mock: mock.Mock{
+			ServiceArgumentsDir:   filepath.Join(dir, "args"),
+			ServiceExtraConfigDir: filepath.Join(dir, "args", "conf.d"),
+			UID:                   os.Getuid(),
+			GID:                   os.Getgid(),
+		},
+	}
+	g := NewWithT(t)
+	g.Expect(os.MkdirAll(s.Mock.ServiceExtraConfigDir, 0o700)).To(Succeed())
+
+	for _, service := range []string{"kubelet", "containerd", "kube-proxy"} {
+		_, err := snaputil.UpdateServiceArguments(s, service, map[string]string{"--v": "2"}, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyServiceArgs(s, setup.ServiceArguments{}, types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{
+				"kubelet":             {"--max-pods": utils.Pointer("50"), "--v": nil},
+				"k8s-apiserver-proxy": {"--refresh-interval": utils.Pointer("10s")},
+			},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(Equal([]string{"kubelet"}))
+
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--max-pods")).To(Equal("50"))
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--v")).To(BeEmpty())
+
+		// services that do not run on the node are skipped
+		g.Expect(filepath.Join(s.Mock.ServiceArgumentsDir, "k8s-apiserver-proxy")).ToNot(BeAnExistingFile())
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyServiceArgs(s, setup.ServiceArguments{}, types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{
+				"kubelet":             {"--max-pods": utils.Pointer("50"), "--v": nil},
+				"k8s-apiserver-proxy": {"--refresh-interval": utils.Pointer("10s")},
+			},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(BeEmpty())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyServiceArgs(s, setup.ServiceArguments{}, types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{
+				"kube-proxy": {"--v": utils.Pointer("4")},
+			},
+			ConfigFiles: map[string]string{"file": "content"},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(ConsistOf("kubelet", "containerd", "kube-proxy"))
+
+		// removed overrides are reverted to their original values
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--max-pods")).To(BeEmpty())
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--v")).To(Equal("2"))
+		g.Expect(snaputil.GetServiceArgument(s, "kube-proxy", "--v")).To(Equal("4"))
+		g.Expect(os.ReadFile(filepath.Join(s.Mock.ServiceExtraConfigDir, "file"))).To(BeEquivalentTo("content"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		g := NewWithT(t)
+
+		restart, err := applyServiceArgs(s, setup.ServiceArguments{}, types.ServiceArgsConfig{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(ConsistOf("kubelet", "containerd", "kube-proxy"))
+
+		g.Expect(snaputil.GetServiceArgument(s, "kube-proxy", "--v")).To(Equal("2"))
+		g.Expect(filepath.Join(s.Mock.ServiceExtraConfigDir, "file")).ToNot(BeAnExistingFile())
+
+		restart, err = applyServiceArgs(s, setup.ServiceArguments{}, types.ServiceArgsConfig{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(BeEmpty())
+	})
+
+	t.Run("ManagedArguments", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// managed arguments are set by the controllers on every reconcile, before the service arguments
+		managed := func() setup.ServiceArguments {
+			args := setup.ServiceArguments{}
+			args.Set("kubelet", map[string]string{"--cluster-dns": "10.152.183.10"}, nil)
+			return args
+		}
+
+		restart, err := applyServiceArgs(s, managed(), types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{"kubelet": {"--cluster-dns": utils.Pointer("10.0.0.10")}},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(Equal([]string{"kubelet"}))
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--cluster-dns")).To(Equal("10.0.0.10"))
+
+		// the override takes precedence over the managed argument, without changing the arguments on every reconcile
+		restart, err = applyServiceArgs(s, managed(), types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{"kubelet": {"--cluster-dns": utils.Pointer("10.0.0.10")}},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(BeEmpty())
+
+		// the managed argument is applied once the override is removed
+		restart, err = applyServiceArgs(s, managed(), types.ServiceArgsConfig{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(restart).To(Equal([]string{"kubelet"}))
+		g.Expect(snaputil.GetServiceArgument(s, "kubelet", "--cluster-dns")).To(Equal("10.152.183.10"))
+	})
+}
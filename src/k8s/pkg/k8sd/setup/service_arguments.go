@@ -0,0 +1,83 @@
+package setup
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/canonical/k8s/pkg/snap"
+	snaputil "github.com/canonical/k8s/pkg/snap/util"
+	"github.com/canonical/k8s/pkg/utils"
+)
+
+// ServiceArguments are the desired arguments of the services on the local node, keyed by service name.
+// A nil value removes the argument.
+// The configuration controllers collect the arguments of all sources that manage them (cluster configuration,
+// cluster-wide service arguments, node pools) and apply them once, so that the sources do not overwrite each other.
+type ServiceArguments map[string]map[string]*string
+
+// Set sets arguments of a service. Arguments that are set later take precedence over the ones that were set before.
+func (a ServiceArguments) Set(service string, updateArgs map[string]string, deleteArgs []string) {
+	for _, key := range deleteArgs {
+		a.set(service, key, nil)
+	}
+	for key, value := range updateArgs {
+		a.set(service, key, utils.Pointer(value))
+	}
+}
+
+func (a ServiceArguments) set(service string, key string, value *string) {
+	if a[service] == nil {
+		a[service] = make(map[string]*string)
+	}
+	a[service][key] = value
+}
+
+// setDefault sets an argument of a service, unless it is already set.
+func (a ServiceArguments) setDefault(service string, key string, value *string) {
+	if _, ok := a[service][key]; !ok {
+		a.set(service, key, value)
+	}
+}
+
+// Apply updates the arguments files of the services.
+// Apply returns the list of services whose arguments changed and must be restarted.
+func (a ServiceArguments) Apply(snap snap.Snap) ([]string, error) {
+	var changedServices []string
+	for _, service := range slices.Sorted(maps.Keys(a)) {
+		updateArgs, deleteArgs := snaputil.ServiceArgsFromMap(a[service])
+		changed, err := snaputil.UpdateServiceArguments(snap, service, updateArgs, deleteArgs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s arguments: %w", service, err)
+		}
+		if changed {
+			changedServices = append(changedServices, service)
+		}
+	}
+	return changedServices, nil
+}
+
+// readServiceArguments returns the current arguments of a service. Unlike snaputil.GetServiceArgument,
+// it allows to distinguish arguments that are not set from arguments with an empty value.
+func readServiceArguments(snap snap.Snap, service string) (map[string]string, error) {
+	b, err := os.ReadFile(filepath.Join(snap.ServiceArgumentsDir(), service))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read arguments file for service %s: %w", service, err)
+	}
+	args := make(map[string]string)
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		key, value := utils.ParseArgumentLine(line)
+		args[key] = value
+	}
+	return args, nil
+}
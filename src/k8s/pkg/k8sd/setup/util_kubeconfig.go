@@ -56,6 +56,45 @@ func KubeconfigString(url string, caPEM string, crtPEM string, keyPEM string) (s
 	return string(kubeconfig), nil
 }
 
+// OIDCKubeconfigString provides a stringified kubeconfig that authenticates with the OpenID Connect issuer.
+// Credentials are retrieved using the "kubectl oidc-login" exec credential plugin (https://github.com/int128/kubelogin).
+func OIDCKubeconfigString(url string, caPEM string, issuerURL string, clientID string) (string, error) {
+	config := createConfig(url, caPEM, "", "")
+	config.AuthInfos["k8s-user"] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+			Command:    "kubectl",
+			Args: []string{
+				"oidc-login",
+				"get-token",
+				fmt.Sprintf("--oidc-issuer-url=%s", issuerURL),
+				fmt.Sprintf("--oidc-client-id=%s", clientID),
+			},
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+	}
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kubeconfig yaml: %w", err)
+	}
+	return string(kubeconfig), nil
+}
+
+// TokenKubeconfigString provides a stringified kubeconfig that authenticates with a bearer token.
+// If namespace is set, it is used as the default namespace of the kubeconfig context.
+func TokenKubeconfigString(url string, caPEM string, token string, namespace string) (string, error) {
+	config := createConfig(url, caPEM, "", "")
+	config.AuthInfos["k8s-user"] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	config.Contexts["k8s"].Namespace = namespace
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kubeconfig yaml: %w", err)
+	}
+	return string(kubeconfig), nil
+}
+
 // SetupControlPlaneKubeconfigs writes kubeconfig files for the control plane components.
 func SetupControlPlaneKubeconfigs(kubeConfigDir string, localhostAddress string, securePort int, pki pki.ControlPlanePKI) error {
 	for _, kubeconfig := range []struct {
@@ -36,3 +36,72 @@ users:
 	g.Expect(actual).To(Equal(expectedConfig))
 	g.Expect(err).To(Not(HaveOccurred()))
 }
+
+func TestOIDCKubeconfigString(t *testing.T) {
+	g := NewWithT(t)
+
+	expectedConfig := `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://server
+  name: k8s
+contexts:
+- context:
+    cluster: k8s
+    user: k8s-user
+  name: k8s
+current-context: k8s
+kind: Config
+preferences: {}
+users:
+- name: k8s-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      args:
+      - oidc-login
+      - get-token
+      - --oidc-issuer-url=https://issuer.example.com
+      - --oidc-client-id=k8s
+      command: kubectl
+      env: null
+      interactiveMode: IfAvailable
+      provideClusterInfo: false
+`
+
+	actual, err := setup.OIDCKubeconfigString("server", "ca", "https://issuer.example.com", "k8s")
+
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(actual).To(Equal(expectedConfig))
+}
+
+func TestTokenKubeconfigString(t *testing.T) {
+	g := NewWithT(t)
+
+	expectedConfig := `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://server
+  name: k8s
+contexts:
+- context:
+    cluster: k8s
+    namespace: dev
+    user: k8s-user
+  name: k8s
+current-context: k8s
+kind: Config
+preferences: {}
+users:
+- name: k8s-user
+  user:
+    token: secret
+`
+
+	actual, err := setup.TokenKubeconfigString("server", "ca", "secret", "dev")
+
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(actual).To(Equal(expectedConfig))
+}
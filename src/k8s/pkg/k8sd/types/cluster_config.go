@@ -15,4 +15,6 @@ type ClusterConfig struct {
 	MetricsServer MetricsServer `json:"metrics-server,omitempty"`
 
 	Annotations Annotations `json:"annotations,omitempty"`
+
+	ServiceArgs *ServiceArgs `json:"service-args,omitempty"`
 }
@@ -0,0 +1,212 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AnnotationAdmissionPlugins is the comma-separated list of admission plugins enabled on the kube-apiserver,
+	// in addition to the plugins that are enabled by default. NodeRestriction is always enabled.
+	AnnotationAdmissionPlugins = "k8sd/v1alpha1/admission/enable-plugins"
+	// AnnotationPodSecurityEnforce is the cluster-wide default Pod Security Standard level that is enforced,
+	// one of "privileged", "baseline" or "restricted".
+	AnnotationPodSecurityEnforce = "k8sd/v1alpha1/admission/pod-security/enforce"
+	// AnnotationPodSecurityAudit is the cluster-wide default Pod Security Standard level that is audited.
+	AnnotationPodSecurityAudit = "k8sd/v1alpha1/admission/pod-security/audit"
+	// AnnotationPodSecurityWarn is the cluster-wide default Pod Security Standard level that users are warned about.
+	AnnotationPodSecurityWarn = "k8sd/v1alpha1/admission/pod-security/warn"
+	// AnnotationPodSecurityExemptNamespaces is the comma-separated list of namespaces that are exempt from Pod Security Admission.
+	AnnotationPodSecurityExemptNamespaces = "k8sd/v1alpha1/admission/pod-security/exempt-namespaces"
+	// AnnotationAdmissionPolicies is a bundle of ValidatingAdmissionPolicy and ValidatingAdmissionPolicyBinding
+	// documents (admissionregistration.k8s.io/v1) that k8sd applies when control plane nodes start.
+	AnnotationAdmissionPolicies = "k8sd/v1alpha1/admission/policies"
+
+	// DefaultAdmissionPlugins are the admission plugins enabled on the kube-apiserver if none are configured.
+	// DefaultAdmissionPlugins are also enabled if they are missing from the configured plugins, as the kubelet
+	// credentials of any node would otherwise allow to modify all nodes and pods of the cluster.
+	DefaultAdmissionPlugins = "NodeRestriction"
+)
+
+// PodSecurityLevels are the levels of the Pod Security Standards.
+var PodSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
+func (c APIServer) GetAdmissionPlugins() string   { return getField(c.AdmissionPlugins) }
+func (c APIServer) GetPodSecurityEnforce() string { return getField(c.PodSecurityEnforce) }
+func (c APIServer) GetPodSecurityAudit() string   { return getField(c.PodSecurityAudit) }
+func (c APIServer) GetPodSecurityWarn() string    { return getField(c.PodSecurityWarn) }
+func (c APIServer) GetPodSecurityExemptNamespaces() string {
+	return getField(c.PodSecurityExemptNamespaces)
+}
+func (c APIServer) GetAdmissionPolicies() string { return getField(c.AdmissionPolicies) }
+
+// EnabledAdmissionPlugins returns the admission plugins enabled on the kube-apiserver.
+// The default admission plugins are always enabled.
+func (c APIServer) EnabledAdmissionPlugins() []string {
+	plugins := splitList(c.GetAdmissionPlugins())
+	if !slices.Contains(plugins, DefaultAdmissionPlugins) {
+		plugins = append([]string{DefaultAdmissionPlugins}, plugins...)
+	}
+	return plugins
+}
+
+// PodSecurityExemptNamespaceList returns the namespaces that are exempt from Pod Security Admission.
+func (c APIServer) PodSecurityExemptNamespaceList() []string {
+	return splitList(c.GetPodSecurityExemptNamespaces())
+}
+
+// PodSecurityConfigured returns true if a cluster-wide Pod Security Admission default is configured.
+func (c APIServer) PodSecurityConfigured() bool {
+	return c.GetPodSecurityEnforce() != "" || c.GetPodSecurityAudit() != "" || c.GetPodSecurityWarn() != "" || c.GetPodSecurityExemptNamespaces() != ""
+}
+
+// EventRateLimitEnabled returns true if the EventRateLimit admission plugin is enabled.
+func (c APIServer) EventRateLimitEnabled() bool {
+	return slices.Contains(c.EnabledAdmissionPlugins(), "EventRateLimit")
+}
+
+// AdmissionConfigEnabled returns true if the kube-apiserver needs an admission control configuration file.
+func (c APIServer) AdmissionConfigEnabled() bool {
+	return c.PodSecurityConfigured() || c.EventRateLimitEnabled()
+}
+
+// ToKubeAPIServerAdmissionArguments returns updateArgs, deleteArgs that can be used with snaputil.UpdateServiceArguments() for the kube-apiserver
+// according to the admission configuration.
+func (c APIServer) ToKubeAPIServerAdmissionArguments(p APIServerPathsProvider) (map[string]string, []string) {
+	updateArgs := map[string]string{
+		"--enable-admission-plugins": strings.Join(c.EnabledAdmissionPlugins(), ","),
+	}
+	var deleteArgs []string
+
+	// the admission control configuration file will be written by setup.EnsureKubeAPIServerAdmissionConfig(), here we only set the path
+	if c.AdmissionConfigEnabled() {
+		updateArgs["--admission-control-config-file"] = filepath.Join(p.ServiceExtraConfigDir(), "admission-control-config.yaml")
+	} else {
+		deleteArgs = append(deleteArgs, "--admission-control-config-file")
+	}
+
+	return updateArgs, deleteArgs
+}
+
+// AdmissionPolicies are the validating admission policies and bindings that k8sd applies on the cluster.
+type AdmissionPolicies struct {
+	Policies []admissionregistrationv1.ValidatingAdmissionPolicy
+	Bindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding
+}
+
+// ParseAdmissionPolicies parses a YAML or JSON bundle of ValidatingAdmissionPolicy and ValidatingAdmissionPolicyBinding
+// documents. Documents of other kinds or API versions are rejected.
+func ParseAdmissionPolicies(s string) (AdmissionPolicies, error) {
+	var result AdmissionPolicies
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(s)))
+	for idx := 0; ; idx++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return AdmissionPolicies{}, fmt.Errorf("failed to read document %d: %w", idx, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var meta struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &meta); err != nil {
+			return AdmissionPolicies{}, fmt.Errorf("failed to parse document %d: %w", idx, err)
+		}
+		if meta.APIVersion != admissionregistrationv1.SchemeGroupVersion.String() {
+			return AdmissionPolicies{}, fmt.Errorf("document %d must have apiVersion %s, not %q", idx, admissionregistrationv1.SchemeGroupVersion, meta.APIVersion)
+		}
+
+		switch meta.Kind {
+		case "ValidatingAdmissionPolicy":
+			var policy admissionregistrationv1.ValidatingAdmissionPolicy
+			if err := yaml.UnmarshalStrict(doc, &policy); err != nil {
+				return AdmissionPolicies{}, fmt.Errorf("failed to parse ValidatingAdmissionPolicy in document %d: %w", idx, err)
+			}
+			if policy.Name == "" {
+				return AdmissionPolicies{}, fmt.Errorf("ValidatingAdmissionPolicy in document %d must have a name", idx)
+			}
+			result.Policies = append(result.Policies, policy)
+		case "ValidatingAdmissionPolicyBinding":
+			var binding admissionregistrationv1.ValidatingAdmissionPolicyBinding
+			if err := yaml.UnmarshalStrict(doc, &binding); err != nil {
+				return AdmissionPolicies{}, fmt.Errorf("failed to parse ValidatingAdmissionPolicyBinding in document %d: %w", idx, err)
+			}
+			if binding.Name == "" || binding.Spec.PolicyName == "" {
+				return AdmissionPolicies{}, fmt.Errorf("ValidatingAdmissionPolicyBinding in document %d must have a name and a policyName", idx)
+			}
+			result.Bindings = append(result.Bindings, binding)
+		default:
+			return AdmissionPolicies{}, fmt.Errorf("document %d must be a ValidatingAdmissionPolicy or ValidatingAdmissionPolicyBinding, not %q", idx, meta.Kind)
+		}
+	}
+
+	return result, nil
+}
+
+// validateAdmission checks the admission plugins, Pod Security Admission defaults and admission policies.
+func validateAdmission(c APIServer) error {
+	if c.AdmissionPlugins != nil {
+		if len(splitList(c.GetAdmissionPlugins())) == 0 {
+			return fmt.Errorf("%s must not be empty", AnnotationAdmissionPlugins)
+		}
+		for _, plugin := range splitList(c.GetAdmissionPlugins()) {
+			if strings.ContainsAny(plugin, " \t=") {
+				return fmt.Errorf("%s contains an invalid plugin name %q", AnnotationAdmissionPlugins, plugin)
+			}
+		}
+	}
+
+	for _, loop := range []struct {
+		annotation string
+		value      string
+	}{
+		{annotation: AnnotationPodSecurityEnforce, value: c.GetPodSecurityEnforce()},
+		{annotation: AnnotationPodSecurityAudit, value: c.GetPodSecurityAudit()},
+		{annotation: AnnotationPodSecurityWarn, value: c.GetPodSecurityWarn()},
+	} {
+		if loop.value != "" && !slices.Contains(PodSecurityLevels, loop.value) {
+			return fmt.Errorf("%s must be one of %v, not %q", loop.annotation, PodSecurityLevels, loop.value)
+		}
+	}
+	for _, namespace := range c.PodSecurityExemptNamespaceList() {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("%s contains an invalid namespace %q: %s", AnnotationPodSecurityExemptNamespaces, namespace, strings.Join(errs, ", "))
+		}
+	}
+
+	if v := c.GetAdmissionPolicies(); v != "" {
+		if _, err := ParseAdmissionPolicies(v); err != nil {
+			return fmt.Errorf("%s must be a valid bundle of admission policies: %w", AnnotationAdmissionPolicies, err)
+		}
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated list, ignoring whitespace and empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
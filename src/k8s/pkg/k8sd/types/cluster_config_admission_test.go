@@ -0,0 +1,140 @@
+package types_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+const admissionPolicies = `apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicy
+metadata:
+  name: max-replicas
+spec:
+  matchConstraints:
+    resourceRules:
+    - apiGroups: ["apps"]
+      apiVersions: ["v1"]
+      operations: ["CREATE", "UPDATE"]
+      resources: ["deployments"]
+  validations:
+  - expression: "object.spec.replicas <= 5"
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicyBinding
+metadata:
+  name: max-replicas
+spec:
+  policyName: max-replicas
+  validationActions: [Deny]
+`
+
+func TestParseAdmissionPolicies(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		policies, err := types.ParseAdmissionPolicies(admissionPolicies)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(policies.Policies).To(HaveLen(1))
+		g.Expect(policies.Policies[0].Name).To(Equal("max-replicas"))
+		g.Expect(policies.Policies[0].Spec.Validations[0].Expression).To(Equal("object.spec.replicas <= 5"))
+		g.Expect(policies.Bindings).To(HaveLen(1))
+		g.Expect(policies.Bindings[0].Spec.PolicyName).To(Equal("max-replicas"))
+	})
+
+	for _, tc := range []struct {
+		name     string
+		policies string
+	}{
+		{name: "WrongKind", policies: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingWebhookConfiguration\nmetadata:\n  name: test\n"},
+		{name: "WrongAPIVersion", policies: "apiVersion: admissionregistration.k8s.io/v1beta1\nkind: ValidatingAdmissionPolicy\nmetadata:\n  name: test\n"},
+		{name: "UnknownField", policies: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingAdmissionPolicy\nmetadata:\n  name: test\nspec:\n  unknown: true\n"},
+		{name: "MissingName", policies: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingAdmissionPolicy\n"},
+		{name: "MissingPolicyName", policies: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingAdmissionPolicyBinding\nmetadata:\n  name: test\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := types.ParseAdmissionPolicies(tc.policies)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestKubeAPIServerAdmissionArguments(t *testing.T) {
+	s := &mock.Snap{Mock: mock.Mock{ServiceExtraConfigDir: "/args/conf.d"}}
+
+	t.Run("Default", func(t *testing.T) {
+		g := NewWithT(t)
+
+		updateArgs, deleteArgs := types.APIServer{}.ToKubeAPIServerAdmissionArguments(s)
+		g.Expect(updateArgs).To(Equal(map[string]string{"--enable-admission-plugins": "NodeRestriction"}))
+		g.Expect(deleteArgs).To(ConsistOf("--admission-control-config-file"))
+	})
+
+	t.Run("PodSecurity", func(t *testing.T) {
+		g := NewWithT(t)
+
+		apiServer := types.APIServer{
+			AdmissionPlugins:   utils.Pointer("NodeRestriction, AlwaysPullImages"),
+			PodSecurityEnforce: utils.Pointer("restricted"),
+		}
+		updateArgs, deleteArgs := apiServer.ToKubeAPIServerAdmissionArguments(s)
+		g.Expect(updateArgs).To(Equal(map[string]string{
+			"--enable-admission-plugins":      "NodeRestriction,AlwaysPullImages",
+			"--admission-control-config-file": filepath.Join("/args/conf.d", "admission-control-config.yaml"),
+		}))
+		g.Expect(deleteArgs).To(BeEmpty())
+	})
+
+	t.Run("NodeRestrictionAlwaysEnabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		apiServer := types.APIServer{AdmissionPlugins: utils.Pointer("AlwaysPullImages")}
+		updateArgs, _ := apiServer.ToKubeAPIServerAdmissionArguments(s)
+		g.Expect(updateArgs).To(HaveKeyWithValue("--enable-admission-plugins", "NodeRestriction,AlwaysPullImages"))
+	})
+}
+
+func TestValidateAdmission(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{name: "Empty"},
+		{name: "Valid", annotations: map[string]string{
+			types.AnnotationAdmissionPlugins:            "NodeRestriction,EventRateLimit",
+			types.AnnotationPodSecurityEnforce:          "baseline",
+			types.AnnotationPodSecurityAudit:            "restricted",
+			types.AnnotationPodSecurityWarn:             "restricted",
+			types.AnnotationPodSecurityExemptNamespaces: "kube-system,metallb-system",
+			types.AnnotationAdmissionPolicies:           admissionPolicies,
+		}},
+		{name: "EmptyPlugins", annotations: map[string]string{types.AnnotationAdmissionPlugins: " , "}, expectErr: true},
+		{name: "InvalidPlugin", annotations: map[string]string{types.AnnotationAdmissionPlugins: "NodeRestriction=true"}, expectErr: true},
+		{name: "InvalidLevel", annotations: map[string]string{types.AnnotationPodSecurityEnforce: "strict"}, expectErr: true},
+		{name: "InvalidNamespace", annotations: map[string]string{types.AnnotationPodSecurityExemptNamespaces: "Kube_System"}, expectErr: true},
+		{name: "InvalidPolicies", annotations: map[string]string{types.AnnotationAdmissionPolicies: "kind: Pod"}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config, err := types.ClusterConfigFromBootstrapConfig(apiv1.BootstrapConfig{
+				ClusterConfig: apiv1.UserFacingClusterConfig{Annotations: tc.annotations},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			config.SetDefaults()
+
+			if tc.expectErr {
+				g.Expect(config.Validate()).ToNot(Succeed())
+			} else {
+				g.Expect(config.Validate()).To(Succeed())
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 type Annotations map[string]string
 
 func (a Annotations) Get(key string) (value string, exists bool) {
@@ -10,3 +12,13 @@ func (a Annotations) Get(key string) (value string, exists bool) {
 	v, ok := a[key]
 	return v, ok
 }
+
+// HasPrefix returns true if any annotation key starts with prefix.
+func (a Annotations) HasPrefix(prefix string) bool {
+	for key := range a {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
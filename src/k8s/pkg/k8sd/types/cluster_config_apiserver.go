@@ -1,10 +1,261 @@
 package types
 
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	// AnnotationOIDCIssuerURL is the URL of the OpenID Connect issuer. Setting it enables OIDC authentication
+	// on the kube-apiserver. Only https URLs are accepted.
+	AnnotationOIDCIssuerURL = "k8sd/v1alpha1/oidc/issuer-url"
+	// AnnotationOIDCClientID is the client ID for the OpenID Connect client. Required if OIDC is enabled.
+	AnnotationOIDCClientID = "k8sd/v1alpha1/oidc/client-id"
+	// AnnotationOIDCUsernameClaim is the JWT claim to use as the user name. Defaults to "sub".
+	AnnotationOIDCUsernameClaim = "k8sd/v1alpha1/oidc/username-claim"
+	// AnnotationOIDCUsernamePrefix is the prefix prepended to username claims to prevent clashes with existing names.
+	AnnotationOIDCUsernamePrefix = "k8sd/v1alpha1/oidc/username-prefix"
+	// AnnotationOIDCGroupsClaim is the JWT claim to use as the user's groups.
+	AnnotationOIDCGroupsClaim = "k8sd/v1alpha1/oidc/groups-claim"
+	// AnnotationOIDCGroupsPrefix is the prefix prepended to group claims to prevent clashes with existing names.
+	AnnotationOIDCGroupsPrefix = "k8sd/v1alpha1/oidc/groups-prefix"
+	// AnnotationOIDCCACert is the PEM encoded CA certificate that signed the certificate of the issuer.
+	// If not set, the host's root CAs are used.
+	AnnotationOIDCCACert = "k8sd/v1alpha1/oidc/ca-crt"
+
+	// AnnotationAuditPolicy is the audit policy document (YAML or JSON). Setting it enables audit logging
+	// on the kube-apiserver. At least one of the log or webhook backends must be configured.
+	AnnotationAuditPolicy = "k8sd/v1alpha1/audit/policy"
+	// AnnotationAuditLogPath is the absolute path of the file where audit events are written (log backend).
+	AnnotationAuditLogPath = "k8sd/v1alpha1/audit/log-path"
+	// AnnotationAuditLogMaxAge is the maximum number of days to retain old audit log files.
+	AnnotationAuditLogMaxAge = "k8sd/v1alpha1/audit/log-max-age"
+	// AnnotationAuditLogMaxSize is the maximum size in megabytes of the audit log file before it gets rotated.
+	AnnotationAuditLogMaxSize = "k8sd/v1alpha1/audit/log-max-size"
+	// AnnotationAuditLogMaxBackups is the maximum number of old audit log files to retain.
+	AnnotationAuditLogMaxBackups = "k8sd/v1alpha1/audit/log-max-backups"
+	// AnnotationAuditWebhookConfig is a kubeconfig document describing the remote audit webhook backend.
+	AnnotationAuditWebhookConfig = "k8sd/v1alpha1/audit/webhook-config"
+
+	// AnnotationSecretsEncryptionProvider is the provider used to encrypt Secrets at rest, one of "aescbc", "aesgcm",
+	// "secretbox" or "kms". Keys for the aescbc, aesgcm and secretbox providers are generated and managed by k8sd.
+	AnnotationSecretsEncryptionProvider = "k8sd/v1alpha1/secrets-encryption/provider"
+	// AnnotationSecretsEncryptionKMSEndpoint is the path of the unix socket of the KMS v2 plugin. Required for the "kms" provider.
+	AnnotationSecretsEncryptionKMSEndpoint = "k8sd/v1alpha1/secrets-encryption/kms-endpoint"
+)
+
 type APIServer struct {
 	SecurePort        *int    `json:"port,omitempty"`
 	AuthorizationMode *string `json:"authorization-mode,omitempty"`
+
+	OIDCIssuerURL      *string `json:"oidc-issuer-url,omitempty"`
+	OIDCClientID       *string `json:"oidc-client-id,omitempty"`
+	OIDCUsernameClaim  *string `json:"oidc-username-claim,omitempty"`
+	OIDCUsernamePrefix *string `json:"oidc-username-prefix,omitempty"`
+	OIDCGroupsClaim    *string `json:"oidc-groups-claim,omitempty"`
+	OIDCGroupsPrefix   *string `json:"oidc-groups-prefix,omitempty"`
+	OIDCCACert         *string `json:"oidc-ca-crt,omitempty"`
+
+	AuditPolicy        *string `json:"audit-policy,omitempty"`
+	AuditLogPath       *string `json:"audit-log-path,omitempty"`
+	AuditLogMaxAge     *int    `json:"audit-log-max-age,omitempty"`
+	AuditLogMaxSize    *int    `json:"audit-log-max-size,omitempty"`
+	AuditLogMaxBackups *int    `json:"audit-log-max-backups,omitempty"`
+	AuditWebhookConfig *string `json:"audit-webhook-config,omitempty"`
+
+	SecretsEncryptionProvider    *string `json:"secrets-encryption-provider,omitempty"`
+	SecretsEncryptionKMSEndpoint *string `json:"secrets-encryption-kms-endpoint,omitempty"`
+
+	AdmissionPlugins            *string `json:"admission-plugins,omitempty"`
+	PodSecurityEnforce          *string `json:"pod-security-enforce,omitempty"`
+	PodSecurityAudit            *string `json:"pod-security-audit,omitempty"`
+	PodSecurityWarn             *string `json:"pod-security-warn,omitempty"`
+	PodSecurityExemptNamespaces *string `json:"pod-security-exempt-namespaces,omitempty"`
+	AdmissionPolicies           *string `json:"admission-policies,omitempty"`
+}
+
+func (c APIServer) GetSecurePort() int            { return getField(c.SecurePort) }
+func (c APIServer) GetAuthorizationMode() string  { return getField(c.AuthorizationMode) }
+func (c APIServer) GetOIDCIssuerURL() string      { return getField(c.OIDCIssuerURL) }
+func (c APIServer) GetOIDCClientID() string       { return getField(c.OIDCClientID) }
+func (c APIServer) GetOIDCUsernameClaim() string  { return getField(c.OIDCUsernameClaim) }
+func (c APIServer) GetOIDCUsernamePrefix() string { return getField(c.OIDCUsernamePrefix) }
+func (c APIServer) GetOIDCGroupsClaim() string    { return getField(c.OIDCGroupsClaim) }
+func (c APIServer) GetOIDCGroupsPrefix() string   { return getField(c.OIDCGroupsPrefix) }
+func (c APIServer) GetOIDCCACert() string         { return getField(c.OIDCCACert) }
+func (c APIServer) GetAuditPolicy() string        { return getField(c.AuditPolicy) }
+func (c APIServer) GetAuditLogPath() string       { return getField(c.AuditLogPath) }
+func (c APIServer) GetAuditLogMaxAge() int        { return getField(c.AuditLogMaxAge) }
+func (c APIServer) GetAuditLogMaxSize() int       { return getField(c.AuditLogMaxSize) }
+func (c APIServer) GetAuditLogMaxBackups() int    { return getField(c.AuditLogMaxBackups) }
+func (c APIServer) GetAuditWebhookConfig() string { return getField(c.AuditWebhookConfig) }
+func (c APIServer) GetSecretsEncryptionProvider() string {
+	return getField(c.SecretsEncryptionProvider)
+}
+
+func (c APIServer) GetSecretsEncryptionKMSEndpoint() string {
+	return getField(c.SecretsEncryptionKMSEndpoint)
+}
+func (c APIServer) Empty() bool { return c == APIServer{} }
+
+// OIDCEnabled returns true if OpenID Connect authentication is configured.
+func (c APIServer) OIDCEnabled() bool { return c.GetOIDCIssuerURL() != "" }
+
+// AuditEnabled returns true if audit logging is configured.
+func (c APIServer) AuditEnabled() bool { return c.GetAuditPolicy() != "" }
+
+// APIServerPathsProvider is to avoid circular dependency for snap.Snap in APIServer.ToKubeAPIServerOIDCArguments().
+type APIServerPathsProvider interface {
+	KubernetesPKIDir() string
+	ServiceExtraConfigDir() string
 }
 
-func (c APIServer) GetSecurePort() int           { return getField(c.SecurePort) }
-func (c APIServer) GetAuthorizationMode() string { return getField(c.AuthorizationMode) }
-func (c APIServer) Empty() bool                  { return c == APIServer{} }
+// ToKubeAPIServerOIDCArguments returns updateArgs, deleteArgs that can be used with snaputil.UpdateServiceArguments() for the kube-apiserver
+// according to the OpenID Connect configuration.
+func (c APIServer) ToKubeAPIServerOIDCArguments(p APIServerPathsProvider) (map[string]string, []string) {
+	var (
+		updateArgs = make(map[string]string)
+		deleteArgs []string
+	)
+
+	// the CA certificate will be written by setup.EnsureOIDCPKI(), here we only set the path
+	var caFile string
+	if c.GetOIDCCACert() != "" {
+		caFile = filepath.Join(p.KubernetesPKIDir(), "oidc-ca.crt")
+	}
+
+	for _, loop := range []struct {
+		arg   string
+		value string
+	}{
+		{arg: "--oidc-issuer-url", value: c.GetOIDCIssuerURL()},
+		{arg: "--oidc-client-id", value: c.GetOIDCClientID()},
+		{arg: "--oidc-username-claim", value: c.GetOIDCUsernameClaim()},
+		{arg: "--oidc-username-prefix", value: c.GetOIDCUsernamePrefix()},
+		{arg: "--oidc-groups-claim", value: c.GetOIDCGroupsClaim()},
+		{arg: "--oidc-groups-prefix", value: c.GetOIDCGroupsPrefix()},
+		{arg: "--oidc-ca-file", value: caFile},
+	} {
+		if c.OIDCEnabled() && loop.value != "" {
+			updateArgs[loop.arg] = loop.value
+		} else {
+			deleteArgs = append(deleteArgs, loop.arg)
+		}
+	}
+
+	return updateArgs, deleteArgs
+}
+
+// ToKubeAPIServerAuditArguments returns updateArgs, deleteArgs that can be used with snaputil.UpdateServiceArguments() for the kube-apiserver
+// according to the audit logging configuration.
+func (c APIServer) ToKubeAPIServerAuditArguments(p APIServerPathsProvider) (map[string]string, []string) {
+	var (
+		updateArgs = make(map[string]string)
+		deleteArgs []string
+	)
+
+	// the policy and webhook configuration files will be written by setup.EnsureKubeAPIServerAuditConfig(), here we only set the paths
+	var policyFile, webhookConfigFile string
+	if c.AuditEnabled() {
+		policyFile = filepath.Join(p.ServiceExtraConfigDir(), "audit-policy.yaml")
+	}
+	if c.GetAuditWebhookConfig() != "" {
+		webhookConfigFile = filepath.Join(p.ServiceExtraConfigDir(), "audit-webhook.conf")
+	}
+
+	// log rotation settings only apply to the log backend
+	var maxAge, maxSize, maxBackups string
+	if c.GetAuditLogPath() != "" {
+		if c.AuditLogMaxAge != nil {
+			maxAge = strconv.Itoa(c.GetAuditLogMaxAge())
+		}
+		if c.AuditLogMaxSize != nil {
+			maxSize = strconv.Itoa(c.GetAuditLogMaxSize())
+		}
+		if c.AuditLogMaxBackups != nil {
+			maxBackups = strconv.Itoa(c.GetAuditLogMaxBackups())
+		}
+	}
+
+	for _, loop := range []struct {
+		arg   string
+		value string
+	}{
+		{arg: "--audit-policy-file", value: policyFile},
+		{arg: "--audit-log-path", value: c.GetAuditLogPath()},
+		{arg: "--audit-log-maxage", value: maxAge},
+		{arg: "--audit-log-maxsize", value: maxSize},
+		{arg: "--audit-log-maxbackup", value: maxBackups},
+		{arg: "--audit-webhook-config-file", value: webhookConfigFile},
+	} {
+		if c.AuditEnabled() && loop.value != "" {
+			updateArgs[loop.arg] = loop.value
+		} else {
+			deleteArgs = append(deleteArgs, loop.arg)
+		}
+	}
+
+	return updateArgs, deleteArgs
+}
+
+// apiServerFromAnnotations sets the OpenID Connect, audit logging, secrets encryption and admission configuration of the
+// kube-apiserver from the cluster annotations.
+func apiServerFromAnnotations(c *APIServer, annotations Annotations) error {
+	for _, loop := range []struct {
+		annotation string
+		val        **string
+	}{
+		{annotation: AnnotationOIDCIssuerURL, val: &c.OIDCIssuerURL},
+		{annotation: AnnotationOIDCClientID, val: &c.OIDCClientID},
+		{annotation: AnnotationOIDCUsernameClaim, val: &c.OIDCUsernameClaim},
+		{annotation: AnnotationOIDCUsernamePrefix, val: &c.OIDCUsernamePrefix},
+		{annotation: AnnotationOIDCGroupsClaim, val: &c.OIDCGroupsClaim},
+		{annotation: AnnotationOIDCGroupsPrefix, val: &c.OIDCGroupsPrefix},
+		{annotation: AnnotationOIDCCACert, val: &c.OIDCCACert},
+		{annotation: AnnotationAuditPolicy, val: &c.AuditPolicy},
+		{annotation: AnnotationAuditLogPath, val: &c.AuditLogPath},
+		{annotation: AnnotationAuditWebhookConfig, val: &c.AuditWebhookConfig},
+		{annotation: AnnotationSecretsEncryptionProvider, val: &c.SecretsEncryptionProvider},
+		{annotation: AnnotationSecretsEncryptionKMSEndpoint, val: &c.SecretsEncryptionKMSEndpoint},
+		{annotation: AnnotationAdmissionPlugins, val: &c.AdmissionPlugins},
+		{annotation: AnnotationPodSecurityEnforce, val: &c.PodSecurityEnforce},
+		{annotation: AnnotationPodSecurityAudit, val: &c.PodSecurityAudit},
+		{annotation: AnnotationPodSecurityWarn, val: &c.PodSecurityWarn},
+		{annotation: AnnotationPodSecurityExemptNamespaces, val: &c.PodSecurityExemptNamespaces},
+		{annotation: AnnotationAdmissionPolicies, val: &c.AdmissionPolicies},
+	} {
+		// "-" is used to remove an annotation
+		if v, ok := annotations.Get(loop.annotation); ok && v != "-" {
+			*loop.val = &v
+		} else {
+			*loop.val = nil
+		}
+	}
+
+	for _, loop := range []struct {
+		annotation string
+		val        **int
+	}{
+		{annotation: AnnotationAuditLogMaxAge, val: &c.AuditLogMaxAge},
+		{annotation: AnnotationAuditLogMaxSize, val: &c.AuditLogMaxSize},
+		{annotation: AnnotationAuditLogMaxBackups, val: &c.AuditLogMaxBackups},
+	} {
+		v, ok := annotations.Get(loop.annotation)
+		if !ok || v == "-" {
+			*loop.val = nil
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", v, loop.annotation, err)
+		}
+		*loop.val = &n
+	}
+
+	if v, ok := annotations.Get(AnnotationHardeningProfile); ok && v == HardeningProfileCIS {
+		applyCISAPIServerDefaults(c)
+	}
+
+	return nil
+}
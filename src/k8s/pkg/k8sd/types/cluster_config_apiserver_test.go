@@ -0,0 +1,216 @@
+package types_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestAPIServerToKubeAPIServerOIDCArguments(t *testing.T) {
+	snap := &mock.Snap{
+		Mock: mock.Mock{
+			KubernetesPKIDir: "/pki/kubernetes",
+		},
+	}
+
+	allArgs := []string{"--oidc-issuer-url", "--oidc-client-id", "--oidc-username-claim", "--oidc-username-prefix", "--oidc-groups-claim", "--oidc-groups-prefix", "--oidc-ca-file"}
+
+	for _, tc := range []struct {
+		name             string
+		config           types.APIServer
+		expectUpdateArgs map[string]string
+		expectDeleteArgs []string
+	}{
+		{
+			name:             "Disabled",
+			expectUpdateArgs: map[string]string{},
+			expectDeleteArgs: allArgs,
+		},
+		{
+			name: "Minimal",
+			config: types.APIServer{
+				OIDCIssuerURL: utils.Pointer("https://issuer.example.com"),
+				OIDCClientID:  utils.Pointer("k8s"),
+			},
+			expectUpdateArgs: map[string]string{
+				"--oidc-issuer-url": "https://issuer.example.com",
+				"--oidc-client-id":  "k8s",
+			},
+			expectDeleteArgs: []string{"--oidc-username-claim", "--oidc-username-prefix", "--oidc-groups-claim", "--oidc-groups-prefix", "--oidc-ca-file"},
+		},
+		{
+			name: "Full",
+			config: types.APIServer{
+				OIDCIssuerURL:      utils.Pointer("https://issuer.example.com"),
+				OIDCClientID:       utils.Pointer("k8s"),
+				OIDCUsernameClaim:  utils.Pointer("email"),
+				OIDCUsernamePrefix: utils.Pointer("oidc:"),
+				OIDCGroupsClaim:    utils.Pointer("groups"),
+				OIDCGroupsPrefix:   utils.Pointer("oidc:"),
+				OIDCCACert:         utils.Pointer("CA DATA"),
+			},
+			expectUpdateArgs: map[string]string{
+				"--oidc-issuer-url":      "https://issuer.example.com",
+				"--oidc-client-id":       "k8s",
+				"--oidc-username-claim":  "email",
+				"--oidc-username-prefix": "oidc:",
+				"--oidc-groups-claim":    "groups",
+				"--oidc-groups-prefix":   "oidc:",
+				"--oidc-ca-file":         "/pki/kubernetes/oidc-ca.crt",
+			},
+		},
+		{
+			name: "IgnoredWithoutIssuer",
+			config: types.APIServer{
+				OIDCClientID: utils.Pointer("k8s"),
+			},
+			expectUpdateArgs: map[string]string{},
+			expectDeleteArgs: allArgs,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			update, delete := tc.config.ToKubeAPIServerOIDCArguments(snap)
+			g.Expect(update).To(Equal(tc.expectUpdateArgs))
+			g.Expect(delete).To(Equal(tc.expectDeleteArgs))
+		})
+	}
+}
+
+func TestAPIServerToKubeAPIServerAuditArguments(t *testing.T) {
+	snap := &mock.Snap{
+		Mock: mock.Mock{
+			ServiceExtraConfigDir: "/args/conf.d",
+		},
+	}
+
+	allArgs := []string{"--audit-policy-file", "--audit-log-path", "--audit-log-maxage", "--audit-log-maxsize", "--audit-log-maxbackup", "--audit-webhook-config-file"}
+
+	for _, tc := range []struct {
+		name             string
+		config           types.APIServer
+		expectUpdateArgs map[string]string
+		expectDeleteArgs []string
+	}{
+		{
+			name:             "Disabled",
+			expectUpdateArgs: map[string]string{},
+			expectDeleteArgs: allArgs,
+		},
+		{
+			name: "LogBackend",
+			config: types.APIServer{
+				AuditPolicy:        utils.Pointer("kind: Policy"),
+				AuditLogPath:       utils.Pointer("/var/log/kubernetes/audit.log"),
+				AuditLogMaxAge:     utils.Pointer(30),
+				AuditLogMaxSize:    utils.Pointer(100),
+				AuditLogMaxBackups: utils.Pointer(0),
+			},
+			expectUpdateArgs: map[string]string{
+				"--audit-policy-file":   "/args/conf.d/audit-policy.yaml",
+				"--audit-log-path":      "/var/log/kubernetes/audit.log",
+				"--audit-log-maxage":    "30",
+				"--audit-log-maxsize":   "100",
+				"--audit-log-maxbackup": "0",
+			},
+			expectDeleteArgs: []string{"--audit-webhook-config-file"},
+		},
+		{
+			name: "WebhookBackend",
+			config: types.APIServer{
+				AuditPolicy:        utils.Pointer("kind: Policy"),
+				AuditLogMaxAge:     utils.Pointer(30),
+				AuditWebhookConfig: utils.Pointer("kind: Config"),
+			},
+			expectUpdateArgs: map[string]string{
+				"--audit-policy-file":         "/args/conf.d/audit-policy.yaml",
+				"--audit-webhook-config-file": "/args/conf.d/audit-webhook.conf",
+			},
+			expectDeleteArgs: []string{"--audit-log-path", "--audit-log-maxage", "--audit-log-maxsize", "--audit-log-maxbackup"},
+		},
+		{
+			name: "IgnoredWithoutPolicy",
+			config: types.APIServer{
+				AuditLogPath: utils.Pointer("/var/log/kubernetes/audit.log"),
+			},
+			expectUpdateArgs: map[string]string{},
+			expectDeleteArgs: allArgs,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			update, delete := tc.config.ToKubeAPIServerAuditArguments(snap)
+			g.Expect(update).To(Equal(tc.expectUpdateArgs))
+			g.Expect(delete).To(Equal(tc.expectDeleteArgs))
+		})
+	}
+}
+
+func TestAPIServerOIDCFromAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+		Annotations: map[string]string{
+			types.AnnotationOIDCIssuerURL:   "https://issuer.example.com",
+			types.AnnotationOIDCClientID:    "k8s",
+			types.AnnotationOIDCGroupsClaim: "-",
+		},
+	})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(config.APIServer.GetOIDCIssuerURL()).To(Equal("https://issuer.example.com"))
+	g.Expect(config.APIServer.GetOIDCClientID()).To(Equal("k8s"))
+	g.Expect(config.APIServer.OIDCGroupsClaim).To(BeNil())
+	g.Expect(config.APIServer.OIDCEnabled()).To(BeTrue())
+}
+
+func TestAPIServerAuditFromAnnotations(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+			Annotations: map[string]string{
+				types.AnnotationAuditPolicy:        "kind: Policy",
+				types.AnnotationAuditLogPath:       "/var/log/kubernetes/audit.log",
+				types.AnnotationAuditLogMaxAge:     "30",
+				types.AnnotationAuditLogMaxBackups: "-",
+			},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(config.APIServer.AuditEnabled()).To(BeTrue())
+		g.Expect(config.APIServer.GetAuditLogPath()).To(Equal("/var/log/kubernetes/audit.log"))
+		g.Expect(config.APIServer.AuditLogMaxAge).To(Equal(utils.Pointer(30)))
+		g.Expect(config.APIServer.AuditLogMaxBackups).To(BeNil())
+	})
+
+	t.Run("InvalidNumber", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+			Annotations: map[string]string{
+				types.AnnotationAuditLogMaxSize: "100M",
+			},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestAPIServerSecretsEncryptionFromAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+		Annotations: map[string]string{
+			types.AnnotationSecretsEncryptionProvider:    "kms",
+			types.AnnotationSecretsEncryptionKMSEndpoint: "/run/kms/kms.sock",
+		},
+	})
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(config.APIServer.GetSecretsEncryptionProvider()).To(Equal("kms"))
+	g.Expect(config.APIServer.GetSecretsEncryptionKMSEndpoint()).To(Equal("/run/kms/kms.sock"))
+	g.Expect(config.SecretsEncryptionEnabled()).To(BeTrue())
+}
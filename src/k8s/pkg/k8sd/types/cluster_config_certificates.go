@@ -14,6 +14,10 @@ type Certificates struct {
 	AdminClientKey             *string `json:"admin-client-key,omitempty"`
 	K8sdPublicKey              *string `json:"k8sd-public-key,omitempty"`
 	K8sdPrivateKey             *string `json:"k8sd-private-key,omitempty"`
+
+	// SecretsEncryptionKeys are the keys used to encrypt Secrets at rest. The first key is used for encryption,
+	// all keys are used for decryption.
+	SecretsEncryptionKeys *[]SecretsEncryptionKey `json:"secrets-encryption-keys,omitempty"`
 }
 
 func (c Certificates) GetCACert() string { return getField(c.CACert) }
@@ -47,6 +51,9 @@ func (c Certificates) GetAdminClientCert() string { return getField(c.AdminClien
 func (c Certificates) GetAdminClientKey() string  { return getField(c.AdminClientKey) }
 func (c Certificates) GetK8sdPublicKey() string   { return getField(c.K8sdPublicKey) }
 func (c Certificates) GetK8sdPrivateKey() string  { return getField(c.K8sdPrivateKey) }
+func (c Certificates) GetSecretsEncryptionKeys() []SecretsEncryptionKey {
+	return getField(c.SecretsEncryptionKeys)
+}
 
 // Empty returns true if all Certificates fields are unset.
 func (c Certificates) Empty() bool { return c == Certificates{} }
@@ -80,7 +80,7 @@ func ClusterConfigFromUserFacing(u apiv1.UserFacingClusterConfig) (ClusterConfig
 		return ClusterConfig{}, fmt.Errorf("invalid load-balancer.cidrs: %w", err)
 	}
 
-	return ClusterConfig{
+	config := ClusterConfig{
 		Annotations: Annotations(u.Annotations),
 		Kubelet: Kubelet{
 			ClusterDNS:    u.DNS.ServiceIP,
@@ -123,7 +123,19 @@ func ClusterConfigFromUserFacing(u apiv1.UserFacingClusterConfig) (ClusterConfig
 		Gateway: Gateway{
 			Enabled: u.Gateway.Enabled,
 		},
-	}, nil
+	}
+
+	if err := apiServerFromAnnotations(&config.APIServer, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse kube-apiserver annotations: %w", err)
+	}
+	if err := kubeletFromAnnotations(&config.Kubelet, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse kubelet annotations: %w", err)
+	}
+	if err := gatewayFromAnnotations(&config.Gateway, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse gateway annotations: %w", err)
+	}
+
+	return config, nil
 }
 
 // ToUserFacing converts a ClusterConfig to a UserFacingClusterConfig from the public API.
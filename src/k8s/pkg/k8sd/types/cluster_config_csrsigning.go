@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AnnotationCSRSigningApprovalAllowedUsages is a comma-separated list of key usages that auto-approved CSRs may request,
+	// e.g. "digital signature,key encipherment,server auth". If not set, the usages are not restricted further.
+	AnnotationCSRSigningApprovalAllowedUsages = "k8sd/v1alpha1/csrsigning/approval-allowed-usages"
+	// AnnotationCSRSigningApprovalAllowedDNSNames is a comma-separated list of glob patterns, e.g. "*.example.com".
+	// Auto-approved CSRs may only request DNS names that match one of the patterns.
+	AnnotationCSRSigningApprovalAllowedDNSNames = "k8sd/v1alpha1/csrsigning/approval-allowed-dns-names"
+	// AnnotationCSRSigningApprovalAllowedIPRanges is a comma-separated list of CIDRs, e.g. "10.0.0.0/8".
+	// Auto-approved CSRs may only request IP addresses within one of the ranges.
+	AnnotationCSRSigningApprovalAllowedIPRanges = "k8sd/v1alpha1/csrsigning/approval-allowed-ip-ranges"
+	// AnnotationCSRSigningApprovalMatchNodeAddresses requires the DNS names and IP addresses of auto-approved CSRs
+	// to match the addresses of the Node object of the requesting node, if set to "true".
+	AnnotationCSRSigningApprovalMatchNodeAddresses = "k8sd/v1alpha1/csrsigning/approval-match-node-addresses"
+	// AnnotationCSRSigningApprovalRateLimit is the max number of CSRs that are auto-approved for each node in a period,
+	// in the "<count>/<period>" format, e.g. "5/1h". CSRs above the limit wait until the period allows them.
+	AnnotationCSRSigningApprovalRateLimit = "k8sd/v1alpha1/csrsigning/approval-rate-limit"
+	// AnnotationCSRSigningMaxValidity is the max validity of signed certificates, e.g. "8760h".
+	// Certificates requesting a longer (or no) expiration are signed with the max validity instead.
+	AnnotationCSRSigningMaxValidity = "k8sd/v1alpha1/csrsigning/max-validity"
+)
+
+// CSRSigningConfig is the configuration of the approval and signing of CSRs by k8sd, set through the csrsigning annotations.
+// Empty fields do not restrict the CSRs.
+type CSRSigningConfig struct {
+	ApprovalAllowedUsages      []string
+	ApprovalAllowedDNSNames    []string
+	ApprovalAllowedIPRanges    []*net.IPNet
+	ApprovalMatchNodeAddresses bool
+	ApprovalRateLimit          int
+	ApprovalRateLimitPeriod    time.Duration
+	MaxValidity                time.Duration
+}
+
+// CSRSigningConfig parses the csrsigning annotations of the cluster.
+func (c ClusterConfig) CSRSigningConfig() (CSRSigningConfig, error) {
+	var cfg CSRSigningConfig
+
+	// "-" is used to remove an annotation
+	get := func(annotation string) (string, bool) {
+		if v, ok := c.Annotations.Get(annotation); ok && v != "-" {
+			return v, true
+		}
+		return "", false
+	}
+
+	if v, ok := get(AnnotationCSRSigningApprovalAllowedUsages); ok {
+		cfg.ApprovalAllowedUsages = splitList(v)
+	}
+	if v, ok := get(AnnotationCSRSigningApprovalAllowedDNSNames); ok {
+		cfg.ApprovalAllowedDNSNames = splitList(v)
+	}
+	if v, ok := get(AnnotationCSRSigningApprovalAllowedIPRanges); ok {
+		for _, cidr := range splitList(v) {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return CSRSigningConfig{}, fmt.Errorf("invalid %s: %w", AnnotationCSRSigningApprovalAllowedIPRanges, err)
+			}
+			cfg.ApprovalAllowedIPRanges = append(cfg.ApprovalAllowedIPRanges, ipNet)
+		}
+	}
+	if v, ok := get(AnnotationCSRSigningApprovalMatchNodeAddresses); ok && v == "true" {
+		cfg.ApprovalMatchNodeAddresses = true
+	}
+	if v, ok := get(AnnotationCSRSigningApprovalRateLimit); ok {
+		count, period, found := strings.Cut(v, "/")
+		if !found {
+			return CSRSigningConfig{}, fmt.Errorf("invalid %s %q: must be in the <count>/<period> format", AnnotationCSRSigningApprovalRateLimit, v)
+		}
+		limit, err := strconv.Atoi(count)
+		if err != nil || limit <= 0 {
+			return CSRSigningConfig{}, fmt.Errorf("invalid %s %q: count must be a positive integer", AnnotationCSRSigningApprovalRateLimit, v)
+		}
+		d, err := time.ParseDuration(period)
+		if err != nil || d <= 0 {
+			return CSRSigningConfig{}, fmt.Errorf("invalid %s %q: period must be a positive duration", AnnotationCSRSigningApprovalRateLimit, v)
+		}
+		cfg.ApprovalRateLimit = limit
+		cfg.ApprovalRateLimitPeriod = d
+	}
+	if v, ok := get(AnnotationCSRSigningMaxValidity); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return CSRSigningConfig{}, fmt.Errorf("invalid %s %q: must be a positive duration", AnnotationCSRSigningMaxValidity, v)
+		}
+		cfg.MaxValidity = d
+	}
+	return cfg, nil
+}
+
+// validateCSRSigning checks the csrsigning annotations of the cluster.
+func validateCSRSigning(c ClusterConfig) error {
+	_, err := c.CSRSigningConfig()
+	return err
+}
@@ -0,0 +1,57 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestCSRSigningConfig(t *testing.T) {
+	t.Run("Full", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{Annotations: types.Annotations{
+			types.AnnotationCSRSigningApprovalAllowedUsages:      "server auth, digital signature,,",
+			types.AnnotationCSRSigningApprovalAllowedDNSNames:    "*.example.com",
+			types.AnnotationCSRSigningApprovalAllowedIPRanges:    "10.0.0.0/8,fd00::/64",
+			types.AnnotationCSRSigningApprovalMatchNodeAddresses: "true",
+			types.AnnotationCSRSigningApprovalRateLimit:          "5/1h",
+			types.AnnotationCSRSigningMaxValidity:                "-",
+		}}
+		cfg, err := config.CSRSigningConfig()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cfg.ApprovalAllowedUsages).To(Equal([]string{"server auth", "digital signature"}))
+		g.Expect(cfg.ApprovalAllowedDNSNames).To(Equal([]string{"*.example.com"}))
+		g.Expect(cfg.ApprovalAllowedIPRanges).To(HaveLen(2))
+		g.Expect(cfg.ApprovalMatchNodeAddresses).To(BeTrue())
+		g.Expect(cfg.ApprovalRateLimit).To(Equal(5))
+		g.Expect(cfg.ApprovalRateLimitPeriod).To(Equal(time.Hour))
+		g.Expect(cfg.MaxValidity).To(BeZero())
+	})
+
+	for _, tc := range []struct {
+		name        string
+		annotations types.Annotations
+		expectErr   bool
+	}{
+		{name: "Empty"},
+		{name: "Valid", annotations: types.Annotations{types.AnnotationCSRSigningMaxValidity: "8760h"}},
+		{name: "InvalidIPRange", annotations: types.Annotations{types.AnnotationCSRSigningApprovalAllowedIPRanges: "10.0.0.1"}, expectErr: true},
+		{name: "InvalidRateLimit", annotations: types.Annotations{types.AnnotationCSRSigningApprovalRateLimit: "0/1h"}, expectErr: true},
+		{name: "InvalidMaxValidity", annotations: types.Annotations{types.AnnotationCSRSigningMaxValidity: "-1h"}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := types.ClusterConfig{Annotations: tc.annotations}
+			config.SetDefaults()
+			if tc.expectErr {
+				g.Expect(config.Validate()).ToNot(Succeed())
+			} else {
+				g.Expect(config.Validate()).To(Succeed())
+			}
+		})
+	}
+}
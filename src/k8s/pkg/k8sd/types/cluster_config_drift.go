@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// AnnotationFeatureDriftPolicy is what k8sd does when the objects of a built-in feature were modified or
+	// deleted on the cluster, one of "warn", "repair" or "ignore". Defaults to "warn".
+	AnnotationFeatureDriftPolicy = "k8sd/v1alpha1/features/drift-policy"
+
+	// FeatureDriftPolicyWarn reports drifted objects in the status of the feature.
+	FeatureDriftPolicyWarn = "warn"
+	// FeatureDriftPolicyRepair reports drifted objects in the status of the feature and re-applies the feature.
+	FeatureDriftPolicyRepair = "repair"
+	// FeatureDriftPolicyIgnore disables drift detection.
+	FeatureDriftPolicyIgnore = "ignore"
+)
+
+// FeatureDriftPolicies are the supported drift policies of the built-in features.
+var FeatureDriftPolicies = []string{FeatureDriftPolicyWarn, FeatureDriftPolicyRepair, FeatureDriftPolicyIgnore}
+
+// FeatureDriftPolicy returns the drift policy of the built-in features.
+func (c ClusterConfig) FeatureDriftPolicy() string {
+	// "-" is used to remove an annotation
+	if v, ok := c.Annotations.Get(AnnotationFeatureDriftPolicy); ok && v != "-" && v != "" {
+		return v
+	}
+	return FeatureDriftPolicyWarn
+}
+
+// validateFeatureDriftPolicy checks the drift policy of the built-in features.
+func validateFeatureDriftPolicy(c ClusterConfig) error {
+	if v := c.FeatureDriftPolicy(); !slices.Contains(FeatureDriftPolicies, v) {
+		return fmt.Errorf("%s must be one of %v, not %q", AnnotationFeatureDriftPolicy, FeatureDriftPolicies, v)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestFeatureDriftPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations types.Annotations
+		expected    string
+		expectErr   bool
+	}{
+		{name: "Default", expected: "warn"},
+		{name: "Removed", annotations: types.Annotations{types.AnnotationFeatureDriftPolicy: "-"}, expected: "warn"},
+		{name: "Repair", annotations: types.Annotations{types.AnnotationFeatureDriftPolicy: "repair"}, expected: "repair"},
+		{name: "Ignore", annotations: types.Annotations{types.AnnotationFeatureDriftPolicy: "ignore"}, expected: "ignore"},
+		{name: "Invalid", annotations: types.Annotations{types.AnnotationFeatureDriftPolicy: "revert"}, expected: "revert", expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := types.ClusterConfig{Annotations: tc.annotations}
+			g.Expect(config.FeatureDriftPolicy()).To(Equal(tc.expected))
+
+			config.SetDefaults()
+			if tc.expectErr {
+				g.Expect(config.Validate()).ToNot(Succeed())
+			} else {
+				g.Expect(config.Validate()).To(Succeed())
+			}
+		})
+	}
+}
@@ -31,6 +31,15 @@ type LoadBalancer_IPRange struct {
 
 type Gateway struct {
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// The fields below are set from the k8sd/v1alpha1/gateway/* annotations, see cluster_config_gateway.go.
+	CRDChannel             *string `json:"crd-channel,omitempty"`
+	Managed                *bool   `json:"managed,omitempty"`
+	Listeners              *string `json:"listeners,omitempty"`
+	Hostnames              *string `json:"hostnames,omitempty"`
+	TLSSecret              *string `json:"tls-secret,omitempty"`
+	AllowedRouteNamespaces *string `json:"allowed-route-namespaces,omitempty"`
+	LoadBalancerIP         *string `json:"load-balancer-ip,omitempty"`
 }
 
 type MetricsServer struct {
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// AnnotationGatewayPrefix is the common prefix of the annotations that configure the Gateway API feature.
+	AnnotationGatewayPrefix = "k8sd/v1alpha1/gateway/"
+	// AnnotationGatewayCRDChannel is the release channel of the Gateway API CRDs, one of "standard" or "experimental".
+	// The experimental channel adds the TLSRoute CRD. Defaults to "experimental".
+	AnnotationGatewayCRDChannel = "k8sd/v1alpha1/gateway/crd-channel"
+	// AnnotationGatewayManaged enables the managed Gateway, a Gateway of the ck-gateway GatewayClass that is reconciled by k8sd.
+	AnnotationGatewayManaged = "k8sd/v1alpha1/gateway/managed"
+	// AnnotationGatewayListeners is the comma-separated list of PROTOCOL:PORT listeners of the managed Gateway, where
+	// PROTOCOL is one of "HTTP", "HTTPS" or "TLS". Defaults to "HTTP:80", and "HTTP:80,HTTPS:443" if a TLS secret is set.
+	AnnotationGatewayListeners = "k8sd/v1alpha1/gateway/listeners"
+	// AnnotationGatewayHostnames is the comma-separated list of hostnames that the listeners of the managed Gateway match.
+	// All hostnames are matched if empty.
+	AnnotationGatewayHostnames = "k8sd/v1alpha1/gateway/hostnames"
+	// AnnotationGatewayTLSSecret is the name of the Secret in the kube-system namespace with the TLS certificate
+	// that HTTPS listeners of the managed Gateway terminate TLS with.
+	AnnotationGatewayTLSSecret = "k8sd/v1alpha1/gateway/tls-secret"
+	// AnnotationGatewayAllowedRouteNamespaces is the namespaces from which routes may attach to the managed Gateway.
+	// One of "All", "Same" or a comma-separated list of namespaces. Defaults to "All".
+	AnnotationGatewayAllowedRouteNamespaces = "k8sd/v1alpha1/gateway/allowed-route-namespaces"
+	// AnnotationGatewayLoadBalancerIP is the static IP address requested for the load balancer service of the managed Gateway.
+	AnnotationGatewayLoadBalancerIP = "k8sd/v1alpha1/gateway/load-balancer-ip"
+
+	// GatewayCRDChannelStandard is the standard release channel of the Gateway API CRDs.
+	GatewayCRDChannelStandard = "standard"
+	// GatewayCRDChannelExperimental is the experimental release channel of the Gateway API CRDs.
+	GatewayCRDChannelExperimental = "experimental"
+
+	// ManagedGatewayName is the name of the managed Gateway.
+	ManagedGatewayName = "ck-gateway"
+	// ManagedGatewayNamespace is the namespace of the managed Gateway.
+	ManagedGatewayNamespace = "kube-system"
+)
+
+// GatewayListenerProtocols are the protocols supported by the listeners of the managed Gateway.
+var GatewayListenerProtocols = []string{"HTTP", "HTTPS", "TLS"}
+
+// GatewayListener is a listener of the managed Gateway.
+type GatewayListener struct {
+	Protocol string
+	Port     int
+}
+
+func (c Gateway) GetCRDChannel() string             { return getField(c.CRDChannel) }
+func (c Gateway) GetManaged() bool                  { return getField(c.Managed) }
+func (c Gateway) GetListeners() string              { return getField(c.Listeners) }
+func (c Gateway) GetHostnames() string              { return getField(c.Hostnames) }
+func (c Gateway) GetTLSSecret() string              { return getField(c.TLSSecret) }
+func (c Gateway) GetAllowedRouteNamespaces() string { return getField(c.AllowedRouteNamespaces) }
+func (c Gateway) GetLoadBalancerIP() string         { return getField(c.LoadBalancerIP) }
+
+// ExperimentalCRDs returns true if the experimental channel of the Gateway API CRDs is used.
+func (c Gateway) ExperimentalCRDs() bool {
+	return c.GetCRDChannel() != GatewayCRDChannelStandard
+}
+
+// ManagedListeners returns the listeners of the managed Gateway.
+func (c Gateway) ManagedListeners() ([]GatewayListener, error) {
+	listeners := splitList(c.GetListeners())
+	if len(listeners) == 0 {
+		listeners = []string{"HTTP:80"}
+		if c.GetTLSSecret() != "" {
+			listeners = append(listeners, "HTTPS:443")
+		}
+	}
+
+	result := make([]GatewayListener, 0, len(listeners))
+	for _, listener := range listeners {
+		protocol, portStr, ok := strings.Cut(listener, ":")
+		if !ok {
+			return nil, fmt.Errorf("listener %q is not in PROTOCOL:PORT format", listener)
+		}
+		protocol = strings.ToUpper(protocol)
+		if !slices.Contains(GatewayListenerProtocols, protocol) {
+			return nil, fmt.Errorf("listener %q has unsupported protocol, must be one of %v", listener, GatewayListenerProtocols)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("listener %q has an invalid port", listener)
+		}
+		result = append(result, GatewayListener{Protocol: protocol, Port: port})
+	}
+	return result, nil
+}
+
+// ManagedHostnames returns the hostnames that the listeners of the managed Gateway match.
+func (c Gateway) ManagedHostnames() []string {
+	return splitList(c.GetHostnames())
+}
+
+// ManagedAllowedRoutes returns from which namespaces routes may attach to the managed Gateway, one of "All", "Same"
+// or "Selector". For "Selector", the allowed namespaces are also returned.
+func (c Gateway) ManagedAllowedRoutes() (string, []string) {
+	switch v := c.GetAllowedRouteNamespaces(); v {
+	case "", "All":
+		return "All", nil
+	case "Same":
+		return "Same", nil
+	default:
+		return "Selector", splitList(v)
+	}
+}
+
+// gatewayFromAnnotations sets the Gateway API CRD channel and the managed Gateway configuration from the cluster annotations.
+func gatewayFromAnnotations(c *Gateway, annotations Annotations) error {
+	for _, loop := range []struct {
+		annotation string
+		val        **string
+	}{
+		{annotation: AnnotationGatewayCRDChannel, val: &c.CRDChannel},
+		{annotation: AnnotationGatewayListeners, val: &c.Listeners},
+		{annotation: AnnotationGatewayHostnames, val: &c.Hostnames},
+		{annotation: AnnotationGatewayTLSSecret, val: &c.TLSSecret},
+		{annotation: AnnotationGatewayAllowedRouteNamespaces, val: &c.AllowedRouteNamespaces},
+		{annotation: AnnotationGatewayLoadBalancerIP, val: &c.LoadBalancerIP},
+	} {
+		// "-" is used to remove an annotation
+		if v, ok := annotations.Get(loop.annotation); ok && v != "-" {
+			*loop.val = &v
+		} else {
+			*loop.val = nil
+		}
+	}
+
+	c.Managed = nil
+	if v, ok := annotations.Get(AnnotationGatewayManaged); ok && v != "-" {
+		managed, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", v, AnnotationGatewayManaged, err)
+		}
+		c.Managed = &managed
+	}
+
+	return nil
+}
+
+// validateGateway checks the Gateway API CRD channel and the managed Gateway configuration.
+func validateGateway(c Gateway) error {
+	switch c.GetCRDChannel() {
+	case "", GatewayCRDChannelStandard, GatewayCRDChannelExperimental:
+	default:
+		return fmt.Errorf("%s must be one of %s, %s", AnnotationGatewayCRDChannel, GatewayCRDChannelStandard, GatewayCRDChannelExperimental)
+	}
+
+	if !c.GetManaged() {
+		if c.Listeners != nil || c.Hostnames != nil || c.TLSSecret != nil || c.AllowedRouteNamespaces != nil || c.LoadBalancerIP != nil {
+			return fmt.Errorf("%s must be set to configure the managed gateway", AnnotationGatewayManaged)
+		}
+		return nil
+	}
+
+	listeners, err := c.ManagedListeners()
+	if err != nil {
+		return fmt.Errorf("%s is invalid: %w", AnnotationGatewayListeners, err)
+	}
+	ports := make(map[int]string, len(listeners))
+	for _, listener := range listeners {
+		if protocol, ok := ports[listener.Port]; ok && protocol != listener.Protocol {
+			return fmt.Errorf("%s uses port %d for both %s and %s", AnnotationGatewayListeners, listener.Port, protocol, listener.Protocol)
+		}
+		ports[listener.Port] = listener.Protocol
+
+		switch listener.Protocol {
+		case "HTTPS":
+			if c.GetTLSSecret() == "" {
+				return fmt.Errorf("%s must be set for HTTPS listeners", AnnotationGatewayTLSSecret)
+			}
+		case "TLS":
+			if !c.ExperimentalCRDs() {
+				return fmt.Errorf("TLS listeners require the %s channel of the Gateway API CRDs", GatewayCRDChannelExperimental)
+			}
+		}
+	}
+
+	for _, hostname := range c.ManagedHostnames() {
+		// wildcard hostnames match a single DNS label, e.g. "*.example.com"
+		if errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(hostname, "*.")); len(errs) > 0 {
+			return fmt.Errorf("%s contains an invalid hostname %q: %s", AnnotationGatewayHostnames, hostname, strings.Join(errs, ", "))
+		}
+	}
+
+	if v := c.GetTLSSecret(); v != "" {
+		if errs := validation.IsDNS1123Subdomain(v); len(errs) > 0 {
+			return fmt.Errorf("%s must be a valid Secret name: %s", AnnotationGatewayTLSSecret, strings.Join(errs, ", "))
+		}
+	}
+
+	if from, namespaces := c.ManagedAllowedRoutes(); from == "Selector" {
+		if len(namespaces) == 0 {
+			return fmt.Errorf("%s must not be empty", AnnotationGatewayAllowedRouteNamespaces)
+		}
+		for _, namespace := range namespaces {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				return fmt.Errorf("%s contains an invalid namespace %q: %s", AnnotationGatewayAllowedRouteNamespaces, namespace, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	if v := c.GetLoadBalancerIP(); v != "" && net.ParseIP(v) == nil {
+		return fmt.Errorf("%s must be a valid IP address", AnnotationGatewayLoadBalancerIP)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package types_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestGatewayManagedListeners(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		gateway   types.Gateway
+		expected  []types.GatewayListener
+		expectErr bool
+	}{
+		{
+			name:     "Default",
+			expected: []types.GatewayListener{{Protocol: "HTTP", Port: 80}},
+		},
+		{
+			name:     "DefaultWithTLSSecret",
+			gateway:  types.Gateway{TLSSecret: utils.Pointer("gateway-tls")},
+			expected: []types.GatewayListener{{Protocol: "HTTP", Port: 80}, {Protocol: "HTTPS", Port: 443}},
+		},
+		{
+			name:     "Custom",
+			gateway:  types.Gateway{Listeners: utils.Pointer("http:8080, TLS:8443")},
+			expected: []types.GatewayListener{{Protocol: "HTTP", Port: 8080}, {Protocol: "TLS", Port: 8443}},
+		},
+		{name: "MissingPort", gateway: types.Gateway{Listeners: utils.Pointer("HTTP")}, expectErr: true},
+		{name: "InvalidProtocol", gateway: types.Gateway{Listeners: utils.Pointer("UDP:53")}, expectErr: true},
+		{name: "InvalidPort", gateway: types.Gateway{Listeners: utils.Pointer("HTTP:0")}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			listeners, err := tc.gateway.ManagedListeners()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(listeners).To(Equal(tc.expected))
+			}
+		})
+	}
+}
+
+func TestGatewayFromAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+		Annotations: map[string]string{
+			types.AnnotationGatewayCRDChannel:             "standard",
+			types.AnnotationGatewayManaged:                "true",
+			types.AnnotationGatewayListeners:              "HTTP:80,HTTPS:443",
+			types.AnnotationGatewayHostnames:              "example.com",
+			types.AnnotationGatewayTLSSecret:              "gateway-tls",
+			types.AnnotationGatewayAllowedRouteNamespaces: "Same",
+			types.AnnotationGatewayLoadBalancerIP:         "-",
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Gateway.GetCRDChannel()).To(Equal("standard"))
+	g.Expect(config.Gateway.ExperimentalCRDs()).To(BeFalse())
+	g.Expect(config.Gateway.GetManaged()).To(BeTrue())
+	g.Expect(config.Gateway.ManagedHostnames()).To(Equal([]string{"example.com"}))
+	g.Expect(config.Gateway.GetTLSSecret()).To(Equal("gateway-tls"))
+	g.Expect(config.Gateway.LoadBalancerIP).To(BeNil())
+
+	from, namespaces := config.Gateway.ManagedAllowedRoutes()
+	g.Expect(from).To(Equal("Same"))
+	g.Expect(namespaces).To(BeEmpty())
+
+	t.Run("InvalidManaged", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+			Annotations: map[string]string{types.AnnotationGatewayManaged: "yes please"},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestValidateGateway(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{name: "Empty"},
+		{name: "Valid", annotations: map[string]string{
+			types.AnnotationGatewayManaged:                "true",
+			types.AnnotationGatewayListeners:              "HTTP:80,HTTPS:443,TLS:8443",
+			types.AnnotationGatewayHostnames:              "example.com,*.example.com",
+			types.AnnotationGatewayTLSSecret:              "gateway-tls",
+			types.AnnotationGatewayAllowedRouteNamespaces: "team-a,team-b",
+			types.AnnotationGatewayLoadBalancerIP:         "10.0.0.10",
+		}},
+		{name: "StandardChannel", annotations: map[string]string{types.AnnotationGatewayCRDChannel: "standard"}},
+		{name: "InvalidChannel", annotations: map[string]string{types.AnnotationGatewayCRDChannel: "beta"}, expectErr: true},
+		{name: "NotManaged", annotations: map[string]string{types.AnnotationGatewayHostnames: "example.com"}, expectErr: true},
+		{name: "HTTPSWithoutSecret", annotations: map[string]string{
+			types.AnnotationGatewayManaged:   "true",
+			types.AnnotationGatewayListeners: "HTTPS:443",
+		}, expectErr: true},
+		{name: "TLSOnStandardChannel", annotations: map[string]string{
+			types.AnnotationGatewayCRDChannel: "standard",
+			types.AnnotationGatewayManaged:    "true",
+			types.AnnotationGatewayListeners:  "TLS:443",
+		}, expectErr: true},
+		{name: "PortConflict", annotations: map[string]string{
+			types.AnnotationGatewayManaged:   "true",
+			types.AnnotationGatewayListeners: "HTTP:443,TLS:443",
+		}, expectErr: true},
+		{name: "InvalidHostname", annotations: map[string]string{
+			types.AnnotationGatewayManaged:   "true",
+			types.AnnotationGatewayHostnames: "Example_com",
+		}, expectErr: true},
+		{name: "InvalidNamespace", annotations: map[string]string{
+			types.AnnotationGatewayManaged:                "true",
+			types.AnnotationGatewayAllowedRouteNamespaces: "Team_A",
+		}, expectErr: true},
+		{name: "InvalidLoadBalancerIP", annotations: map[string]string{
+			types.AnnotationGatewayManaged:        "true",
+			types.AnnotationGatewayLoadBalancerIP: "10.0.0",
+		}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config, err := types.ClusterConfigFromBootstrapConfig(apiv1.BootstrapConfig{
+				ClusterConfig: apiv1.UserFacingClusterConfig{Annotations: tc.annotations},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			config.SetDefaults()
+
+			if tc.expectErr {
+				g.Expect(config.Validate()).ToNot(Succeed())
+			} else {
+				g.Expect(config.Validate()).To(Succeed())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// AnnotationHardeningProfile is the hardening profile of the cluster. The only supported profile is "cis", which
+	// applies the service arguments, admission plugins and file permissions required by the CIS Kubernetes benchmark
+	// when nodes are bootstrapped or joined. The hardening profile can not be changed or removed once set.
+	AnnotationHardeningProfile = "k8sd/v1alpha1/hardening-profile"
+
+	// HardeningProfileCIS is the hardening profile for the CIS Kubernetes benchmark.
+	HardeningProfileCIS = "cis"
+)
+
+// HardeningProfiles are the supported hardening profiles.
+var HardeningProfiles = []string{HardeningProfileCIS}
+
+const (
+	// cisAuditPolicy is the audit policy of the CIS hardening profile, used if no audit policy is configured.
+	// It logs the metadata of all requests, so that the request and response bodies (e.g. of Secrets) are not logged.
+	cisAuditPolicy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - RequestReceived
+rules:
+  - level: Metadata
+`
+	cisAuditLogPath       = "/var/log/kubernetes/audit.log"
+	cisAuditLogMaxAge     = 30
+	cisAuditLogMaxSize    = 100
+	cisAuditLogMaxBackups = 10
+
+	// cisSecretsEncryptionProvider is the provider used to encrypt Secrets at rest with the CIS hardening profile,
+	// if no provider is configured.
+	cisSecretsEncryptionProvider = "aescbc"
+
+	// cisAdmissionPlugins are the admission plugins enabled on the kube-apiserver with the CIS hardening profile,
+	// if no admission plugins are configured.
+	cisAdmissionPlugins = "NodeRestriction,EventRateLimit,AlwaysPullImages"
+)
+
+// HardeningProfile returns the hardening profile of the cluster, or an empty string if none is set.
+func (c ClusterConfig) HardeningProfile() string {
+	// "-" is used to remove an annotation
+	if v, ok := c.Annotations.Get(AnnotationHardeningProfile); ok && v != "-" {
+		return v
+	}
+	return ""
+}
+
+// applyCISAPIServerDefaults enables audit logging, secrets encryption and admission plugins on the kube-apiserver,
+// as required by the CIS hardening profile. Explicitly configured values are kept.
+func applyCISAPIServerDefaults(c *APIServer) {
+	for _, loop := range []struct {
+		val          **string
+		defaultValue string
+	}{
+		{val: &c.AuditPolicy, defaultValue: cisAuditPolicy},
+		{val: &c.AuditLogPath, defaultValue: cisAuditLogPath},
+		{val: &c.SecretsEncryptionProvider, defaultValue: cisSecretsEncryptionProvider},
+		{val: &c.AdmissionPlugins, defaultValue: cisAdmissionPlugins},
+	} {
+		if *loop.val == nil {
+			v := loop.defaultValue
+			*loop.val = &v
+		}
+	}
+
+	for _, loop := range []struct {
+		val          **int
+		defaultValue int
+	}{
+		{val: &c.AuditLogMaxAge, defaultValue: cisAuditLogMaxAge},
+		{val: &c.AuditLogMaxSize, defaultValue: cisAuditLogMaxSize},
+		{val: &c.AuditLogMaxBackups, defaultValue: cisAuditLogMaxBackups},
+	} {
+		if *loop.val == nil {
+			v := loop.defaultValue
+			*loop.val = &v
+		}
+	}
+}
+
+// validateHardeningProfile checks the hardening profile of the cluster.
+func validateHardeningProfile(c ClusterConfig) error {
+	if v := c.HardeningProfile(); v != "" && !slices.Contains(HardeningProfiles, v) {
+		return fmt.Errorf("%s must be one of %v, not %q", AnnotationHardeningProfile, HardeningProfiles, v)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestHardeningProfile(t *testing.T) {
+	t.Run("CISDefaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromBootstrapConfig(apiv1.BootstrapConfig{
+			ClusterConfig: apiv1.UserFacingClusterConfig{
+				Annotations: map[string]string{types.AnnotationHardeningProfile: "cis"},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.HardeningProfile()).To(Equal("cis"))
+		g.Expect(config.APIServer.AuditPolicy).ToNot(BeNil())
+		g.Expect(config.APIServer.AuditLogPath).To(Equal(utils.Pointer("/var/log/kubernetes/audit.log")))
+		g.Expect(config.APIServer.AuditLogMaxAge).To(Equal(utils.Pointer(30)))
+		g.Expect(config.APIServer.AuditLogMaxSize).To(Equal(utils.Pointer(100)))
+		g.Expect(config.APIServer.AuditLogMaxBackups).To(Equal(utils.Pointer(10)))
+		g.Expect(config.APIServer.SecretsEncryptionProvider).To(Equal(utils.Pointer("aescbc")))
+		g.Expect(config.APIServer.EnabledAdmissionPlugins()).To(Equal([]string{"NodeRestriction", "EventRateLimit", "AlwaysPullImages"}))
+
+		config.SetDefaults()
+		g.Expect(config.Validate()).To(Succeed())
+	})
+
+	t.Run("CISKeepsConfiguredValues", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromBootstrapConfig(apiv1.BootstrapConfig{
+			ClusterConfig: apiv1.UserFacingClusterConfig{
+				Annotations: map[string]string{
+					types.AnnotationHardeningProfile:          "cis",
+					types.AnnotationSecretsEncryptionProvider: "secretbox",
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.APIServer.SecretsEncryptionProvider).To(Equal(utils.Pointer("secretbox")))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromBootstrapConfig(apiv1.BootstrapConfig{
+			ClusterConfig: apiv1.UserFacingClusterConfig{
+				Annotations: map[string]string{types.AnnotationHardeningProfile: "stig"},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.APIServer.AuditPolicy).To(BeNil())
+
+		config.SetDefaults()
+		g.Expect(config.Validate()).ToNot(Succeed())
+	})
+
+	t.Run("MergePreventsChange", func(t *testing.T) {
+		existing := types.ClusterConfig{Annotations: types.Annotations{types.AnnotationHardeningProfile: "cis"}}
+		existing.SetDefaults()
+
+		for _, tc := range []struct {
+			name        string
+			annotations types.Annotations
+			expectErr   bool
+		}{
+			{name: "Unchanged", annotations: nil},
+			{name: "Same", annotations: types.Annotations{types.AnnotationHardeningProfile: "cis"}},
+			{name: "Remove", annotations: types.Annotations{types.AnnotationHardeningProfile: "-"}, expectErr: true},
+			{name: "Change", annotations: types.Annotations{types.AnnotationHardeningProfile: "other"}, expectErr: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				merged, err := types.MergeClusterConfig(existing, types.ClusterConfig{Annotations: tc.annotations})
+				if tc.expectErr {
+					g.Expect(err).To(HaveOccurred())
+				} else {
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(merged.HardeningProfile()).To(Equal("cis"))
+				}
+			})
+		}
+	})
+
+	t.Run("MergeSetsProfileOnBootstrap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{Annotations: types.Annotations{types.AnnotationHardeningProfile: "cis"}}
+		config.SetDefaults()
+
+		merged, err := types.MergeClusterConfig(types.ClusterConfig{}, config)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(merged.HardeningProfile()).To(Equal("cis"))
+		g.Expect(merged.APIServer.SecretsEncryptionProvider).To(Equal(utils.Pointer("aescbc")))
+	})
+
+	t.Run("MergePreventsProfileAfterBootstrap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		existing := types.ClusterConfig{}
+		existing.SetDefaults()
+
+		_, err := types.MergeClusterConfig(existing, types.ClusterConfig{Annotations: types.Annotations{types.AnnotationHardeningProfile: "cis"}})
+		g.Expect(err).To(MatchError(ContainSubstring("can only be set when the cluster is bootstrapped")))
+	})
+}
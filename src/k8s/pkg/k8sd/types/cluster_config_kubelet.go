@@ -8,20 +8,38 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
+// AnnotationKubeletConfig is a KubeletConfiguration document (kubelet.config.k8s.io/v1beta1) that is applied
+// on the kubelet of all nodes in the cluster. Only a subset of the KubeletConfiguration fields is supported,
+// see KubeletConfiguration.
+const AnnotationKubeletConfig = "k8sd/v1alpha1/kubelet/config"
+
+// AnnotationKubeletRotateServerCertificates disables the rotation of kubelet serving certificates, if set to "false".
+// By default, kubelets request their serving certificates with CSRs that are validated and signed by k8sd, if the
+// cluster CA key is available.
+const AnnotationKubeletRotateServerCertificates = "k8sd/v1alpha1/kubelet/rotate-server-certificates"
+
 type Kubelet struct {
 	CloudProvider      *string   `json:"cloud-provider,omitempty"`
 	ClusterDNS         *string   `json:"cluster-dns,omitempty"`
 	ClusterDomain      *string   `json:"cluster-domain,omitempty"`
 	ControlPlaneTaints *[]string `json:"control-plane-taints,omitempty"`
+	// Config is the normalized KubeletConfiguration that is applied on all nodes, see AnnotationKubeletConfig.
+	Config *string `json:"config,omitempty"`
+	// RotateServerCertificates is set when distributing the configuration to the nodes, see
+	// ClusterConfig.KubeletServerCertificateRotationEnabled.
+	RotateServerCertificates *bool `json:"rotate-server-certificates,omitempty"`
 }
 
-func (c Kubelet) GetCloudProvider() string        { return getField(c.CloudProvider) }
-func (c Kubelet) GetClusterDNS() string           { return getField(c.ClusterDNS) }
-func (c Kubelet) GetClusterDomain() string        { return getField(c.ClusterDomain) }
-func (c Kubelet) GetControlPlaneTaints() []string { return getField(c.ControlPlaneTaints) }
-func (c Kubelet) Empty() bool                     { return c == Kubelet{} }
+func (c Kubelet) GetCloudProvider() string          { return getField(c.CloudProvider) }
+func (c Kubelet) GetClusterDNS() string             { return getField(c.ClusterDNS) }
+func (c Kubelet) GetClusterDomain() string          { return getField(c.ClusterDomain) }
+func (c Kubelet) GetControlPlaneTaints() []string   { return getField(c.ControlPlaneTaints) }
+func (c Kubelet) GetConfig() string                 { return getField(c.Config) }
+func (c Kubelet) GetRotateServerCertificates() bool { return getField(c.RotateServerCertificates) }
+func (c Kubelet) Empty() bool                       { return c == Kubelet{} }
 
 // hash returns a sha256 sum from the Kubelet configuration.
 func (c Kubelet) hash() ([]byte, error) {
@@ -54,6 +72,12 @@ func (c Kubelet) ToConfigMap(key *rsa.PrivateKey) (map[string]string, error) {
 	if v := c.ClusterDomain; v != nil {
 		data["cluster-domain"] = *v
 	}
+	if v := c.Config; v != nil {
+		data["kubelet-config"] = *v
+	}
+	if v := c.RotateServerCertificates; v != nil {
+		data["rotate-server-certificates"] = strconv.FormatBool(*v)
+	}
 
 	if key != nil {
 		hash, err := c.hash()
@@ -88,6 +112,16 @@ func KubeletFromConfigMap(m map[string]string, key *rsa.PublicKey) (Kubelet, err
 	if v, ok := m["cluster-domain"]; ok {
 		c.ClusterDomain = &v
 	}
+	if v, ok := m["kubelet-config"]; ok {
+		c.Config = &v
+	}
+	if v, ok := m["rotate-server-certificates"]; ok {
+		rotate, err := strconv.ParseBool(v)
+		if err != nil {
+			return Kubelet{}, fmt.Errorf("invalid rotate-server-certificates %q: %w", v, err)
+		}
+		c.RotateServerCertificates = &rotate
+	}
 
 	if key != nil {
 		hash, err := c.hash()
@@ -105,3 +139,34 @@ func KubeletFromConfigMap(m map[string]string, key *rsa.PublicKey) (Kubelet, err
 
 	return c, nil
 }
+
+// kubeletFromAnnotations sets the cluster-wide kubelet configuration from the cluster annotations.
+// If the annotation is removed, the configuration is set to the empty string (instead of nil), so that
+// nodes remove the previously applied configuration.
+func kubeletFromAnnotations(c *Kubelet, annotations Annotations) error {
+	v, ok := annotations.Get(AnnotationKubeletConfig)
+	// "-" is used to remove an annotation
+	if !ok || v == "-" {
+		if c.Config != nil {
+			c.Config = new(string)
+		}
+		return nil
+	}
+
+	config, err := normalizeKubeletConfiguration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", AnnotationKubeletConfig, err)
+	}
+	c.Config = &config
+	return nil
+}
+
+// KubeletServerCertificateRotationEnabled returns true if kubelets must request their serving certificates with CSRs.
+// This requires the cluster CA key, so that k8sd can sign the certificates, and can be disabled with
+// AnnotationKubeletRotateServerCertificates.
+func (c ClusterConfig) KubeletServerCertificateRotationEnabled() bool {
+	if v, ok := c.Annotations.Get(AnnotationKubeletRotateServerCertificates); ok && v == "false" {
+		return false
+	}
+	return c.Certificates.GetCAKey() != ""
+}
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KubeletConfigurationAPIVersion is the apiVersion of the KubeletConfiguration documents rendered for the kubelet.
+	KubeletConfigurationAPIVersion = "kubelet.config.k8s.io/v1beta1"
+	// KubeletConfigurationKind is the kind of the KubeletConfiguration documents rendered for the kubelet.
+	KubeletConfigurationKind = "KubeletConfiguration"
+)
+
+// kubeletEvictionSignals are the eviction signals supported by the kubelet.
+var kubeletEvictionSignals = []string{
+	"memory.available",
+	"nodefs.available",
+	"nodefs.inodesFree",
+	"imagefs.available",
+	"imagefs.inodesFree",
+	"containerfs.available",
+	"containerfs.inodesFree",
+	"pid.available",
+}
+
+// KubeletConfiguration is the subset of the kubelet configuration (kubelet.config.k8s.io/v1beta1) that
+// can be set cluster-wide. Fields use the same names and formats as the upstream KubeletConfiguration.
+type KubeletConfiguration struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+
+	MaxPods *int32 `json:"maxPods,omitempty"`
+
+	EvictionHard                     map[string]string `json:"evictionHard,omitempty"`
+	EvictionSoft                     map[string]string `json:"evictionSoft,omitempty"`
+	EvictionSoftGracePeriod          map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	EvictionPressureTransitionPeriod *string           `json:"evictionPressureTransitionPeriod,omitempty"`
+	EvictionMaxPodGracePeriod        *int32            `json:"evictionMaxPodGracePeriod,omitempty"`
+
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+	KubeReserved   map[string]string `json:"kubeReserved,omitempty"`
+
+	ImageGCHighThresholdPercent *int32  `json:"imageGCHighThresholdPercent,omitempty"`
+	ImageGCLowThresholdPercent  *int32  `json:"imageGCLowThresholdPercent,omitempty"`
+	ImageMinimumGCAge           *string `json:"imageMinimumGCAge,omitempty"`
+	ImageMaximumGCAge           *string `json:"imageMaximumGCAge,omitempty"`
+
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	ShutdownGracePeriod             *string `json:"shutdownGracePeriod,omitempty"`
+	ShutdownGracePeriodCriticalPods *string `json:"shutdownGracePeriodCriticalPods,omitempty"`
+}
+
+// ParseKubeletConfiguration parses and validates a YAML or JSON KubeletConfiguration document.
+// Fields that are not part of KubeletConfiguration are rejected.
+func ParseKubeletConfiguration(s string) (KubeletConfiguration, error) {
+	var c KubeletConfiguration
+	if err := yaml.UnmarshalStrict([]byte(s), &c); err != nil {
+		return KubeletConfiguration{}, fmt.Errorf("failed to parse kubelet configuration: %w", err)
+	}
+	if err := c.validate(); err != nil {
+		return KubeletConfiguration{}, err
+	}
+	return c, nil
+}
+
+// normalizeKubeletConfiguration returns the canonical representation of a KubeletConfiguration document.
+// Equivalent documents always produce the same string, so that unrelated changes do not restart the kubelet.
+func normalizeKubeletConfiguration(s string) (string, error) {
+	c, err := ParseKubeletConfiguration(s)
+	if err != nil {
+		return "", err
+	}
+	c.APIVersion, c.Kind = "", ""
+
+	// encoding/json.Marshal() sorts map keys, so the output is deterministic.
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kubelet configuration: %w", err)
+	}
+	return string(b), nil
+}
+
+func (c KubeletConfiguration) validate() error {
+	if c.APIVersion != "" && c.APIVersion != KubeletConfigurationAPIVersion {
+		return fmt.Errorf("apiVersion must be %q, not %q", KubeletConfigurationAPIVersion, c.APIVersion)
+	}
+	if c.Kind != "" && c.Kind != KubeletConfigurationKind {
+		return fmt.Errorf("kind must be %q, not %q", KubeletConfigurationKind, c.Kind)
+	}
+
+	if v := c.MaxPods; v != nil && *v <= 0 {
+		return fmt.Errorf("maxPods must be positive, not %d", *v)
+	}
+	if v := c.EvictionMaxPodGracePeriod; v != nil && *v < 0 {
+		return fmt.Errorf("evictionMaxPodGracePeriod cannot be negative")
+	}
+
+	for name, thresholds := range map[string]map[string]string{"evictionHard": c.EvictionHard, "evictionSoft": c.EvictionSoft} {
+		for signal, threshold := range thresholds {
+			if !slices.Contains(kubeletEvictionSignals, signal) {
+				return fmt.Errorf("%s has unknown eviction signal %q, must be one of %v", name, signal, kubeletEvictionSignals)
+			}
+			if err := validateKubeletEvictionThreshold(threshold); err != nil {
+				return fmt.Errorf("%s has invalid threshold for %s: %w", name, signal, err)
+			}
+		}
+	}
+	for signal, period := range c.EvictionSoftGracePeriod {
+		if _, ok := c.EvictionSoft[signal]; !ok {
+			return fmt.Errorf("evictionSoftGracePeriod is set for %s, but evictionSoft is not", signal)
+		}
+		if _, err := time.ParseDuration(period); err != nil {
+			return fmt.Errorf("evictionSoftGracePeriod has invalid duration for %s: %w", signal, err)
+		}
+	}
+	for signal := range c.EvictionSoft {
+		if _, ok := c.EvictionSoftGracePeriod[signal]; !ok {
+			return fmt.Errorf("evictionSoft is set for %s, but evictionSoftGracePeriod is not", signal)
+		}
+	}
+
+	for name, reserved := range map[string]map[string]string{"systemReserved": c.SystemReserved, "kubeReserved": c.KubeReserved} {
+		for resourceName, quantity := range reserved {
+			if _, err := resource.ParseQuantity(quantity); err != nil {
+				return fmt.Errorf("%s has invalid quantity for %s: %w", name, resourceName, err)
+			}
+		}
+	}
+
+	for name, v := range map[string]*int32{"imageGCHighThresholdPercent": c.ImageGCHighThresholdPercent, "imageGCLowThresholdPercent": c.ImageGCLowThresholdPercent} {
+		if v != nil && (*v < 0 || *v > 100) {
+			return fmt.Errorf("%s must be between 0 and 100, not %d", name, *v)
+		}
+	}
+	if high, low := c.ImageGCHighThresholdPercent, c.ImageGCLowThresholdPercent; high != nil && low != nil && *low >= *high {
+		return fmt.Errorf("imageGCLowThresholdPercent (%d) must be less than imageGCHighThresholdPercent (%d)", *low, *high)
+	}
+
+	durations := make(map[string]time.Duration)
+	for name, v := range map[string]*string{
+		"evictionPressureTransitionPeriod": c.EvictionPressureTransitionPeriod,
+		"imageMinimumGCAge":                c.ImageMinimumGCAge,
+		"imageMaximumGCAge":                c.ImageMaximumGCAge,
+		"shutdownGracePeriod":              c.ShutdownGracePeriod,
+		"shutdownGracePeriodCriticalPods":  c.ShutdownGracePeriodCriticalPods,
+	} {
+		if v == nil {
+			continue
+		}
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("%s has invalid duration: %w", name, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("%s cannot be negative", name)
+		}
+		durations[name] = d
+	}
+	if c.ShutdownGracePeriodCriticalPods != nil && durations["shutdownGracePeriodCriticalPods"] > durations["shutdownGracePeriod"] {
+		return fmt.Errorf("shutdownGracePeriodCriticalPods cannot be longer than shutdownGracePeriod")
+	}
+	if maxAge, ok := durations["imageMaximumGCAge"]; ok && maxAge > 0 && maxAge <= durations["imageMinimumGCAge"] {
+		return fmt.Errorf("imageMaximumGCAge must be longer than imageMinimumGCAge")
+	}
+
+	return nil
+}
+
+// validateKubeletEvictionThreshold checks that an eviction threshold is either a percentage or a quantity.
+func validateKubeletEvictionThreshold(threshold string) error {
+	if v, ok := strings.CutSuffix(threshold, "%"); ok {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil || p < 0 || p > 100 {
+			return fmt.Errorf("%q is not a valid percentage", threshold)
+		}
+		return nil
+	}
+	if _, err := resource.ParseQuantity(threshold); err != nil {
+		return fmt.Errorf("%q is not a valid quantity: %w", threshold, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package types_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseKubeletConfiguration(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := types.ParseKubeletConfiguration(`
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+maxPods: 250
+evictionHard:
+  memory.available: 200Mi
+  nodefs.available: 10%
+evictionSoft:
+  memory.available: 500Mi
+evictionSoftGracePeriod:
+  memory.available: 1m30s
+systemReserved:
+  cpu: 500m
+  memory: 1Gi
+kubeReserved:
+  memory: 512Mi
+imageGCHighThresholdPercent: 85
+imageGCLowThresholdPercent: 80
+imageMaximumGCAge: 168h
+featureGates:
+  GracefulNodeShutdown: true
+shutdownGracePeriod: 30s
+shutdownGracePeriodCriticalPods: 10s
+`)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(c.MaxPods).To(Equal(utils.Pointer(int32(250))))
+		g.Expect(c.EvictionHard).To(HaveKeyWithValue("nodefs.available", "10%"))
+		g.Expect(c.SystemReserved).To(HaveKeyWithValue("cpu", "500m"))
+		g.Expect(c.FeatureGates).To(HaveKeyWithValue("GracefulNodeShutdown", true))
+		g.Expect(c.ShutdownGracePeriod).To(Equal(utils.Pointer("30s")))
+	})
+
+	for _, tc := range []struct {
+		name   string
+		config string
+	}{
+		{name: "UnsupportedField", config: "clusterDNS: [10.0.0.1]"},
+		{name: "WrongKind", config: "kind: KubeProxyConfiguration"},
+		{name: "WrongAPIVersion", config: "apiVersion: kubelet.config.k8s.io/v1alpha1"},
+		{name: "NegativeMaxPods", config: "maxPods: -1"},
+		{name: "UnknownEvictionSignal", config: "evictionHard: {cpu.available: 10%}"},
+		{name: "InvalidEvictionThreshold", config: "evictionHard: {memory.available: lots}"},
+		{name: "InvalidEvictionPercentage", config: "evictionHard: {memory.available: 110%}"},
+		{name: "SoftWithoutGracePeriod", config: "evictionSoft: {memory.available: 1Gi}"},
+		{name: "GracePeriodWithoutSoft", config: "evictionSoftGracePeriod: {memory.available: 1m}"},
+		{name: "InvalidReserved", config: "systemReserved: {memory: a-lot}"},
+		{name: "InvalidImageGCThreshold", config: "imageGCHighThresholdPercent: 120"},
+		{name: "ImageGCLowAboveHigh", config: "imageGCHighThresholdPercent: 70\nimageGCLowThresholdPercent: 80"},
+		{name: "InvalidDuration", config: "shutdownGracePeriod: 30"},
+		{name: "CriticalPodsLongerThanShutdown", config: "shutdownGracePeriod: 10s\nshutdownGracePeriodCriticalPods: 20s"},
+		{name: "ImageMaxAgeBelowMinAge", config: "imageMinimumGCAge: 2h\nimageMaximumGCAge: 1h"},
+		{name: "NotYAML", config: "maxPods: [1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := types.ParseKubeletConfiguration(tc.config)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestKubeletFromAnnotations(t *testing.T) {
+	t.Run("Normalized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+			Annotations: map[string]string{
+				types.AnnotationKubeletConfig: "kind: KubeletConfiguration\nmaxPods: 200\nkubeReserved: {memory: 1Gi, cpu: 100m}\n",
+			},
+		})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(config.Kubelet.GetConfig()).To(Equal(`{"maxPods":200,"kubeReserved":{"cpu":"100m","memory":"1Gi"}}`))
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{})
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(config.Kubelet.Config).To(BeNil())
+		g.Expect(config.Kubelet.Empty()).To(BeTrue())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := types.ClusterConfigFromUserFacing(apiv1.UserFacingClusterConfig{
+			Annotations: map[string]string{
+				types.AnnotationKubeletConfig: "maxPods: many",
+			},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
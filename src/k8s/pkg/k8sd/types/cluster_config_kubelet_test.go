@@ -66,6 +66,33 @@ func TestKubelet(t *testing.T) {
 				CloudProvider: utils.Pointer("external"),
 			},
 		},
+		{
+			name: "KubeletConfig",
+			configmap: map[string]string{
+				"kubelet-config": `{"maxPods":200}`,
+			},
+			kubelet: types.Kubelet{
+				Config: utils.Pointer(`{"maxPods":200}`),
+			},
+		},
+		{
+			name: "RemoveKubeletConfig",
+			configmap: map[string]string{
+				"kubelet-config": "",
+			},
+			kubelet: types.Kubelet{
+				Config: utils.Pointer(""),
+			},
+		},
+		{
+			name: "RotateServerCertificates",
+			configmap: map[string]string{
+				"rotate-server-certificates": "true",
+			},
+			kubelet: types.Kubelet{
+				RotateServerCertificates: utils.Pointer(true),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Run("ToConfigMap", func(t *testing.T) {
@@ -87,6 +114,36 @@ func TestKubelet(t *testing.T) {
 	}
 }
 
+func TestKubeletServerCertificateRotationEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config types.ClusterConfig
+		expect bool
+	}{
+		{
+			name:   "CAKey",
+			config: types.ClusterConfig{Certificates: types.Certificates{CAKey: utils.Pointer("key")}},
+			expect: true,
+		},
+		{
+			name:   "NoCAKey",
+			config: types.ClusterConfig{},
+		},
+		{
+			name: "Disabled",
+			config: types.ClusterConfig{
+				Certificates: types.Certificates{CAKey: utils.Pointer("key")},
+				Annotations:  types.Annotations{types.AnnotationKubeletRotateServerCertificates: "false"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tc.config.KubeletServerCertificateRotationEnabled()).To(Equal(tc.expect))
+		})
+	}
+}
+
 func TestKubeletSign(t *testing.T) {
 	g := NewWithT(t)
 	key, err := rsa.GenerateKey(rand.Reader, 4096)
@@ -96,6 +153,7 @@ func TestKubeletSign(t *testing.T) {
 		CloudProvider: utils.Pointer("external"),
 		ClusterDNS:    utils.Pointer("10.0.0.1"),
 		ClusterDomain: utils.Pointer("cluster.local"),
+		Config:        utils.Pointer(`{"maxPods":200}`),
 	}
 
 	configmap, err := kubelet.ToConfigMap(key)
@@ -52,6 +52,7 @@ func MergeClusterConfig(existing ClusterConfig, new ClusterConfig) (ClusterConfi
 		{name: "kubelet cluster DNS", val: &config.Kubelet.ClusterDNS, old: existing.Kubelet.ClusterDNS, new: new.Kubelet.ClusterDNS, allowChange: !boolFieldRemainedEnabled(existing.DNS.Enabled, new.DNS.Enabled)},
 		{name: "kubelet cluster domain", val: &config.Kubelet.ClusterDomain, old: existing.Kubelet.ClusterDomain, new: new.Kubelet.ClusterDomain, allowChange: true},
 		{name: "kubelet cloud provider", val: &config.Kubelet.CloudProvider, old: existing.Kubelet.CloudProvider, new: new.Kubelet.CloudProvider, allowChange: true},
+		{name: "kubelet configuration", val: &config.Kubelet.Config, old: existing.Kubelet.Config, new: new.Kubelet.Config, allowChange: true},
 		// ingress
 		{name: "ingress default TLS secret", val: &config.Ingress.DefaultTLSSecret, old: existing.Ingress.DefaultTLSSecret, new: new.Ingress.DefaultTLSSecret, allowChange: true},
 		// load balancer
@@ -84,6 +85,11 @@ func MergeClusterConfig(existing ClusterConfig, new ClusterConfig) (ClusterConfi
 		}
 	}
 
+	// update secrets encryption keys
+	if config.Certificates.SecretsEncryptionKeys, err = mergeSliceField(existing.Certificates.SecretsEncryptionKeys, new.Certificates.SecretsEncryptionKeys, true); err != nil {
+		return ClusterConfig{}, fmt.Errorf("prevented update of secrets encryption keys: %w", err)
+	}
+
 	// update LoadBalancer_IPRange fields
 	if config.LoadBalancer.IPRanges, err = mergeSliceField(existing.LoadBalancer.IPRanges, new.LoadBalancer.IPRanges, true); err != nil {
 		return ClusterConfig{}, fmt.Errorf("prevented update of load balancer IP ranges: %w", err)
@@ -146,6 +152,32 @@ func MergeClusterConfig(existing ClusterConfig, new ClusterConfig) (ClusterConfi
 	// merge annotations
 	config.Annotations = mergeAnnotationsField(existing.Annotations, new.Annotations)
 
+	// the service arguments are always replaced as a whole, so that arguments and files can be removed
+	config.ServiceArgs = existing.ServiceArgs
+	if new.ServiceArgs != nil {
+		config.ServiceArgs = new.ServiceArgs
+	}
+
+	// the hardening profile is applied when nodes are bootstrapped or joined, so it can not be changed once set.
+	// the datastore type is always set when the cluster is bootstrapped, so it tells whether the cluster exists already.
+	if existing.Datastore.Type != nil && existing.HardeningProfile() != config.HardeningProfile() {
+		if existing.HardeningProfile() == "" {
+			return ClusterConfig{}, fmt.Errorf("prevented update of %s: hardening profile can only be set when the cluster is bootstrapped", AnnotationHardeningProfile)
+		}
+		return ClusterConfig{}, fmt.Errorf("prevented update of %s: hardening profile can not be changed once set", AnnotationHardeningProfile)
+	}
+
+	// the OIDC, audit and secrets encryption configuration of the kube-apiserver, the kubelet configuration and the gateway configuration follow the annotations
+	if err := apiServerFromAnnotations(&config.APIServer, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse kube-apiserver annotations: %w", err)
+	}
+	if err := kubeletFromAnnotations(&config.Kubelet, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse kubelet annotations: %w", err)
+	}
+	if err := gatewayFromAnnotations(&config.Gateway, config.Annotations); err != nil {
+		return ClusterConfig{}, fmt.Errorf("failed to parse gateway annotations: %w", err)
+	}
+
 	if err := config.Validate(); err != nil {
 		return ClusterConfig{}, fmt.Errorf("updated cluster configuration is not valid: %w", err)
 	}
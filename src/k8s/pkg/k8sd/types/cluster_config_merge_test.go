@@ -86,6 +86,13 @@ func TestMergeClusterConfig(t *testing.T) {
 		generateMergeClusterConfigTestCases("Certificates/AdminClientKey", true, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Certificates.AdminClientKey = utils.Pointer(v.(string)) }),
 		generateMergeClusterConfigTestCases("Certificates/K8sdPublicKey", false, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Certificates.K8sdPublicKey = utils.Pointer(v.(string)) }),
 		generateMergeClusterConfigTestCases("Certificates/K8sdPrivateKey", false, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Certificates.K8sdPrivateKey = utils.Pointer(v.(string)) }),
+		generateMergeClusterConfigTestCases("Certificates/SecretsEncryptionKeys", true,
+			[]types.SecretsEncryptionKey{{Name: "key-1", Provider: "aescbc", Secret: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}},
+			[]types.SecretsEncryptionKey{{Name: "key-2", Provider: "aesgcm", Secret: "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="}},
+			func(c *types.ClusterConfig, v any) {
+				c.Certificates.SecretsEncryptionKeys = utils.Pointer(v.([]types.SecretsEncryptionKey))
+			},
+		),
 		generateMergeClusterConfigTestCases("Datastore/Type", false, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Datastore.Type = utils.Pointer(v.(string)) }),
 		generateMergeClusterConfigTestCases("Datastore/K8sDqliteCert", false, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Datastore.K8sDqliteCert = utils.Pointer(v.(string)) }),
 		generateMergeClusterConfigTestCases("Datastore/K8sDqliteKey", false, "v1", "v2", func(c *types.ClusterConfig, v any) { c.Datastore.K8sDqliteKey = utils.Pointer(v.(string)) }),
@@ -226,6 +233,50 @@ func TestMergeClusterConfig_Scenarios(t *testing.T) {
 		expectMerged types.ClusterConfig
 		expectErr    bool
 	}{
+		{
+			name: "ServiceArgs/Replace",
+			old: types.ClusterConfig{
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2")}},
+				}},
+			},
+			new: types.ClusterConfig{
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kube-proxy": {"--v": utils.Pointer("4")}},
+				}},
+			},
+			expectMerged: types.ClusterConfig{
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kube-proxy": {"--v": utils.Pointer("4")}},
+				}},
+			},
+		},
+		{
+			name: "ServiceArgs/Keep",
+			old: types.ClusterConfig{
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2")}},
+				}},
+			},
+			new: types.ClusterConfig{
+				DNS: types.DNS{Enabled: utils.Pointer(true)},
+			},
+			expectMerged: types.ClusterConfig{
+				DNS: types.DNS{Enabled: utils.Pointer(true)},
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("2")}},
+				}},
+			},
+		},
+		{
+			name: "ServiceArgs/Invalid",
+			new: types.ClusterConfig{
+				ServiceArgs: &types.ServiceArgs{ServiceArgsConfig: types.ServiceArgsConfig{
+					Args: map[string]map[string]*string{"kubelet": {"v": utils.Pointer("2")}},
+				}},
+			},
+			expectErr: true,
+		},
 		{
 			name: "Kubelet/AllowSetClusterDNS/EnableDNSAfter",
 			old: types.ClusterConfig{
@@ -324,6 +375,109 @@ func TestMergeClusterConfig_Scenarios(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "APIServer/EnableOIDC",
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationOIDCIssuerURL: "https://issuer.example.com",
+					types.AnnotationOIDCClientID:  "k8s",
+				},
+			},
+			expectMerged: types.ClusterConfig{
+				APIServer: types.APIServer{
+					OIDCIssuerURL: utils.Pointer("https://issuer.example.com"),
+					OIDCClientID:  utils.Pointer("k8s"),
+				},
+				Annotations: types.Annotations{
+					types.AnnotationOIDCIssuerURL: "https://issuer.example.com",
+					types.AnnotationOIDCClientID:  "k8s",
+				},
+			},
+		},
+		{
+			name: "APIServer/DisableOIDC",
+			old: types.ClusterConfig{
+				APIServer: types.APIServer{
+					OIDCIssuerURL: utils.Pointer("https://issuer.example.com"),
+					OIDCClientID:  utils.Pointer("k8s"),
+				},
+				Annotations: types.Annotations{
+					types.AnnotationOIDCIssuerURL: "https://issuer.example.com",
+					types.AnnotationOIDCClientID:  "k8s",
+					"other":                       "value",
+				},
+			},
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationOIDCIssuerURL: "-",
+					types.AnnotationOIDCClientID:  "-",
+				},
+			},
+			expectMerged: types.ClusterConfig{
+				Annotations: types.Annotations{
+					"other": "value",
+				},
+			},
+		},
+		{
+			name: "Kubelet/SetConfig",
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationKubeletConfig: "maxPods: 200\nfeatureGates:\n  GracefulNodeShutdown: true\n",
+				},
+			},
+			expectMerged: types.ClusterConfig{
+				Kubelet: types.Kubelet{
+					Config: utils.Pointer(`{"maxPods":200,"featureGates":{"GracefulNodeShutdown":true}}`),
+				},
+				Annotations: types.Annotations{
+					types.AnnotationKubeletConfig: "maxPods: 200\nfeatureGates:\n  GracefulNodeShutdown: true\n",
+				},
+			},
+		},
+		{
+			name: "Kubelet/RemoveConfig",
+			old: types.ClusterConfig{
+				Kubelet: types.Kubelet{
+					Config: utils.Pointer(`{"maxPods":200}`),
+				},
+				Annotations: types.Annotations{
+					types.AnnotationKubeletConfig: "maxPods: 200",
+					"other":                       "value",
+				},
+			},
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationKubeletConfig: "-",
+				},
+			},
+			expectMerged: types.ClusterConfig{
+				Kubelet: types.Kubelet{
+					Config: utils.Pointer(""),
+				},
+				Annotations: types.Annotations{
+					"other": "value",
+				},
+			},
+		},
+		{
+			name: "Kubelet/InvalidConfig",
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationKubeletConfig: "clusterDNS: [10.0.0.1]",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "APIServer/OIDCMissingClientID",
+			new: types.ClusterConfig{
+				Annotations: types.Annotations{
+					types.AnnotationOIDCIssuerURL: "https://issuer.example.com",
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "LocalStorage/InvalidReclaimPolicy",
 			new: types.ClusterConfig{
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// SecretsEncryptionProviders are the supported providers to encrypt Secrets at rest.
+var SecretsEncryptionProviders = []string{"aescbc", "aesgcm", "secretbox", "kms"}
+
+// SecretsEncryptionKey is a key used by the kube-apiserver to encrypt Secrets at rest.
+type SecretsEncryptionKey struct {
+	// Name is the unique name of the key.
+	Name string `json:"name"`
+	// Provider is the encryption provider the key is used with, one of "aescbc", "aesgcm" or "secretbox".
+	Provider string `json:"provider"`
+	// Secret is the base64 encoded key.
+	Secret string `json:"secret"`
+}
+
+// NewSecretsEncryptionKey generates a new random key for the specified provider.
+func NewSecretsEncryptionKey(provider string) (SecretsEncryptionKey, error) {
+	switch provider {
+	case "aescbc", "aesgcm", "secretbox":
+	default:
+		return SecretsEncryptionKey{}, fmt.Errorf("keys cannot be generated for secrets encryption provider %q", provider)
+	}
+
+	// 32 byte keys are valid for all providers (AES-256 and XSalsa20-Poly1305)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return SecretsEncryptionKey{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return SecretsEncryptionKey{}, fmt.Errorf("failed to generate key name: %w", err)
+	}
+
+	return SecretsEncryptionKey{
+		Name:     fmt.Sprintf("key-%s-%s", time.Now().UTC().Format("20060102"), hex.EncodeToString(suffix)),
+		Provider: provider,
+		Secret:   base64.StdEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// SecretsEncryptionEnabled returns true if the kube-apiserver must be configured with an encryption provider configuration.
+// This is also the case after disabling secrets encryption, until all existing keys have been retired.
+func (c ClusterConfig) SecretsEncryptionEnabled() bool {
+	return c.APIServer.GetSecretsEncryptionProvider() != "" || len(c.Certificates.GetSecretsEncryptionKeys()) > 0
+}
+
+// SecretsEncryptionKeysForProvider returns the secrets encryption keys of the cluster, ensuring that the first key can
+// be used with the configured secrets encryption provider. A new key is generated if needed, and existing keys are
+// kept so that Secrets that are already encrypted can still be read.
+// SecretsEncryptionKeysForProvider returns true if the keys were changed.
+func (c ClusterConfig) SecretsEncryptionKeysForProvider() ([]SecretsEncryptionKey, bool, error) {
+	keys := c.Certificates.GetSecretsEncryptionKeys()
+
+	provider := c.APIServer.GetSecretsEncryptionProvider()
+	switch {
+	case provider == "" || provider == "kms":
+		// no keys are needed, or keys are managed by the KMS plugin
+		return keys, false, nil
+	case len(keys) > 0 && keys[0].Provider == provider:
+		return keys, false, nil
+	}
+
+	key, err := NewSecretsEncryptionKey(provider)
+	if err != nil {
+		return nil, false, err
+	}
+	return append([]SecretsEncryptionKey{key}, keys...), true, nil
+}
+
+// ToKubeAPIServerSecretsEncryptionArguments returns updateArgs, deleteArgs that can be used with snaputil.UpdateServiceArguments() for the kube-apiserver
+// according to the secrets encryption configuration.
+func (c ClusterConfig) ToKubeAPIServerSecretsEncryptionArguments(p APIServerPathsProvider) (map[string]string, []string) {
+	if !c.SecretsEncryptionEnabled() {
+		return map[string]string{}, []string{"--encryption-provider-config", "--encryption-provider-config-automatic-reload"}
+	}
+	return map[string]string{
+		// the encryption provider configuration will be written by setup.SecretsEncryption(), here we only set the path
+		"--encryption-provider-config": filepath.Join(p.ServiceExtraConfigDir(), "encryption-config.yaml"),
+	}, []string{"--encryption-provider-config-automatic-reload"}
+}
+
+// validateSecretsEncryption checks the secrets encryption configuration of the cluster.
+func validateSecretsEncryption(c ClusterConfig) error {
+	provider := c.APIServer.GetSecretsEncryptionProvider()
+	if provider != "" && !slices.Contains(SecretsEncryptionProviders, provider) {
+		return fmt.Errorf("%s must be one of %v, not %q", AnnotationSecretsEncryptionProvider, SecretsEncryptionProviders, provider)
+	}
+	if v := c.APIServer.GetSecretsEncryptionKMSEndpoint(); v != "" && !filepath.IsAbs(v) {
+		return fmt.Errorf("%s must be the absolute path of a unix socket", AnnotationSecretsEncryptionKMSEndpoint)
+	}
+	if provider == "kms" && c.APIServer.GetSecretsEncryptionKMSEndpoint() == "" {
+		return fmt.Errorf("%s must be set when %s is %q", AnnotationSecretsEncryptionKMSEndpoint, AnnotationSecretsEncryptionProvider, provider)
+	}
+
+	names := make(map[string]struct{}, len(c.Certificates.GetSecretsEncryptionKeys()))
+	for _, key := range c.Certificates.GetSecretsEncryptionKeys() {
+		if _, ok := names[key.Name]; ok || key.Name == "" {
+			return fmt.Errorf("secrets encryption key names must be unique and not empty")
+		}
+		names[key.Name] = struct{}{}
+
+		switch key.Provider {
+		case "aescbc", "aesgcm", "secretbox":
+		default:
+			return fmt.Errorf("secrets encryption key %s has invalid provider %q", key.Name, key.Provider)
+		}
+		if b, err := base64.StdEncoding.DecodeString(key.Secret); err != nil || len(b) != 32 {
+			return fmt.Errorf("secrets encryption key %s must be a base64 encoded 32 byte key", key.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package types_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestNewSecretsEncryptionKey(t *testing.T) {
+	for _, provider := range []string{"aescbc", "aesgcm", "secretbox"} {
+		t.Run(provider, func(t *testing.T) {
+			g := NewWithT(t)
+
+			key, err := types.NewSecretsEncryptionKey(provider)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(key.Provider).To(Equal(provider))
+			g.Expect(key.Name).To(MatchRegexp(`^key-\d{8}-[0-9a-f]{8}$`))
+
+			secret, err := base64.StdEncoding.DecodeString(key.Secret)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(secret).To(HaveLen(32))
+
+			other, err := types.NewSecretsEncryptionKey(provider)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(other.Name).ToNot(Equal(key.Name))
+			g.Expect(other.Secret).ToNot(Equal(key.Secret))
+		})
+	}
+
+	t.Run("kms", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := types.NewSecretsEncryptionKey("kms")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestSecretsEncryptionKeysForProvider(t *testing.T) {
+	aescbc := types.SecretsEncryptionKey{Name: "key-1", Provider: "aescbc", Secret: "secret"}
+
+	t.Run("Disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		keys, changed, err := types.ClusterConfig{}.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+		g.Expect(keys).To(BeEmpty())
+	})
+
+	t.Run("DisabledKeepsKeys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{aescbc}}}
+		keys, changed, err := config.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+		g.Expect(keys).To(Equal([]types.SecretsEncryptionKey{aescbc}))
+		g.Expect(config.SecretsEncryptionEnabled()).To(BeTrue())
+	})
+
+	t.Run("GenerateFirstKey", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{APIServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("aesgcm")}}
+		keys, changed, err := config.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeTrue())
+		g.Expect(keys).To(HaveLen(1))
+		g.Expect(keys[0].Provider).To(Equal("aesgcm"))
+	})
+
+	t.Run("SameProvider", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{
+			APIServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")},
+			Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{aescbc}},
+		}
+		keys, changed, err := config.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+		g.Expect(keys).To(Equal([]types.SecretsEncryptionKey{aescbc}))
+	})
+
+	t.Run("ChangeProvider", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{
+			APIServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("secretbox")},
+			Certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{aescbc}},
+		}
+		keys, changed, err := config.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeTrue())
+		g.Expect(keys).To(HaveLen(2))
+		g.Expect(keys[0].Provider).To(Equal("secretbox"))
+		g.Expect(keys[1]).To(Equal(aescbc))
+	})
+
+	t.Run("KMS", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{APIServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("kms")}}
+		keys, changed, err := config.SecretsEncryptionKeysForProvider()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeFalse())
+		g.Expect(keys).To(BeEmpty())
+	})
+}
+
+func TestClusterConfigToKubeAPIServerSecretsEncryptionArguments(t *testing.T) {
+	snap := &mock.Snap{
+		Mock: mock.Mock{
+			ServiceExtraConfigDir: "/args/conf.d",
+		},
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		updateArgs, deleteArgs := types.ClusterConfig{}.ToKubeAPIServerSecretsEncryptionArguments(snap)
+		g.Expect(updateArgs).To(BeEmpty())
+		g.Expect(deleteArgs).To(ConsistOf("--encryption-provider-config", "--encryption-provider-config-automatic-reload"))
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := types.ClusterConfig{APIServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")}}
+		updateArgs, deleteArgs := config.ToKubeAPIServerSecretsEncryptionArguments(snap)
+		g.Expect(updateArgs).To(Equal(map[string]string{"--encryption-provider-config": "/args/conf.d/encryption-config.yaml"}))
+		g.Expect(deleteArgs).To(ConsistOf("--encryption-provider-config-automatic-reload"))
+	})
+}
@@ -0,0 +1,307 @@
+package types
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	// ControlPlaneServices are the services that run on control plane nodes only.
+	// Their extra arguments are applied by the control plane nodes from the cluster configuration.
+	ControlPlaneServices = []string{"k8s-dqlite", "kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+	// NodeServices are the services that run on all nodes (k8s-apiserver-proxy runs on worker nodes only).
+	// Their extra arguments are distributed to the nodes through the k8sd-config configmap.
+	NodeServices = []string{"containerd", "k8s-apiserver-proxy", "kube-proxy", "kubelet"}
+
+	// managedServiceArgs are security-sensitive arguments that are managed by k8sd and cannot be overridden.
+	managedServiceArgs = map[string][]string{
+		"kube-apiserver": {
+			"--anonymous-auth",
+			"--authentication-token-webhook-config-file",
+			"--authorization-mode",
+			"--client-ca-file",
+			"--encryption-provider-config",
+			"--kubelet-certificate-authority",
+			"--kubelet-client-certificate",
+			"--kubelet-client-key",
+			"--proxy-client-cert-file",
+			"--proxy-client-key-file",
+			"--requestheader-client-ca-file",
+			"--service-account-issuer",
+			"--service-account-key-file",
+			"--service-account-signing-key-file",
+			"--tls-cert-file",
+			"--tls-private-key-file",
+		},
+		"kube-controller-manager": {
+			"--cluster-signing-cert-file",
+			"--cluster-signing-key-file",
+			"--cluster-signing-kube-apiserver-client-cert-file",
+			"--cluster-signing-kube-apiserver-client-key-file",
+			"--cluster-signing-kubelet-client-cert-file",
+			"--cluster-signing-kubelet-client-key-file",
+			"--cluster-signing-kubelet-serving-cert-file",
+			"--cluster-signing-kubelet-serving-key-file",
+			"--cluster-signing-legacy-unknown-cert-file",
+			"--cluster-signing-legacy-unknown-key-file",
+			"--kubeconfig",
+			"--root-ca-file",
+			"--service-account-private-key-file",
+		},
+		"kube-scheduler": {"--kubeconfig"},
+		"kube-proxy":     {"--kubeconfig"},
+		"kubelet": {
+			"--anonymous-auth",
+			"--authorization-mode",
+			"--client-ca-file",
+			"--kubeconfig",
+			"--tls-cert-file",
+			"--tls-private-key-file",
+		},
+	}
+)
+
+// ServiceArgsConfig are extra arguments and configuration files of the Kubernetes services.
+type ServiceArgsConfig struct {
+	// Args maps service names to their extra arguments. A nil value removes the argument.
+	Args map[string]map[string]*string `json:"args,omitempty" yaml:"args,omitempty"`
+	// ConfigFiles are extra files written in the extra configuration directory of the services.
+	ConfigFiles map[string]string `json:"config-files,omitempty" yaml:"config-files,omitempty"`
+}
+
+// ServiceArgs is the cluster-wide configuration of the extra arguments and configuration files of the Kubernetes services.
+// ServiceArgs is reconciled on all nodes of the cluster, so that changing the arguments of a service does not require editing
+// the arguments files of each node.
+type ServiceArgs struct {
+	ServiceArgsConfig `json:",inline" yaml:",inline"`
+
+	// Nodes are per-node overrides, keyed by node name.
+	// The arguments and configuration files of a node take precedence over the cluster-wide ones.
+	Nodes map[string]ServiceArgsConfig `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// Validate checks that the service arguments configuration is valid.
+func (s ServiceArgs) Validate() error {
+	if err := s.ServiceArgsConfig.validate(); err != nil {
+		return err
+	}
+	for nodeName, node := range s.Nodes {
+		if nodeName == "" {
+			return fmt.Errorf("node overrides must have a node name")
+		}
+		if err := node.validate(); err != nil {
+			return fmt.Errorf("invalid overrides for node %q: %w", nodeName, err)
+		}
+	}
+	return nil
+}
+
+func (c ServiceArgsConfig) validate() error {
+	for service, args := range c.Args {
+		if !slices.Contains(ControlPlaneServices, service) && !slices.Contains(NodeServices, service) {
+			return fmt.Errorf("unknown service %q, must be one of %s", service, strings.Join(slices.Concat(ControlPlaneServices, NodeServices), ", "))
+		}
+		for arg := range args {
+			if !strings.HasPrefix(arg, "--") || len(arg) < 3 {
+				return fmt.Errorf("invalid argument %q for %s: arguments must start with --", arg, service)
+			}
+			if slices.Contains(managedServiceArgs[service], arg) {
+				return fmt.Errorf("argument %q for %s is managed by k8sd and cannot be overridden", arg, service)
+			}
+		}
+	}
+	for filename := range c.ConfigFiles {
+		if filename == "" || filename == "." || filename == ".." || strings.Contains(filename, "/") {
+			return fmt.Errorf("invalid file name %q: must not be empty, %q, %q or contain any slashes", filename, ".", "..")
+		}
+	}
+	return nil
+}
+
+// ForNode returns the extra arguments and configuration files that apply on the specified node.
+func (s ServiceArgs) ForNode(nodeName string) ServiceArgsConfig {
+	result := ServiceArgsConfig{
+		Args:        make(map[string]map[string]*string, len(s.Args)),
+		ConfigFiles: maps.Clone(s.ConfigFiles),
+	}
+	for service, args := range s.Args {
+		result.Args[service] = maps.Clone(args)
+	}
+
+	node, ok := s.Nodes[nodeName]
+	if !ok {
+		return result
+	}
+	for service, args := range node.Args {
+		if result.Args[service] == nil {
+			result.Args[service] = make(map[string]*string, len(args))
+		}
+		maps.Copy(result.Args[service], args)
+	}
+	if len(node.ConfigFiles) > 0 && result.ConfigFiles == nil {
+		result.ConfigFiles = make(map[string]string, len(node.ConfigFiles))
+	}
+	maps.Copy(result.ConfigFiles, node.ConfigFiles)
+	return result
+}
+
+// ForServices returns a copy of the configuration with the extra arguments of the specified services only.
+func (s ServiceArgs) ForServices(services []string) ServiceArgs {
+	filter := func(c ServiceArgsConfig) ServiceArgsConfig {
+		result := ServiceArgsConfig{ConfigFiles: c.ConfigFiles}
+		for service, args := range c.Args {
+			if slices.Contains(services, service) {
+				if result.Args == nil {
+					result.Args = make(map[string]map[string]*string)
+				}
+				result.Args[service] = args
+			}
+		}
+		return result
+	}
+
+	result := ServiceArgs{ServiceArgsConfig: filter(s.ServiceArgsConfig)}
+	for nodeName, node := range s.Nodes {
+		if result.Nodes == nil {
+			result.Nodes = make(map[string]ServiceArgsConfig, len(s.Nodes))
+		}
+		result.Nodes[nodeName] = filter(node)
+	}
+	return result
+}
+
+// DiffServiceArgs returns a human-readable preview of the changes between two service arguments configurations.
+// Added, changed and removed entries are prefixed with "+", "~" and "-" respectively.
+// DiffServiceArgs returns an empty string if there are no changes.
+func DiffServiceArgs(old ServiceArgs, new ServiceArgs) string {
+	b := &strings.Builder{}
+	writeScope := func(scope string, old ServiceArgsConfig, new ServiceArgsConfig) {
+		lines := old.diff(new)
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(b, "%s:\n", scope)
+		for _, line := range lines {
+			fmt.Fprintf(b, "  %s\n", line)
+		}
+	}
+
+	// cluster-wide changes are listed first, as they affect all nodes
+	writeScope("cluster-wide", old.ServiceArgsConfig, new.ServiceArgsConfig)
+	for _, nodeName := range sortedKeys(old.Nodes, new.Nodes) {
+		writeScope(fmt.Sprintf("node %s", nodeName), old.Nodes[nodeName], new.Nodes[nodeName])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// diff returns the changed arguments and configuration files between two configurations, sorted by service and name.
+func (c ServiceArgsConfig) diff(new ServiceArgsConfig) []string {
+	formatValue := func(v *string) string {
+		if v == nil {
+			return "null"
+		}
+		return fmt.Sprintf("%q", *v)
+	}
+
+	var lines []string
+	for _, service := range sortedKeys(c.Args, new.Args) {
+		oldArgs, newArgs := c.Args[service], new.Args[service]
+		for _, arg := range sortedKeys(oldArgs, newArgs) {
+			oldValue, inOld := oldArgs[arg]
+			newValue, inNew := newArgs[arg]
+			switch {
+			case !inOld:
+				lines = append(lines, fmt.Sprintf("+ %s %s=%s", service, arg, formatValue(newValue)))
+			case !inNew:
+				lines = append(lines, fmt.Sprintf("- %s %s=%s", service, arg, formatValue(oldValue)))
+			case formatValue(oldValue) != formatValue(newValue):
+				lines = append(lines, fmt.Sprintf("~ %s %s=%s -> %s", service, arg, formatValue(oldValue), formatValue(newValue)))
+			}
+		}
+	}
+
+	for _, filename := range sortedKeys(c.ConfigFiles, new.ConfigFiles) {
+		oldContent, inOld := c.ConfigFiles[filename]
+		newContent, inNew := new.ConfigFiles[filename]
+		switch {
+		case !inOld:
+			lines = append(lines, fmt.Sprintf("+ file %s", filename))
+		case !inNew:
+			lines = append(lines, fmt.Sprintf("- file %s", filename))
+		case oldContent != newContent:
+			lines = append(lines, fmt.Sprintf("~ file %s", filename))
+		}
+	}
+	return lines
+}
+
+// sortedKeys returns the sorted keys that are set in any of two maps.
+func sortedKeys[V any](a map[string]V, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ToConfigMap converts the service arguments to a map[string]string to store in a Kubernetes configmap.
+// ToConfigMap will append a "service-args-mac" field with a signed hash of the contents, if a key is specified.
+func (s ServiceArgs) ToConfigMap(key *rsa.PrivateKey) (map[string]string, error) {
+	// encoding/json.Marshal() ensures alphabetical order on map keys, so will
+	// always produce the same JSON document.
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal service arguments: %w", err)
+	}
+
+	data := map[string]string{"service-args": string(b)}
+
+	if key != nil {
+		hash := sha256.Sum256(b)
+		mac, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign hash: %w", err)
+		}
+		data["service-args-mac"] = base64.StdEncoding.EncodeToString(mac)
+	}
+
+	return data, nil
+}
+
+// ServiceArgsFromConfigMap parses configmap data into the service arguments configuration.
+// ServiceArgsFromConfigMap will attempt to validate the signature (found in the "service-args-mac" field) if a key is specified.
+// ServiceArgsFromConfigMap returns false if the configmap does not contain any service arguments configuration.
+func ServiceArgsFromConfigMap(m map[string]string, key *rsa.PublicKey) (ServiceArgs, bool, error) {
+	v, ok := m["service-args"]
+	if !ok {
+		return ServiceArgs{}, false, nil
+	}
+
+	if key != nil {
+		hash := sha256.Sum256([]byte(v))
+		signature, err := base64.StdEncoding.DecodeString(m["service-args-mac"])
+		if err != nil {
+			return ServiceArgs{}, false, fmt.Errorf("failed to parse signature: %w", err)
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature); err != nil {
+			return ServiceArgs{}, false, fmt.Errorf("failed to verify signature: %w", err)
+		}
+	}
+
+	var s ServiceArgs
+	if err := json.Unmarshal([]byte(v), &s); err != nil {
+		return ServiceArgs{}, false, fmt.Errorf("failed to parse service arguments: %w", err)
+	}
+	return s, true, nil
+}
@@ -0,0 +1,187 @@
+package types_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+)
+
+func TestServiceArgsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		serviceArgs types.ServiceArgs
+		expectErr   bool
+	}{
+		{name: "Empty"},
+		{name: "Valid", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{
+				Args:        map[string]map[string]*string{"kube-apiserver": {"--v": utils.Pointer("3")}, "kubelet": {"--max-pods": nil}},
+				ConfigFiles: map[string]string{"file.yaml": "content"},
+			},
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node1": {Args: map[string]map[string]*string{"containerd": {"--log-level": utils.Pointer("debug")}}},
+			},
+		}},
+		{name: "UnknownService", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{Args: map[string]map[string]*string{"k8sd": {"--v": utils.Pointer("3")}}},
+		}, expectErr: true},
+		{name: "InvalidArgument", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{Args: map[string]map[string]*string{"kubelet": {"max-pods": utils.Pointer("50")}}},
+		}, expectErr: true},
+		{name: "ManagedArgument", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{Args: map[string]map[string]*string{"kube-apiserver": {"--authorization-mode": utils.Pointer("AlwaysAllow")}}},
+		}, expectErr: true},
+		{name: "ManagedArgumentNodeOverride", serviceArgs: types.ServiceArgs{
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node1": {Args: map[string]map[string]*string{"kubelet": {"--anonymous-auth": nil}}},
+			},
+		}, expectErr: true},
+		{name: "InvalidFileName", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{ConfigFiles: map[string]string{"../file": "content"}},
+		}, expectErr: true},
+		{name: "CurrentDirectoryFileName", serviceArgs: types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{ConfigFiles: map[string]string{".": "content"}},
+		}, expectErr: true},
+		{name: "ParentDirectoryFileName", serviceArgs: types.ServiceArgs{
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node1": {ConfigFiles: map[string]string{"..": "content"}},
+			},
+		}, expectErr: true},
+		{name: "InvalidNodeOverride", serviceArgs: types.ServiceArgs{
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node1": {Args: map[string]map[string]*string{"kubelet": {"-v": utils.Pointer("3")}}},
+			},
+		}, expectErr: true},
+		{name: "MissingNodeName", serviceArgs: types.ServiceArgs{
+			Nodes: map[string]types.ServiceArgsConfig{"": {}},
+		}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.serviceArgs.Validate()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+			}
+		})
+	}
+}
+
+func TestServiceArgs(t *testing.T) {
+	serviceArgs := types.ServiceArgs{
+		ServiceArgsConfig: types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{
+				"kube-apiserver": {"--v": utils.Pointer("3")},
+				"kubelet":        {"--v": utils.Pointer("2"), "--max-pods": utils.Pointer("80")},
+			},
+			ConfigFiles: map[string]string{"file": "content"},
+		},
+		Nodes: map[string]types.ServiceArgsConfig{
+			"node1": {
+				Args:        map[string]map[string]*string{"kubelet": {"--v": utils.Pointer("4")}, "containerd": {"--log-level": utils.Pointer("debug")}},
+				ConfigFiles: map[string]string{"file": "node1", "other": "content"},
+			},
+		},
+	}
+
+	t.Run("ForNode", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(serviceArgs.ForNode("node1")).To(Equal(types.ServiceArgsConfig{
+			Args: map[string]map[string]*string{
+				"kube-apiserver": {"--v": utils.Pointer("3")},
+				"kubelet":        {"--v": utils.Pointer("4"), "--max-pods": utils.Pointer("80")},
+				"containerd":     {"--log-level": utils.Pointer("debug")},
+			},
+			ConfigFiles: map[string]string{"file": "node1", "other": "content"},
+		}))
+		g.Expect(serviceArgs.ForNode("node2")).To(Equal(serviceArgs.ServiceArgsConfig))
+
+		// the cluster-wide configuration is not modified
+		g.Expect(serviceArgs.Args["kubelet"]).To(HaveKeyWithValue("--v", utils.Pointer("2")))
+	})
+
+	t.Run("ForServices", func(t *testing.T) {
+		g := NewWithT(t)
+
+		filtered := serviceArgs.ForServices(types.NodeServices)
+		g.Expect(filtered.Args).To(HaveLen(1))
+		g.Expect(filtered.Args).To(HaveKey("kubelet"))
+		g.Expect(filtered.ConfigFiles).To(Equal(serviceArgs.ConfigFiles))
+		g.Expect(filtered.Nodes["node1"].Args).To(HaveLen(2))
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(types.DiffServiceArgs(serviceArgs, serviceArgs)).To(BeEmpty())
+
+		updated := types.ServiceArgs{
+			ServiceArgsConfig: types.ServiceArgsConfig{
+				Args: map[string]map[string]*string{
+					"kubelet": {"--v": utils.Pointer("2"), "--max-pods": utils.Pointer("100"), "--node-ip": nil},
+				},
+				ConfigFiles: map[string]string{"file": "updated"},
+			},
+			Nodes: map[string]types.ServiceArgsConfig{
+				"node2": {ConfigFiles: map[string]string{"new": "content"}},
+			},
+		}
+		g.Expect(types.DiffServiceArgs(serviceArgs, updated)).To(Equal(`cluster-wide:
+  - kube-apiserver --v="3"
+  ~ kubelet --max-pods="80" -> "100"
+  + kubelet --node-ip=null
+  ~ file file
+node node1:
+  - containerd --log-level="debug"
+  - kubelet --v="4"
+  - file file
+  - file other
+node node2:
+  + file new`))
+	})
+
+	t.Run("ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cm, err := serviceArgs.ToConfigMap(nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(cm).To(HaveKey("service-args"))
+		g.Expect(cm).ToNot(HaveKey("service-args-mac"))
+
+		parsed, ok, err := types.ServiceArgsFromConfigMap(cm, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeTrue())
+		g.Expect(parsed).To(Equal(serviceArgs))
+
+		_, ok, err = types.ServiceArgsFromConfigMap(map[string]string{"cluster-dns": "10.0.0.1"}, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		g.Expect(err).To(Not(HaveOccurred()))
+		wrongKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		cm, err := serviceArgs.ToConfigMap(key)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(cm).To(HaveKey("service-args-mac"))
+
+		parsed, ok, err := types.ServiceArgsFromConfigMap(cm, &key.PublicKey)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeTrue())
+		g.Expect(parsed).To(Equal(serviceArgs))
+
+		_, _, err = types.ServiceArgsFromConfigMap(cm, &wrongKey.PublicKey)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
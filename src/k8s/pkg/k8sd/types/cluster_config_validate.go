@@ -1,13 +1,16 @@
 package types
 
 import (
+	"encoding/pem"
 	"fmt"
 	"net"
 	"net/netip"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/canonical/k8s/pkg/utils"
+	"gopkg.in/yaml.v2"
 )
 
 func validateCIDRs(cidrString string) error {
@@ -110,6 +113,11 @@ func (c *ClusterConfig) Validate() error {
 		}
 	}
 
+	// check: gateway configuration
+	if err := validateGateway(c.Gateway); err != nil {
+		return err
+	}
+
 	// check: load-balancer CIDRs
 	for _, cidr := range c.LoadBalancer.GetCIDRs() {
 		// Handle CIDR
@@ -171,6 +179,95 @@ func (c *ClusterConfig) Validate() error {
 		// TODO: ensure dns.service-ip is part of new.Network.ServiceCIDR
 	}
 
+	// check: OIDC configuration
+	if c.APIServer.OIDCEnabled() {
+		if u, err := url.Parse(c.APIServer.GetOIDCIssuerURL()); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%s must be a valid https URL", AnnotationOIDCIssuerURL)
+		}
+		if c.APIServer.GetOIDCClientID() == "" {
+			return fmt.Errorf("%s must be set when %s is set", AnnotationOIDCClientID, AnnotationOIDCIssuerURL)
+		}
+		if v := c.APIServer.GetOIDCCACert(); v != "" {
+			if block, _ := pem.Decode([]byte(v)); block == nil {
+				return fmt.Errorf("%s must be a PEM encoded certificate", AnnotationOIDCCACert)
+			}
+		}
+	} else if c.APIServer.OIDCClientID != nil || c.APIServer.OIDCUsernameClaim != nil || c.APIServer.OIDCUsernamePrefix != nil ||
+		c.APIServer.OIDCGroupsClaim != nil || c.APIServer.OIDCGroupsPrefix != nil || c.APIServer.OIDCCACert != nil {
+		return fmt.Errorf("%s must be set to configure OIDC authentication", AnnotationOIDCIssuerURL)
+	}
+
+	// check: audit configuration
+	if c.APIServer.AuditEnabled() {
+		var policy struct {
+			Kind string `yaml:"kind"`
+		}
+		if err := yaml.Unmarshal([]byte(c.APIServer.GetAuditPolicy()), &policy); err != nil {
+			return fmt.Errorf("%s must be a valid audit policy document: %w", AnnotationAuditPolicy, err)
+		} else if policy.Kind != "Policy" {
+			return fmt.Errorf("%s must be an audit policy document with kind Policy, not %q", AnnotationAuditPolicy, policy.Kind)
+		}
+		if c.APIServer.GetAuditLogPath() == "" && c.APIServer.GetAuditWebhookConfig() == "" {
+			return fmt.Errorf("one of %s or %s must be set when %s is set", AnnotationAuditLogPath, AnnotationAuditWebhookConfig, AnnotationAuditPolicy)
+		}
+		if v := c.APIServer.GetAuditLogPath(); v != "" && !filepath.IsAbs(v) {
+			return fmt.Errorf("%s must be an absolute path", AnnotationAuditLogPath)
+		}
+		for _, loop := range []struct {
+			annotation string
+			val        *int
+		}{
+			{annotation: AnnotationAuditLogMaxAge, val: c.APIServer.AuditLogMaxAge},
+			{annotation: AnnotationAuditLogMaxSize, val: c.APIServer.AuditLogMaxSize},
+			{annotation: AnnotationAuditLogMaxBackups, val: c.APIServer.AuditLogMaxBackups},
+		} {
+			if loop.val != nil && *loop.val < 0 {
+				return fmt.Errorf("%s must not be negative", loop.annotation)
+			}
+		}
+		if v := c.APIServer.GetAuditWebhookConfig(); v != "" {
+			var kubeconfig map[string]any
+			if err := yaml.Unmarshal([]byte(v), &kubeconfig); err != nil {
+				return fmt.Errorf("%s must be a valid kubeconfig document: %w", AnnotationAuditWebhookConfig, err)
+			}
+		}
+	} else if c.APIServer.AuditLogPath != nil || c.APIServer.AuditLogMaxAge != nil || c.APIServer.AuditLogMaxSize != nil ||
+		c.APIServer.AuditLogMaxBackups != nil || c.APIServer.AuditWebhookConfig != nil {
+		return fmt.Errorf("%s must be set to configure audit logging", AnnotationAuditPolicy)
+	}
+
+	// check: secrets encryption configuration
+	if err := validateSecretsEncryption(*c); err != nil {
+		return err
+	}
+
+	// check: admission configuration
+	if err := validateAdmission(c.APIServer); err != nil {
+		return err
+	}
+
+	// check: hardening profile
+	if err := validateHardeningProfile(*c); err != nil {
+		return err
+	}
+
+	// check: csrsigning configuration
+	if err := validateCSRSigning(*c); err != nil {
+		return err
+	}
+
+	// check: feature drift policy
+	if err := validateFeatureDriftPolicy(*c); err != nil {
+		return err
+	}
+
+	// check: service arguments
+	if c.ServiceArgs != nil {
+		if err := c.ServiceArgs.Validate(); err != nil {
+			return fmt.Errorf("invalid service arguments: %w", err)
+		}
+	}
+
 	// check: all external datastore servers are valid URLs
 	for _, server := range c.Datastore.GetExternalServers() {
 		if _, err := url.Parse(server); err != nil {
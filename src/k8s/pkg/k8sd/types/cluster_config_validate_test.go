@@ -131,3 +131,213 @@ func TestValidateExternalServers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOIDC(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		apiServer types.APIServer
+		expectErr bool
+	}{
+		{name: "Disabled"},
+		{
+			name: "Valid",
+			apiServer: types.APIServer{
+				OIDCIssuerURL:   utils.Pointer("https://issuer.example.com/realms/k8s"),
+				OIDCClientID:    utils.Pointer("k8s"),
+				OIDCGroupsClaim: utils.Pointer("groups"),
+			},
+		},
+		{
+			name: "InsecureIssuer",
+			apiServer: types.APIServer{
+				OIDCIssuerURL: utils.Pointer("http://issuer.example.com"),
+				OIDCClientID:  utils.Pointer("k8s"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "MissingClientID",
+			apiServer: types.APIServer{
+				OIDCIssuerURL: utils.Pointer("https://issuer.example.com"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "InvalidCACert",
+			apiServer: types.APIServer{
+				OIDCIssuerURL: utils.Pointer("https://issuer.example.com"),
+				OIDCClientID:  utils.Pointer("k8s"),
+				OIDCCACert:    utils.Pointer("not a certificate"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "MissingIssuer",
+			apiServer: types.APIServer{
+				OIDCClientID: utils.Pointer("k8s"),
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := types.ClusterConfig{APIServer: tc.apiServer}
+			config.SetDefaults()
+
+			err := config.Validate()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+			}
+		})
+	}
+}
+
+func TestValidateAudit(t *testing.T) {
+	policy := `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+`
+
+	for _, tc := range []struct {
+		name      string
+		apiServer types.APIServer
+		expectErr bool
+	}{
+		{name: "Disabled"},
+		{
+			name: "LogBackend",
+			apiServer: types.APIServer{
+				AuditPolicy:     utils.Pointer(policy),
+				AuditLogPath:    utils.Pointer("/var/log/kubernetes/audit.log"),
+				AuditLogMaxSize: utils.Pointer(100),
+			},
+		},
+		{
+			name: "WebhookBackend",
+			apiServer: types.APIServer{
+				AuditPolicy:        utils.Pointer(policy),
+				AuditWebhookConfig: utils.Pointer("apiVersion: v1\nkind: Config\n"),
+			},
+		},
+		{
+			name: "InvalidPolicy",
+			apiServer: types.APIServer{
+				AuditPolicy:  utils.Pointer("kind: ConfigMap"),
+				AuditLogPath: utils.Pointer("/var/log/kubernetes/audit.log"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "NoBackend",
+			apiServer: types.APIServer{
+				AuditPolicy: utils.Pointer(policy),
+			},
+			expectErr: true,
+		},
+		{
+			name: "RelativeLogPath",
+			apiServer: types.APIServer{
+				AuditPolicy:  utils.Pointer(policy),
+				AuditLogPath: utils.Pointer("audit.log"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "NegativeMaxAge",
+			apiServer: types.APIServer{
+				AuditPolicy:    utils.Pointer(policy),
+				AuditLogPath:   utils.Pointer("/var/log/kubernetes/audit.log"),
+				AuditLogMaxAge: utils.Pointer(-1),
+			},
+			expectErr: true,
+		},
+		{
+			name: "MissingPolicy",
+			apiServer: types.APIServer{
+				AuditLogPath: utils.Pointer("/var/log/kubernetes/audit.log"),
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := types.ClusterConfig{APIServer: tc.apiServer}
+			config.SetDefaults()
+
+			err := config.Validate()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+			}
+		})
+	}
+}
+
+func TestValidateSecretsEncryption(t *testing.T) {
+	key := types.SecretsEncryptionKey{Name: "key-1", Provider: "aescbc", Secret: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}
+
+	for _, tc := range []struct {
+		name         string
+		apiServer    types.APIServer
+		certificates types.Certificates
+		expectErr    bool
+	}{
+		{name: "Disabled"},
+		{
+			name:         "Enabled",
+			apiServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")},
+			certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{key}},
+		},
+		{
+			name:      "KMS",
+			apiServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("kms"), SecretsEncryptionKMSEndpoint: utils.Pointer("/run/kms/kms.sock")},
+		},
+		{
+			name:      "InvalidProvider",
+			apiServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("rot13")},
+			expectErr: true,
+		},
+		{
+			name:      "KMSWithoutEndpoint",
+			apiServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("kms")},
+			expectErr: true,
+		},
+		{
+			name:      "RelativeKMSEndpoint",
+			apiServer: types.APIServer{SecretsEncryptionProvider: utils.Pointer("kms"), SecretsEncryptionKMSEndpoint: utils.Pointer("kms.sock")},
+			expectErr: true,
+		},
+		{
+			name:         "DuplicateKeyNames",
+			apiServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")},
+			certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{key, key}},
+			expectErr:    true,
+		},
+		{
+			name:         "InvalidKeySize",
+			apiServer:    types.APIServer{SecretsEncryptionProvider: utils.Pointer("aescbc")},
+			certificates: types.Certificates{SecretsEncryptionKeys: &[]types.SecretsEncryptionKey{{Name: "key-1", Provider: "aescbc", Secret: "c2hvcnQ="}}},
+			expectErr:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := types.ClusterConfig{APIServer: tc.apiServer, Certificates: tc.certificates}
+			config.SetDefaults()
+
+			err := config.Validate()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+			}
+		})
+	}
+}
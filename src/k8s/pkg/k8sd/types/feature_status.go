@@ -6,16 +6,132 @@ import (
 	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
 )
 
+// FeatureHealth summarizes the health of a feature.
+type FeatureHealth string
+
+const (
+	// FeatureHealthHealthy means that the last health check of the feature succeeded.
+	FeatureHealthHealthy FeatureHealth = "healthy"
+	// FeatureHealthUnhealthy means that the feature failed to apply or the last health check failed.
+	FeatureHealthUnhealthy FeatureHealth = "unhealthy"
+	// FeatureHealthUnknown means that the feature has not been checked yet.
+	FeatureHealthUnknown FeatureHealth = "unknown"
+)
+
+const (
+	// FeatureConditionApplied reports whether the last reconciliation of the feature succeeded.
+	FeatureConditionApplied = "Applied"
+	// FeatureConditionHealthy reports whether the last health check of the feature succeeded.
+	FeatureConditionHealthy = "Healthy"
+	// FeatureConditionInSync reports whether the objects of the feature on the cluster match its manifests.
+	FeatureConditionInSync = "InSync"
+)
+
+const (
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+)
+
+// FeatureCondition describes one aspect of the current state of a feature.
+type FeatureCondition struct {
+	// Type is the type of the condition, e.g. "Applied" or "Healthy".
+	Type string `json:"type" yaml:"type"`
+	// Status is one of "True", "False" or "Unknown".
+	Status string `json:"status" yaml:"status"`
+	// Reason is a short, machine readable reason for the last transition.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Message is a human readable description of the condition.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// LastTransitionTime is the last time the status of the condition changed.
+	LastTransitionTime time.Time `json:"last-transition-time" yaml:"last-transition-time"`
+}
+
 // FeatureStatus encapsulates the deployment status of a feature.
 type FeatureStatus struct {
 	// Enabled shows whether or not the deployment of manifests for a status was successful.
-	Enabled bool
+	Enabled bool `json:"enabled" yaml:"enabled"`
 	// Message contains information about the status of a feature. It is only supposed to be human readable and informative and should not be programmatically parsed.
-	Message string
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 	// Version shows the version of the deployed feature.
-	Version string
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 	// UpdatedAt shows when the last update was done.
-	UpdatedAt time.Time
+	UpdatedAt time.Time `json:"updated-at" yaml:"updated-at"`
+	// Health summarizes the conditions of the feature.
+	Health FeatureHealth `json:"health,omitempty" yaml:"health,omitempty"`
+	// Conditions contains the latest observations of the feature state.
+	Conditions []FeatureCondition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// GetCondition returns the condition with the given type, if any.
+func (f FeatureStatus) GetCondition(conditionType string) (FeatureCondition, bool) {
+	for _, condition := range f.Conditions {
+		if condition.Type == conditionType {
+			return condition, true
+		}
+	}
+	return FeatureCondition{}, false
+}
+
+// SetCondition adds or updates a condition of the feature and recomputes its health.
+// LastTransitionTime is only updated if the status of the condition changed. If condition.LastTransitionTime
+// is not set, the current time is used.
+// SetCondition returns true if anything about the condition changed.
+func (f *FeatureStatus) SetCondition(condition FeatureCondition) bool {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = time.Now()
+	}
+
+	changed := true
+	found := false
+	for idx, existing := range f.Conditions {
+		if existing.Type != condition.Type {
+			continue
+		}
+		found = true
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+			changed = existing.Reason != condition.Reason || existing.Message != condition.Message
+		}
+		f.Conditions[idx] = condition
+		break
+	}
+	if !found {
+		f.Conditions = append(f.Conditions, condition)
+	}
+
+	f.Health = f.computeHealth()
+	return changed
+}
+
+// MergeConditions carries over conditions from a previous status of the feature.
+// Conditions that are already set on f take precedence, but keep the previous
+// LastTransitionTime if their status did not change.
+func (f *FeatureStatus) MergeConditions(previous FeatureStatus) {
+	current := f.Conditions
+	f.Conditions = append([]FeatureCondition(nil), previous.Conditions...)
+	for _, condition := range current {
+		f.SetCondition(condition)
+	}
+	f.Health = f.computeHealth()
+}
+
+func (f FeatureStatus) computeHealth() FeatureHealth {
+	if applied, ok := f.GetCondition(FeatureConditionApplied); ok && applied.Status == ConditionFalse {
+		return FeatureHealthUnhealthy
+	}
+	healthy, ok := f.GetCondition(FeatureConditionHealthy)
+	if !ok {
+		return FeatureHealthUnknown
+	}
+	switch healthy.Status {
+	case ConditionTrue:
+		return FeatureHealthHealthy
+	case ConditionFalse:
+		return FeatureHealthUnhealthy
+	default:
+		return FeatureHealthUnknown
+	}
 }
 
 func (f FeatureStatus) ToAPI() apiv1.FeatureStatus {
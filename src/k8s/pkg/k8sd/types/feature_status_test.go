@@ -40,3 +40,77 @@ func TestAPIFeatureStatusToK8sd(t *testing.T) {
 	g.Expect(k8sdFS.Version).To(Equal(apiFS.Version))
 	g.Expect(k8sdFS.UpdatedAt).To(Equal(apiFS.UpdatedAt))
 }
+
+func TestFeatureStatusSetCondition(t *testing.T) {
+	t0 := time.Now().Add(-time.Hour)
+
+	t.Run("New", func(t *testing.T) {
+		g := NewWithT(t)
+		status := types.FeatureStatus{}
+
+		g.Expect(status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue})).To(BeTrue())
+		g.Expect(status.Health).To(Equal(types.FeatureHealthHealthy))
+
+		condition, ok := status.GetCondition(types.FeatureConditionHealthy)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(condition.LastTransitionTime).ToNot(BeZero())
+	})
+
+	t.Run("SameStatusKeepsTransitionTime", func(t *testing.T) {
+		g := NewWithT(t)
+		status := types.FeatureStatus{
+			Conditions: []types.FeatureCondition{{Type: types.FeatureConditionHealthy, Status: types.ConditionFalse, Message: "pods not ready", LastTransitionTime: t0}},
+		}
+
+		g.Expect(status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionFalse, Message: "pods not ready"})).To(BeFalse())
+		g.Expect(status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionFalse, Message: "no pods"})).To(BeTrue())
+		g.Expect(status.Conditions).To(HaveLen(1))
+		g.Expect(status.Conditions[0].LastTransitionTime).To(Equal(t0))
+		g.Expect(status.Conditions[0].Message).To(Equal("no pods"))
+		g.Expect(status.Health).To(Equal(types.FeatureHealthUnhealthy))
+	})
+
+	t.Run("StatusChangeUpdatesTransitionTime", func(t *testing.T) {
+		g := NewWithT(t)
+		status := types.FeatureStatus{
+			Conditions: []types.FeatureCondition{{Type: types.FeatureConditionHealthy, Status: types.ConditionFalse, LastTransitionTime: t0}},
+		}
+
+		g.Expect(status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue})).To(BeTrue())
+		g.Expect(status.Conditions[0].LastTransitionTime).To(BeTemporally(">", t0))
+		g.Expect(status.Health).To(Equal(types.FeatureHealthHealthy))
+	})
+
+	t.Run("FailedApplyIsUnhealthy", func(t *testing.T) {
+		g := NewWithT(t)
+		status := types.FeatureStatus{}
+
+		status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue})
+		status.SetCondition(types.FeatureCondition{Type: types.FeatureConditionApplied, Status: types.ConditionFalse})
+		g.Expect(status.Health).To(Equal(types.FeatureHealthUnhealthy))
+	})
+}
+
+func TestFeatureStatusMergeConditions(t *testing.T) {
+	g := NewWithT(t)
+	t0 := time.Now().Add(-time.Hour)
+
+	previous := types.FeatureStatus{
+		Conditions: []types.FeatureCondition{
+			{Type: types.FeatureConditionApplied, Status: types.ConditionTrue, LastTransitionTime: t0},
+			{Type: types.FeatureConditionHealthy, Status: types.ConditionTrue, LastTransitionTime: t0},
+		},
+	}
+	status := types.FeatureStatus{
+		Conditions: []types.FeatureCondition{
+			{Type: types.FeatureConditionApplied, Status: types.ConditionTrue, Message: "enabled"},
+		},
+	}
+
+	status.MergeConditions(previous)
+	g.Expect(status.Conditions).To(HaveLen(2))
+	g.Expect(status.Conditions[0].Message).To(Equal("enabled"))
+	g.Expect(status.Conditions[0].LastTransitionTime).To(Equal(t0))
+	g.Expect(status.Conditions[1].Type).To(Equal(types.FeatureConditionHealthy))
+	g.Expect(status.Health).To(Equal(types.FeatureHealthHealthy))
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+	"path"
+
+	"sigs.k8s.io/yaml"
+)
+
+// InspectionReport is a set of files that are collected for troubleshooting a cluster.
+type InspectionReport struct {
+	// Files are the contents of the report, by path relative to the root of the report.
+	Files map[string][]byte `json:"files,omitempty"`
+	// Errors are the failures to collect parts of the report.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// AddFile adds a file to the report. Existing files are replaced.
+func (r *InspectionReport) AddFile(filePath string, data []byte) {
+	if r.Files == nil {
+		r.Files = make(map[string][]byte)
+	}
+	r.Files[filePath] = data
+}
+
+// AddYAML adds an object to the report as a YAML document.
+func (r *InspectionReport) AddYAML(filePath string, obj any) {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		r.AddError("failed to encode %s: %v", filePath, err)
+		return
+	}
+	r.AddFile(filePath, b)
+}
+
+// AddError records a failure to collect part of the report.
+func (r *InspectionReport) AddError(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// Merge adds the files and errors of another report, with their paths prefixed by dir.
+func (r *InspectionReport) Merge(dir string, other InspectionReport) {
+	for filePath, data := range other.Files {
+		r.AddFile(path.Join(dir, filePath), data)
+	}
+	for _, err := range other.Errors {
+		if dir != "" {
+			err = fmt.Sprintf("%s: %s", dir, err)
+		}
+		r.Errors = append(r.Errors, err)
+	}
+}
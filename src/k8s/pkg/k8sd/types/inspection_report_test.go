@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestInspectionReport(t *testing.T) {
+	g := NewWithT(t)
+
+	var node types.InspectionReport
+	node.AddFile("services/kubelet.log", []byte("logs"))
+	node.AddYAML("services.yaml", map[string]string{"kubelet": "active"})
+	node.AddError("failed to get logs of service %s", "containerd")
+
+	var report types.InspectionReport
+	report.AddFile("config.yaml", []byte("config"))
+	report.AddError("failed to list nodes")
+	report.Merge("nodes/node1", node)
+
+	g.Expect(report.Files).To(Equal(map[string][]byte{
+		"config.yaml":                      []byte("config"),
+		"nodes/node1/services/kubelet.log": []byte("logs"),
+		"nodes/node1/services.yaml":        []byte("kubelet: active\n"),
+	}))
+	g.Expect(report.Errors).To(Equal([]string{
+		"failed to list nodes",
+		"nodes/node1: failed to get logs of service containerd",
+	}))
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// JoinToken describes an outstanding token that can be used to join a node to the cluster.
+// The token itself is not part of the description.
+type JoinToken struct {
+	// ID identifies a worker node token. Control plane tokens are identified by their name.
+	ID int64 `json:"id,omitempty" yaml:"id,omitempty"`
+	// Name is the name of the node that can join with the token. Worker node tokens without a name can be used by any node.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Worker is true for tokens that join worker nodes.
+	Worker bool `json:"worker" yaml:"worker"`
+	// Expiry is the time after which the token can no longer be used.
+	Expiry time.Time `json:"expiry" yaml:"expiry"`
+	// MultiUse is true for worker node tokens that can be used to join more than one node.
+	// Control plane tokens are always single-use.
+	MultiUse bool `json:"multi-use,omitempty" yaml:"multi-use,omitempty"`
+	// AllowedCIDRs restricts the addresses of worker nodes that can join with the token. Any address is allowed if empty.
+	AllowedCIDRs []string `json:"allowed-cidrs,omitempty" yaml:"allowed-cidrs,omitempty"`
+	// Pool is the node pool the joining node becomes a member of.
+	Pool string `json:"pool,omitempty" yaml:"pool,omitempty"`
+}
+
+// ValidateAllowedCIDRs checks that the allowed CIDRs of the token are valid.
+func (t JoinToken) ValidateAllowedCIDRs() error {
+	for _, cidr := range t.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid allowed CIDR %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+// AllowsAddress returns true if a node with the specified IP address may join with the token.
+// AllowsAddress returns false for addresses that cannot be parsed, unless the token allows any address.
+func (t JoinToken) AllowsAddress(address string) bool {
+	if len(t.AllowedCIDRs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range t.AllowedCIDRs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestJoinTokenAllowsAddress(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		allowedCIDRs []string
+		address      string
+		expectAllow  bool
+	}{
+		{name: "AnyAddress", address: "10.0.0.1", expectAllow: true},
+		{name: "AnyInvalidAddress", address: "invalid", expectAllow: true},
+		{name: "InRange", allowedCIDRs: []string{"10.0.0.0/24"}, address: "10.0.0.1", expectAllow: true},
+		{name: "OutOfRange", allowedCIDRs: []string{"10.0.0.0/24"}, address: "10.0.1.1"},
+		{name: "SecondRange", allowedCIDRs: []string{"10.0.0.0/24", "192.168.0.0/16"}, address: "192.168.10.1", expectAllow: true},
+		{name: "IPv6", allowedCIDRs: []string{"fd00::/64"}, address: "fd00::10", expectAllow: true},
+		{name: "IPv6OutOfRange", allowedCIDRs: []string{"10.0.0.0/24"}, address: "fd00::10"},
+		{name: "InvalidAddress", allowedCIDRs: []string{"10.0.0.0/24"}, address: "invalid"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			token := types.JoinToken{AllowedCIDRs: tc.allowedCIDRs}
+			g.Expect(token.AllowsAddress(tc.address)).To(Equal(tc.expectAllow))
+		})
+	}
+}
+
+func TestJoinTokenValidateAllowedCIDRs(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		g := NewWithT(t)
+		token := types.JoinToken{AllowedCIDRs: []string{"10.0.0.0/8", "fd00::/64"}}
+		g.Expect(token.ValidateAllowedCIDRs()).To(Succeed())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+		token := types.JoinToken{AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.1"}}
+		g.Expect(token.ValidateAllowedCIDRs()).ToNot(Succeed())
+	})
+}
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// KubernetesAuthToken describes a token that is used to authenticate with the Kubernetes API server.
+// The token itself is never stored, so it is not part of the description.
+type KubernetesAuthToken struct {
+	// ID uniquely identifies the token.
+	ID int64 `json:"id" yaml:"id"`
+	// Username is the name of the user that is authenticated with the token.
+	Username string `json:"username" yaml:"username"`
+	// Groups are the groups of the user that is authenticated with the token.
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Description is a human readable description of the token.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// CreatedAt is the time the token was created. It is not set for tokens created before it was recorded.
+	CreatedAt time.Time `json:"created-at,omitempty" yaml:"created-at,omitempty"`
+	// Expiry is the time after which the token is no longer valid. Tokens without an expiry never expire.
+	Expiry time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	// LastUsedAt is the last time the token was used to authenticate.
+	LastUsedAt time.Time `json:"last-used-at,omitempty" yaml:"last-used-at,omitempty"`
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+)
+
+// ServiceState is the state of a k8s service on a node.
+type ServiceState string
+
+const (
+	ServiceStateActive   ServiceState = "active"
+	ServiceStateInactive ServiceState = "inactive"
+	ServiceStateUnknown  ServiceState = "unknown"
+)
+
+// NodeDatastoreStatus is the state of the local datastore member of a control plane node.
+type NodeDatastoreStatus struct {
+	// Role is the role of the node in the datastore cluster.
+	Role apiv1.DatastoreRole `json:"role,omitempty" yaml:"role,omitempty"`
+	// Leader is the address of the current datastore leader.
+	Leader string `json:"leader,omitempty" yaml:"leader,omitempty"`
+	// IsLeader is true if this node is the datastore leader.
+	IsLeader bool `json:"is-leader" yaml:"is-leader"`
+	// Latency is the round-trip time for querying the datastore leader from this node.
+	Latency time.Duration `json:"latency,omitempty" yaml:"latency,omitempty"`
+	// Error is set if the datastore could not be reached.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NodeDetails holds detailed information about a single node of the cluster.
+type NodeDetails struct {
+	// Name is the name of the node.
+	Name string `json:"name" yaml:"name"`
+	// Address is the address of the node.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// ClusterRole is the role of the node in the cluster.
+	ClusterRole apiv1.ClusterRole `json:"cluster-role,omitempty" yaml:"cluster-role,omitempty"`
+	// Ready is true if the Kubernetes node is ready.
+	Ready bool `json:"ready" yaml:"ready"`
+	// SnapRevision is the revision of the k8s snap installed on the node.
+	SnapRevision string `json:"snap-revision,omitempty" yaml:"snap-revision,omitempty"`
+	// KubernetesVersion is the version of Kubernetes running on the node.
+	KubernetesVersion string `json:"kubernetes-version,omitempty" yaml:"kubernetes-version,omitempty"`
+	// Services is the state of the k8s services on the node.
+	Services map[string]ServiceState `json:"services,omitempty" yaml:"services,omitempty"`
+	// Datastore is the state of the datastore member on the node. It is only set for
+	// control plane nodes using the managed datastore.
+	Datastore *NodeDatastoreStatus `json:"datastore,omitempty" yaml:"datastore,omitempty"`
+	// CertificatesExpiry is the earliest expiry time of the certificates of the node.
+	CertificatesExpiry time.Time `json:"certificates-expiry,omitempty" yaml:"certificates-expiry,omitempty"`
+	// Error is set if the details of the node could not be retrieved.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
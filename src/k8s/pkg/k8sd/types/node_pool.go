@@ -0,0 +1,157 @@
+package types
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// NodePool is a named configuration profile that is applied on all nodes that are members of the pool.
+type NodePool struct {
+	// Name uniquely identifies the node pool.
+	Name string `json:"name" yaml:"name"`
+	// Labels are set on the Kubernetes Node objects of the pool members.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Taints are set on the Kubernetes Node objects of the pool members, in the "key=value:Effect" format.
+	Taints []string `json:"taints,omitempty" yaml:"taints,omitempty"`
+
+	// ExtraNodeKubeletArgs are extra arguments for kubelet. A nil value removes the argument.
+	ExtraNodeKubeletArgs map[string]*string `json:"extra-node-kubelet-args,omitempty" yaml:"extra-node-kubelet-args,omitempty"`
+	// ExtraNodeContainerdArgs are extra arguments for containerd. A nil value removes the argument.
+	ExtraNodeContainerdArgs map[string]*string `json:"extra-node-containerd-args,omitempty" yaml:"extra-node-containerd-args,omitempty"`
+	// ExtraNodeKubeProxyArgs are extra arguments for kube-proxy. A nil value removes the argument.
+	ExtraNodeKubeProxyArgs map[string]*string `json:"extra-node-kube-proxy-args,omitempty" yaml:"extra-node-kube-proxy-args,omitempty"`
+	// ExtraNodeConfigFiles are extra files written in the extra configuration directory of the services.
+	ExtraNodeConfigFiles map[string]string `json:"extra-node-config-files,omitempty" yaml:"extra-node-config-files,omitempty"`
+}
+
+// Validate checks that the node pool configuration is valid.
+func (p NodePool) Validate() error {
+	if errs := validation.IsDNS1123Label(p.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid name %q: %s", p.Name, strings.Join(errs, ", "))
+	}
+	for key, value := range p.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q for label %q: %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+	for _, taint := range p.Taints {
+		if _, err := ParseTaint(taint); err != nil {
+			return fmt.Errorf("invalid taint %q: %w", taint, err)
+		}
+	}
+	for filename := range p.ExtraNodeConfigFiles {
+		if filename == "" || strings.Contains(filename, "/") {
+			return fmt.Errorf("invalid file name %q: must not be empty or contain any slashes", filename)
+		}
+	}
+	return nil
+}
+
+// ParseTaint parses a taint in the "key=value:Effect" or "key:Effect" format.
+func ParseTaint(s string) (corev1.Taint, error) {
+	keyValue, effect, ok := strings.Cut(s, ":")
+	if !ok {
+		return corev1.Taint{}, fmt.Errorf("missing effect")
+	}
+	key, value, _ := strings.Cut(keyValue, "=")
+
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return corev1.Taint{}, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return corev1.Taint{}, fmt.Errorf("invalid value %q: %s", value, strings.Join(errs, ", "))
+	}
+	switch corev1.TaintEffect(effect) {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return corev1.Taint{}, fmt.Errorf("unknown effect %q", effect)
+	}
+
+	return corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)}, nil
+}
+
+// NodePools is the configuration of all node pools and their members, as distributed to the cluster nodes.
+type NodePools struct {
+	// Pools is the list of node pools, sorted by name.
+	Pools []NodePool `json:"pools,omitempty"`
+	// Members maps node names to the name of their node pool.
+	Members map[string]string `json:"members,omitempty"`
+}
+
+// PoolForNode returns the node pool of the specified node.
+// PoolForNode returns false if the node is not a member of any node pool.
+func (p NodePools) PoolForNode(nodeName string) (NodePool, bool) {
+	poolName, ok := p.Members[nodeName]
+	if !ok {
+		return NodePool{}, false
+	}
+	for _, pool := range p.Pools {
+		if pool.Name == poolName {
+			return pool, true
+		}
+	}
+	return NodePool{}, false
+}
+
+// ToConfigMap converts the node pools to a map[string]string to store in a Kubernetes configmap.
+// ToConfigMap will append a "node-pools-mac" field with a signed hash of the contents, if a key is specified.
+func (p NodePools) ToConfigMap(key *rsa.PrivateKey) (map[string]string, error) {
+	// encoding/json.Marshal() ensures alphabetical order on map keys, so will
+	// always produce the same JSON document.
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node pools: %w", err)
+	}
+
+	data := map[string]string{"node-pools": string(b)}
+
+	if key != nil {
+		hash := sha256.Sum256(b)
+		mac, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign hash: %w", err)
+		}
+		data["node-pools-mac"] = base64.StdEncoding.EncodeToString(mac)
+	}
+
+	return data, nil
+}
+
+// NodePoolsFromConfigMap parses configmap data into the node pools configuration.
+// NodePoolsFromConfigMap will attempt to validate the signature (found in the "node-pools-mac" field) if a key is specified.
+// NodePoolsFromConfigMap returns false if the configmap does not contain any node pools configuration.
+func NodePoolsFromConfigMap(m map[string]string, key *rsa.PublicKey) (NodePools, bool, error) {
+	v, ok := m["node-pools"]
+	if !ok {
+		return NodePools{}, false, nil
+	}
+
+	if key != nil {
+		hash := sha256.Sum256([]byte(v))
+		signature, err := base64.StdEncoding.DecodeString(m["node-pools-mac"])
+		if err != nil {
+			return NodePools{}, false, fmt.Errorf("failed to parse signature: %w", err)
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature); err != nil {
+			return NodePools{}, false, fmt.Errorf("failed to verify signature: %w", err)
+		}
+	}
+
+	var p NodePools
+	if err := json.Unmarshal([]byte(v), &p); err != nil {
+		return NodePools{}, false, fmt.Errorf("failed to parse node pools: %w", err)
+	}
+	return p, true, nil
+}
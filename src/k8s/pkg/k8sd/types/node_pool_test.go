@@ -0,0 +1,141 @@
+package types_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/canonical/k8s/pkg/k8sd/types"
+	"github.com/canonical/k8s/pkg/utils"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNodePoolValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		pool      types.NodePool
+		expectErr bool
+	}{
+		{name: "Valid", pool: types.NodePool{
+			Name:                 "gpu",
+			Labels:               map[string]string{"example.com/accelerator": "nvidia"},
+			Taints:               []string{"gpu=true:NoSchedule", "dedicated:NoExecute"},
+			ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("50"), "--v": nil},
+			ExtraNodeConfigFiles: map[string]string{"file.yaml": "content"},
+		}},
+		{name: "MissingName", pool: types.NodePool{}, expectErr: true},
+		{name: "InvalidName", pool: types.NodePool{Name: "GPU_Pool"}, expectErr: true},
+		{name: "InvalidLabelKey", pool: types.NodePool{Name: "gpu", Labels: map[string]string{"a b": "c"}}, expectErr: true},
+		{name: "InvalidLabelValue", pool: types.NodePool{Name: "gpu", Labels: map[string]string{"a": "b c"}}, expectErr: true},
+		{name: "InvalidTaint", pool: types.NodePool{Name: "gpu", Taints: []string{"gpu=true"}}, expectErr: true},
+		{name: "InvalidFileName", pool: types.NodePool{Name: "gpu", ExtraNodeConfigFiles: map[string]string{"../file": "content"}}, expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.pool.Validate()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+			}
+		})
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	for _, tc := range []struct {
+		taint     string
+		expect    corev1.Taint
+		expectErr bool
+	}{
+		{taint: "key=value:NoSchedule", expect: corev1.Taint{Key: "key", Value: "value", Effect: corev1.TaintEffectNoSchedule}},
+		{taint: "example.com/key:NoExecute", expect: corev1.Taint{Key: "example.com/key", Effect: corev1.TaintEffectNoExecute}},
+		{taint: "key=:PreferNoSchedule", expect: corev1.Taint{Key: "key", Effect: corev1.TaintEffectPreferNoSchedule}},
+		{taint: "key=value", expectErr: true},
+		{taint: "key=value:Unknown", expectErr: true},
+		{taint: "=value:NoSchedule", expectErr: true},
+	} {
+		t.Run(tc.taint, func(t *testing.T) {
+			g := NewWithT(t)
+			taint, err := types.ParseTaint(tc.taint)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Not(HaveOccurred()))
+				g.Expect(taint).To(Equal(tc.expect))
+			}
+		})
+	}
+}
+
+func TestNodePools(t *testing.T) {
+	nodePools := types.NodePools{
+		Pools: []types.NodePool{
+			{Name: "edge", ExtraNodeKubeletArgs: map[string]*string{"--max-pods": utils.Pointer("20")}},
+			{Name: "gpu", Labels: map[string]string{"accelerator": "nvidia"}},
+		},
+		Members: map[string]string{"node1": "gpu", "node2": "missing"},
+	}
+
+	t.Run("PoolForNode", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pool, ok := nodePools.PoolForNode("node1")
+		g.Expect(ok).To(BeTrue())
+		g.Expect(pool).To(Equal(nodePools.Pools[1]))
+
+		_, ok = nodePools.PoolForNode("node2")
+		g.Expect(ok).To(BeFalse())
+
+		_, ok = nodePools.PoolForNode("node3")
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cm, err := nodePools.ToConfigMap(nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(cm).To(HaveKey("node-pools"))
+		g.Expect(cm).ToNot(HaveKey("node-pools-mac"))
+
+		parsed, ok, err := types.NodePoolsFromConfigMap(cm, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeTrue())
+		g.Expect(parsed).To(Equal(nodePools))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, ok, err := types.NodePoolsFromConfigMap(map[string]string{"cluster-dns": "10.0.0.1"}, nil)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		g.Expect(err).To(Not(HaveOccurred()))
+		wrongKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		cm, err := nodePools.ToConfigMap(key)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(cm).To(HaveKey("node-pools-mac"))
+
+		parsed, ok, err := types.NodePoolsFromConfigMap(cm, &key.PublicKey)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(ok).To(BeTrue())
+		g.Expect(parsed).To(Equal(nodePools))
+
+		_, _, err = types.NodePoolsFromConfigMap(cm, &wrongKey.PublicKey)
+		g.Expect(err).To(HaveOccurred())
+
+		cm["node-pools"] = `{}`
+		_, _, err = types.NodePoolsFromConfigMap(cm, &key.PublicKey)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -0,0 +1,17 @@
+package types
+
+// QueryDatabaseRPC is the path for the QueryDatabase RPC.
+const QueryDatabaseRPC = "k8sd/database/query"
+
+// QueryDatabaseRequest is the request message for the QueryDatabase RPC.
+type QueryDatabaseRequest struct {
+	// Query is a single SELECT statement against the k8sd tables.
+	Query string `json:"query"`
+}
+
+// QueryDatabaseResponse is the response message for the QueryDatabase RPC.
+// Secrets in the result are redacted.
+type QueryDatabaseResponse struct {
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
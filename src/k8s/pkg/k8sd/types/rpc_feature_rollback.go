@@ -0,0 +1,26 @@
+package types
+
+// RollbackFeatureRPC is the path for the RollbackFeature RPC.
+const RollbackFeatureRPC = "k8sd/feature-rollback"
+
+// RollbackFeatureRequest is the request message for the RollbackFeature RPC.
+type RollbackFeatureRequest struct {
+	// Name is the name of the feature to roll back.
+	Name FeatureName `json:"name"`
+}
+
+// RollbackFeatureResponse is the response message for the RollbackFeature RPC.
+type RollbackFeatureResponse struct {
+	// Charts are the Helm releases of the feature that were rolled back.
+	Charts []FeatureChartRollback `json:"charts"`
+}
+
+// FeatureChartRollback describes the rollback of a Helm release of a feature.
+type FeatureChartRollback struct {
+	// Name is the name of the Helm release.
+	Name string `json:"name" yaml:"name"`
+	// FromRevision is the revision of the release before the rollback.
+	FromRevision int `json:"from-revision" yaml:"from-revision"`
+	// ToRevision is the revision of the release that was restored.
+	ToRevision int `json:"to-revision" yaml:"to-revision"`
+}
@@ -0,0 +1,15 @@
+package types
+
+// GetFeatureStatusRPC is the path for the GetFeatureStatus RPC.
+const GetFeatureStatusRPC = "k8sd/feature-status"
+
+// GetFeatureStatusRequest is the request message for the GetFeatureStatus RPC.
+type GetFeatureStatusRequest struct {
+	// Name is the name of the feature. If empty, the status of all features is returned.
+	Name FeatureName `json:"name,omitempty"`
+}
+
+// GetFeatureStatusResponse is the response message for the GetFeatureStatus RPC.
+type GetFeatureStatusResponse struct {
+	Features map[FeatureName]FeatureStatus `json:"features"`
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+)
+
+// GetJoinTokenRPC is the path for the GetJoinToken RPC.
+const GetJoinTokenRPC = apiv1.GetJoinTokenRPC
+
+// GetJoinTokenRequest is the request message for the GetJoinToken RPC.
+// It extends apiv1.GetJoinTokenRequest with the option to add the node to a node pool and to limit the use of worker node tokens.
+type GetJoinTokenRequest struct {
+	apiv1.GetJoinTokenRequest
+
+	// Pool is the name of the node pool the node will be a member of. Requires a node name for control plane nodes.
+	Pool string `json:"pool,omitempty"`
+	// MultiUse allows a worker node token to be used to join more than one node until it expires.
+	// Control plane tokens are always single-use.
+	MultiUse bool `json:"multi-use,omitempty"`
+	// AllowedCIDRs restricts the addresses of the worker nodes that can join with the token.
+	AllowedCIDRs []string `json:"allowed-cidrs,omitempty"`
+}
+
+// ListJoinTokensRPC is the path for the ListJoinTokens RPC.
+const ListJoinTokensRPC = apiv1.GetJoinTokenRPC
+
+// ListJoinTokensRequest is the request message for the ListJoinTokens RPC.
+type ListJoinTokensRequest struct{}
+
+// ListJoinTokensResponse is the response message for the ListJoinTokens RPC.
+type ListJoinTokensResponse struct {
+	Tokens []JoinToken `json:"tokens"`
+}
+
+// RevokeJoinTokenRPC is the path for the RevokeJoinToken RPC.
+const RevokeJoinTokenRPC = apiv1.GetJoinTokenRPC
+
+// RevokeJoinTokenRequest is the request message for the RevokeJoinToken RPC.
+// Either ID or Name must be set. ID identifies a worker node token.
+// Name identifies the control plane token of a node, or all worker node tokens of a node if Worker is set.
+type RevokeJoinTokenRequest struct {
+	ID     int64  `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Worker bool   `json:"worker,omitempty"`
+}
+
+// RevokeJoinTokenResponse is the response message for the RevokeJoinToken RPC.
+type RevokeJoinTokenResponse struct{}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// InspectRPC is the path for the Inspect RPC.
+const InspectRPC = "k8sd/inspect"
+
+// InspectRequest is the request message for the Inspect RPC.
+type InspectRequest struct {
+	// Node collects the information of the local node (service logs and arguments, certificates, system diagnostics).
+	Node bool `json:"node,omitempty"`
+	// Cluster collects the cluster-wide information (configuration, feature statuses, datastore members,
+	// Kubernetes objects and events).
+	Cluster bool `json:"cluster,omitempty"`
+	// Members collects the information of all other cluster members. The files of each member are prefixed
+	// with "nodes/<name>/".
+	Members bool `json:"members,omitempty"`
+	// Options configure the collection.
+	Options InspectOptions `json:"options"`
+}
+
+// InspectOptions configure the collection of inspection reports.
+type InspectOptions struct {
+	// NumLogEntries is the maximum number of log entries to collect for each service.
+	NumLogEntries int `json:"num-log-entries,omitempty"`
+	// AllNamespaces collects Kubernetes objects and events from all namespaces, instead of the
+	// "default" and "kube-system" namespaces only.
+	AllNamespaces bool `json:"all-namespaces,omitempty"`
+	// Timeout is the maximum time to wait for each command.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// CoreDumpDir is the directory with the core dumps to collect. Core dumps are not collected if empty.
+	// Core dumps are only collected from the local node by the CLI, as they can be very large. CoreDumpDir is
+	// never sent to k8sd, so that k8sd does not read host paths chosen by the client.
+	CoreDumpDir string `json:"-"`
+}
+
+// InspectResponse is the response message for the Inspect RPC.
+type InspectResponse struct {
+	Report InspectionReport `json:"report"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+)
+
+// KubeConfigRPC is the path for the KubeConfig RPC.
+const KubeConfigRPC = apiv1.KubeConfigRPC
+
+// KubeConfigRequest is the request message for the KubeConfig RPC.
+// It extends apiv1.KubeConfigRequest with the option to generate an OpenID Connect kubeconfig
+// or a kubeconfig for a user with limited permissions.
+type KubeConfigRequest struct {
+	// Server is the address of the kube-apiserver to use in the kubeconfig.
+	Server string `json:"server"`
+	// OIDC generates a kubeconfig that authenticates using the configured OpenID Connect issuer
+	// through the "kubectl oidc-login" exec credential plugin, instead of the admin client certificate.
+	OIDC bool `json:"oidc,omitempty"`
+
+	// Username generates a kubeconfig for the specified user instead of the cluster admin.
+	// The user authenticates with a new k8sd auth token, which can be listed and revoked later.
+	Username string `json:"username,omitempty"`
+	// Groups is the list of groups of the user.
+	Groups []string `json:"groups,omitempty"`
+	// Role is the name of a ClusterRole to grant to the user. If empty, no permissions are granted.
+	Role string `json:"role,omitempty"`
+	// Namespace limits the Role to a namespace (using a RoleBinding). If empty, the role is granted
+	// cluster-wide (using a ClusterRoleBinding). Namespace is also the default namespace of the kubeconfig.
+	Namespace string `json:"namespace,omitempty"`
+	// TTL is the duration the credentials are valid for. A zero TTL means that the credentials do not expire.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
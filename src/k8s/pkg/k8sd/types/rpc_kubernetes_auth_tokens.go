@@ -0,0 +1,50 @@
+package types
+
+import (
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+)
+
+// GenerateKubernetesAuthTokenRPC is the path for the GenerateKubernetesAuthToken RPC.
+const GenerateKubernetesAuthTokenRPC = apiv1.GenerateKubernetesAuthTokenRPC
+
+// GenerateKubernetesAuthTokenRequest is the request message for the GenerateKubernetesAuthToken RPC.
+// It extends apiv1.GenerateKubernetesAuthTokenRequest with an optional description and time to live.
+type GenerateKubernetesAuthTokenRequest struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+	// Description is a human readable description of the token.
+	Description string `json:"description,omitempty"`
+	// TTL is the duration the token is valid for. A zero TTL means that the token does not expire.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// GenerateKubernetesAuthTokenResponse is the response message for the GenerateKubernetesAuthToken RPC.
+type GenerateKubernetesAuthTokenResponse struct {
+	// ID identifies the token, e.g. to revoke it.
+	ID int64 `json:"id"`
+	// Token is the generated token. It cannot be retrieved again.
+	Token string `json:"token"`
+}
+
+// RevokeKubernetesAuthTokenRPC is the path for the RevokeKubernetesAuthToken RPC.
+const RevokeKubernetesAuthTokenRPC = apiv1.RevokeKubernetesAuthTokenRPC
+
+// RevokeKubernetesAuthTokenRequest is the request message for the RevokeKubernetesAuthToken RPC.
+// Exactly one of Token or ID must be set.
+type RevokeKubernetesAuthTokenRequest struct {
+	Token string `json:"token,omitempty"`
+	ID    int64  `json:"id,omitempty"`
+}
+
+// ListKubernetesAuthTokensRPC is the path for the ListKubernetesAuthTokens RPC.
+const ListKubernetesAuthTokensRPC = apiv1.GenerateKubernetesAuthTokenRPC
+
+// ListKubernetesAuthTokensRequest is the request message for the ListKubernetesAuthTokens RPC.
+type ListKubernetesAuthTokensRequest struct{}
+
+// ListKubernetesAuthTokensResponse is the response message for the ListKubernetesAuthTokens RPC.
+type ListKubernetesAuthTokensResponse struct {
+	Tokens []KubernetesAuthToken `json:"tokens"`
+}
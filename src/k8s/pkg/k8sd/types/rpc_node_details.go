@@ -0,0 +1,23 @@
+package types
+
+// GetNodeDetailsRPC is the path for the GetNodeDetails RPC.
+const GetNodeDetailsRPC = "k8sd/node/details"
+
+// GetNodeDetailsRequest is the request message for the GetNodeDetails RPC.
+type GetNodeDetailsRequest struct{}
+
+// GetNodeDetailsResponse is the response message for the GetNodeDetails RPC.
+type GetNodeDetailsResponse struct {
+	Node NodeDetails `json:"node"`
+}
+
+// GetClusterNodesRPC is the path for the GetClusterNodes RPC.
+const GetClusterNodesRPC = "k8sd/cluster/nodes"
+
+// GetClusterNodesRequest is the request message for the GetClusterNodes RPC.
+type GetClusterNodesRequest struct{}
+
+// GetClusterNodesResponse is the response message for the GetClusterNodes RPC.
+type GetClusterNodesResponse struct {
+	Nodes []NodeDetails `json:"nodes"`
+}
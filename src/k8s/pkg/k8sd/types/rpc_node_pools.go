@@ -0,0 +1,38 @@
+package types
+
+// ListNodePoolsRPC is the path for the ListNodePools RPC.
+const ListNodePoolsRPC = "k8sd/node-pools"
+
+// ListNodePoolsRequest is the request message for the ListNodePools RPC.
+type ListNodePoolsRequest struct{}
+
+// ListNodePoolsResponse is the response message for the ListNodePools RPC.
+type ListNodePoolsResponse struct {
+	// Pools is the list of node pools, sorted by name.
+	Pools []NodePool `json:"pools"`
+	// Members maps node names to the name of their node pool.
+	Members map[string]string `json:"members,omitempty"`
+}
+
+// SetNodePoolRPC is the path for the SetNodePool RPC.
+const SetNodePoolRPC = ListNodePoolsRPC
+
+// SetNodePoolRequest is the request message for the SetNodePool RPC.
+// SetNodePool creates a new node pool, or replaces the configuration of an existing node pool.
+type SetNodePoolRequest struct {
+	Pool NodePool `json:"pool"`
+}
+
+// SetNodePoolResponse is the response message for the SetNodePool RPC.
+type SetNodePoolResponse struct{}
+
+// DeleteNodePoolRPC is the path for the DeleteNodePool RPC.
+const DeleteNodePoolRPC = ListNodePoolsRPC
+
+// DeleteNodePoolRequest is the request message for the DeleteNodePool RPC.
+type DeleteNodePoolRequest struct {
+	Name string `json:"name"`
+}
+
+// DeleteNodePoolResponse is the response message for the DeleteNodePool RPC.
+type DeleteNodePoolResponse struct{}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"time"
+
+	apiv1 "github.com/canonical/k8s-snap-api/api/v1"
+)
+
+// DefaultDrainTimeout is the max time to wait for the pods of a node to be evicted, if not specified.
+const DefaultDrainTimeout = 5 * time.Minute
+
+// RemoveNodeRPC is the path for the RemoveNode RPC.
+const RemoveNodeRPC = apiv1.RemoveNodeRPC
+
+// RemoveNodeRequest is the request message for the RemoveNode RPC.
+// It extends apiv1.RemoveNodeRequest with the options to drain the node before it is removed.
+type RemoveNodeRequest struct {
+	apiv1.RemoveNodeRequest
+
+	// SkipDrain removes the node without cordoning it and evicting its pods first.
+	// Forced removals never drain the node.
+	SkipDrain bool `json:"skip-drain,omitempty"`
+	// DrainTimeout is the max time to wait for the pods of the node to be evicted.
+	// If not set, DefaultDrainTimeout is used.
+	DrainTimeout time.Duration `json:"drain-timeout,omitempty"`
+}
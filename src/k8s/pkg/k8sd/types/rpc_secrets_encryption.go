@@ -0,0 +1,25 @@
+package types
+
+// RotateSecretsEncryptionKeyRPC is the path for the RotateSecretsEncryptionKey RPC.
+const RotateSecretsEncryptionKeyRPC = "k8sd/secrets-encryption/rotate"
+
+// RotateSecretsEncryptionKeyRequest is the request message for the RotateSecretsEncryptionKey RPC.
+type RotateSecretsEncryptionKeyRequest struct{}
+
+// RotateSecretsEncryptionKeyResponse is the response message for the RotateSecretsEncryptionKey RPC.
+type RotateSecretsEncryptionKeyResponse struct {
+	// KeyName is the name of the new key used to encrypt Secrets. It is empty if secrets encryption was disabled.
+	KeyName string `json:"key-name,omitempty"`
+	// RewrittenSecrets is the number of Secrets that were re-encrypted with the new key.
+	RewrittenSecrets int `json:"rewritten-secrets"`
+}
+
+// ApplySecretsEncryptionConfigRPC is the path for the ApplySecretsEncryptionConfig RPC.
+// ApplySecretsEncryptionConfig is used internally to apply the secrets encryption configuration on each control plane node.
+const ApplySecretsEncryptionConfigRPC = "k8sd/secrets-encryption/apply"
+
+// ApplySecretsEncryptionConfigRequest is the request message for the ApplySecretsEncryptionConfig RPC.
+type ApplySecretsEncryptionConfigRequest struct{}
+
+// ApplySecretsEncryptionConfigResponse is the response message for the ApplySecretsEncryptionConfig RPC.
+type ApplySecretsEncryptionConfigResponse struct{}
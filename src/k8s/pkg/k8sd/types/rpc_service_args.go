@@ -0,0 +1,25 @@
+package types
+
+// GetServiceArgsRPC is the path for the GetServiceArgs RPC.
+const GetServiceArgsRPC = "k8sd/service-args"
+
+// GetServiceArgsRequest is the request message for the GetServiceArgs RPC.
+type GetServiceArgsRequest struct{}
+
+// GetServiceArgsResponse is the response message for the GetServiceArgs RPC.
+type GetServiceArgsResponse struct {
+	// ServiceArgs is the cluster-wide service arguments configuration.
+	ServiceArgs ServiceArgs `json:"service-args"`
+}
+
+// SetServiceArgsRPC is the path for the SetServiceArgs RPC.
+const SetServiceArgsRPC = GetServiceArgsRPC
+
+// SetServiceArgsRequest is the request message for the SetServiceArgs RPC.
+// SetServiceArgs replaces the cluster-wide service arguments configuration.
+type SetServiceArgsRequest struct {
+	ServiceArgs ServiceArgs `json:"service-args"`
+}
+
+// SetServiceArgsResponse is the response message for the SetServiceArgs RPC.
+type SetServiceArgsResponse struct{}
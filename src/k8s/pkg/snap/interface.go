@@ -2,6 +2,7 @@ package snap
 
 import (
 	"context"
+	"errors"
 
 	"github.com/canonical/k8s/pkg/client/dqlite"
 	"github.com/canonical/k8s/pkg/client/helm"
@@ -10,8 +11,19 @@ import (
 	"github.com/canonical/k8s/pkg/k8sd/types"
 )
 
+const (
+	// RuntimeEnvironmentSnap is the runtime environment of nodes that run from the k8s snap.
+	RuntimeEnvironmentSnap = "snap"
+	// RuntimeEnvironmentPebble is the runtime environment of nodes that run as containers, with services managed by pebble.
+	RuntimeEnvironmentPebble = "pebble"
+)
+
+// ErrNotSupported is returned by operations that are not available in the current runtime environment.
+var ErrNotSupported = errors.New("not supported by the runtime environment")
+
 // Snap abstracts file system paths and interacting with the k8s services.
 type Snap interface {
+	RuntimeEnvironment() string                   // RuntimeEnvironment is one of RuntimeEnvironmentSnap or RuntimeEnvironmentPebble.
 	Revision(ctx context.Context) (string, error) // Revision returns the snap revision.
 	Strict() bool                                 // Strict returns true if the snap is installed with strict confinement.
 	OnLXD(context.Context) (bool, error)          // OnLXD returns true if the host runs on LXD.
@@ -23,6 +35,8 @@ type Snap interface {
 	StartServices(ctx context.Context, services []string, extraSnapArgs ...string) error   // snap start $service
 	StopServices(ctx context.Context, services []string, extraSnapArgs ...string) error    // snap stop $service
 	RestartServices(ctx context.Context, services []string, extraSnapArgs ...string) error // snap restart $service
+	ServiceActive(ctx context.Context, service string) (bool, error)                       // snapctl services $service
+	ServiceLogs(ctx context.Context, service string, numEntries int) ([]byte, error)       // snap logs -n $numEntries $service
 
 	SnapctlGet(ctx context.Context, args ...string) ([]byte, error) // snapctl get $args...
 	SnapctlSet(ctx context.Context, args ...string) error           // snapctl set $args...
@@ -13,6 +13,7 @@ import (
 )
 
 type Mock struct {
+	RuntimeEnvironment          string
 	Revision                    string
 	RevisionErr                 error
 	Strict                      bool
@@ -55,6 +56,10 @@ type Mock struct {
 	K8sDqliteClient             *dqlite.Client
 	K8sdClient                  k8sd.Client
 	SnapctlGet                  map[string][]byte
+	ServiceActive               map[string]bool
+	ServiceActiveErr            error
+	ServiceLogs                 map[string][]byte
+	ServiceLogsErr              error
 }
 
 // Snap is a mock implementation for snap.Snap.
@@ -104,6 +109,14 @@ func (s *Snap) StopServices(ctx context.Context, names []string, extraSnapArgs .
 	return s.StopServicesErr
 }
 
+func (s *Snap) ServiceActive(ctx context.Context, name string) (bool, error) {
+	return s.Mock.ServiceActive[name], s.Mock.ServiceActiveErr
+}
+
+func (s *Snap) ServiceLogs(ctx context.Context, name string, numEntries int) ([]byte, error) {
+	return s.Mock.ServiceLogs[name], s.Mock.ServiceLogsErr
+}
+
 func (s *Snap) RestartServices(ctx context.Context, names []string, extraSnapArgs ...string) error {
 	if len(s.RestartServicesCalledWith) == 0 {
 		s.RestartServicesCalledWith = [][]string{names}
@@ -141,6 +154,10 @@ func (s *Snap) Strict() bool {
 	return s.Mock.Strict
 }
 
+func (s *Snap) RuntimeEnvironment() string {
+	return s.Mock.RuntimeEnvironment
+}
+
 func (s *Snap) OnLXD(context.Context) (bool, error) {
 	return s.Mock.OnLXD, s.Mock.OnLXDErr
 }
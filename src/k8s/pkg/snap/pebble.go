@@ -1,16 +1,17 @@
 package snap
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"time"
+	"slices"
+	"strconv"
 
 	"github.com/canonical/k8s/pkg/k8sd/types"
-	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/utils"
 )
 
@@ -22,7 +23,8 @@ type PebbleOpts struct {
 }
 
 // pebble implements the Snap interface.
-// pebble is the same as snap, but uses pebble for managing services, and disables snapctl.
+// pebble is the same as snap, but uses pebble for managing services, and is used to run nodes as containers.
+// pebble disables snapctl, and delegates refreshes to updating the container image.
 type pebble struct {
 	snap
 }
@@ -73,44 +75,32 @@ func (s *pebble) RestartServices(ctx context.Context, names []string, extraPebbl
 	return s.runCommand(ctx, s.buildPebbleCommand("restart", names, extraPebbleArgs...))
 }
 
-func (s *pebble) Refresh(ctx context.Context, to types.RefreshOpts) (string, error) {
-	switch {
-	case to.Revision != "":
-		return "", fmt.Errorf("pebble does not support refreshing to a revision, only a local path")
-	case to.Channel != "":
-		return "", fmt.Errorf("pebble does not support refreshing to a channel, only a local path")
-	case to.LocalPath != "":
-		go func() {
-			select {
-			case <-ctx.Done():
-				return
-			case <-time.After(5 * time.Second):
-				log.FromContext(ctx).Info("Refreshing kubernetes snap")
-			}
-			// replace the "kubernetes" binary with the new source.
-			// "cp -f" will replace the binary in case it's currently in use.
-			if err := s.runCommand(ctx, []string{"cp", "-f", to.LocalPath, filepath.Join(s.snapDir, "bin", "kubernetes")}); err != nil {
-				log.FromContext(ctx).Error(err, "Warning: failed to update the kubernetes binary")
-			}
-			// restart services if already running.
-			services := []string{"kube-apiserver", "kubelet", "kube-controller-manager", "kube-proxy", "kube-scheduler"}
-			if err := s.RestartServices(ctx, services); err != nil {
-				log.FromContext(ctx).WithValues("services", services).Error(err, "Warning: failed to restart after updating kubernetes binary")
-			}
-		}()
-		return "0", nil
-	default:
-		return "", fmt.Errorf("empty refresh options")
+// ServiceActive returns true if the k8s service is active.
+func (s *pebble) ServiceActive(ctx context.Context, name string) (bool, error) {
+	var b bytes.Buffer
+	if err := s.runCommand(ctx, s.buildPebbleCommand("services", []string{name}), func(c *exec.Cmd) { c.Stdout = &b }); err != nil {
+		return false, fmt.Errorf("failed to get status of service %q: %w", name, err)
 	}
+	return serviceActive(b.Bytes())
+}
+
+// ServiceLogs returns the last numEntries log entries of a k8s service.
+func (s *pebble) ServiceLogs(ctx context.Context, name string, numEntries int) ([]byte, error) {
+	var b bytes.Buffer
+	if err := s.runCommand(ctx, s.buildPebbleCommand("logs", []string{name}, "-n", strconv.Itoa(numEntries)), func(c *exec.Cmd) { c.Stdout = &b }); err != nil {
+		return nil, fmt.Errorf("failed to get logs of service %q: %w", name, err)
+	}
+	return b.Bytes(), nil
+}
+
+// Refresh is not supported, as nodes running with pebble are updated by replacing their container image.
+func (s *pebble) Refresh(ctx context.Context, to types.RefreshOpts) (string, error) {
+	return "", fmt.Errorf("refresh is delegated to the container image, update the image of the node instead: %w", ErrNotSupported)
 }
 
+// RefreshStatus is not supported, as nodes running with pebble are updated by replacing their container image.
 func (s *pebble) RefreshStatus(ctx context.Context, changeID string) (*types.RefreshStatus, error) {
-	// pebble does not support refresh status checks
-	return &types.RefreshStatus{
-		Status: "Done",
-		Ready:  true,
-		Err:    "",
-	}, nil
+	return nil, fmt.Errorf("refresh is delegated to the container image, there is no refresh status: %w", ErrNotSupported)
 }
 
 // Revision returns the k8s revision from the bom.json file.
@@ -138,18 +128,29 @@ func (s *pebble) Revision(ctx context.Context) (string, error) {
 	return bom.K8s.Revision, nil
 }
 
+func (s *pebble) RuntimeEnvironment() string {
+	return RuntimeEnvironmentPebble
+}
+
 func (s *pebble) Strict() bool {
 	return false
 }
 
+// OnLXD always returns true, as nodes running with pebble are containers and have the same restrictions as LXD containers,
+// e.g. they cannot change the conntrack sysctl settings of the host.
 func (s *pebble) OnLXD(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// SnapctlGet returns an empty configuration, with snapd configuration sync disabled (meta.orb is "none").
 func (s *pebble) SnapctlGet(ctx context.Context, args ...string) ([]byte, error) {
-	return []byte(`{"meta": {"apiVersion": "1.30", "orb": "none"}`), nil
+	if slices.Contains(args, "meta") {
+		return []byte(`{"meta": {"apiVersion": "1.30", "orb": "none"}}`), nil
+	}
+	return []byte(`{}`), nil
 }
 
+// SnapctlSet is a no-op, as there is no snapd configuration to update.
 func (s *pebble) SnapctlSet(ctx context.Context, args ...string) error {
 	return nil
 }
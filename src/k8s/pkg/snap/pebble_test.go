@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/snap"
 	"github.com/canonical/k8s/pkg/snap/mock"
+	"github.com/canonical/k8s/pkg/utils/experimental/snapdconfig"
 	. "github.com/onsi/gomega"
 )
 
@@ -78,6 +80,74 @@ func TestPebble(t *testing.T) {
 		})
 	})
 
+	t.Run("ServiceLogs", func(t *testing.T) {
+		g := NewWithT(t)
+		mockRunner := &mock.Runner{}
+		snap := snap.NewPebble(snap.PebbleOpts{
+			SnapDir:       "testdir",
+			SnapCommonDir: "testdir",
+			RunCommand:    mockRunner.Run,
+		})
+
+		_, err := snap.ServiceLogs(context.Background(), "test-service", 100)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(mockRunner.CalledWithCommand).To(ConsistOf("testdir/bin/pebble logs test-service -n 100"))
+
+		t.Run("Fail", func(t *testing.T) {
+			g := NewWithT(t)
+			mockRunner.Err = fmt.Errorf("some error")
+
+			_, err := snap.ServiceLogs(context.Background(), "test-service", 100)
+			g.Expect(err).To(HaveOccurred())
+		})
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		g := NewWithT(t)
+		mockRunner := &mock.Runner{}
+		s := snap.NewPebble(snap.PebbleOpts{
+			SnapDir:       "testdir",
+			SnapCommonDir: "testdir",
+			RunCommand:    mockRunner.Run,
+		})
+
+		for _, opts := range []types.RefreshOpts{
+			{Channel: "1.32-classic/stable"},
+			{Revision: "123"},
+			{LocalPath: "/tmp/k8s.snap"},
+		} {
+			_, err := s.Refresh(context.Background(), opts)
+			g.Expect(err).To(MatchError(snap.ErrNotSupported))
+		}
+		g.Expect(mockRunner.CalledWithCommand).To(BeEmpty())
+
+		_, err := s.RefreshStatus(context.Background(), "1")
+		g.Expect(err).To(MatchError(snap.ErrNotSupported))
+	})
+
+	t.Run("RuntimeEnvironment", func(t *testing.T) {
+		g := NewWithT(t)
+		s := snap.NewPebble(snap.PebbleOpts{})
+
+		g.Expect(s.RuntimeEnvironment()).To(Equal(snap.RuntimeEnvironmentPebble))
+	})
+
+	t.Run("Snapctl", func(t *testing.T) {
+		g := NewWithT(t)
+		s := snap.NewPebble(snap.PebbleOpts{})
+
+		meta, empty, err := snapdconfig.ParseMeta(context.Background(), s)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(empty).To(BeTrue())
+		g.Expect(meta.Orb).To(Equal("none"))
+
+		b, err := s.SnapctlGet(context.Background(), "-d", "dns")
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(string(b)).To(Equal("{}"))
+
+		g.Expect(s.SnapctlSet(context.Background(), "dns={}")).To(Succeed())
+	})
+
 	t.Run("Revision", func(t *testing.T) {
 		t.Run("returns revision from bom.json", func(t *testing.T) {
 			g := NewWithT(t)
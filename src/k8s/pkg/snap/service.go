@@ -13,3 +13,18 @@ func serviceName(serviceName string) string {
 	}
 	return fmt.Sprintf("k8s.%s", serviceName)
 }
+
+// serviceActive parses the output of "snapctl services <service>" or "pebble services <service>"
+// and returns true if the service is active. Both print a table with the header
+// "Service Startup Current ..." and one row per service.
+func serviceActive(output []byte) (bool, error) {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) < 2 {
+		return false, fmt.Errorf("unexpected output %q", string(output))
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) < 3 {
+		return false, fmt.Errorf("unexpected service status line %q", lines[1])
+	}
+	return fields[2] == "active", nil
+}
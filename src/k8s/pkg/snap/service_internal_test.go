@@ -26,3 +26,31 @@ func Test_serviceName(t *testing.T) {
 		})
 	}
 }
+
+func Test_serviceActive(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  bool
+		expectErr bool
+	}{
+		{"SnapActive", "Service      Startup  Current  Notes\nk8s.kubelet  enabled  active   -\n", true, false},
+		{"SnapInactive", "Service      Startup  Current   Notes\nk8s.kubelet  disabled inactive  -\n", false, false},
+		{"PebbleActive", "Service  Startup  Current  Since\nkubelet  enabled  active   today at 10:00 UTC\n", true, false},
+		{"NoRows", "Service  Startup  Current  Notes\n", false, true},
+		{"Empty", "", false, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			active, err := serviceActive([]byte(tc.output))
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(active).To(Equal(tc.expected))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/canonical/k8s/pkg/client/dqlite"
@@ -16,6 +17,7 @@ import (
 	"github.com/canonical/k8s/pkg/client/k8sd"
 	"github.com/canonical/k8s/pkg/client/kubernetes"
 	"github.com/canonical/k8s/pkg/client/snapd"
+	"github.com/canonical/k8s/pkg/k8sd/manifests"
 	"github.com/canonical/k8s/pkg/k8sd/types"
 	"github.com/canonical/k8s/pkg/log"
 	"github.com/canonical/k8s/pkg/utils"
@@ -104,10 +106,28 @@ func (s *snap) RestartServices(ctx context.Context, names []string, extraSnapArg
 	return s.runCommand(ctx, cmd)
 }
 
+// ServiceActive returns true if the k8s service is active. The name can be either prefixed or not.
+func (s *snap) ServiceActive(ctx context.Context, name string) (bool, error) {
+	var b bytes.Buffer
+	if err := s.runCommand(ctx, []string{"snapctl", "services", serviceName(name)}, func(c *exec.Cmd) { c.Stdout = &b }); err != nil {
+		return false, fmt.Errorf("failed to get status of service %q: %w", name, err)
+	}
+	return serviceActive(b.Bytes())
+}
+
+// ServiceLogs returns the last numEntries log entries of a k8s service. The name can be either prefixed or not.
+func (s *snap) ServiceLogs(ctx context.Context, name string, numEntries int) ([]byte, error) {
+	var b bytes.Buffer
+	if err := s.runCommand(ctx, []string{"snap", "logs", "-n", strconv.Itoa(numEntries), serviceName(name)}, func(c *exec.Cmd) { c.Stdout = &b }); err != nil {
+		return nil, fmt.Errorf("failed to get logs of service %q: %w", name, err)
+	}
+	return b.Bytes(), nil
+}
+
 // Refresh refreshes the snap to a different track, revision or custom snap.
 func (s *snap) Refresh(ctx context.Context, to types.RefreshOpts) (string, error) {
 	if s.Strict() {
-		return "", fmt.Errorf("refresh operation not available on strictly confined deployments")
+		return "", fmt.Errorf("refresh operation not available on strictly confined deployments: %w", ErrNotSupported)
 	}
 
 	var out []byte
@@ -136,7 +156,7 @@ func (s *snap) Refresh(ctx context.Context, to types.RefreshOpts) (string, error
 // RefreshStatus returns the status of a refresh operation.
 func (s *snap) RefreshStatus(ctx context.Context, changeID string) (*types.RefreshStatus, error) {
 	if s.Strict() {
-		return nil, fmt.Errorf("refresh status operation not available on strictly confined deployments")
+		return nil, fmt.Errorf("refresh status operation not available on strictly confined deployments: %w", ErrNotSupported)
 	}
 
 	client, err := snapd.NewClient()
@@ -157,6 +177,10 @@ func (s *snap) PostRefreshLockPath() string {
 	return filepath.Join(s.LockFilesDir(), "post-refresh")
 }
 
+func (s *snap) RuntimeEnvironment() string {
+	return RuntimeEnvironmentSnap
+}
+
 type snapcraftYml struct {
 	Confinement string `yaml:"confinement"`
 }
@@ -362,9 +386,11 @@ func (s *snap) KubernetesNodeClient(namespace string) (*kubernetes.Client, error
 	return kubernetes.NewClient(s.restClientGetter(filepath.Join(s.KubernetesConfigDir(), "kubelet.conf"), namespace))
 }
 
+// HelmClient returns a Helm client that deploys the charts embedded in k8sd.
+// Charts in "$SNAP_COMMON/manifests/charts" take precedence over the embedded charts, which can be used to hotfix a chart.
 func (s *snap) HelmClient() helm.Client {
 	return helm.NewClient(
-		filepath.Join(s.snapDir, "k8s", "manifests"),
+		manifests.FS(filepath.Join(s.snapCommonDir, "manifests")),
 		func(namespace string) genericclioptions.RESTClientGetter {
 			return s.restClientGetter(filepath.Join(s.KubernetesConfigDir(), "admin.conf"), namespace)
 		},
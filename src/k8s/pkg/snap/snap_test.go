@@ -142,6 +142,28 @@ func TestSnap(t *testing.T) {
 		})
 	})
 
+	t.Run("ServiceLogs", func(t *testing.T) {
+		g := NewWithT(t)
+		mockRunner := &mock.Runner{}
+		snap := snap.NewSnap(snap.SnapOpts{
+			SnapDir:       "testdir",
+			SnapCommonDir: "testdir",
+			RunCommand:    mockRunner.Run,
+		})
+
+		_, err := snap.ServiceLogs(context.Background(), "test-service", 100)
+		g.Expect(err).To(Not(HaveOccurred()))
+		g.Expect(mockRunner.CalledWithCommand).To(ConsistOf("snap logs -n 100 k8s.test-service"))
+
+		t.Run("Fail", func(t *testing.T) {
+			g := NewWithT(t)
+			mockRunner.Err = fmt.Errorf("some error")
+
+			_, err := snap.ServiceLogs(context.Background(), "test-service", 100)
+			g.Expect(err).To(HaveOccurred())
+		})
+	})
+
 	t.Run("PreInitChecks", func(t *testing.T) {
 		g := NewWithT(t)
 		// Replace the ContainerdSocketDir to avoid checking against a real containerd.sock that may be running.
@@ -4,6 +4,8 @@ import (
 	"archive/tar"
 	"bufio"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -262,6 +264,77 @@ func CreateTarball(tarballPath string, rootDir string, walkDir string, excludeFi
 	return nil
 }
 
+// ExtractTarball extracts the gz-compressed tarball at tarballPath into destDir.
+// ExtractTarball rejects entries that would be extracted outside of destDir.
+func ExtractTarball(tarballPath string, destDir string) error {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+
+	gzReader, err := gzip.NewReader(tarball)
+	if err != nil {
+		return fmt.Errorf("could not create gz reader, error: %w", err)
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar header, error: %w", err)
+		}
+
+		if !filepath.IsLocal(header.Name) {
+			return fmt.Errorf("tarball entry %q is outside of the destination directory", header.Name)
+		}
+		fullPath := filepath.Join(destDir, header.Name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(fullPath, fs.FileMode(header.Mode).Perm()); err != nil {
+				return fmt.Errorf("could not create directory: %s, error: %w", fullPath, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(fullPath), 0o700); err != nil {
+				return fmt.Errorf("could not create directory for file: %s, error: %w", fullPath, err)
+			}
+			file, err := os.OpenFile(fullPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(header.Mode).Perm())
+			if err != nil {
+				return fmt.Errorf("could not create file: %s, error: %w", fullPath, err)
+			}
+			if _, err := io.Copy(file, tarReader); err != nil {
+				file.Close()
+				return fmt.Errorf("tar read failure: %s, error: %w", fullPath, err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("could not close file: %s, error: %w", fullPath, err)
+			}
+		default:
+			return fmt.Errorf("unsupported type %v for tarball entry %q", header.Typeflag, header.Name)
+		}
+	}
+}
+
+// FileSHA256 returns the hex-encoded SHA256 checksum of the file contents.
+func FileSHA256(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // WriteFile writes data to a file with the given name and permissions.
 // The file is written to a temporary file in the same directory as the target file
 // and then renamed to the target file to avoid partial writes in case of a crash.
@@ -318,3 +318,43 @@ func TestIsYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestTarball(t *testing.T) {
+	g := NewWithT(t)
+
+	srcDir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(srcDir, "subdir"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(srcDir, "file"), []byte("contents"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(srcDir, "subdir", "file"), []byte("subdir contents"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(srcDir, "excluded"), []byte("excluded"), 0o600)).To(Succeed())
+
+	tarballPath := filepath.Join(t.TempDir(), "test.tar.gz")
+	g.Expect(utils.CreateTarball(tarballPath, srcDir, ".", []string{"excluded"})).To(Succeed())
+
+	destDir := t.TempDir()
+	g.Expect(utils.ExtractTarball(tarballPath, destDir)).To(Succeed())
+
+	b, err := os.ReadFile(filepath.Join(destDir, "file"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(Equal("contents"))
+
+	b, err = os.ReadFile(filepath.Join(destDir, "subdir", "file"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(Equal("subdir contents"))
+
+	g.Expect(filepath.Join(destDir, "excluded")).ToNot(BeAnExistingFile())
+}
+
+func TestFileSHA256(t *testing.T) {
+	g := NewWithT(t)
+
+	name := filepath.Join(t.TempDir(), "testfile")
+	g.Expect(os.WriteFile(name, []byte("hello\n"), 0o600)).To(Succeed())
+
+	checksum, err := utils.FileSHA256(name)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checksum).To(Equal("5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"))
+
+	_, err = utils.FileSHA256(filepath.Join(t.TempDir(), "missing"))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -31,7 +31,7 @@ var nextIdx int
 //			g := NewWithT(t)
 //			WithState(t, func(ctx context.Context, s state.State) {
 //				err := db.Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
-//					token, err := s.Database().GetOrCreateToken(ctx, tx, "user1", []string{"group1", "group2"})
+//					_, token, err := database.CreateToken(ctx, tx, "user1", []string{"group1", "group2"}, "", 0)
 //					if !g.Expect(err).To(Not(HaveOccurred())) {
 //						return err
 //					}